@@ -0,0 +1,99 @@
+// Command dryrun previews what a character profile would do, without a game client.
+//
+//	dryrun -character <name> (-recording <recording.jsonl.gz> | -data <debug-data.json>) [-timeout 30s] [-v]
+//
+// The town routine and the runs of the character are executed headlessly against the game data of a recording (see
+// debug.recordReplays) or of a /debug-data export, the recording includes the map data needed by the pathing. The
+// inputs the bot would send are listed by run instead of being sent. Run it from the koolo folder.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/dryrun"
+	"github.com/hectorgimenez/koolo/internal/game"
+	"github.com/hectorgimenez/koolo/internal/replay"
+)
+
+func main() {
+	character := flag.String("character", "", "character whose profile is previewed")
+	recording := flag.String("recording", "", "recorded game used as game data")
+	dataFile := flag.String("data", "", "game data exported from /debug-data")
+	timeout := flag.Duration("timeout", 30*time.Second, "max duration of every run")
+	verbose := flag.Bool("v", false, "list every input")
+	flag.Parse()
+
+	if *character == "" || (*recording == "") == (*dataFile == "") {
+		fmt.Fprintln(os.Stderr, "usage: dryrun -character <name> (-recording <file> | -data <file>) [-timeout 30s] [-v]")
+		os.Exit(2)
+	}
+
+	if err := config.Load(); err != nil {
+		fmt.Fprintln(os.Stderr, "error loading the config:", err)
+		os.Exit(2)
+	}
+	cfg, found := config.GetCharacter(*character)
+	if !found {
+		fmt.Fprintf(os.Stderr, "character %s not found\n", *character)
+		os.Exit(2)
+	}
+
+	source, err := loadSource(*recording, *dataFile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	bot, err := dryrun.NewBot(*character, cfg, source, logger)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	for _, r := range bot.Preview(*timeout) {
+		status := "ok"
+		if r.Err != nil {
+			status = r.Err.Error()
+		}
+		fmt.Printf("%s: %d inputs in %s, %s\n", r.Run, len(r.Inputs), r.Duration.Round(time.Millisecond), status)
+		if !*verbose {
+			continue
+		}
+		for _, in := range r.Inputs {
+			fmt.Printf("\t%s/%s %s x=%d y=%d key=%d modifier=%d\n", in.Action, in.Step, in.Kind, in.X, in.Y, in.Key, in.Modifier)
+		}
+	}
+}
+
+func loadSource(recording, dataFile string) (*dryrun.ScriptedSource, error) {
+	if recording != "" {
+		rec, err := replay.Open(recording)
+		if err != nil {
+			return nil, err
+		}
+		defer rec.Close()
+
+		return dryrun.FromRecording(rec)
+	}
+
+	content, err := os.ReadFile(dataFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var export struct {
+		GameData game.Data
+	}
+	if err := json.Unmarshal(content, &export); err != nil {
+		return nil, fmt.Errorf("error reading game data %s: %w", dataFile, err)
+	}
+
+	return dryrun.NewScriptedSource(export.GameData), nil
+}
@@ -48,11 +48,34 @@ func wrapWithRecover(logger *slog.Logger, f func() error) func() error {
 	}
 }
 
+// validate runs "koolo validate", printing every problem found in the configuration without starting any client. It
+// exits with 1 when there are errors, warnings alone don't fail.
+func validate() int {
+	if err := config.Load(); err != nil {
+		fmt.Println("[error] " + err.Error())
+	}
+
+	issues := bot.ValidateConfigs()
+	for _, issue := range issues {
+		fmt.Println(issue.String())
+	}
+	if config.HasValidationErrors(issues) {
+		return 1
+	}
+
+	fmt.Printf("Configuration is valid, %d warnings\n", len(issues))
+	return 0
+}
+
 func main() {
 
 	_ = buildID
 	_ = buildTime
 
+	if len(os.Args) > 1 && os.Args[1] == "validate" {
+		os.Exit(validate())
+	}
+
 	err := config.Load()
 	if err != nil {
 		utils.ShowDialog("Error loading configuration", err.Error())
@@ -0,0 +1,6 @@
+//go:build plugins
+
+package main
+
+// The plugins are compiled in with the plugins build tag, see the plugins package.
+import _ "github.com/hectorgimenez/koolo/plugins"
@@ -0,0 +1,129 @@
+// Command niplint checks pickit files without starting the bot.
+//
+//	niplint <file.nip|pickit dir>... [-item item.json]
+//
+// Every line of the files is compiled, syntax errors and unknown stat names are reported with their line number.
+// With -item the item JSON is evaluated against the rules, listing the rules matching it.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/nip"
+	"github.com/hectorgimenez/koolo/internal/pickit"
+)
+
+func main() {
+	itemFile := flag.String("item", "", "item JSON file to evaluate against the rules")
+	paths := parseArgs(os.Args[1:])
+
+	if len(paths) == 0 {
+		fmt.Fprintln(os.Stderr, "usage: niplint <file.nip|pickit dir>... [-item item.json]")
+		os.Exit(2)
+	}
+
+	files, err := nipFiles(paths)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	rules := make(nip.Rules, 0)
+	issues := 0
+	for _, file := range files {
+		f, err := os.Open(file)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		fileRules, fileIssues := pickit.ParseNIP(f, file)
+		f.Close()
+
+		for _, issue := range fileIssues {
+			fmt.Printf("%s:%d: %s\n\t%s\n", issue.File, issue.Line, issue.Error, issue.Rule)
+		}
+		rules = append(rules, fileRules...)
+		issues += len(fileIssues)
+	}
+	fmt.Printf("%d rules, %d issues\n", len(rules), issues)
+
+	if *itemFile != "" {
+		if err := evaluateItem(rules, *itemFile); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+	}
+
+	if issues > 0 {
+		os.Exit(1)
+	}
+}
+
+// parseArgs parses the flags wherever they are, the flag package stops at the first positional argument so the
+// parsing is resumed after each of them. The positional arguments are returned in order.
+func parseArgs(args []string) []string {
+	var positionals []string
+	for {
+		flag.CommandLine.Parse(args)
+		args = flag.Args()
+		if len(args) == 0 {
+			return positionals
+		}
+		positionals = append(positionals, args[0])
+		args = args[1:]
+	}
+}
+
+// nipFiles expands the directories in the arguments to the NIP files they contain
+func nipFiles(args []string) ([]string, error) {
+	var files []string
+	for _, arg := range args {
+		info, err := os.Stat(arg)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, arg)
+			continue
+		}
+
+		dirFiles, err := filepath.Glob(filepath.Join(arg, "*.nip"))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, dirFiles...)
+	}
+
+	return files, nil
+}
+
+func evaluateItem(rules nip.Rules, itemFile string) error {
+	content, err := os.ReadFile(itemFile)
+	if err != nil {
+		return err
+	}
+
+	var itm data.Item
+	if err := json.Unmarshal(content, &itm); err != nil {
+		return fmt.Errorf("error reading item %s: %w", itemFile, err)
+	}
+
+	matches, err := pickit.DryEvaluate(rules, itm)
+	if err != nil {
+		return err
+	}
+	if len(matches) == 0 {
+		fmt.Println("No rule matches the item")
+		return nil
+	}
+	for _, match := range matches {
+		fmt.Printf("%s match %s:%d\n\t%s\n", match.Result, match.File, match.Line, match.Rule)
+	}
+
+	return nil
+}
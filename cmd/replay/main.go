@@ -0,0 +1,63 @@
+// Command replay checks offline the pathing of a game recorded with debug.recordReplays and the movement inputs
+// recorded along it. The bot decisions are not re-run.
+//
+//	replay -character <name> <recording.jsonl.gz>...
+//
+// The configs are loaded from the config folder of the current directory, run it from the koolo folder. Every step of
+// the recording is checked and the steps where the pathing or the recorded inputs diverge from the recorded game are
+// listed.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/replay"
+)
+
+func main() {
+	character := flag.String("character", "", "character whose config is used for the replay")
+	flag.Parse()
+
+	if flag.NArg() == 0 || *character == "" {
+		fmt.Fprintln(os.Stderr, "usage: replay -character <name> <recording.jsonl.gz>...")
+		os.Exit(2)
+	}
+
+	if err := config.Load(); err != nil {
+		fmt.Fprintln(os.Stderr, "error loading the config:", err)
+		os.Exit(2)
+	}
+	cfg, found := config.GetCharacter(*character)
+	if !found {
+		fmt.Fprintf(os.Stderr, "character %s not found\n", *character)
+		os.Exit(2)
+	}
+
+	divergences := 0
+	for _, path := range flag.Args() {
+		rec, err := replay.Open(path)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+
+		report, err := replay.NewChecker(rec, cfg).Run(replay.PathCheck, replay.MoveOutputCheck)
+		rec.Close()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		for _, d := range report.Divergences {
+			fmt.Printf("%s: %s\n", path, d)
+		}
+		fmt.Printf("%s: %d steps, %d divergences\n", path, report.Steps, len(report.Divergences))
+		divergences += len(report.Divergences)
+	}
+
+	if divergences > 0 {
+		os.Exit(1)
+	}
+}
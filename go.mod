@@ -27,7 +27,7 @@ require (
 
 require (
 	git.wow.st/gmp/jni v0.0.0-20210610011705-34026c7e22d0 // indirect
-	github.com/expr-lang/expr v1.16.9 // indirect
+	github.com/expr-lang/expr v1.16.9
 	github.com/go-rod/rod v0.116.2
 	github.com/go-stack/stack v1.8.1 // indirect
 	github.com/google/uuid v1.6.0
@@ -0,0 +1,110 @@
+package action
+
+import (
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/koolo/internal/context"
+	"github.com/hectorgimenez/koolo/internal/game"
+	"github.com/hectorgimenez/koolo/internal/ui"
+	"github.com/hectorgimenez/koolo/internal/utils"
+)
+
+const (
+	antiIdleDefaultMinSeconds = 20
+	antiIdleDefaultMaxSeconds = 60
+	antiIdleRepositionRange   = 3
+)
+
+// AntiIdle does one of the configured idle behaviors from time to time while waiting (leader, rushee, realm queue):
+// a small mouse movement, a few steps around or opening and closing the inventory. It's meant to be called on every
+// iteration of the wait loops and does nothing until the next behavior is due, or when it's disabled.
+func AntiIdle() {
+	ctx := context.Get()
+
+	cfg := ctx.CharacterCfg.AntiIdle
+	if !cfg.Enabled {
+		return
+	}
+
+	if ctx.CurrentGame.NextIdleAt.IsZero() {
+		scheduleAntiIdle(ctx)
+		return
+	}
+	if time.Now().Before(ctx.CurrentGame.NextIdleAt) {
+		return
+	}
+	defer scheduleAntiIdle(ctx)
+
+	inGame := ctx.Manager.InGame() && ctx.Data.PlayerUnit.ID != 0
+	behaviors := make([]func(*context.Status), 0, 3)
+	if cfg.MouseMovement {
+		behaviors = append(behaviors, idleMouseMovement)
+	}
+	// Walking and the inventory only make sense in game, the realm queue only gets the mouse movements
+	if inGame && cfg.Reposition && !ctx.Data.OpenMenus.IsMenuOpen() {
+		behaviors = append(behaviors, idleReposition)
+	}
+	if inGame && cfg.ToggleInventory && !ctx.Data.OpenMenus.IsMenuOpen() {
+		behaviors = append(behaviors, idleToggleInventory)
+	}
+	if len(behaviors) == 0 {
+		return
+	}
+
+	behaviors[utils.RandRng(0, len(behaviors)-1)](ctx)
+}
+
+func scheduleAntiIdle(ctx *context.Status) {
+	cfg := ctx.CharacterCfg.AntiIdle
+
+	minSeconds, maxSeconds := cfg.MinSeconds, cfg.MaxSeconds
+	if minSeconds <= 0 {
+		minSeconds = antiIdleDefaultMinSeconds
+	}
+	if maxSeconds < minSeconds {
+		maxSeconds = max(minSeconds, antiIdleDefaultMaxSeconds)
+	}
+
+	ctx.CurrentGame.NextIdleAt = time.Now().Add(time.Duration(utils.RandRng(minSeconds, maxSeconds)) * time.Second)
+}
+
+// idleMouseMovement nudges the pointer around the center of the game area.
+func idleMouseMovement(ctx *context.Status) {
+	x := ctx.GameReader.GameAreaSizeX/2 + utils.RandRng(-120, 120)
+	y := ctx.GameReader.GameAreaSizeY/2 + utils.RandRng(-80, 80)
+
+	ctx.Logger.Debug("Anti-idle: moving the mouse", "x", x, "y", y)
+	ctx.HID.MovePointer(x, y)
+}
+
+// idleReposition walks a couple of steps to a random walkable spot next to the character.
+func idleReposition(ctx *context.Status) {
+	current := ctx.Data.PlayerUnit.Position
+	for range 5 {
+		target := data.Position{
+			X: current.X + utils.RandRng(-antiIdleRepositionRange, antiIdleRepositionRange),
+			Y: current.Y + utils.RandRng(-antiIdleRepositionRange, antiIdleRepositionRange),
+		}
+		if target == current || !ctx.Data.AreaData.IsWalkable(target) {
+			continue
+		}
+
+		ctx.Logger.Debug("Anti-idle: repositioning", "x", target.X, "y", target.Y)
+		screenX, screenY := ui.GameCoordsToScreenCords(target.X, target.Y)
+		ctx.HID.Click(game.LeftButton, screenX, screenY)
+		utils.Sleep(500)
+		return
+	}
+}
+
+// idleToggleInventory opens the inventory for a moment, like a player checking its items.
+func idleToggleInventory(ctx *context.Status) {
+	ctx.Logger.Debug("Anti-idle: checking the inventory")
+	ctx.HID.PressKeyBinding(ctx.Data.KeyBindings.Inventory)
+	utils.Sleep(utils.RandRng(800, 2500))
+	ctx.RefreshGameData()
+	if ctx.Data.OpenMenus.Inventory {
+		ctx.HID.PressKeyBinding(ctx.Data.KeyBindings.Inventory)
+	}
+}
@@ -58,6 +58,26 @@ func AutoRespecIfNeeded() error {
 	}
 	ctx.Logger.Info("Auto respec: applying targets", "targetLevel", autoCfg.Respec.TargetLevel, "stats", statTargets, "skills", skillTargets)
 
+	if !respecCharacter(autoCfg.Respec.TokenFirst) {
+		return nil
+	}
+
+	ctx.CharacterCfg.Character.AutoStatSkill.Respec.Applied = true
+	ctx.CharacterCfg.Character.AutoStatSkill.Respec.Enabled = false
+	if err := config.SaveSupervisorConfig(ctx.Name, ctx.CharacterCfg); err != nil {
+		ctx.Logger.Error("Auto respec: failed to save config", "error", err)
+		return err
+	}
+
+	ctx.Logger.Info("Auto respec: completed", "targetLevel", autoCfg.Respec.TargetLevel)
+	return nil
+}
+
+// respecCharacter resets the stat and skill points with Akara's reward or a Token of Absolution, trying the token
+// first when asked. False when neither of them worked.
+func respecCharacter(tokenFirst bool) bool {
+	ctx := context.Get()
+
 	beforeStatPoints := getStatValue(stat.StatPoints)
 	beforeSkillPoints := getStatValue(stat.SkillPoints)
 
@@ -88,7 +108,7 @@ func AutoRespecIfNeeded() error {
 		return afterTokenStatPoints != beforeStatPoints || afterTokenSkillPoints != beforeSkillPoints
 	}
 
-	if autoCfg.Respec.TokenFirst {
+	if tokenFirst {
 		tokenUsed = tryToken()
 		if !tokenUsed {
 			usedAkara = tryAkara()
@@ -102,7 +122,7 @@ func AutoRespecIfNeeded() error {
 
 	if !usedAkara && !tokenUsed {
 		ctx.Logger.Warn("Auto respec: no respec method succeeded")
-		return nil
+		return false
 	}
 
 	ctx.RefreshGameData()
@@ -112,15 +132,7 @@ func AutoRespecIfNeeded() error {
 		ctx.Logger.Warn("Auto respec: no point change detected after respec", "statBefore", beforeStatPoints, "statAfter", afterStatPoints, "skillBefore", beforeSkillPoints, "skillAfter", afterSkillPoints)
 	}
 
-	ctx.CharacterCfg.Character.AutoStatSkill.Respec.Applied = true
-	ctx.CharacterCfg.Character.AutoStatSkill.Respec.Enabled = false
-	if err := config.SaveSupervisorConfig(ctx.Name, ctx.CharacterCfg); err != nil {
-		ctx.Logger.Error("Auto respec: failed to save config", "error", err)
-		return err
-	}
-
-	ctx.Logger.Info("Auto respec: completed", "targetLevel", autoCfg.Respec.TargetLevel)
-	return nil
+	return true
 }
 
 func tryConsumeRespecToken() (bool, error) {
@@ -59,6 +59,16 @@ var (
 	}
 )
 
+// AutoEquipEnabled returns whether the town routines should run AutoEquip for the character.
+func AutoEquipEnabled() bool {
+	ctx := context.Get()
+	if _, isLevelingChar := ctx.Char.(context.LevelingCharacter); isLevelingChar {
+		return ctx.CharacterCfg.Game.Leveling.AutoEquip
+	}
+
+	return ctx.CharacterCfg.Game.Leveling.AutoEquipNonLeveling
+}
+
 func isBarbLevelingCharacter() bool {
 	ctx := context.Get()
 	return ctx.CharacterCfg.Character.Class == "barb_leveling"
@@ -270,6 +280,12 @@ func equipCTAIfFound(allItems []data.Item) (bool, error) {
 // isEquippable checks if an item can be equipped, considering the stats of the item that would be unequipped.
 // It requires the specific body location to perform an accurate stat check.
 func isEquippable(newItem data.Item, bodyloc item.LocationType, target item.LocationType) bool {
+	return isEquippableWithStats(newItem, bodyloc, target, true)
+}
+
+// isEquippableWithStats is isEquippable with the player strength/dexterity check optional, the weapon loadout solver
+// checks them for the weapon + offhand pair.
+func isEquippableWithStats(newItem data.Item, bodyloc item.LocationType, target item.LocationType, checkStats bool) bool {
 	ctx := context.Get()
 
 	// General item property checks
@@ -342,6 +358,10 @@ func isEquippable(newItem data.Item, bodyloc item.LocationType, target item.Loca
 			return false
 		}
 
+		if !checkStats {
+			return true
+		}
+
 		// Now check stats, considering the item that will be unequipped
 		baseStr := ctx.Data.PlayerUnit.Stats[stat.Strength].Value
 		baseDex := ctx.Data.PlayerUnit.Stats[stat.Dexterity].Value
@@ -507,7 +527,10 @@ func evaluateItems(items []data.Item, target item.LocationType, scoreFunc func(d
 			}
 
 			for bodyLoc, score := range bodyLocScores {
-				if !isEquippable(itm, bodyLoc, target) {
+				// Weapon requirements are solved per loadout, the offhand can give the stats the weapon needs
+				checkStats := target != item.LocationEquipped || isBarbLevelingCharacter() ||
+					(bodyLoc != item.LocLeftArm && bodyLoc != item.LocRightArm)
+				if !isEquippableWithStats(itm, bodyLoc, target, checkStats) {
 					continue
 				}
 
@@ -536,47 +559,6 @@ func evaluateItems(items []data.Item, target item.LocationType, scoreFunc func(d
 		ctx.Logger.Debug("**********************************")
 	}
 
-	// "Best Combo" logic for Two-Handed Weapons
-	if target == item.LocationEquipped {
-		class := ctx.Data.PlayerUnit.Class
-		isBarbLeveling := isBarbLevelingCharacter()
-
-		if items, ok := itemsByLoc[item.LocLeftArm]; ok && len(items) > 0 {
-			if _, found := items[0].FindStat(stat.TwoHandedMinDamage, 0); found {
-				if !isBarbLeveling && (class != data.Barbarian || items[0].Desc().Type != "swor") {
-					var bestComboScore float64
-					for _, itm := range items {
-						if _, isTwoHanded := itm.FindStat(stat.TwoHandedMinDamage, 0); !isTwoHanded {
-							if score, exists := itemScores[itm.UnitID][item.LocLeftArm]; exists {
-								ctx.Logger.Debug(fmt.Sprintf("Best one-handed weapon score: %.1f", score))
-								bestComboScore = score
-								break
-							}
-						}
-					}
-
-					if rightArmItems, ok := itemsByLoc[item.LocRightArm]; ok && len(rightArmItems) > 0 {
-						if score, exists := itemScores[rightArmItems[0].UnitID][item.LocRightArm]; exists {
-							ctx.Logger.Debug(fmt.Sprintf("Best shield score: %.1f", score))
-							bestComboScore += score
-							ctx.Logger.Debug(fmt.Sprintf("Best one-hand + shield combo score: %.1f", bestComboScore))
-						}
-					}
-
-					if twoHandedScore, exists := itemScores[items[0].UnitID][item.LocLeftArm]; exists {
-						if bestComboScore >= twoHandedScore {
-							ctx.Logger.Debug(fmt.Sprintf("Removing two-handed weapon: %s", items[0].IdentifiedName))
-							itemsByLoc[item.LocLeftArm] = itemsByLoc[item.LocLeftArm][1:]
-						} else {
-							ctx.Logger.Debug("Two-handed weapon is better, preventing shield equip.")
-							delete(itemsByLoc, item.LocRightArm)
-						}
-					}
-				}
-			}
-		}
-	}
-
 	return itemsByLoc, itemScores
 }
 
@@ -600,6 +582,20 @@ func equipBestItems(itemsByLoc map[item.LocationType][]data.Item, itemScores map
 		}
 	}
 
+	// Weapon and offhand are picked together, the best items per slot may not fit each other
+	if !isBarbLeveling && target == item.LocationEquipped {
+		weaponsChanged, err := equipWeaponLoadout(itemsByLoc, itemScores)
+		if weaponsChanged {
+			equippedSomething = true
+			*ctx.Data = ctx.GameReader.GetData()
+		}
+		if err != nil {
+			return equippedSomething, err
+		}
+		delete(itemsByLoc, item.LocLeftArm)
+		delete(itemsByLoc, item.LocRightArm)
+	}
+
 	locationOrder := make([]item.LocationType, 0, len(itemsByLoc))
 	for loc := range itemsByLoc {
 		locationOrder = append(locationOrder, loc)
@@ -622,6 +618,11 @@ func equipBestItems(itemsByLoc map[item.LocationType][]data.Item, itemScores map
 			if equippedLoc, equipped := equippedByID[itm.UnitID]; equipped && equippedLoc != loc {
 				continue
 			}
+			// The stats of the slots changed before can make the item unwearable, or the item can take away the
+			// stats the rest of the loadout needs
+			if target == item.LocationEquipped && !keepsLoadoutLegal(itm, loc) {
+				continue
+			}
 			// A valid candidate is not equipped, OR is already equipped in the current slot we are checking.
 			bestCandidate = itm // And here
 			foundCandidate = true
@@ -655,6 +656,13 @@ func equipBestItems(itemsByLoc map[item.LocationType][]data.Item, itemScores map
 					bestCandidate.IdentifiedName, loc, newScore, oldScore))
 				continue
 			}
+
+			// Only clear upgrades are worth the swap when a min upgrade is configured
+			if !isClearUpgrade(newScore, oldScore) {
+				ctx.Logger.Debug(fmt.Sprintf("Skipping equip of %s to %s: Candidate score (%.2f) is not a clear upgrade over equipped item score (%.2f).",
+					bestCandidate.IdentifiedName, loc, newScore, oldScore))
+				continue
+			}
 		}
 
 		// Attempting to equip the best item
@@ -708,6 +716,16 @@ func equipBestItems(itemsByLoc map[item.LocationType][]data.Item, itemScores map
 	return equippedSomething, nil
 }
 
+// isClearUpgrade checks the score gain against the configured min upgrade percent.
+func isClearUpgrade(newScore, oldScore float64) bool {
+	if newScore <= oldScore {
+		return false
+	}
+
+	minUpgrade := context.Get().CharacterCfg.Game.Leveling.AutoEquipMinUpgrade
+	return minUpgrade <= 0 || oldScore <= 0 || newScore >= oldScore*(1+float64(minUpgrade)/100)
+}
+
 func getEquippedUnitLocations(target item.LocationType) map[data.UnitID]item.LocationType {
 	ctx := context.Get()
 	equipped := make(map[data.UnitID]item.LocationType)
@@ -0,0 +1,247 @@
+package action
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/item"
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+	"github.com/hectorgimenez/koolo/internal/context"
+	"github.com/hectorgimenez/koolo/internal/game"
+	"github.com/hectorgimenez/koolo/internal/utils"
+)
+
+// weaponLoadout is a legal combination for the weapon slots, right is empty when the weapon is two-handed or there's
+// nothing worth holding in the offhand.
+type weaponLoadout struct {
+	left       data.Item
+	right      data.Item
+	score      float64
+	rightFirst bool // The weapon requirements are only met with the offhand bonuses
+}
+
+// solveWeaponLoadout picks the best scoring legal weapon + offhand combination instead of the best item per slot. Two
+// handed weapons leave the offhand empty, claws only pair with claws and the strength/dexterity requirements are
+// checked against the whole loadout, so a weapon can be used when the offhand gives the stats it needs.
+func solveWeaponLoadout(lefts, rights []data.Item, itemScores map[data.UnitID]map[item.LocationType]float64) (weaponLoadout, bool) {
+	ctx := context.Get()
+
+	// Requirements are checked without the current weapons, they are replaced by the loadout
+	baseStr := ctx.Data.PlayerUnit.Stats[stat.Strength].Value
+	baseDex := ctx.Data.PlayerUnit.Stats[stat.Dexterity].Value
+	currentLeft := GetEquippedItem(ctx.Data.Inventory, item.LocLeftArm)
+	for _, equipped := range []data.Item{currentLeft, GetEquippedItem(ctx.Data.Inventory, item.LocRightArm)} {
+		if equipped.UnitID == 0 {
+			continue
+		}
+		baseStr -= itemStatValue(equipped, stat.Strength)
+		baseDex -= itemStatValue(equipped, stat.Dexterity)
+	}
+
+	var best weaponLoadout
+	found := false
+	consider := func(l weaponLoadout) {
+		if !found || l.score > best.score {
+			best, found = l, true
+		}
+	}
+
+	for _, left := range lefts {
+		if left.InTradeOrStoreScreen {
+			continue
+		}
+		leftScore := itemScores[left.UnitID][item.LocLeftArm]
+
+		if meetsRequirements(left, baseStr, baseDex) {
+			consider(weaponLoadout{left: left, score: leftScore})
+		}
+		if occupiesBothHands(left) {
+			continue
+		}
+
+		for _, right := range rights {
+			if right.InTradeOrStoreScreen || right.UnitID == left.UnitID || !canHoldTogether(left, right) {
+				continue
+			}
+
+			l := weaponLoadout{left: left, right: right, score: leftScore + itemScores[right.UnitID][item.LocRightArm]}
+			switch {
+			case meetsRequirements(left, baseStr, baseDex) && meetsRequirements(right, baseStr+itemStatValue(left, stat.Strength), baseDex+itemStatValue(left, stat.Dexterity)):
+			case meetsRequirements(right, baseStr, baseDex) && meetsRequirements(left, baseStr+itemStatValue(right, stat.Strength), baseDex+itemStatValue(right, stat.Dexterity)):
+				// The offhand can't go in first while a two-handed weapon is held
+				if currentLeft.UnitID != 0 && occupiesBothHands(currentLeft) {
+					continue
+				}
+				l.rightFirst = true
+			default:
+				continue
+			}
+			consider(l)
+		}
+	}
+
+	return best, found
+}
+
+// equipWeaponLoadout equips the best legal weapon loadout when it's a clear upgrade over the current one, the offhand
+// is removed when the new weapon is two-handed and kept along a one-handed weapon picked alone.
+func equipWeaponLoadout(itemsByLoc map[item.LocationType][]data.Item, itemScores map[data.UnitID]map[item.LocationType]float64) (bool, error) {
+	ctx := context.Get()
+
+	// Weapons are only evaluated on the main weapon set, the second one is kept for CTA or teleport staves
+	if ctx.Data.ActiveWeaponSlot != 0 {
+		return false, nil
+	}
+
+	best, found := solveWeaponLoadout(itemsByLoc[item.LocLeftArm], itemsByLoc[item.LocRightArm], itemScores)
+	if !found {
+		return false, nil
+	}
+
+	leftEquipped := GetEquippedItem(ctx.Data.Inventory, item.LocLeftArm)
+	rightEquipped := GetEquippedItem(ctx.Data.Inventory, item.LocRightArm)
+	// A one-handed weapon picked alone keeps the current offhand, only the two-handed weapons need it off
+	if best.right.UnitID == 0 && rightEquipped.UnitID != 0 && rightEquipped.UnitID != best.left.UnitID &&
+		!occupiesBothHands(best.left) && canHoldTogether(best.left, rightEquipped) {
+		best.right = rightEquipped
+		best.score += itemScores[rightEquipped.UnitID][item.LocRightArm]
+	}
+	if best.left.UnitID == leftEquipped.UnitID && best.right.UnitID == rightEquipped.UnitID {
+		return false, nil
+	}
+
+	currentScore := itemScores[leftEquipped.UnitID][item.LocLeftArm] + itemScores[rightEquipped.UnitID][item.LocRightArm]
+	if leftEquipped.UnitID != 0 && !isClearUpgrade(best.score, currentScore) {
+		ctx.Logger.Debug(fmt.Sprintf("Skipping weapon loadout %s + %s: score %.2f is not a clear upgrade over %.2f.",
+			best.left.IdentifiedName, best.right.IdentifiedName, best.score, currentScore))
+		return false, nil
+	}
+
+	ctx.Logger.Info(fmt.Sprintf("Equipping weapon loadout %s + %s (score %.2f, current %.2f)",
+		best.left.IdentifiedName, best.right.IdentifiedName, best.score, currentScore))
+
+	// A two-handed weapon can't be put on while holding something in the offhand
+	if occupiesBothHands(best.left) && rightEquipped.UnitID != 0 {
+		if err := unequipBodyLocation(rightEquipped); err != nil {
+			return false, err
+		}
+	}
+
+	order := []item.LocationType{item.LocLeftArm, item.LocRightArm}
+	if best.rightFirst {
+		order = []item.LocationType{item.LocRightArm, item.LocLeftArm}
+	}
+
+	changed := false
+	for _, loc := range order {
+		target := best.left
+		if loc == item.LocRightArm {
+			target = best.right
+		}
+		if target.UnitID == 0 || GetEquippedItem(ctx.Data.Inventory, loc).UnitID == target.UnitID {
+			continue
+		}
+
+		// Previous equips move items around, use the current location
+		if updated, found := ctx.Data.Inventory.FindByID(target.UnitID); found {
+			target = updated
+		}
+		if err := equip(target, loc, item.LocationEquipped); err != nil {
+			return changed, err
+		}
+		changed = true
+		*ctx.Data = ctx.GameReader.GetData()
+	}
+
+	return changed, nil
+}
+
+// keepsLoadoutLegal tells if the candidate can replace the item in the slot with the stats the rest of the loadout
+// gives, and if every other equipped item stays wearable once the strength and dexterity of the swap are counted.
+func keepsLoadoutLegal(candidate data.Item, loc item.LocationType) bool {
+	ctx := context.Get()
+
+	current := GetEquippedItem(ctx.Data.Inventory, loc)
+	str := ctx.Data.PlayerUnit.Stats[stat.Strength].Value - itemStatValue(current, stat.Strength)
+	dex := ctx.Data.PlayerUnit.Stats[stat.Dexterity].Value - itemStatValue(current, stat.Dexterity)
+	if !meetsRequirements(candidate, str, dex) {
+		return false
+	}
+
+	str += itemStatValue(candidate, stat.Strength)
+	dex += itemStatValue(candidate, stat.Dexterity)
+	for _, equipped := range ctx.Data.Inventory.ByLocation(item.LocationEquipped) {
+		if equipped.UnitID == current.UnitID || equipped.UnitID == candidate.UnitID {
+			continue
+		}
+		if !meetsRequirements(equipped, str, dex) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// unequipBodyLocation moves the equipped item back to the inventory.
+func unequipBodyLocation(equipped data.Item) error {
+	ctx := context.Get()
+
+	if _, found := findInventorySpace(equipped); !found {
+		return ErrNotEnoughSpace
+	}
+
+	coords, err := getBodyLocationScreenCoords(equipped.Location.BodyLocation)
+	if err != nil {
+		return err
+	}
+
+	if !ctx.Data.OpenMenus.Inventory {
+		ctx.HID.PressKeyBinding(ctx.Data.KeyBindings.Inventory)
+		utils.Sleep(EquipDelayMS)
+	}
+	ctx.HID.ClickWithModifier(game.LeftButton, coords.X, coords.Y, game.ShiftKey)
+	utils.Sleep(1000)
+	*ctx.Data = ctx.GameReader.GetData()
+
+	if GetEquippedItem(ctx.Data.Inventory, equipped.Location.BodyLocation).UnitID != 0 {
+		return fmt.Errorf("failed to unequip %s from %s", equipped.IdentifiedName, equipped.Location.BodyLocation)
+	}
+
+	return nil
+}
+
+// occupiesBothHands returns true for two-handed weapons, barbarians wield two-handed swords in one hand.
+func occupiesBothHands(itm data.Item) bool {
+	if _, isTwoHanded := itm.FindStat(stat.TwoHandedMinDamage, 0); !isTwoHanded {
+		return false
+	}
+
+	return context.Get().Data.PlayerUnit.Class != data.Barbarian || itm.Desc().Type != "swor"
+}
+
+// canHoldTogether checks the weapon + offhand pair, claws in the offhand need claws in the main hand too.
+func canHoldTogether(left, right data.Item) bool {
+	if slices.Contains(shieldTypes, string(left.Desc().Type)) {
+		return false
+	}
+
+	isClaw := func(itm data.Item) bool { return itm.Desc().Type == "h2h" || itm.Desc().Type == "h2h2" }
+	if isClaw(right) {
+		return isClaw(left)
+	}
+
+	return true
+}
+
+func meetsRequirements(itm data.Item, str, dex int) bool {
+	return str >= itm.Desc().RequiredStrength && dex >= itm.Desc().RequiredDexterity
+}
+
+func itemStatValue(itm data.Item, id stat.ID) int {
+	if s, found := itm.FindStat(id, 0); found {
+		return s.Value
+	}
+
+	return 0
+}
@@ -1,6 +1,8 @@
 package action
 
 import (
+	"strings"
+
 	"github.com/hectorgimenez/d2go/pkg/data"
 	"github.com/hectorgimenez/d2go/pkg/data/difficulty"
 	"github.com/hectorgimenez/d2go/pkg/data/item"
@@ -379,8 +381,9 @@ func calculatePerLevelStats(itm data.Item) float64 {
 	lifePerlvl, _ := itm.FindStat(stat.LifePerLevel, 0)
 	manaPerlvl, _ := itm.FindStat(stat.ManaPerLevel, 0)
 
-	lifeScore := (float64(lifePerlvl.Value) / 2048) * float64(charLevel.Value) * generalWeights[stat.LifePerLevel]
-	manaScore := (float64(manaPerlvl.Value) / 2048) * float64(charLevel.Value) * generalWeights[stat.ManaPerLevel]
+	weights := withStatWeightOverrides(generalWeights, true)
+	lifeScore := (float64(lifePerlvl.Value) / 2048) * float64(charLevel.Value) * weights[stat.LifePerLevel]
+	manaScore := (float64(manaPerlvl.Value) / 2048) * float64(charLevel.Value) * weights[stat.ManaPerLevel]
 
 	totalScore := lifeScore + manaScore
 	//if totalScore > 0 {
@@ -394,7 +397,7 @@ func calculateBaseStats(itm data.Item) float64 {
 	score := 0.0
 	class := context.Get().Data.PlayerUnit.Class
 
-	for statID, baseWeight := range generalWeights {
+	for statID, baseWeight := range withStatWeightOverrides(generalWeights, true) {
 		if statData, found := itm.FindStat(statID, 0); found {
 			weight := baseWeight
 
@@ -416,6 +419,50 @@ func calculateBaseStats(itm data.Item) float64 {
 	return score
 }
 
+// withStatWeightOverrides returns the weights with the configured overrides applied. Only the stats already weighted
+// are overridden, unless addMissing is set, then the configured stats not scored anywhere else are added too.
+func withStatWeightOverrides(weights map[stat.ID]float64, addMissing bool) map[stat.ID]float64 {
+	overrides := context.Get().CharacterCfg.Game.Leveling.AutoEquipStatWeights
+	if len(overrides) == 0 {
+		return weights
+	}
+
+	merged := make(map[stat.ID]float64, len(weights)+len(overrides))
+	for id, w := range weights {
+		merged[id] = w
+	}
+	for name, w := range overrides {
+		id, found := statIDByName(name)
+		if !found {
+			continue
+		}
+		if _, weighted := merged[id]; weighted {
+			merged[id] = w
+			continue
+		}
+		_, isResist := resistWeightsMain[id]
+		_, isOtherResist := resistWeightsOther[id]
+		_, isSkill := skillWeights[id]
+		if addMissing && !isResist && !isOtherResist && !isSkill {
+			merged[id] = w
+		}
+	}
+
+	return merged
+}
+
+// statIDByName resolves a stat by its d2go name ("fasterhitrecovery"), ignoring the case.
+func statIDByName(name string) (stat.ID, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for id, s := range stat.StringStats {
+		if s == name {
+			return stat.ID(id), true
+		}
+	}
+
+	return 0, false
+}
+
 // Resists
 
 // calculateResistScore evaluates item resistance values and returns a weighted score
@@ -542,7 +589,7 @@ func calculateOtherResistScore(itm data.Item) float64 {
 	//ctx := context.Get()
 	var score float64
 
-	for statID, weight := range resistWeightsOther {
+	for statID, weight := range withStatWeightOverrides(resistWeightsOther, false) {
 		if statData, found := itm.FindStat(statID, 0); found {
 			statScore := float64(statData.Value) * weight
 			//ctx.Logger.Debug(fmt.Sprintf("Item: %s, Other resist %s: value %d, weight %.1f, score %.1f", itm.IdentifiedName, statID, statData.Value, weight, statScore))
@@ -0,0 +1,191 @@
+package action
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/npc"
+	"github.com/hectorgimenez/d2go/pkg/data/state"
+	"github.com/hectorgimenez/koolo/internal/action/step"
+	"github.com/hectorgimenez/koolo/internal/context"
+	"github.com/hectorgimenez/koolo/internal/pather"
+	"github.com/hectorgimenez/koolo/internal/utils"
+)
+
+// BossPositioning is a pluggable strategy deciding where to stand during a boss fight.
+type BossPositioning interface {
+	// Prepare runs once before the fight starts, e.g. walking to the moat spot.
+	Prepare() error
+	// Reposition runs between attacks, it should return quickly when there is nothing to do.
+	Reposition(boss data.Monster) error
+}
+
+// BossScript is the scripted behavior for a boss, keyed by the boss NPC.
+type BossScript struct {
+	Boss        npc.ID
+	Positioning BossPositioning
+	// ShouldAttack allows the script to refuse targets, e.g. Baal clones. Nil means always attack.
+	ShouldAttack func(m data.Monster) bool
+}
+
+var (
+	bossScriptsMu sync.RWMutex
+	bossScripts   = map[npc.ID]BossScript{}
+)
+
+func init() {
+	RegisterBossScript(BossScript{Boss: npc.Diablo, Positioning: DiabloHoseDodge{}})
+	RegisterBossScript(BossScript{Boss: npc.BaalCrabClone, ShouldAttack: func(m data.Monster) bool {
+		// Killing the clone doesn't finish the fight and takes as long as the real Baal
+		context.Get().Logger.Debug("Baal clone detected, ignoring it")
+		return false
+	}})
+	RegisterBossScript(BossScript{Boss: npc.Duriel, Positioning: HolyFreezeKite{MinDistance: 6}})
+}
+
+// RegisterBossScript adds or replaces the script for the given boss.
+func RegisterBossScript(script BossScript) {
+	bossScriptsMu.Lock()
+	defer bossScriptsMu.Unlock()
+	bossScripts[script.Boss] = script
+}
+
+func BossScriptFor(boss npc.ID) (BossScript, bool) {
+	bossScriptsMu.RLock()
+	defer bossScriptsMu.RUnlock()
+	script, found := bossScripts[boss]
+	return script, found
+}
+
+// PrepareBossFight runs the positioning preparation of the boss script, if any.
+func PrepareBossFight(boss npc.ID, positioning BossPositioning) error {
+	if positioning == nil {
+		script, found := BossScriptFor(boss)
+		if !found || script.Positioning == nil {
+			return nil
+		}
+		positioning = script.Positioning
+	}
+
+	return positioning.Prepare()
+}
+
+// BossFightTick is called by the characters before every attack. It applies the boss script repositioning and
+// returns false if the target should not be attacked.
+func BossFightTick(target data.Monster) bool {
+	script, found := BossScriptFor(target.Name)
+	if !found {
+		return true
+	}
+
+	if script.ShouldAttack != nil && !script.ShouldAttack(target) {
+		return false
+	}
+
+	if script.Positioning != nil {
+		if err := script.Positioning.Reposition(target); err != nil {
+			context.Get().Logger.Debug("Boss repositioning failed", "boss", target.Name, "error", err)
+		}
+	}
+
+	return true
+}
+
+// MephistoMoat walks to the spot across the moat, Mephisto can't reach it and ranged characters can attack freely.
+type MephistoMoat struct{}
+
+func (MephistoMoat) Prepare() error {
+	type positionAndWaitTime struct {
+		x        int
+		y        int
+		duration int
+	}
+
+	utils.Sleep(350)
+	if err := step.MoveTo(data.Position{X: 17563, Y: 8072}, step.WithIgnoreMonsters()); err != nil {
+		return err
+	}
+	utils.Sleep(350)
+
+	// Mephisto follows us along the moat, the waits make him get stuck on the other side
+	initialPositions := []positionAndWaitTime{
+		{17575, 8086, 350}, {17584, 8088, 1200},
+		{17600, 8090, 550}, {17609, 8090, 2500},
+	}
+	for _, pos := range initialPositions {
+		if err := step.MoveTo(data.Position{X: pos.x, Y: pos.y}, step.WithIgnoreMonsters()); err != nil {
+			return err
+		}
+		utils.Sleep(pos.duration)
+	}
+
+	if err := ClearAreaAroundPosition(data.Position{X: 17609, Y: 8090}, 10, data.MonsterAnyFilter()); err != nil {
+		return err
+	}
+
+	return step.MoveTo(data.Position{X: 17609, Y: 8090}, step.WithIgnoreMonsters())
+}
+
+func (MephistoMoat) Reposition(boss data.Monster) error {
+	return nil
+}
+
+// DiabloHoseDodge steps out of the lightning hose when Diablo starts casting towards us, the hose is tracked by the
+// hazard map.
+type DiabloHoseDodge struct{}
+
+func (DiabloHoseDodge) Prepare() error {
+	return nil
+}
+
+func (DiabloHoseDodge) Reposition(boss data.Monster) error {
+	step.DodgeBossHazards()
+	return nil
+}
+
+// HolyFreezeKite keeps ranged characters away from bosses with Holy Freeze (Duriel), once chilled we can't outrun them.
+type HolyFreezeKite struct {
+	MinDistance int
+}
+
+var (
+	holyFreezeKiteMu   sync.Mutex
+	lastHolyFreezeKite = make(map[string]time.Time)
+)
+
+func (HolyFreezeKite) Prepare() error {
+	return nil
+}
+
+func (h HolyFreezeKite) Reposition(boss data.Monster) error {
+	ctx := context.Get()
+
+	// Melee characters have to stand next to the boss anyway
+	if !ctx.Data.CanTeleport() {
+		return nil
+	}
+
+	chilled := ctx.Data.PlayerUnit.States.HasState(state.Cold) || ctx.Data.PlayerUnit.States.HasState(state.Freeze)
+	distance := pather.DistanceFromPoint(ctx.Data.PlayerUnit.Position, boss.Position)
+	if !chilled || distance >= h.MinDistance {
+		return nil
+	}
+
+	holyFreezeKiteMu.Lock()
+	recent := time.Since(lastHolyFreezeKite[ctx.Name]) < 2*time.Second
+	if !recent {
+		lastHolyFreezeKite[ctx.Name] = time.Now()
+	}
+	holyFreezeKiteMu.Unlock()
+	if recent {
+		return nil
+	}
+
+	safePos, found := FindSafePosition(boss, h.MinDistance, h.MinDistance+2, h.MinDistance, h.MinDistance+8)
+	if !found {
+		return nil
+	}
+
+	return step.MoveTo(safePos, step.WithIgnoreMonsters())
+}
@@ -57,7 +57,7 @@ func Buff() {
 	ctx := context.Get()
 	ctx.SetLastAction("Buff")
 
-	if ctx.Data.PlayerUnit.Area.IsTown() || time.Since(ctx.LastBuffAt) < time.Second*30 {
+	if ctx.Data.PlayerUnit.Area.IsTown() || !buffCooldownElapsed() {
 		return
 	}
 
@@ -113,7 +113,9 @@ func Buff() {
 		// Optionally swap to offhand (CTA / buff weapon) before class buffs.
 		if useSwapForBuffs {
 			ctx.Logger.Debug("Using weapon swap for class buff skills")
-			step.SwapToCTA()
+			if err := step.SwapToCTA(); err != nil {
+				ctx.Logger.Warn("Failed swapping weapons for class buffs, buffing with the current set", slog.Any("error", err))
+			}
 			utils.PingSleep(utils.Light, 400)
 		}
 
@@ -129,7 +131,9 @@ func Buff() {
 		// If we swapped, make sure we go back to main weapon.
 		if useSwapForBuffs {
 			utils.PingSleep(utils.Light, 400)
-			step.SwapToMainWeapon()
+			if err := step.SwapToMainWeapon(); err != nil {
+				ctx.Logger.Warn("Failed swapping back to the main weapon after buffing", slog.Any("error", err))
+			}
 		}
 	}
 
@@ -145,46 +149,107 @@ func Buff() {
 
 	if buffsSuccessful {
 		ctx.LastBuffAt = time.Now()
+		ctx.CurrentGame.PendingRunBuff = false
+		ctx.CurrentGame.BuffsLostOnDeath = false
+	}
+}
+
+// buffStates maps the buff skills to the player states they apply, any of the states means the buff is active.
+var buffStates = map[skill.ID][]state.State{
+	skill.HolyShield:       {state.Holyshield},
+	skill.FrozenArmor:      {state.Frozenarmor, state.Shiverarmor, state.Chillingarmor},
+	skill.ShiverArmor:      {state.Frozenarmor, state.Shiverarmor, state.Chillingarmor},
+	skill.ChillingArmor:    {state.Frozenarmor, state.Shiverarmor, state.Chillingarmor},
+	skill.EnergyShield:     {state.Energyshield},
+	skill.ThunderStorm:     {state.Thunderstorm},
+	skill.Enchant:          {state.Enchant},
+	skill.CycloneArmor:     {state.Cyclonearmor},
+	skill.Hurricane:        {state.Hurricane},
+	skill.OakSage:          {state.Oaksage},
+	skill.HeartOfWolverine: {state.Wolverine},
+	skill.SpiritOfBarbs:    {state.Barbs},
+	skill.BoneArmor:        {state.Bonearmor},
+	skill.BurstOfSpeed:     {state.Quickness},
+	skill.Fade:             {state.Fade},
+	skill.BladeShield:      {state.Bladeshield},
+	skill.Venom:            {state.Venomclaws},
+	skill.Shout:            {state.Shout},
+	skill.BattleOrders:     {state.Battleorders},
+	skill.BattleCommand:    {state.Battlecommand},
+}
+
+// IsBuffActive returns true if the player has any of the states applied by the given buff skill. Skills without a
+// known state are considered active, we can't tell when they expire.
+func IsBuffActive(d game.Data, buff skill.ID) bool {
+	states, found := buffStates[buff]
+	if !found {
+		return true
+	}
+
+	for _, st := range states {
+		if d.PlayerUnit.States.HasState(st) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RequestRunBuff flags the character to be buffed as soon as it leaves town, it's called at the start of every run
+// so the buffs are fresh before reaching the run area.
+func RequestRunBuff() {
+	ctx := context.Get()
+	if ctx.CharacterCfg.Character.BuffOnRunStart {
+		ctx.CurrentGame.PendingRunBuff = true
+	}
+}
+
+// buffCooldownElapsed prevents double buffing because of network lag, the cooldown is skipped when buffs were
+// requested by the run start or lost on death.
+func buffCooldownElapsed() bool {
+	ctx := context.Get()
+
+	if ctx.CurrentGame.PendingRunBuff || ctx.CurrentGame.BuffsLostOnDeath {
+		return true
 	}
+
+	return time.Since(ctx.LastBuffAt) >= time.Second*30
 }
 
-// IsRebuffRequired is left as original: 30s cooldown, CTA priority, and
-// simple state-based checks for known buff skills.
+// IsRebuffRequired checks the 30s cooldown, CTA priority and the states applied by the character buff skills.
 func IsRebuffRequired() bool {
 	ctx := context.Get()
 	ctx.SetLastAction("IsRebuffRequired")
 
+	if ctx.Data.PlayerUnit.IsDead() {
+		ctx.CurrentGame.BuffsLostOnDeath = true
+		return false
+	}
+
 	// Don't buff if we are in town, or we did it recently
 	// (prevents double buffing because of network lag).
-	if ctx.Data.PlayerUnit.Area.IsTown() || time.Since(ctx.LastBuffAt) < time.Second*30 {
+	if ctx.Data.PlayerUnit.Area.IsTown() || !buffCooldownElapsed() {
 		return false
 	}
 
+	if ctx.CurrentGame.PendingRunBuff || ctx.CurrentGame.BuffsLostOnDeath {
+		return true
+	}
+
+	// Refresh the buffs before they run out in the middle of a fight, the remaining duration can't be read
+	if interval := ctx.CharacterCfg.Character.RebuffIntervalSeconds; interval > 0 &&
+		time.Since(ctx.LastBuffAt) >= time.Duration(interval)*time.Second {
+		return true
+	}
+
 	if ctaFound(*ctx.Data) &&
-		(!ctx.Data.PlayerUnit.States.HasState(state.Battleorders) ||
-			!ctx.Data.PlayerUnit.States.HasState(state.Battlecommand)) {
+		(!IsBuffActive(*ctx.Data, skill.BattleOrders) || !IsBuffActive(*ctx.Data, skill.BattleCommand)) {
 		return true
 	}
 
-	// TODO: Find a better way to convert skill to state
-	buffs := ctx.Char.BuffSkills()
-	for _, buff := range buffs {
-		if _, found := ctx.Data.KeyBindings.KeyBindingForSkill(buff); found {
-			if buff == skill.HolyShield && !ctx.Data.PlayerUnit.States.HasState(state.Holyshield) {
-				return true
-			}
-			if buff == skill.FrozenArmor &&
-				(!ctx.Data.PlayerUnit.States.HasState(state.Frozenarmor) &&
-					!ctx.Data.PlayerUnit.States.HasState(state.Shiverarmor) &&
-					!ctx.Data.PlayerUnit.States.HasState(state.Chillingarmor)) {
-				return true
-			}
-			if buff == skill.EnergyShield && !ctx.Data.PlayerUnit.States.HasState(state.Energyshield) {
-				return true
-			}
-			if buff == skill.CycloneArmor && !ctx.Data.PlayerUnit.States.HasState(state.Cyclonearmor) {
-				return true
-			}
+	for _, buff := range ctx.Char.BuffSkills() {
+		if _, found := ctx.Data.KeyBindings.KeyBindingForSkill(buff); found && !IsBuffActive(*ctx.Data, buff) {
+			return true
 		}
 	}
 
@@ -203,7 +268,10 @@ func buffCTA() {
 		// Swap weapon only in case we don't have the CTA already equipped
 		// (for example chicken previous game during buff stage).
 		if _, found := ctx.Data.PlayerUnit.Skills[skill.BattleCommand]; !found {
-			step.SwapToCTA()
+			if err := step.SwapToCTA(); err != nil {
+				ctx.Logger.Warn("Failed swapping to CTA, skipping Battle Command / Battle Orders", slog.Any("error", err))
+				return
+			}
 			utils.PingSleep(utils.Light, 150)
 		}
 
@@ -218,7 +286,9 @@ func buffCTA() {
 		utils.Sleep(100)
 
 		utils.PingSleep(utils.Light, 400)
-		step.SwapToMainWeapon()
+		if err := step.SwapToMainWeapon(); err != nil {
+			ctx.Logger.Warn("Failed swapping back to the main weapon after CTA buffs", slog.Any("error", err))
+		}
 	}
 }
 
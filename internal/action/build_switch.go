@@ -0,0 +1,70 @@
+package action
+
+import (
+	"strings"
+
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/context"
+)
+
+// SwitchBuildIfNeeded respecs the character into the build switch plan once it reaches the plan level. The plan
+// targets become the auto stat/skill targets, so the points are allocated and the skills bound again right away. When
+// the class changes the supervisor is restarted to load it, ErrBuildSwitched is returned to stop the current run.
+func SwitchBuildIfNeeded() error {
+	ctx := context.Get()
+	ctx.SetLastAction("SwitchBuildIfNeeded")
+
+	plan := ctx.CharacterCfg.Character.BuildSwitch
+	if !plan.Enabled || plan.Applied || plan.Level <= 0 {
+		return nil
+	}
+
+	level, ok := ctx.Data.PlayerUnit.FindStat(stat.Level, 0)
+	if !ok || level.Value < plan.Level || !ctx.Data.PlayerUnit.Area.IsTown() {
+		return nil
+	}
+
+	currentClass := ctx.CharacterCfg.Character.Class
+	classChanged := plan.Class != "" && !strings.EqualFold(plan.Class, currentClass)
+	ctx.Logger.Info("Build switch: respeccing", "level", level.Value, "from", currentClass, "to", plan.Class)
+
+	if !respecCharacter(plan.TokenFirst) {
+		return nil
+	}
+
+	autoCfg := &ctx.CharacterCfg.Character.AutoStatSkill
+	if len(plan.Stats) > 0 || len(plan.Skills) > 0 {
+		autoCfg.Enabled = true
+		autoCfg.Stats = plan.Stats
+		autoCfg.Skills = plan.Skills
+	}
+	if classChanged {
+		ctx.CharacterCfg.Character.Class = plan.Class
+	}
+	if len(plan.Runs) > 0 {
+		ctx.CharacterCfg.Game.Runs = plan.Runs
+	}
+	ctx.CharacterCfg.Character.BuildSwitch.Applied = true
+	ctx.CharacterCfg.Character.BuildSwitch.Enabled = false
+	if err := config.SaveSupervisorConfig(ctx.Name, ctx.CharacterCfg); err != nil {
+		ctx.Logger.Error("Build switch: failed to save config", "error", err)
+		return err
+	}
+
+	if classChanged {
+		// The character logic is picked by class when the supervisor starts, points and bindings are done after it
+		ctx.Logger.Info("Build switch: class changed, restarting the supervisor", "class", plan.Class)
+		ctx.RestartWithCharacter = ctx.Name
+		ctx.CleanStopRequested = true
+		ctx.StopSupervisor()
+		return ErrBuildSwitched
+	}
+
+	EnsureStatPoints()
+	EnsureSkillPoints()
+	EnsureSkillBindings()
+
+	ctx.Logger.Info("Build switch: completed", "level", level.Value)
+	return nil
+}
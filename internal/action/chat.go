@@ -0,0 +1,30 @@
+package action
+
+import (
+	"github.com/hectorgimenez/koolo/internal/context"
+	"github.com/hectorgimenez/koolo/internal/utils"
+	"github.com/lxn/win"
+)
+
+// Say sends the message to the game chat. Only letters, digits, spaces, dashes and slashes can be
+// typed, enough for the chat commands like /players 8.
+func Say(message string) {
+	ctx := context.Get()
+	ctx.SetLastAction("Say")
+
+	if message == "" {
+		return
+	}
+
+	ctx.HID.PressKey(win.VK_RETURN)
+	utils.Sleep(150)
+	for _, ch := range message {
+		if ch == ' ' {
+			ctx.HID.PressKey(win.VK_SPACE)
+			continue
+		}
+		ctx.HID.PressKey(ctx.HID.GetASCIICode(string(ch)))
+	}
+	ctx.HID.PressKey(win.VK_RETURN)
+	utils.Sleep(150)
+}
@@ -0,0 +1,43 @@
+package action
+
+import (
+	"slices"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/object"
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/context"
+	"github.com/hectorgimenez/koolo/internal/town"
+)
+
+// CanOpenChest tells if the chest can be opened, locked ones take a skeleton key unless the character is an assassin.
+func CanOpenChest(o data.Object) bool {
+	if o.InteractType&object.InteractTypeLocked == 0 {
+		return true
+	}
+
+	ctx := context.Get()
+	if ctx.Data.PlayerUnit.Class == data.Assassin {
+		return true
+	}
+	keys, _ := town.ShouldBuyKeys()
+
+	return keys > 0
+}
+
+// needsKeysForChests tells if keys have to be bought for the chests the character is set to open.
+func needsKeysForChests() bool {
+	ctx := context.Get()
+	if ctx.Data.PlayerUnit.Class == data.Assassin {
+		return false
+	}
+
+	opensChests := ctx.CharacterCfg.Game.InteractWithChests || ctx.CharacterCfg.Game.InteractWithSuperChests ||
+		slices.Contains(ctx.CharacterCfg.Game.Runs, config.LowerKurastChestRun)
+	if !opensChests {
+		return false
+	}
+	_, shouldBuy := town.ShouldBuyKeys()
+
+	return shouldBuy
+}
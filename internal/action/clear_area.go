@@ -39,6 +39,11 @@ func IsPriorityMonster(m data.Monster) bool {
 
 func SortEnemiesByPriority(enemies *[]data.Monster) {
 	ctx := context.Get()
+	if ctx.CharacterCfg.Character.ThreatScoring.Enabled {
+		SortEnemiesByThreat(enemies)
+		return
+	}
+
 	sort.Slice(*enemies, func(i, j int) bool {
 		monsterI := (*enemies)[i]
 		monsterJ := (*enemies)[j]
@@ -63,6 +68,7 @@ func SortEnemiesByPriority(enemies *[]data.Monster) {
 
 func ClearAreaAroundPosition(pos data.Position, radius int, filters ...data.MonsterFilter) error {
 	ctx := context.Get()
+	defer ctx.Profiler.Enter(context.PhaseCombat)()
 	ctx.SetLastAction("ClearAreaAroundPosition")
 
 	// Disable item pickup at the beginning of the function
@@ -71,6 +77,8 @@ func ClearAreaAroundPosition(pos data.Position, radius int, filters ...data.Mons
 	// Defer the re-enabling of item pickup to ensure it happens regardless of how the function exits
 	defer ctx.EnableItemPickup()
 
+	override := ctx.CharacterCfg.AreaOverride(ctx.Data.PlayerUnit.Area)
+
 	return ctx.Char.KillMonsterSequence(func(d game.Data) (data.UnitID, bool) {
 		enemies := d.Monsters.Enemies(filters...)
 
@@ -81,6 +89,9 @@ func ClearAreaAroundPosition(pos data.Position, radius int, filters ...data.Mons
 			if distanceToTarget > radius {
 				continue
 			}
+			if override.SkipsMonster(m) {
+				continue
+			}
 
 			// Special case: Vizier can spawn on weird/off-grid tiles in Chaos Sanctuary.
 			isVizier := m.Type == data.MonsterTypeSuperUnique && m.Name == npc.StormCaster
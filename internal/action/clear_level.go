@@ -26,13 +26,18 @@ var interactableShrines = []object.ShrineType{
 }
 
 func ClearCurrentLevel(openChests bool, filter data.MonsterFilter) error {
-	return ClearCurrentLevelEx(openChests, filter, nil)
+	return RunAction("ClearCurrentLevel", func() error { return ClearCurrentLevelEx(openChests, filter, nil) })
 }
 
 func ClearCurrentLevelEx(openChests bool, filter data.MonsterFilter, shouldInterrupt func() bool) error {
 	ctx := context.Get()
 	ctx.SetLastAction("ClearCurrentLevel")
 
+	if ctx.CharacterCfg.AreaOverride(ctx.Data.PlayerUnit.Area).NoClear {
+		ctx.Logger.Debug("Level clear disabled for this area, skipping", slog.String("area", ctx.Data.PlayerUnit.Area.Area().Name))
+		return nil
+	}
+
 	openAllChests := ctx.CharacterCfg.Game.InteractWithChests
 	openSuperOnly := ctx.CharacterCfg.Game.InteractWithSuperChests && !openAllChests
 
@@ -76,6 +81,11 @@ func ClearCurrentLevelEx(openChests bool, filter data.MonsterFilter, shouldInter
 					}
 				}
 
+				if shouldOpen && !CanOpenChest(o) {
+					ctx.Logger.Debug("Skipping locked chest, no keys left", slog.Any("chest", o.Name))
+					shouldOpen = false
+				}
+
 				if shouldOpen {
 					ctx.Logger.Debug(fmt.Sprintf(
 						"Found chest. attempting to interact. Name=%s.\nID=%v UnitID=%v Pos=%v,%v Area='%s' InteractType=%v",
@@ -188,6 +198,7 @@ func getMonstersInRoom(room data.Room, filter data.MonsterFilter) []data.Monster
 	ctx := context.Get()
 	ctx.SetLastAction("getMonstersInRoom")
 
+	override := ctx.CharacterCfg.AreaOverride(ctx.Data.PlayerUnit.Area)
 	monstersInRoom := make([]data.Monster, 0)
 	for _, m := range ctx.Data.Monsters.Enemies(filter) {
 		// Fix operator precedence: alive AND (in room OR close to player).
@@ -197,6 +208,9 @@ func getMonstersInRoom(room data.Room, filter data.MonsterFilter) []data.Monster
 		if !(room.IsInside(m.Position) || ctx.PathFinder.DistanceFromMe(m.Position) < 30) {
 			continue
 		}
+		if override.SkipsMonster(m) {
+			continue
+		}
 
 		// Skip monsters that exist in data but are placed on non-walkable tiles (often "underwater/off-grid").
 		// Keep Vizier exception (Chaos Sanctuary).
@@ -0,0 +1,117 @@
+package action
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/area"
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+	"github.com/hectorgimenez/d2go/pkg/data/state"
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/context"
+)
+
+var (
+	// ErrDangerousRun stops the run when the danger score of an area reaches the threshold, the bot goes on with the
+	// next run.
+	ErrDangerousRun = errors.New("dangerous map roll, run skipped")
+	// ErrDangerousGame ends the game when the danger score of an area reaches the threshold, to reroll the map.
+	ErrDangerousGame = errors.New("dangerous map roll, rerolling the game")
+)
+
+// dangerAuras maps the aura names of the danger rules to the state the aura sets on the monsters.
+var dangerAuras = map[string]state.State{
+	"fanaticism":    state.Fanaticism,
+	"conviction":    state.Conviction,
+	"might":         state.Might,
+	"holyfreeze":    state.Holywindcold,
+	"holyshock":     state.Holyshock,
+	"holyfire":      state.Holyfire,
+	"concentration": state.Concentration,
+	"blessedaim":    state.Blessedaim,
+	"thorns":        state.Thorns,
+	"sanctuary":     state.Sanctuary,
+}
+
+// AssessDanger scores the elite packs loaded around the player against the danger rules, once per area and game. It
+// returns ErrDangerousRun or ErrDangerousGame, depending on the configured action, when the score reaches the
+// threshold.
+func AssessDanger() error {
+	ctx := context.Get()
+	cfg := ctx.CharacterCfg.Game.DangerScoring
+	currentArea := ctx.Data.PlayerUnit.Area
+	if !cfg.Enabled || cfg.Threshold <= 0 || currentArea.IsTown() || ctx.CurrentGame.DangerAssessed[currentArea] {
+		return nil
+	}
+	ctx.CurrentGame.DangerAssessed[currentArea] = true
+
+	score := 0
+	var matched []string
+	for _, m := range ctx.Data.Monsters.Enemies(data.MonsterEliteFilter()) {
+		// Minions share the aura of their leader, only the leaders and the champions are scored
+		if m.Type == data.MonsterTypeMinion {
+			continue
+		}
+		if cfg.Radius > 0 && ctx.PathFinder.DistanceFromMe(m.Position) > cfg.Radius {
+			continue
+		}
+
+		for _, rule := range cfg.Rules {
+			if dangerRuleMatches(rule, currentArea, ctx.CurrentGame.CurrentRun, m) {
+				score += rule.Score
+				matched = append(matched, rule.Name)
+			}
+		}
+	}
+
+	if score < cfg.Threshold {
+		return nil
+	}
+
+	ctx.Logger.Warn("Dangerous map roll detected",
+		slog.String("area", currentArea.Area().Name),
+		slog.String("run", ctx.CurrentGame.CurrentRun),
+		slog.Int("score", score),
+		slog.Int("threshold", cfg.Threshold),
+		slog.Any("rules", matched),
+	)
+
+	if strings.EqualFold(cfg.Action, "reroll") {
+		return fmt.Errorf("%w: %s scored %d", ErrDangerousGame, currentArea.Area().Name, score)
+	}
+
+	return fmt.Errorf("%w: %s scored %d", ErrDangerousRun, currentArea.Area().Name, score)
+}
+
+// dangerRuleMatches tells if the monster has all the immunities, auras and physical resist of the rule.
+func dangerRuleMatches(rule config.DangerRule, areaID area.ID, run string, m data.Monster) bool {
+	if len(rule.Areas) > 0 && !slices.Contains(rule.Areas, areaID) {
+		return false
+	}
+	if len(rule.Runs) > 0 && !slices.Contains(rule.Runs, run) {
+		return false
+	}
+
+	for _, resist := range rule.Immunities {
+		if !m.IsImmune(resist) {
+			return false
+		}
+	}
+
+	for _, aura := range rule.Auras {
+		st, found := dangerAuras[strings.ToLower(aura)]
+		if !found || !m.States.HasState(st) {
+			return false
+		}
+	}
+
+	if rule.PhysicalResistAt > 0 && int(int32(m.Stats[stat.DamageReduced])) < rule.PhysicalResistAt {
+		return false
+	}
+
+	return true
+}
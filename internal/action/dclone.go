@@ -0,0 +1,50 @@
+package action
+
+import (
+	"fmt"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/npc"
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+	"github.com/hectorgimenez/koolo/internal/context"
+	"github.com/hectorgimenez/koolo/internal/event"
+)
+
+// FindDiabloClone returns Diablo Clone if it's alive and around.
+func FindDiabloClone() (data.Monster, bool) {
+	ctx := context.Get()
+	for _, m := range ctx.Data.Monsters.Enemies() {
+		if m.Name == npc.DiabloClone && m.Stats[stat.Life] > 0 {
+			return m, true
+		}
+	}
+
+	return data.Monster{}, false
+}
+
+// DetectDiabloClone checks if Diablo Clone ("Diablo walks the Earth") spawned in the game, the first time it's seen
+// the position is stored in the current game and a notification is sent. Returns true only on the first sighting.
+func DetectDiabloClone() bool {
+	ctx := context.Get()
+	if _, _, seen := ctx.DiabloCloneSighting(); seen {
+		return false
+	}
+
+	dclone, found := FindDiabloClone()
+	if !found {
+		return false
+	}
+
+	// The bot and the data routines both look for it, only the first one reports it
+	if !ctx.SetDiabloCloneSeen(ctx.Data.PlayerUnit.Area, dclone.Position) {
+		return false
+	}
+
+	ctx.Logger.Info("Diablo Clone spotted!", "area", ctx.Data.PlayerUnit.Area.Area().Name, "position", dclone.Position)
+	event.Send(event.DiabloCloneSpawned(
+		event.WithScreenshot(ctx.Name, fmt.Sprintf("Diablo Clone spotted in %s", ctx.Data.PlayerUnit.Area.Area().Name), ctx.GameReader.Screenshot()),
+		ctx.Data.PlayerUnit.Area,
+	))
+
+	return true
+}
@@ -3,3 +3,6 @@ package action
 import "errors"
 
 var ErrMulingNeeded = errors.New("muling needed")
+
+// ErrBuildSwitched stops the run after switching to a build of another class, the supervisor restarts with it.
+var ErrBuildSwitched = errors.New("build switched")
@@ -0,0 +1,107 @@
+package action
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+	"github.com/hectorgimenez/koolo/internal/context"
+	"github.com/hectorgimenez/koolo/internal/event"
+)
+
+// minExperienceSamples is the number of runs needed before trusting the XP/hour of a run.
+const minExperienceSamples = 3
+
+// RunExperience is the experience gained by a run type during the session.
+type RunExperience struct {
+	Runs     int
+	Gained   int64
+	Duration time.Duration
+}
+
+// PerHour returns the experience gained per hour spent in the run.
+func (r RunExperience) PerHour() float64 {
+	if r.Duration <= 0 {
+		return 0
+	}
+
+	return float64(r.Gained) / r.Duration.Hours()
+}
+
+var runExperience = struct {
+	mu   sync.Mutex
+	runs map[string]map[string]RunExperience
+	// Runs tracked inside another tracked run, e.g. the leveling farming runs
+	depth map[string]int
+}{runs: make(map[string]map[string]RunExperience), depth: make(map[string]int)}
+
+func currentExperience() (uint64, int) {
+	ctx := context.Get()
+
+	var exp uint64
+	if v, found := ctx.Data.PlayerUnit.FindStat(stat.Experience, 0); found {
+		// Treat as unsigned to handle values > 2^31-1
+		exp = uint64(uint32(v.Value))
+	}
+	lvl, _ := ctx.Data.PlayerUnit.FindStat(stat.Level, 0)
+
+	return exp, lvl.Value
+}
+
+// TrackExperience executes the run and records the experience it gained, deaths count as negative gains.
+func TrackExperience(runName string, run func() error) error {
+	ctx := context.Get()
+
+	startExp, startLvl := currentExperience()
+	startedAt := time.Now()
+
+	runExperience.mu.Lock()
+	nested := runExperience.depth[ctx.Name] > 0
+	runExperience.depth[ctx.Name]++
+	runExperience.mu.Unlock()
+	defer func() {
+		runExperience.mu.Lock()
+		runExperience.depth[ctx.Name]--
+		runExperience.mu.Unlock()
+	}()
+
+	err := run()
+
+	ctx.RefreshGameData()
+	exp, lvl := currentExperience()
+	gained := int64(exp) - int64(startExp)
+	duration := time.Since(startedAt)
+
+	runExperience.mu.Lock()
+	if runExperience.runs[ctx.Name] == nil {
+		runExperience.runs[ctx.Name] = make(map[string]RunExperience)
+	}
+	r := runExperience.runs[ctx.Name][runName]
+	r.Runs++
+	r.Gained += gained
+	r.Duration += duration
+	runExperience.runs[ctx.Name][runName] = r
+	runExperience.mu.Unlock()
+
+	if lvl > startLvl && !nested {
+		ctx.Logger.Info("Level up", "level", lvl, "run", runName)
+	}
+	event.Send(event.ExperienceGained(event.Text(ctx.Name, ""), runName, gained, duration, lvl, nested))
+
+	return err
+}
+
+// RunExperiencePerHour returns the XP/hour of the run in the current session, false until there are enough samples.
+func RunExperiencePerHour(runName string) (float64, bool) {
+	ctx := context.Get()
+
+	runExperience.mu.Lock()
+	defer runExperience.mu.Unlock()
+
+	r, found := runExperience.runs[ctx.Name][runName]
+	if !found || r.Runs < minExperienceSamples {
+		return 0, false
+	}
+
+	return r.PerHour(), true
+}
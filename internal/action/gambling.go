@@ -8,10 +8,10 @@ import (
 	"github.com/hectorgimenez/d2go/pkg/data"
 	"github.com/hectorgimenez/d2go/pkg/data/item"
 	"github.com/hectorgimenez/d2go/pkg/data/npc"
-	"github.com/hectorgimenez/d2go/pkg/data/stat"
 	"github.com/hectorgimenez/d2go/pkg/nip"
 	"github.com/hectorgimenez/koolo/internal/action/step"
 	"github.com/hectorgimenez/koolo/internal/context"
+	"github.com/hectorgimenez/koolo/internal/event"
 	"github.com/hectorgimenez/koolo/internal/game"
 	"github.com/hectorgimenez/koolo/internal/town"
 	"github.com/hectorgimenez/koolo/internal/ui"
@@ -23,8 +23,7 @@ func Gamble() error {
 	ctx := context.Get()
 	ctx.SetLastAction("Gamble")
 
-	stashedGold, _ := ctx.Data.PlayerUnit.FindStat(stat.StashGold, 0)
-	if ctx.CharacterCfg.Gambling.Enabled && stashedGold.Value >= 2480000 {
+	if ctx.CharacterCfg.Gambling.Enabled && ctx.Data.PlayerUnit.TotalPlayerGold() >= ctx.CharacterCfg.Gambling.StartGold {
 		ctx.Logger.Info("Time to gamble! Visiting vendor...")
 
 		vendorNPC := town.GetTownByArea(ctx.Data.PlayerUnit.Area).GamblingNPC()
@@ -37,16 +36,8 @@ func Gamble() error {
 			})
 		}
 
-		InteractNPC(vendorNPC)
-		// Jamella gamble button is the second one
-		if vendorNPC == npc.Jamella {
-			ctx.HID.KeySequence(win.VK_HOME, win.VK_DOWN, win.VK_RETURN)
-		} else {
-			ctx.HID.KeySequence(win.VK_HOME, win.VK_DOWN, win.VK_DOWN, win.VK_RETURN)
-		}
-
-		if !ctx.Data.OpenMenus.NPCShop {
-			return errors.New("failed opening gambling window")
+		if err := OpenNPCMenu(vendorNPC, gambleMenuKeys(vendorNPC)...); err != nil {
+			return err
 		}
 
 		return gambleItems()
@@ -76,16 +67,8 @@ func GambleSingleItem(items []string, desiredQuality item.Quality) error {
 			})
 		}
 
-		InteractNPC(vendorNPC)
-		// Jamella gamble button is the second one
-		if vendorNPC == npc.Jamella {
-			ctx.HID.KeySequence(win.VK_HOME, win.VK_DOWN, win.VK_RETURN)
-		} else {
-			ctx.HID.KeySequence(win.VK_HOME, win.VK_DOWN, win.VK_DOWN, win.VK_RETURN)
-		}
-
-		if !ctx.Data.OpenMenus.NPCShop {
-			return errors.New("failed opening gambling window")
+		if err := OpenNPCMenu(vendorNPC, gambleMenuKeys(vendorNPC)...); err != nil {
+			return err
 		}
 	}
 
@@ -173,6 +156,9 @@ func gambleItems() error {
 		purchaseCounters[getCounterKey(itemName)] = 0
 	}
 
+	startingGold := ctx.Data.PlayerUnit.TotalPlayerGold()
+	bought, kept := 0, 0
+
 	checkAndResetCounters := func() {
 		for _, itemName := range ctx.Data.CharacterCfg.Gambling.Items {
 			if purchaseCounters[getCounterKey(itemName)] < maxPurchasesPerItem {
@@ -188,9 +174,15 @@ func gambleItems() error {
 		ctx.PauseIfNotPriority()
 		ctx.RefreshGameData()
 
-		if ctx.Data.PlayerUnit.TotalPlayerGold() < 500000 {
-			ctx.Logger.Info("Finished gambling - gold below 500k",
-				slog.Int("currentGold", ctx.Data.PlayerUnit.TotalPlayerGold()))
+		if currentGold := ctx.Data.PlayerUnit.TotalPlayerGold(); currentGold < ctx.CharacterCfg.Gambling.GoldFloor {
+			goldSpent := max(0, startingGold-currentGold)
+			ctx.Logger.Info("Finished gambling - gold below floor",
+				slog.Int("currentGold", currentGold),
+				slog.Int("bought", bought),
+				slog.Int("kept", kept),
+				slog.Int("goldSpent", goldSpent),
+			)
+			event.Send(event.GambleFinished(event.Text(ctx.Name, "Finished gambling"), bought, kept, goldSpent))
 			return step.CloseAllMenus()
 		}
 
@@ -206,8 +198,10 @@ func gambleItems() error {
 				}
 			}
 
+			bought++
 			if _, result := ctx.Data.CharacterCfg.Runtime.Rules.EvaluateAll(itemBought); result == nip.RuleResultFullMatch {
 				ctx.Logger.Info("Found item matching NIP rules, keeping", slog.Any("item", itemBought))
+				kept++
 			} else {
 				ctx.Logger.Debug("Item doesn't match NIP rules, selling", slog.Any("item", itemBought))
 				town.SellItem(itemBought)
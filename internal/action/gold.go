@@ -0,0 +1,59 @@
+package action
+
+import (
+	"github.com/hectorgimenez/koolo/internal/context"
+	"github.com/hectorgimenez/koolo/internal/event"
+)
+
+// goldStashThreshold returns the inventory gold above which it's deposited in the stash. The town visits for the
+// carried gold use the same threshold, so the gold is always deposited once back in town.
+func goldStashThreshold() int {
+	ctx := context.Get()
+
+	maxGold := ctx.Data.PlayerUnit.MaxGold()
+	threshold := maxGold / 3
+	if stashAbove := ctx.CharacterCfg.Gold.StashAbove; stashAbove > 0 {
+		threshold = min(stashAbove, maxGold)
+	}
+	if percent := ctx.CharacterCfg.Gold.TownVisitPercent; percent > 0 {
+		threshold = min(threshold, maxGold*percent/100)
+	}
+
+	return threshold
+}
+
+// CarryingTooMuchGold returns true when the carried gold is above the stash threshold and the stash can take it,
+// dying would lose part of it so it's worth an extra town visit.
+func CarryingTooMuchGold() bool {
+	ctx := context.Get()
+
+	if ctx.CharacterCfg.Gold.TownVisitPercent <= 0 || ctx.Data.PlayerUnit.MaxGold() <= 0 || isStashGoldFull() {
+		return false
+	}
+
+	return ctx.Data.Inventory.Gold > goldStashThreshold()
+}
+
+func isStashGoldFull() bool {
+	ctx := context.Get()
+
+	for _, goldInStash := range ctx.Data.Inventory.StashedGold {
+		if goldInStash < maxGoldPerStashTab {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ReportGold sends the current gold to the stats, used to track the gold flow of the session.
+func ReportGold() {
+	ctx := context.Get()
+
+	stashed := 0
+	for _, goldInStash := range ctx.Data.Inventory.StashedGold {
+		stashed += goldInStash
+	}
+
+	event.Send(event.GoldUpdated(event.Text(ctx.Name, ""), ctx.Data.Inventory.Gold, stashed, ctx.Data.PlayerUnit.MaxGold()))
+}
@@ -2,32 +2,50 @@ package action
 
 import (
 	"fmt"
+	"log/slog"
 
 	"github.com/hectorgimenez/koolo/internal/action/step"
 	"github.com/hectorgimenez/koolo/internal/context"
 	"github.com/hectorgimenez/koolo/internal/town"
 )
 
+// townHealerAt returns the life and mana percent under which the character is healed on the town healer.
+func townHealerAt() int {
+	if healAt := context.Get().CharacterCfg.Health.FreeHealing.TownHealerAt; healAt > 0 {
+		return healAt
+	}
+
+	return 80
+}
+
 func HealAtNPC() error {
 	ctx := context.Get()
 	ctx.SetLastAction("HealAtNPC")
 
+	healAt := townHealerAt()
+
 	shouldHeal := false
-	if ctx.Data.PlayerUnit.HPPercent() < 80 {
+	if ctx.Data.PlayerUnit.HPPercent() < healAt {
 		ctx.Logger.Info(fmt.Sprintf("Current life is %d, healing on NPC", ctx.Data.PlayerUnit.HPPercent()))
 		shouldHeal = true
 	}
 
+	if ctx.Data.PlayerUnit.MPPercent() < healAt {
+		ctx.Logger.Info(fmt.Sprintf("Current mana is %d, healing on NPC", ctx.Data.PlayerUnit.MPPercent()))
+		shouldHeal = true
+	}
+
 	if ctx.Data.PlayerUnit.HasDebuff() {
 		ctx.Logger.Info("Debuff detected, healing on NPC")
 		shouldHeal = true
 	}
 
-	if shouldHeal {
-		err := InteractNPC(town.GetTownByArea(ctx.Data.PlayerUnit.Area).HealNPC())
-		if err != nil {
-			ctx.Logger.Warn("Failed to heal on NPC: %v", err)
-		}
+	if !shouldHeal {
+		return nil
+	}
+
+	if err := InteractNPC(town.GetTownByArea(ctx.Data.PlayerUnit.Area).HealNPC()); err != nil {
+		ctx.Logger.Warn("Failed to heal on NPC", slog.Any("error", err))
 	}
 
 	return step.CloseAllMenus()
@@ -7,7 +7,6 @@ import (
 
 	"github.com/hectorgimenez/d2go/pkg/data"
 	"github.com/hectorgimenez/d2go/pkg/data/item"
-	"github.com/hectorgimenez/d2go/pkg/data/object"
 	"github.com/hectorgimenez/koolo/internal/action/step"
 	"github.com/hectorgimenez/koolo/internal/context"
 	"github.com/hectorgimenez/koolo/internal/game"
@@ -22,11 +21,7 @@ func CubeAddItems(items ...data.Item) error {
 
 	// Ensure stash is open
 	if !ctx.Data.OpenMenus.Stash {
-		bank, _ := ctx.Data.Objects.FindOne(object.Bank)
-		err := InteractObject(bank, func() bool {
-			return ctx.Data.OpenMenus.Stash
-		})
-		if err != nil {
+		if err := OpenStash(); err != nil {
 			return err
 		}
 	}
@@ -189,11 +184,7 @@ func ensureCubeIsOpen() error {
 
 		// Ensure stash is open
 		if !ctx.Data.OpenMenus.Stash {
-			bank, _ := ctx.Data.Objects.FindOne(object.Bank)
-			err := InteractObject(bank, func() bool {
-				return ctx.Data.OpenMenus.Stash
-			})
-			if err != nil {
+			if err := OpenStash(); err != nil {
 				return err
 			}
 		}
@@ -205,9 +196,8 @@ func ensureCubeIsOpen() error {
 
 	utils.Sleep(300)
 	ctx.HID.Click(game.RightButton, screenPos.X, screenPos.Y)
-	utils.Sleep(500)
 
-	if ctx.Data.OpenMenus.Cube {
+	if step.WaitForMenu(func(m data.OpenMenus) bool { return m.Cube }, true, 500) {
 		ctx.Logger.Debug("Horadric Cube window detected")
 		return nil
 	}
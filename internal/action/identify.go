@@ -7,6 +7,7 @@ import (
 	"github.com/hectorgimenez/d2go/pkg/data"
 	"github.com/hectorgimenez/d2go/pkg/data/difficulty"
 	"github.com/hectorgimenez/d2go/pkg/data/item"
+	"github.com/hectorgimenez/d2go/pkg/data/quest"
 	"github.com/hectorgimenez/d2go/pkg/data/stat"
 	"github.com/hectorgimenez/d2go/pkg/nip"
 	"github.com/hectorgimenez/koolo/internal/action/step"
@@ -31,6 +32,14 @@ func IdentifyAll(skipIdentify bool) error {
 	}
 
 	shouldUseCain := ctx.CharacterCfg.Game.UseCainIdentify
+	if !shouldUseCain && !hasIDScrollsFor(len(items)) && cainAvailable() {
+		ctx.Logger.Debug("Not enough ID scrolls, identifying with Cain instead")
+		shouldUseCain = true
+	}
+	if shouldUseCain && !cainAvailable() {
+		ctx.Logger.Debug("Cain is not in town yet, identifying with tome")
+		shouldUseCain = false
+	}
 
 	// Check conditions to force "skip Cain" even if UseCainIdentify is true
 	_, isLevelingChar := ctx.Char.(context.LevelingCharacter)
@@ -130,6 +139,31 @@ func CainIdentify() error {
 	return nil
 }
 
+// cainAvailable tells if Cain is in the current town, in Act 1 he's only there once rescued.
+func cainAvailable() bool {
+	ctx := context.Get()
+	if !ctx.Data.PlayerUnit.Area.IsTown() {
+		return false
+	}
+	if ctx.Data.PlayerUnit.Area.Act() == 1 && !ctx.Data.Quests[quest.Act1TheSearchForCain].Completed() {
+		return false
+	}
+	_, found := getNPCPosition(town.GetTownByArea(ctx.Data.PlayerUnit.Area).IdentifyNPC(), ctx.Data)
+
+	return found
+}
+
+func hasIDScrollsFor(count int) bool {
+	ctx := context.Get()
+	idTome, found := ctx.Data.Inventory.Find(item.TomeOfIdentify, item.LocationInventory)
+	if !found {
+		return false
+	}
+	qty, found := idTome.FindStat(stat.Quantity, 0)
+
+	return found && qty.Value >= count
+}
+
 func itemsToIdentify() (items []data.Item) {
 	ctx := context.Get()
 	ctx.SetLastAction("itemsToIdentify")
@@ -139,6 +173,10 @@ func itemsToIdentify() (items []data.Item) {
 			continue
 		}
 
+		if ctx.CharacterCfg.NeverIdentify(i) || ctx.CharacterCfg.KeepUnidentified(i) {
+			continue
+		}
+
 		// Skip identifying items that fully match a rule when unid and we're not leveling
 		_, isLevelingChar := ctx.Char.(context.LevelingCharacter)
 
@@ -167,6 +205,9 @@ func HaveItemsToStashUnidentified() bool {
 		for _, i := range items {
 
 			if !i.Identified {
+				if ctx.CharacterCfg.KeepUnidentified(i) {
+					return true
+				}
 				if _, result := ctx.CharacterCfg.Runtime.Rules.EvaluateAll(i); result == nip.RuleResultFullMatch {
 					return true
 				}
@@ -177,6 +218,72 @@ func HaveItemsToStashUnidentified() bool {
 	return false
 }
 
+// IdentifyInField identifies the inventory items with the tome when the inventory is full, dropping the ones not
+// matching the pickit rules. Returns true if some space was freed, so we can keep picking up without going to town.
+func IdentifyInField() bool {
+	ctx := context.Get()
+	ctx.SetLastAction("IdentifyInField")
+
+	if !ctx.CharacterCfg.Game.Identify.InField || ctx.Data.PlayerUnit.Area.IsTown() {
+		return false
+	}
+
+	items := itemsToIdentify()
+	if len(items) == 0 {
+		return false
+	}
+
+	idTome, found := ctx.Data.Inventory.Find(item.TomeOfIdentify, item.LocationInventory)
+	if !found {
+		return false
+	}
+	if st, statFound := idTome.FindStat(stat.Quantity, 0); !statFound || st.Value == 0 {
+		return false
+	}
+
+	ctx.Logger.Info(fmt.Sprintf("Inventory full, identifying %d items in the field...", len(items)))
+
+	step.CloseAllMenus()
+	for !ctx.Data.OpenMenus.Inventory {
+		ctx.HID.PressKeyBinding(ctx.Data.KeyBindings.Inventory)
+		utils.PingSleep(utils.Critical, 1000) // Critical operation: Wait for inventory to open
+	}
+
+	identified := make(map[data.UnitID]bool)
+	for _, i := range items {
+		ctx.RefreshInventory()
+		idTome, found = ctx.Data.Inventory.Find(item.TomeOfIdentify, item.LocationInventory)
+		if !found {
+			break
+		}
+		if st, statFound := idTome.FindStat(stat.Quantity, 0); !statFound || st.Value == 0 {
+			break
+		}
+		identifyItem(idTome, i)
+		identified[i.UnitID] = true
+	}
+	step.CloseAllMenus()
+	ctx.RefreshGameData()
+
+	dropped := 0
+	for _, i := range ctx.Data.Inventory.ByLocation(item.LocationInventory) {
+		if !identified[i.UnitID] || !i.Identified || IsInLockedInventorySlot(i) {
+			continue
+		}
+		if _, result := ctx.CharacterCfg.Runtime.Rules.EvaluateAll(i); result == nip.RuleResultFullMatch {
+			continue
+		}
+
+		DropItem(i)
+		blacklistItem(i)
+		dropped++
+	}
+
+	ctx.Logger.Debug("Field identification finished", "identified", len(identified), "dropped", dropped)
+
+	return dropped > 0
+}
+
 func identifyItem(idTome data.Item, i data.Item) {
 	ctx := context.Get()
 	screenPos := ui.GetScreenCoordsForItem(idTome)
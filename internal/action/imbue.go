@@ -0,0 +1,121 @@
+package action
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/area"
+	"github.com/hectorgimenez/d2go/pkg/data/item"
+	"github.com/hectorgimenez/d2go/pkg/data/npc"
+	"github.com/hectorgimenez/d2go/pkg/data/quest"
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+	"github.com/hectorgimenez/d2go/pkg/nip"
+	"github.com/hectorgimenez/koolo/internal/action/step"
+	"github.com/hectorgimenez/koolo/internal/context"
+	"github.com/hectorgimenez/koolo/internal/game"
+	"github.com/hectorgimenez/koolo/internal/rewards"
+	"github.com/hectorgimenez/koolo/internal/ui"
+	"github.com/hectorgimenez/koolo/internal/utils"
+	"github.com/lxn/win"
+)
+
+// ImbueIfAvailable records if Charsi's imbue is still unused in the current difficulty and, when it is, imbues the
+// first inventory or stash item matching the imbue base rules. The result is checked against the pickit rules, items
+// not matching them are left for the town routine to sell.
+func ImbueIfAvailable() error {
+	ctx := context.Get()
+	ctx.SetLastAction("ImbueIfAvailable")
+
+	diff := ctx.CharacterCfg.Game.Difficulty
+	pending := ctx.Data.Quests[quest.Act1ToolsOfTheTrade].HasStatus(quest.StatusRewardPending)
+	if err := rewards.Update(ctx.Name, diff, func(s *rewards.State) { s.ImbuePending = pending }); err != nil {
+		ctx.Logger.Warn("Failed saving quest rewards", "error", err)
+	}
+
+	if !pending || !ctx.CharacterCfg.Game.Imbue.Enabled || len(ctx.CharacterCfg.Runtime.ImbueBaseRules) == 0 {
+		return nil
+	}
+
+	base, found := findImbueBase()
+	if !found {
+		ctx.Logger.Debug("Imbue available but there's no base matching the imbue rules", "difficulty", diff)
+		return nil
+	}
+
+	if ctx.Data.PlayerUnit.Area != area.RogueEncampment {
+		if err := WayPoint(area.RogueEncampment); err != nil {
+			return err
+		}
+	}
+
+	if base.Location.LocationType != item.LocationInventory {
+		if err := TakeItemsFromStash([]data.Item{base}); err != nil {
+			return err
+		}
+		step.CloseAllMenus()
+		ctx.RefreshGameData()
+		if base, found = ctx.Data.Inventory.FindByID(base.UnitID); !found || base.Location.LocationType != item.LocationInventory {
+			return errors.New("imbue base not moved to the inventory")
+		}
+	}
+
+	ctx.Logger.Info("Imbuing item with Charsi", "item", base.Name, "difficulty", diff)
+	if err := InteractNPC(npc.Charsi); err != nil {
+		return err
+	}
+	// Imbue comes after talk and trade
+	ctx.HID.KeySequence(win.VK_HOME, win.VK_DOWN, win.VK_DOWN, win.VK_RETURN)
+	utils.PingSleep(utils.Medium, 500)
+
+	screenPos := ui.GetScreenCoordsForItem(base)
+	ctx.HID.Click(game.LeftButton, screenPos.X, screenPos.Y)
+	utils.PingSleep(utils.Medium, 800)
+	step.CloseAllMenus()
+
+	ctx.RefreshGameData()
+	imbued, found := ctx.Data.Inventory.FindByID(base.UnitID)
+	if !found || imbued.Quality != item.QualityRare {
+		return fmt.Errorf("%s wasn't imbued", base.Name)
+	}
+
+	name := imbued.IdentifiedName
+	if name == "" {
+		name = string(imbued.Name)
+	}
+	if err := rewards.Update(ctx.Name, diff, func(s *rewards.State) {
+		s.ImbuePending = false
+		s.ImbuedItem = name
+	}); err != nil {
+		ctx.Logger.Warn("Failed saving quest rewards", "error", err)
+	}
+
+	if _, result := ctx.CharacterCfg.Runtime.Rules.EvaluateAll(imbued); result == nip.RuleResultFullMatch {
+		ctx.Logger.Info("Imbued item matches the pickit rules, keeping it", "item", name)
+	} else {
+		ctx.Logger.Info("Imbued item doesn't match the pickit rules, it will be sold", "item", name)
+	}
+
+	return nil
+}
+
+// findImbueBase returns the first normal or superior item of the inventory or stash matching the imbue base rules,
+// items already socketed or made into a runeword can't be imbued.
+func findImbueBase() (data.Item, bool) {
+	ctx := context.Get()
+
+	for _, i := range ctx.Data.Inventory.ByLocation(item.LocationInventory, item.LocationStash, item.LocationSharedStash) {
+		if i.Quality != item.QualityNormal && i.Quality != item.QualitySuperior {
+			continue
+		}
+		// Socketed items can't be imbued, even with empty sockets
+		if sockets, found := i.FindStat(stat.NumSockets, 0); i.IsRuneword || len(i.Sockets) > 0 || found && sockets.Value > 0 {
+			continue
+		}
+		if _, result := ctx.CharacterCfg.Runtime.ImbueBaseRules.EvaluateAll(i); result == nip.RuleResultFullMatch {
+			return i, true
+		}
+	}
+
+	return data.Item{}, false
+}
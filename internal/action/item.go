@@ -47,7 +47,7 @@ func DropMouseItem() {
 	if len(ctx.Data.Inventory.ByLocation(item.LocationCursor)) > 0 {
 		utils.Sleep(1000)
 		ctx.HID.Click(game.LeftButton, 500, 500)
-		utils.Sleep(1000)
+		step.WaitForCursorEmpty(1000)
 	}
 }
 
@@ -70,7 +70,7 @@ func DropAndRecoverCursorItem() {
 	// Drop the item
 	utils.Sleep(500)
 	ctx.HID.Click(game.LeftButton, 500, 500)
-	utils.Sleep(500)
+	step.WaitForCursorEmpty(500)
 
 	// Wait for game to register the dropped item on ground
 	ctx.RefreshGameData()
@@ -120,8 +120,7 @@ func DropAndRecoverCursorItem() {
 		}
 
 		// Verify pickup succeeded
-		utils.Sleep(300)
-		ctx.RefreshGameData()
+		step.WaitForItemMoved(groundItem.UnitID, item.LocationGround, 300)
 		stillOnGround := false
 		for _, gi := range ctx.Data.Inventory.ByLocation(item.LocationGround) {
 			if gi.UnitID == groundItem.UnitID {
@@ -237,8 +236,7 @@ func EnsureItemNotEquipped(itm data.Item) (data.Item, error) {
 				ctx.Logger.Debug("Moving inventory item to stash to free space", "item", invItem.Name)
 				screenPos := ui.GetScreenCoordsForItem(invItem)
 				ctx.HID.ClickWithModifier(game.LeftButton, screenPos.X, screenPos.Y, game.CtrlKey)
-				utils.Sleep(300)
-				ctx.RefreshGameData()
+				step.WaitForItemMoved(invItem.UnitID, item.LocationInventory, 300)
 
 				updated, found := ctx.Data.Inventory.FindByID(invItem.UnitID)
 				if !found || updated.Location.LocationType != item.LocationInventory {
@@ -292,8 +290,7 @@ func tryUnequip(ctx *context.Status, itm data.Item) (data.Item, bool, error) {
 	if targetSlot != originalSlot {
 		ctx.Logger.Debug("Swapping weapon slot to unequip item", "item", itm.Name, "fromSlot", originalSlot, "toSlot", targetSlot)
 		ctx.HID.PressKeyBinding(ctx.Data.KeyBindings.SwapWeapons)
-		utils.Sleep(200)
-		ctx.RefreshGameData()
+		step.WaitFor(func(d *game.Data) bool { return d.ActiveWeaponSlot == targetSlot }, 200)
 
 		if ctx.Data.ActiveWeaponSlot != targetSlot {
 			return itm, false, fmt.Errorf("failed to switch to weapon slot %d to unequip %s", targetSlot, itm.Name)
@@ -303,8 +300,7 @@ func tryUnequip(ctx *context.Status, itm data.Item) (data.Item, bool, error) {
 	if swapped {
 		defer func() {
 			ctx.HID.PressKeyBinding(ctx.Data.KeyBindings.SwapWeapons)
-			utils.Sleep(200)
-			ctx.RefreshGameData()
+			step.WaitFor(func(d *game.Data) bool { return d.ActiveWeaponSlot == originalSlot }, 200)
 		}()
 	}
 
@@ -315,8 +311,7 @@ func tryUnequip(ctx *context.Status, itm data.Item) (data.Item, bool, error) {
 
 	ctx.Logger.Debug("Unequipping item", "item", itm.Name, "slot", itm.Location.BodyLocation)
 	ctx.HID.ClickWithModifier(game.LeftButton, slotPos.X, slotPos.Y, game.ShiftKey)
-	utils.Sleep(300)
-	ctx.RefreshGameData()
+	step.WaitForItemMoved(itm.UnitID, item.LocationEquipped, 300)
 
 	updated, found := ctx.Data.Inventory.FindByID(itm.UnitID)
 	if !found {
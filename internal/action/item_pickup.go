@@ -77,9 +77,20 @@ func HasTPsAvailable() bool {
 }
 
 func ItemPickup(maxDistance int) error {
+	return ItemPickupPrioritized(maxDistance, nil)
+}
+
+// ItemPickupPrioritized works like ItemPickup but picks up the items matching isPriority before the rest, e.g. runes
+// on crowded floors where other players can grab them first. The rest are picked up by value and distance.
+func ItemPickupPrioritized(maxDistance int, isPriority func(data.Item) bool) error {
 	ctx := context.Get()
+	defer ctx.Profiler.Enter(context.PhasePickup)()
 	ctx.SetLastAction("ItemPickup")
 
+	if ctx.CharacterCfg.AreaOverride(ctx.Data.PlayerUnit.Area).SkipPickup {
+		return nil
+	}
+
 	const maxRetries = 5                                        // Base retries for various issues
 	const maxItemTooFarAttempts = 5                             // Additional retries specifically for "item too far"
 	const totalMaxAttempts = maxRetries + maxItemTooFarAttempts // Combined total attempts
@@ -112,6 +123,7 @@ outer:
 		if len(itemsToPickup) == 0 {
 			return nil
 		}
+		sortItemsByPickupPriority(itemsToPickup, isPriority)
 
 		var itemToPickup data.Item
 		for _, i := range itemsToPickup {
@@ -126,6 +138,9 @@ outer:
 			if debugPickit {
 				ctx.Logger.Debug("No fitting items found for pickup after filtering.")
 			}
+			if IdentifyInField() {
+				continue
+			}
 			if HasTPsAvailable() {
 				consecutiveNoFitTownTrips++
 				if consecutiveNoFitTownTrips > 1 {
@@ -149,6 +164,15 @@ outer:
 
 		consecutiveNoFitTownTrips = 0
 
+		// Telekinesis grabs gold, potions and scrolls at range, walking to them is the fallback
+		if step.CanTelekinesisPickup(itemToPickup) {
+			if err := step.PickupItemTelekinesis(itemToPickup); err == nil {
+				continue
+			} else if debugPickit {
+				ctx.Logger.Debug("Telekinesis pickup failed, walking to the item", "error", err)
+			}
+		}
+
 		if debugPickit {
 			ctx.Logger.Info(fmt.Sprintf(
 				"Attempting to pickup item: %s [%d] at X:%d Y:%d",
@@ -391,11 +415,8 @@ func GetItemsToPickup(maxDistance int) []data.Item {
 	ctx := context.Get()
 	ctx.SetLastAction("GetItemsToPickup")
 
-	missingHealingPotions := ctx.BeltManager.GetMissingCount(data.HealingPotion) + ctx.Data.MissingPotionCountInInventory(data.HealingPotion)
-	missingManaPotions := ctx.BeltManager.GetMissingCount(data.ManaPotion) + ctx.Data.MissingPotionCountInInventory(data.ManaPotion)
-	missingRejuvenationPotions := ctx.BeltManager.GetMissingCount(data.RejuvenationPotion) + ctx.Data.MissingPotionCountInInventory(data.RejuvenationPotion)
-
 	var itemsToPickup []data.Item
+	var potions []data.Item
 	_, isLevelingChar := ctx.Char.(context.LevelingCharacter)
 
 	for _, itm := range ctx.Data.Inventory.ByLocation(item.LocationGround) {
@@ -423,39 +444,28 @@ func GetItemsToPickup(maxDistance int) []data.Item {
 			continue
 		}
 
-		// Skip items that are outside pickup radius, this is useful when clearing big areas to prevent
-		// character going back to pickup potions all the time after using them
+		// Skip items that are outside pickup radius of their class, this is useful when clearing big areas to prevent
+		// character going back to pickup potions or gold all the time
 		itemDistance := ctx.PathFinder.DistanceFromMe(itm.Position)
-		if maxDistance > 0 && itemDistance > maxDistance && itm.IsPotion() {
+		if radius := pickupRadius(ctx, itm, maxDistance); radius > 0 && itemDistance > radius {
 			continue
 		}
 
 		if itm.IsPotion() {
-			if (itm.IsHealingPotion() && missingHealingPotions > 0) ||
-				(itm.IsManaPotion() && missingManaPotions > 0) ||
-				(itm.IsRejuvPotion() && missingRejuvenationPotions > 0) {
-				if shouldBePickedUp(itm) {
-					itemsToPickup = append(itemsToPickup, itm)
-					switch {
-					case itm.IsHealingPotion():
-						missingHealingPotions--
-					case itm.IsManaPotion():
-						missingManaPotions--
-					case itm.IsRejuvPotion():
-						missingRejuvenationPotions--
-					}
-				}
+			if shouldBePickedUp(itm) {
+				potions = append(potions, itm)
 			}
 		} else if shouldBePickedUp(itm) {
 			itemsToPickup = append(itemsToPickup, itm)
 		}
 	}
+	itemsToPickup = append(itemsToPickup, selectPotionsToPickup(potions)...)
 
 	// Remove blacklisted items from the list, we don't want to pick them up
 	filteredItems := make([]data.Item, 0, len(itemsToPickup))
 	for _, itm := range itemsToPickup {
 		isBlacklisted := IsBlacklisted(itm)
-		if !isBlacklisted {
+		if !isBlacklisted && lootAssignedToMe(itm) {
 			filteredItems = append(filteredItems, itm)
 		}
 	}
@@ -630,6 +640,15 @@ func shouldBePickedUp(i data.Item) bool {
 		return true
 	}
 
+	// Bases the enabled runewords still need
+	if IsWantedRunewordBase(i) {
+		return true
+	}
+
+	if ctx.CharacterCfg.Flavor().IsCurrency(string(i.Name)) {
+		return true
+	}
+
 	// After all heuristics, defer to strict pickit/tier evaluation.
 	// This function encapsulates the final rule logic (tiers and NIP) and
 	// handles quantity blacklisting without re‑implementing it here.
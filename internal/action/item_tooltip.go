@@ -0,0 +1,66 @@
+package action
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"log/slog"
+	"strings"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/koolo/internal/context"
+	"github.com/hectorgimenez/koolo/internal/event"
+	"github.com/hectorgimenez/koolo/internal/ocr"
+	"github.com/hectorgimenez/koolo/internal/ui"
+	"github.com/hectorgimenez/koolo/internal/utils"
+)
+
+// ReadItemTooltip hovers the item and reads its tooltip with OCR, the panel showing the item has to be open. It's the
+// fallback when the memory data of the item is incomplete.
+func ReadItemTooltip(itm data.Item) (ocr.Tooltip, error) {
+	ctx := context.Get()
+	ctx.SetLastAction("ReadItemTooltip")
+
+	if !ocr.Enabled() {
+		return ocr.Tooltip{}, ocr.ErrDisabled
+	}
+
+	screenPos := ui.GetScreenCoordsForItem(itm)
+	ctx.HID.MovePointer(screenPos.X, screenPos.Y)
+	utils.PingSleep(utils.Medium, 250)
+
+	screenshot := ctx.GameReader.Screenshot()
+	if screenshot == nil {
+		return ocr.Tooltip{}, errors.New("failed taking the screenshot")
+	}
+
+	return readTooltipAt(ctx, screenshot, screenPos)
+}
+
+func readTooltipAt(ctx *context.Status, screenshot image.Image, screenPos data.Position) (ocr.Tooltip, error) {
+	region := ctx.GameReader.Coordinates().RectToWindow(ocr.TooltipRegion(screenPos.X, screenPos.Y))
+	return ocr.ReadTooltip(screenshot, region)
+}
+
+// verifyItemTooltip cross-checks the memory data of the item with its tooltip in the screenshot taken while hovering
+// it, the mismatches are logged and notified. It doesn't use the HID so it can run in the background.
+func verifyItemTooltip(ctx *context.Status, itm data.Item, screenshot image.Image, screenPos data.Position) {
+	tooltip, err := readTooltipAt(ctx, screenshot, screenPos)
+	if err != nil {
+		ctx.Logger.Debug("Failed reading the item tooltip", slog.String("item", string(itm.Name)), slog.Any("error", err))
+		return
+	}
+
+	mismatches := tooltip.Verify(itm)
+	if len(mismatches) == 0 {
+		return
+	}
+
+	details := make([]string, 0, len(mismatches))
+	for _, m := range mismatches {
+		details = append(details, fmt.Sprintf("%s: memory %q, tooltip %q", m.Field, m.Memory, m.Tooltip))
+	}
+	ctx.Logger.Warn("Item tooltip doesn't match the memory data, the memory offsets may be broken",
+		slog.String("item", string(itm.Name)), slog.String("mismatches", strings.Join(details, "; ")))
+	event.Send(event.WithScreenshot(ctx.Name, fmt.Sprintf("Item %s tooltip doesn't match the memory data: %s", itm.Name, strings.Join(details, "; ")), screenshot))
+}
@@ -0,0 +1,84 @@
+package action
+
+import (
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/item"
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+	"github.com/hectorgimenez/koolo/internal/context"
+)
+
+func isJavelin(itm data.Item) bool {
+	itmType := itm.Type()
+	return itmType.IsType(item.TypeJavelin) || itmType.IsType(item.TypeAmazonJavelin)
+}
+
+func javelinQuantity(itm data.Item) int {
+	qty, found := itm.FindStat(stat.Quantity, 0)
+	if !found {
+		return 0
+	}
+
+	return max(0, qty.Value)
+}
+
+// ReplenishJavelinsFromStash swaps the equipped javelin stack with a fuller copy of the same javelin kept in the stash.
+// Useful for ethereal javelins, they can't be refilled by repairing.
+func ReplenishJavelinsFromStash() error {
+	ctx := context.Get()
+	ctx.SetLastAction("ReplenishJavelinsFromStash")
+
+	if ctx.CharacterCfg.Character.Class != "javazon" || !ctx.CharacterCfg.Character.Javazon.ReplenishFromStash {
+		return nil
+	}
+
+	if !ctx.Data.PlayerUnit.Area.IsTown() {
+		return nil
+	}
+
+	threshold := ctx.CharacterCfg.Character.Javazon.DensityKillerForceRefillBelowPercent
+	if threshold <= 0 || threshold > 100 {
+		threshold = 50
+	}
+
+	for _, equipped := range ctx.Data.Inventory.ByLocation(item.LocationEquipped) {
+		if equipped.Location.BodyLocation != item.LocLeftArm && equipped.Location.BodyLocation != item.LocRightArm {
+			continue
+		}
+		if !isJavelin(equipped) {
+			continue
+		}
+
+		maxQty := getMaxJavelinQuantity(equipped)
+		if maxQty <= 0 {
+			continue
+		}
+
+		qty := javelinQuantity(equipped)
+		if qty*100/maxQty >= threshold {
+			return nil
+		}
+
+		var best data.Item
+		bestQty := qty
+		for _, stashed := range ctx.Data.Inventory.ByLocation(item.LocationStash, item.LocationSharedStash) {
+			if stashed.Name != equipped.Name || !isJavelin(stashed) {
+				continue
+			}
+			if stashedQty := javelinQuantity(stashed); stashedQty > bestQty {
+				best = stashed
+				bestQty = stashedQty
+			}
+		}
+
+		if best.UnitID == 0 {
+			ctx.Logger.Debug("No fuller javelin stack found in stash", "javelin", equipped.Name, "quantity", qty)
+			return nil
+		}
+
+		ctx.Logger.Info("Replenishing javelins from stash", "javelin", equipped.Name, "from", qty, "to", bestQty)
+		// The depleted stack goes back to the inventory, it's handled later by the regular stash routine
+		return equip(best, equipped.Location.BodyLocation, item.LocationEquipped)
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package action
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/area"
+	"github.com/hectorgimenez/d2go/pkg/data/item"
+	"github.com/hectorgimenez/d2go/pkg/data/npc"
+	"github.com/hectorgimenez/d2go/pkg/data/quest"
+	"github.com/hectorgimenez/koolo/internal/action/step"
+	"github.com/hectorgimenez/koolo/internal/context"
+	"github.com/hectorgimenez/koolo/internal/game"
+	"github.com/hectorgimenez/koolo/internal/rewards"
+	"github.com/hectorgimenez/koolo/internal/ui"
+	"github.com/hectorgimenez/koolo/internal/utils"
+	"github.com/lxn/win"
+)
+
+// SocketWithLarzuk records if Larzuk's socket is still unused in the current difficulty and, when it is, spends it on
+// the highest priority queued item found in the inventory or stash. Queued items not found are skipped, they keep
+// their place for the reward of the next difficulty.
+func SocketWithLarzuk() error {
+	ctx := context.Get()
+	ctx.SetLastAction("SocketWithLarzuk")
+
+	// Talking to Larzuk completes the quest
+	if QuestKeptOpen(quest.Act5SiegeOnHarrogath) {
+		return nil
+	}
+
+	diff := ctx.CharacterCfg.Game.Difficulty
+	pending := ctx.Data.Quests[quest.Act5SiegeOnHarrogath].HasStatus(quest.StatusRewardPending)
+	if err := rewards.Update(ctx.Name, diff, func(s *rewards.State) { s.SocketPending = pending }); err != nil {
+		ctx.Logger.Warn("Failed saving quest rewards", "error", err)
+	}
+
+	if !pending || !ctx.CharacterCfg.Game.Larzuk.Enabled || len(ctx.CharacterCfg.Game.Larzuk.Queue) == 0 {
+		return nil
+	}
+
+	record, err := rewards.Get(ctx.Name)
+	if err != nil {
+		return err
+	}
+
+	var target data.Item
+	var job rewards.SocketJob
+	found := false
+	for _, j := range rewards.PendingSocketJobs(record, ctx.CharacterCfg.Game.Larzuk.Queue) {
+		if target, found = findSocketTarget(j.Item); found {
+			job = j
+			break
+		}
+	}
+	if !found {
+		ctx.Logger.Debug("Socket reward available but none of the queued items was found", "difficulty", diff)
+		return nil
+	}
+
+	if ctx.Data.PlayerUnit.Area != area.Harrogath {
+		if err := WayPoint(area.Harrogath); err != nil {
+			return err
+		}
+	}
+
+	if target.Location.LocationType != item.LocationInventory {
+		if err := TakeItemsFromStash([]data.Item{target}); err != nil {
+			return err
+		}
+		step.CloseAllMenus()
+		ctx.RefreshGameData()
+		if target, found = ctx.Data.Inventory.FindByID(target.UnitID); !found || target.Location.LocationType != item.LocationInventory {
+			return errors.New("item to socket not moved to the inventory")
+		}
+	}
+
+	ctx.Logger.Info("Socketing item with Larzuk", "item", job.Item, "priority", job.Priority, "difficulty", diff)
+	if err := InteractNPC(npc.Larzuk); err != nil {
+		return err
+	}
+	// Socket comes after talk and trade
+	ctx.HID.KeySequence(win.VK_HOME, win.VK_DOWN, win.VK_DOWN, win.VK_RETURN)
+	utils.PingSleep(utils.Medium, 500)
+
+	screenPos := ui.GetScreenCoordsForItem(target)
+	ctx.HID.Click(game.LeftButton, screenPos.X, screenPos.Y)
+	utils.PingSleep(utils.Medium, 800)
+	step.CloseAllMenus()
+
+	ctx.RefreshGameData()
+	if socketed, found := ctx.Data.Inventory.FindByID(target.UnitID); !found || !socketed.HasSockets {
+		return fmt.Errorf("%s wasn't socketed", job.Item)
+	}
+
+	return rewards.Update(ctx.Name, diff, func(s *rewards.State) {
+		s.SocketPending = false
+		s.SocketedItem = job.Item
+	})
+}
+
+// findSocketTarget returns the first inventory or stash item with the name and no sockets yet.
+func findSocketTarget(name string) (data.Item, bool) {
+	ctx := context.Get()
+
+	for _, i := range ctx.Data.Inventory.ByLocation(item.LocationInventory, item.LocationStash, item.LocationSharedStash) {
+		if !i.HasSockets && !i.IsRuneword && itemNameMatches(i, name) {
+			return i, true
+		}
+	}
+
+	return data.Item{}, false
+}
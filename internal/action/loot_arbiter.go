@@ -0,0 +1,116 @@
+package action
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/nip"
+	"github.com/hectorgimenez/koolo/internal/context"
+)
+
+// A bot stops bidding for an item once it can't reach or doesn't want it anymore, its bid expires after this time so
+// the item is assigned to someone else instead of being ignored by everyone.
+const lootBidTTL = 10 * time.Second
+
+// lootBid is how well suited a bot is to pick up a ground item.
+type lootBid struct {
+	fits     bool
+	match    nip.RuleResult
+	distance int
+	at       time.Time
+}
+
+// betterThan ranks the bids by inventory space first, then by how well the pickit rules match and then by distance.
+func (b lootBid) betterThan(other lootBid) bool {
+	if b.fits != other.fits {
+		return b.fits
+	}
+	if b.match != other.match {
+		return b.match < other.match
+	}
+
+	return b.distance < other.distance
+}
+
+// lootArbiter assigns each ground item to a single bot when several companions play the same game, keyed by game
+// name, item unit ID and supervisor name.
+var lootArbiter = struct {
+	mu    sync.Mutex
+	games map[string]map[data.UnitID]map[string]lootBid
+}{games: make(map[string]map[data.UnitID]map[string]lootBid)}
+
+// lootAssignedToMe bids for the ground item and returns true if this bot is the one that should pick it up. Without
+// the companion system every item is ours.
+func lootAssignedToMe(i data.Item) bool {
+	ctx := context.Get()
+
+	if !ctx.CharacterCfg.Companion.Enabled || i.IsPotion() {
+		return true
+	}
+
+	gameName := ctx.GameReader.LastGameName()
+	if gameName == "" {
+		return true
+	}
+
+	_, match := ctx.CharacterCfg.Runtime.Rules.EvaluateAll(i)
+	bid := lootBid{
+		fits:     !itemNeedsInventorySpace(i) || itemFitsInventory(i),
+		match:    match,
+		distance: ctx.PathFinder.DistanceFromMe(i.Position),
+		at:       time.Now(),
+	}
+
+	lootArbiter.mu.Lock()
+	defer lootArbiter.mu.Unlock()
+
+	items, found := lootArbiter.games[gameName]
+	if !found {
+		pruneLootGames()
+		items = make(map[data.UnitID]map[string]lootBid)
+		lootArbiter.games[gameName] = items
+	}
+	bids, found := items[i.UnitID]
+	if !found {
+		bids = make(map[string]lootBid)
+		items[i.UnitID] = bids
+	}
+	bids[ctx.Name] = bid
+
+	winner, best := ctx.Name, bid
+	for name, b := range bids {
+		if time.Since(b.at) > lootBidTTL {
+			delete(bids, name)
+			continue
+		}
+		// Same bids are settled by name so every bot gets the same answer
+		if b.betterThan(best) || (!best.betterThan(b) && name < winner) {
+			winner, best = name, b
+		}
+	}
+
+	if winner != ctx.Name {
+		ctx.Logger.Debug("Item assigned to another companion", "item", i.Name, "companion", winner)
+		return false
+	}
+
+	return true
+}
+
+// pruneLootGames drops the games nobody has bid in lately, caller must hold the lock.
+func pruneLootGames() {
+	for gameName, items := range lootArbiter.games {
+		active := false
+		for _, bids := range items {
+			for _, b := range bids {
+				if time.Since(b.at) <= lootBidTTL {
+					active = true
+				}
+			}
+		}
+		if !active {
+			delete(lootArbiter.games, gameName)
+		}
+	}
+}
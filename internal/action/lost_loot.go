@@ -0,0 +1,175 @@
+package action
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/area"
+	"github.com/hectorgimenez/d2go/pkg/data/item"
+	"github.com/hectorgimenez/d2go/pkg/nip"
+	"github.com/hectorgimenez/koolo/internal/context"
+	"github.com/hectorgimenez/koolo/internal/event"
+)
+
+const (
+	lostLootFile       = "lost_loot.json"
+	lostLootMaxEntries = 100
+)
+
+// LostLootEntry is a valuable item left on the ground and never recovered.
+type LostLootEntry struct {
+	Name     string        `json:"name"`
+	Quality  string        `json:"quality"`
+	Area     string        `json:"area"`
+	Position data.Position `json:"position"`
+	Game     string        `json:"game"`
+	Reason   string        `json:"reason"`
+	LostAt   time.Time     `json:"lostAt"`
+}
+
+// valuableGroundItems returns the ground items matching the pickit rules that are worth going back for.
+func valuableGroundItems() []data.Item {
+	ctx := context.Get()
+
+	var items []data.Item
+	for _, i := range ctx.Data.Inventory.ByLocation(item.LocationGround) {
+		if pickupTier(i) < pickupTierRare || IsBlacklisted(i) {
+			continue
+		}
+		if _, result := ctx.CharacterCfg.Runtime.Rules.EvaluateAll(i); result == nip.RuleResultNoMatch {
+			continue
+		}
+		items = append(items, i)
+	}
+
+	return items
+}
+
+// SnapshotLostLoot remembers the valuable items around before leaving the area in a hurry, RecoverLostLoot goes
+// back for them once it's safe.
+func SnapshotLostLoot() {
+	ctx := context.Get()
+	ctx.SetLastAction("SnapshotLostLoot")
+
+	ctx.RefreshGameData()
+	items := valuableGroundItems()
+	if len(items) == 0 {
+		return
+	}
+
+	ctx.CurrentGame.LostLoot = items
+	ctx.CurrentGame.LostLootArea = ctx.Data.PlayerUnit.Area
+	ctx.Logger.Info("Valuable items left on the ground", "items", len(items), "area", ctx.Data.PlayerUnit.Area.Area().Name)
+}
+
+// RecoverLostLoot walks back to the items remembered by SnapshotLostLoot and picks them up. It only works while we
+// are still in the same game and area, the town portal brings us back there.
+func RecoverLostLoot() error {
+	ctx := context.Get()
+	ctx.SetLastAction("RecoverLostLoot")
+
+	if len(ctx.CurrentGame.LostLoot) == 0 || ctx.Data.PlayerUnit.Area != ctx.CurrentGame.LostLootArea {
+		return nil
+	}
+
+	var pending []data.Item
+	for _, lost := range ctx.CurrentGame.LostLoot {
+		ctx.RefreshGameData()
+		onGround, found := ctx.Data.Inventory.FindByID(lost.UnitID)
+		if !found || onGround.Location.LocationType != item.LocationGround {
+			continue
+		}
+
+		if err := MoveToCoords(onGround.Position); err != nil {
+			ctx.Logger.Debug("Failed moving back to lost item", "item", onGround.Name, "error", err)
+			pending = append(pending, onGround)
+			continue
+		}
+		if err := ItemPickup(10); err != nil {
+			return err
+		}
+
+		ctx.RefreshGameData()
+		if i, found := ctx.Data.Inventory.FindByID(lost.UnitID); found && i.Location.LocationType == item.LocationGround {
+			pending = append(pending, i)
+		}
+	}
+
+	recovered := len(ctx.CurrentGame.LostLoot) - len(pending)
+	if recovered > 0 {
+		ctx.Logger.Info("Recovered items left on the ground", "items", recovered)
+	}
+	ctx.CurrentGame.LostLoot = pending
+
+	return nil
+}
+
+// ReportLostLoot records the valuable items we are about to lose for good, the ones still on the ground when the
+// game ends by death or chicken plus the ones never recovered after a town chicken.
+func ReportLostLoot(reason string) {
+	ctx := context.Get()
+	ctx.SetLastAction("ReportLostLoot")
+
+	ctx.RefreshGameData()
+	// Pending items in the current area are listed again by the ground snapshot
+	if ctx.Data.PlayerUnit.Area != ctx.CurrentGame.LostLootArea {
+		recordLostLoot(ctx, ctx.CurrentGame.LostLoot, ctx.CurrentGame.LostLootArea, reason)
+	}
+	recordLostLoot(ctx, valuableGroundItems(), ctx.Data.PlayerUnit.Area, reason)
+	ctx.CurrentGame.LostLoot = nil
+}
+
+func recordLostLoot(ctx *context.Status, items []data.Item, areaID area.ID, reason string) {
+	if len(items) == 0 {
+		return
+	}
+
+	if err := appendLostLoot(ctx, items, areaID, reason); err != nil {
+		ctx.Logger.Warn("Failed to record lost loot", "error", err)
+	}
+	ctx.Logger.Info("Valuable items lost", "items", len(items), "area", areaID.Area().Name, "reason", reason)
+	event.Send(event.LootLost(event.Text(ctx.Name, ""), items, areaID, reason))
+}
+
+func appendLostLoot(ctx *context.Status, items []data.Item, areaID area.ID, reason string) error {
+	entries, _ := LoadLostLoot(ctx.Name)
+	for _, i := range items {
+		entries = append(entries, LostLootEntry{
+			Name:     formatItemName(i),
+			Quality:  i.Quality.ToString(),
+			Area:     areaID.Area().Name,
+			Position: i.Position,
+			Game:     ctx.GameReader.LastGameName(),
+			Reason:   reason,
+			LostAt:   time.Now(),
+		})
+	}
+	if len(entries) > lostLootMaxEntries {
+		entries = entries[len(entries)-lostLootMaxEntries:]
+	}
+
+	jsonData, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join("config", ctx.Name, lostLootFile), jsonData, 0644)
+}
+
+// LoadLostLoot reads the items recorded as lost for the character, oldest first.
+func LoadLostLoot(characterName string) ([]LostLootEntry, error) {
+	jsonData, err := os.ReadFile(filepath.Join("config", characterName, lostLootFile))
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []LostLootEntry
+	if err := json.Unmarshal(jsonData, &entries); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
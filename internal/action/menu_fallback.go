@@ -0,0 +1,185 @@
+package action
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/npc"
+	"github.com/hectorgimenez/d2go/pkg/data/object"
+	"github.com/hectorgimenez/koolo/internal/action/step"
+	"github.com/hectorgimenez/koolo/internal/context"
+	"github.com/hectorgimenez/koolo/internal/game"
+	"github.com/hectorgimenez/koolo/internal/ui"
+	"github.com/hectorgimenez/koolo/internal/utils"
+	"github.com/lxn/win"
+)
+
+// Time given to a menu to show up after each method before trying the next one
+const menuOpenTimeout = 1500 * time.Millisecond
+
+// menuMethod is one way of opening a menu.
+type menuMethod struct {
+	name string
+	run  func() error
+}
+
+// openMenuWithFallback tries the methods in order until the menu is open. A method counts as failed when the menu
+// state didn't change after it, even when it didn't return any error.
+func openMenuWithFallback(menu string, isOpen func() bool, methods ...menuMethod) error {
+	ctx := context.Get()
+
+	ctx.RefreshGameData()
+	if isOpen() {
+		return nil
+	}
+
+	var errs []error
+	for _, m := range methods {
+		ctx.PauseIfNotPriority()
+
+		err := m.run()
+		if waitForMenu(isOpen) {
+			if len(errs) > 0 {
+				ctx.Logger.Debug("Menu opened after fallback", "menu", menu, "method", m.name)
+			}
+			return nil
+		}
+
+		if err == nil {
+			err = errors.New("menu didn't open")
+		}
+		ctx.Logger.Debug("Failed opening menu, trying next method", "menu", menu, "method", m.name, "error", err)
+		errs = append(errs, fmt.Errorf("%s: %w", m.name, err))
+	}
+
+	return fmt.Errorf("failed opening %s: %w", menu, errors.Join(errs...))
+}
+
+func waitForMenu(isOpen func() bool) bool {
+	ctx := context.Get()
+
+	deadline := time.Now().Add(time.Duration(utils.PingMultiplier(utils.Medium, int(menuOpenTimeout.Milliseconds()))) * time.Millisecond)
+	for {
+		ctx.RefreshGameData()
+		if isOpen() {
+			return true
+		}
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// OpenStash opens the stash, clicking it again around its position and reopening it when the first click misfires.
+func OpenStash() error {
+	ctx := context.Get()
+	ctx.SetLastAction("OpenStash")
+
+	bank, found := ctx.Data.Objects.FindOne(object.Bank)
+	if !found {
+		return errors.New("stash not found")
+	}
+
+	isOpen := func() bool { return ctx.Data.OpenMenus.Stash }
+
+	return openMenuWithFallback("stash", isOpen,
+		menuMethod{name: "click", run: func() error {
+			return InteractObject(bank, isOpen)
+		}},
+		menuMethod{name: "click with offset", run: func() error {
+			return clickObjectWithOffset(bank)
+		}},
+		menuMethod{name: "reopen", run: func() error {
+			if err := step.CloseAllMenus(); err != nil {
+				return err
+			}
+			return InteractObject(bank, isOpen)
+		}},
+	)
+}
+
+// OpenNPCMenu talks to the NPC and picks the menu entry with the given key sequence, waiting for the shop window. When
+// the dialog is open but the shop isn't, the entry is picked again before closing everything and starting over.
+func OpenNPCMenu(npcID npc.ID, keys ...byte) error {
+	ctx := context.Get()
+	ctx.SetLastAction("OpenNPCMenu")
+
+	isOpen := func() bool { return ctx.Data.OpenMenus.NPCShop }
+
+	return openMenuWithFallback(fmt.Sprintf("NPC %d menu", npcID), isOpen,
+		menuMethod{name: "click", run: func() error {
+			if err := InteractNPC(npcID); err != nil {
+				return err
+			}
+			ctx.HID.KeySequence(keys...)
+			return nil
+		}},
+		menuMethod{name: "keyboard", run: func() error {
+			ctx.RefreshGameData()
+			if !ctx.Data.OpenMenus.NPCInteract {
+				if err := InteractNPC(npcID); err != nil {
+					return err
+				}
+			}
+			utils.PingSleep(utils.Light, 200)
+			ctx.HID.KeySequence(keys...)
+			return nil
+		}},
+		menuMethod{name: "reopen", run: func() error {
+			if err := step.CloseAllMenus(); err != nil {
+				return err
+			}
+			if err := InteractNPC(npcID); err != nil {
+				return err
+			}
+			ctx.HID.KeySequence(keys...)
+			return nil
+		}},
+	)
+}
+
+// clickObjectWithOffset hovers a few points around the object and clicks it as soon as it's highlighted, for objects
+// whose hitbox doesn't match their position.
+func clickObjectWithOffset(obj data.Object) error {
+	ctx := context.Get()
+
+	offsets := []data.Position{{X: -2, Y: -2}, {X: 0, Y: -1}, {X: -1, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 1}, {X: -3, Y: -3}}
+	for _, offset := range offsets {
+		x, y := ui.GameCoordsToScreenCords(obj.Position.X+offset.X, obj.Position.Y+offset.Y)
+		ctx.HID.MovePointer(x, y)
+		utils.PingSleep(utils.Light, 150)
+
+		ctx.RefreshGameData()
+		if o, found := ctx.Data.Objects.FindByID(obj.ID); found && o.IsHovered {
+			ctx.HID.Click(game.LeftButton, x, y)
+			return nil
+		}
+	}
+
+	// Nothing got highlighted, click where the object is expected and let the menu check tell
+	x, y := ui.GameCoordsToScreenCords(obj.Position.X-2, obj.Position.Y-2)
+	ctx.HID.Click(game.LeftButton, x, y)
+
+	return nil
+}
+
+// Keys picking the trade entry in the NPC dialog, Jamella's trade button is the first one
+func tradeMenuKeys(vendorNPC npc.ID) []byte {
+	if vendorNPC == npc.Jamella {
+		return []byte{win.VK_HOME, win.VK_RETURN}
+	}
+
+	return []byte{win.VK_HOME, win.VK_DOWN, win.VK_RETURN}
+}
+
+// Keys picking the gamble entry in the NPC dialog, Jamella's gamble button is the second one
+func gambleMenuKeys(vendorNPC npc.ID) []byte {
+	if vendorNPC == npc.Jamella {
+		return []byte{win.VK_HOME, win.VK_DOWN, win.VK_RETURN}
+	}
+
+	return []byte{win.VK_HOME, win.VK_DOWN, win.VK_DOWN, win.VK_RETURN}
+}
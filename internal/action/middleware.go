@@ -0,0 +1,159 @@
+package action
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/context"
+	"github.com/hectorgimenez/koolo/internal/drop"
+	"github.com/hectorgimenez/koolo/internal/event"
+	"github.com/hectorgimenez/koolo/internal/health"
+	"github.com/hectorgimenez/koolo/internal/utils"
+)
+
+// ErrActionTimeout is returned by RunAction when the action is still running once its timeout expires, the steps return
+// an error from their next boundary on.
+var ErrActionTimeout = errors.New("action timed out")
+
+// ActionRun is an action going through the middlewares, Attempts is updated by the retries.
+type ActionRun struct {
+	Name     string
+	Policy   config.ActionPolicy
+	Attempts int
+}
+
+// ActionMiddleware wraps the execution of an action, it calls next to go on with the chain.
+type ActionMiddleware func(run *ActionRun, next func() error) error
+
+var actionMiddlewares = struct {
+	mu    sync.RWMutex
+	chain []ActionMiddleware
+}{chain: []ActionMiddleware{delayActionMiddleware, logActionMiddleware, metricsActionMiddleware, retryActionMiddleware, timeoutActionMiddleware}}
+
+// UseActionMiddleware adds a middleware to the chain, it runs inside the built-in ones (humanization delay, logging,
+// metrics, retries and timeout) so it's called once per attempt.
+func UseActionMiddleware(m ActionMiddleware) {
+	actionMiddlewares.mu.Lock()
+	defer actionMiddlewares.mu.Unlock()
+
+	actionMiddlewares.chain = append(actionMiddlewares.chain, m)
+}
+
+// RunAction runs the action through the middlewares: entry/exit logging, duration metrics, bounded retries with
+// backoff and the timeout, all set by the action policy of the character config.
+func RunAction(name string, fn func() error) error {
+	ctx := context.Get()
+
+	run := &ActionRun{Name: name, Policy: actionPolicy(ctx, name)}
+
+	actionMiddlewares.mu.RLock()
+	chain := append([]ActionMiddleware(nil), actionMiddlewares.chain...)
+	actionMiddlewares.mu.RUnlock()
+
+	next := fn
+	for i := len(chain) - 1; i >= 0; i-- {
+		m, inner := chain[i], next
+		next = func() error { return m(run, inner) }
+	}
+
+	return next()
+}
+
+func actionPolicy(ctx *context.Status, name string) config.ActionPolicy {
+	if policy, found := ctx.CharacterCfg.ActionPolicies[name]; found {
+		return policy
+	}
+
+	return ctx.CharacterCfg.ActionPolicies["default"]
+}
+
+// delayActionMiddleware pauses before the action as set by the humanization profile.
+func delayActionMiddleware(run *ActionRun, next func() error) error {
+	if delay := context.Get().Humanizer.ActionDelay(); delay > 0 {
+		time.Sleep(delay)
+	}
+
+	return next()
+}
+
+func logActionMiddleware(run *ActionRun, next func() error) error {
+	ctx := context.Get()
+
+	ctx.Logger.Debug("Action started", "action", run.Name)
+	startedAt := time.Now()
+	err := next()
+	ctx.Logger.Debug("Action finished", "action", run.Name, "duration", time.Since(startedAt), "attempts", run.Attempts, "error", err)
+
+	return err
+}
+
+func metricsActionMiddleware(run *ActionRun, next func() error) error {
+	ctx := context.Get()
+
+	startedAt := time.Now()
+	err := next()
+	switch {
+	case err == nil:
+		ctx.CurrentGame.LastActionError, ctx.CurrentGame.ActionErrorRepeats = "", 0
+	case err.Error() == ctx.CurrentGame.LastActionError:
+		ctx.CurrentGame.ActionErrorRepeats++
+	default:
+		ctx.CurrentGame.LastActionError, ctx.CurrentGame.ActionErrorRepeats = err.Error(), 1
+	}
+	event.Send(event.ActionFinished(event.Text(ctx.Name, ""), run.Name, time.Since(startedAt), run.Attempts, err != nil, errors.Is(err, ErrActionTimeout)))
+
+	return err
+}
+
+func retryActionMiddleware(run *ActionRun, next func() error) error {
+	ctx := context.Get()
+
+	backoff := time.Duration(run.Policy.BackoffMS) * time.Millisecond
+	for {
+		run.Attempts++
+		err := next()
+		if err == nil || run.Attempts > run.Policy.Retries || !isRetryableActionError(err) {
+			return err
+		}
+
+		ctx.Logger.Debug("Retrying action", "action", run.Name, "attempt", run.Attempts+1, "error", err)
+		if backoff > 0 {
+			utils.Sleep(int(backoff.Milliseconds()))
+			backoff *= 2
+		}
+	}
+}
+
+// timeoutActionMiddleware sets the deadline of the attempt, the steps check it at their boundaries and return
+// context.ErrActionDeadline once it passed. The error of a nested action is left to the action whose deadline it is.
+func timeoutActionMiddleware(run *ActionRun, next func() error) error {
+	timeout := time.Duration(run.Policy.TimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		return next()
+	}
+
+	ctx := context.Get()
+	depth := ctx.PushActionDeadline(time.Now().Add(timeout))
+	defer ctx.PopActionDeadline(depth)
+
+	err := next()
+	if errors.Is(err, context.ErrActionDeadline) && ctx.ActionDeadlinePassed(depth) {
+		return fmt.Errorf("%w: %s after %s", ErrActionTimeout, run.Name, timeout)
+	}
+
+	return err
+}
+
+// Deaths, chickens and the supervisor requests stop the run, retrying the action would only delay it.
+func isRetryableActionError(err error) bool {
+	for _, stop := range []error{health.ErrDied, health.ErrChicken, health.ErrMercChicken, drop.ErrInterrupt, ErrMulingNeeded, ErrBuildSwitched, ErrActionTimeout, context.ErrActionDeadline} {
+		if errors.Is(err, stop) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -51,6 +51,39 @@ var prioritizedShrines = []struct {
 	{shrineType: object.SkillShrine, state: state.ShrineSkill},
 }
 
+// healthWells restore life and mana and cure the poison, manaWells only restore mana
+var healthWells = []object.Name{
+	object.HealingWell,
+	object.Act1WildernessWell,
+	object.CathedralWell,
+	object.DesertWell,
+	object.CaveWell,
+	object.JungleHealWell,
+	object.Act3SewersHealthWell,
+	object.MaggotHealthWell,
+	object.ArcaneHealthWell,
+	object.Act2TombWell,
+	object.Act3KurastHealthWell,
+	object.ExpansionWell,
+	object.ExpansionSnowyWell,
+	object.WorldstoneWell,
+	object.ExpansionTempleWell,
+	object.IceCaveWell,
+}
+
+var manaWells = []object.Name{
+	object.ManaWell1,
+	object.ManaWell2,
+	object.ManaWell3,
+	object.ManaWell4,
+	object.ManaWell5,
+	object.ManaWell7,
+	object.Act3SewersManaWell,
+	object.MaggotManaWell,
+	object.ArcaneManaWell,
+	object.Act3KurastManaWell,
+}
+
 var curseBreakingShrines = []object.ShrineType{
 	object.ExperienceShrine,
 	object.ManaRegenShrine,
@@ -122,7 +155,12 @@ func ensureAreaSync(ctx *context.Status, expectedArea area.ID) error {
 }
 
 func MoveToArea(dst area.ID) error {
+	return RunAction("MoveToArea", func() error { return moveToArea(dst) })
+}
+
+func moveToArea(dst area.ID) error {
 	ctx := context.Get()
+	defer ctx.Profiler.Enter(context.PhaseTravel)()
 	ctx.SetLastAction("MoveToArea")
 
 	// Proactive death check at the start of the action
@@ -276,7 +314,7 @@ func MoveToArea(dst area.ID) error {
 
 			if currentDistance > 7 {
 				// For distances > 7, recursively call MoveToArea as it includes the entrance interaction
-				return MoveToArea(dst)
+				return moveToArea(dst)
 			} else if currentDistance > 3 && currentDistance <= 7 {
 				// For distances between 4 and 7, use direct click
 				screenX, screenY := ctx.PathFinder.GameCoordsToScreenCords(
@@ -322,11 +360,12 @@ func MoveToArea(dst area.ID) error {
 	}
 
 	event.Send(event.InteractedTo(event.Text(ctx.Name, ""), int(dst), event.InteractionTypeEntrance))
-	return nil
+	return AssessDanger()
 }
 
 func MoveToCoords(to data.Position, options ...step.MoveOption) error {
 	ctx := context.Get()
+	defer ctx.Profiler.Enter(context.PhaseTravel)()
 
 	// Proactive death check at the start of the action
 	if err := checkPlayerDeath(ctx); err != nil {
@@ -386,6 +425,7 @@ func getPathOffsets(to data.Position) (int, int) {
 
 func MoveTo(toFunc func() (data.Position, bool), options ...step.MoveOption) error {
 	ctx := context.Get()
+	defer ctx.Profiler.Enter(context.PhaseTravel)()
 	ctx.SetLastAction("MoveTo")
 
 	// Initialize options
@@ -509,20 +549,28 @@ func MoveTo(toFunc func() (data.Position, bool), options ...step.MoveOption) err
 				}
 			}
 
+			//Check wells nearby, they're used as the shrines to save potions
+			if !ignoreShrines && ctx.CharacterCfg.Health.FreeHealing.Enabled && shrine.ID == 0 {
+				if closestWell := findClosestWell(); closestWell != nil && !blacklistedInteractions[closestWell.ID] {
+					shrine = *closestWell
+					chest = (data.Object{})
+				}
+			}
+
 			// Check chests nearby
 			if shrine.ID == 0 && chest.ID == 0 {
 				// "Super chests only" has priority over the generic "all chests" mode.
 				if ctx.CharacterCfg.Game.InteractWithSuperChests && !ctx.CharacterCfg.Game.InteractWithChests {
 					if closestChest, chestFound := ctx.PathFinder.GetClosestSuperChest(ctx.Data.PlayerUnit.Position, true); chestFound {
-						blacklisted, exists := blacklistedInteractions[closestChest.ID]
-						if !exists || !blacklisted {
+						// A locked chest is only skipped while we have no key, it's opened once we pick one up
+						if !blacklistedInteractions[closestChest.ID] && CanOpenChest(*closestChest) {
 							chest = *closestChest
 						}
 					}
 				} else if ctx.CharacterCfg.Game.InteractWithChests {
 					if closestChest, chestFound := ctx.PathFinder.GetClosestChest(ctx.Data.PlayerUnit.Position, true); chestFound {
-						blacklisted, exists := blacklistedInteractions[closestChest.ID]
-						if !exists || !blacklisted {
+						// A locked chest is only skipped while we have no key, it's opened once we pick one up
+						if !blacklistedInteractions[closestChest.ID] && CanOpenChest(*closestChest) {
 							chest = *closestChest
 							//ctx.Logger.Debug(fmt.Sprintf("MoveTo: Found chest at %v, redirecting destination from %v", chest.Position, targetPosition))
 						}
@@ -604,10 +652,14 @@ func MoveTo(toFunc func() (data.Position, bool), options ...step.MoveOption) err
 		if distanceToTarget <= finishMoveDist || (adjustMinDist && distanceToTarget <= finishMoveDist*2) {
 			if shrine.ID != 0 && targetPosition == shrine.Position {
 				//Handle shrine if any
-				if err := InteractObject(shrine, func() bool {
+				isCompleted := func() bool {
 					obj, found := ctx.Data.Objects.FindByID(shrine.ID)
 					return found && !obj.Selectable
-				}); err != nil {
+				}
+				if isWell(shrine) {
+					isCompleted = wellUsed()
+				}
+				if err := InteractObject(shrine, isCompleted); err != nil {
 					ctx.Logger.Warn("Failed to interact with shrine", slog.Any("error", err))
 				}
 				blacklistedInteractions[shrine.ID] = true
@@ -727,6 +779,62 @@ func MoveTo(toFunc func() (data.Position, bool), options ...step.MoveOption) err
 	}
 }
 
+// findClosestWell returns the closest usable well when life or mana are below the threshold or the player is poisoned.
+func findClosestWell() *data.Object {
+	ctx := context.Get()
+
+	cfg := ctx.CharacterCfg.Health.FreeHealing
+	if ctx.Data.PlayerUnit.IsDead() || ctx.Data.AreaData.Area.IsTown() {
+		return nil
+	}
+
+	needsLife := ctx.Data.PlayerUnit.HPPercent() < cfg.WellAt || ctx.Data.PlayerUnit.States.HasState(state.Poison)
+	needsMana := ctx.Data.PlayerUnit.MPPercent() < cfg.WellAt
+	if !needsLife && !needsMana {
+		return nil
+	}
+
+	maxDistance := cfg.WellMaxDistance
+	if maxDistance <= 0 {
+		maxDistance = 30
+	}
+
+	var closestWell *data.Object
+	minDistance := maxDistance
+	for _, o := range ctx.Data.Objects {
+		if !o.Selectable {
+			continue
+		}
+		if !(slices.Contains(healthWells, o.Name) || (needsMana && slices.Contains(manaWells, o.Name))) {
+			continue
+		}
+
+		if distance := ctx.PathFinder.DistanceFromMe(o.Position); distance < minDistance {
+			minDistance = distance
+			closestWell = &o
+		}
+	}
+
+	return closestWell
+}
+
+func isWell(o data.Object) bool {
+	return slices.Contains(healthWells, o.Name) || slices.Contains(manaWells, o.Name)
+}
+
+// wellUsed tells if drinking from the well worked: the life or the mana went up or the poison is gone. The wells often
+// stay selectable once used, unlike the shrines.
+func wellUsed() func() bool {
+	ctx := context.Get()
+	hp, mp := ctx.Data.PlayerUnit.HPPercent(), ctx.Data.PlayerUnit.MPPercent()
+	poisoned := ctx.Data.PlayerUnit.States.HasState(state.Poison)
+
+	return func() bool {
+		pu := ctx.Data.PlayerUnit
+		return pu.HPPercent() > hp || pu.MPPercent() > mp || (poisoned && !pu.States.HasState(state.Poison))
+	}
+}
+
 func findClosestShrine(maxScanDistance float64) *data.Object {
 	ctx := context.Get()
 
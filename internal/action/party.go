@@ -0,0 +1,61 @@
+package action
+
+import (
+	"slices"
+
+	"github.com/hectorgimenez/koolo/internal/context"
+	"github.com/hectorgimenez/koolo/internal/game"
+	"github.com/hectorgimenez/koolo/internal/ui"
+	"github.com/hectorgimenez/koolo/internal/utils"
+)
+
+// PartyWith clicks the party button of the given players, it invites them or accepts their invitation. With no
+// names every other player in the game is invited. Each player is only clicked once per game.
+func PartyWith(names ...string) {
+	ctx := context.Get()
+	ctx.SetLastAction("PartyWith")
+
+	if ctx.CurrentGame.PartyInvited == nil {
+		ctx.CurrentGame.PartyInvited = make(map[string]bool)
+	}
+
+	rows := make(map[string]int)
+	row := 0
+	for _, member := range ctx.Data.Roster {
+		if member.Name == ctx.Data.PlayerUnit.Name {
+			continue
+		}
+		rows[member.Name] = row
+		row++
+	}
+
+	pending := make(map[string]int)
+	for name, row := range rows {
+		if ctx.CurrentGame.PartyInvited[name] {
+			continue
+		}
+		if len(names) == 0 || slices.Contains(names, name) {
+			pending[name] = row
+		}
+	}
+	if len(pending) == 0 {
+		return
+	}
+
+	ctx.HID.PressKeyBinding(ctx.Data.KeyBindings.PartyScreen)
+	utils.PingSleep(utils.Light, 300) // Light operation: Wait for party screen to open
+
+	for name, row := range pending {
+		x, y := ui.PartyInviteBtnX, ui.PartyInviteBtnY+row*ui.PartyRowHeight
+		if ctx.Data.LegacyGraphics {
+			x, y = ui.PartyInviteBtnXClassic, ui.PartyInviteBtnYClassic+row*ui.PartyRowHeightClassic
+		}
+		ctx.HID.Click(game.LeftButton, x, y)
+		ctx.CurrentGame.PartyInvited[name] = true
+		ctx.Logger.Debug("Party button clicked", "player", name)
+		utils.Sleep(200)
+	}
+
+	ctx.HID.PressKeyBinding(ctx.Data.KeyBindings.PartyScreen)
+	utils.Sleep(200)
+}
@@ -18,7 +18,7 @@ func shouldMatchRulesOnly(i data.Item) bool {
 	ctx := context.Get()
 
 	// Evaluate tier rules (player and merc tiers).
-	playerRule, mercRule := ctx.Data.CharacterCfg.Runtime.Rules.EvaluateTiers(i, ctx.Data.CharacterCfg.Runtime.TierRules)
+	playerRule, mercRule := ctx.Data.CharacterCfg.Runtime.PickupRules.EvaluateTiers(i, ctx.Data.CharacterCfg.Runtime.PickupTierRules)
 	if playerRule.Tier() > 0.0 || mercRule.MercTier() > 0.0 {
 		// If the item does not need to be identified (QualitySuperior or lower),
 		// check whether it actually upgrades the equipment.
@@ -39,7 +39,7 @@ func shouldMatchRulesOnly(i data.Item) bool {
 	}
 
 	// Evaluate all rules ignoring tiers.  The result can be FullMatch, Partial, or NoMatch.
-	matchedRule, result := ctx.Data.CharacterCfg.Runtime.Rules.EvaluateAllIgnoreTiers(i)
+	matchedRule, result := ctx.Data.CharacterCfg.Runtime.PickupRules.EvaluateAllIgnoreTiers(i)
 	switch result {
 	case nip.RuleResultNoMatch:
 		return false
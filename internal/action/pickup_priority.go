@@ -0,0 +1,88 @@
+package action
+
+import (
+	"sort"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/item"
+	"github.com/hectorgimenez/koolo/internal/context"
+)
+
+const (
+	pickupTierConsumable = iota
+	pickupTierCommon
+	pickupTierRare
+	pickupTierValuable
+)
+
+// pickupTierDistance is how much farther we walk for an item one tier above, beyond that the closer item goes first.
+const pickupTierDistance = 15
+
+// pickupTier returns how valuable the ground item is, valuable items are grabbed first in case the pickup gets
+// interrupted by a chicken or by the area getting too dangerous.
+func pickupTier(i data.Item) int {
+	itmType := i.Type()
+
+	switch {
+	case i.IsFromQuest(),
+		itmType.IsType(item.TypeRune),
+		i.Quality == item.QualityUnique,
+		i.Quality == item.QualitySet,
+		itmType.IsType(item.TypeSmallCharm), itmType.IsType(item.TypeMediumCharm), itmType.IsType(item.TypeLargeCharm),
+		itmType.IsType(item.TypeJewel):
+		return pickupTierValuable
+	case i.Name == "Gold",
+		i.IsPotion(),
+		itmType.IsType(item.TypeScroll),
+		itmType.IsType(item.TypeKey):
+		return pickupTierConsumable
+	case i.Quality == item.QualityRare:
+		return pickupTierRare
+	}
+
+	return pickupTierCommon
+}
+
+// pickupRadius returns the max distance the item is fetched from as set by item class, maxDistance is the radius of the
+// pickup and only applies to the potions. 0 or less means no limit.
+func pickupRadius(ctx *context.Status, i data.Item, maxDistance int) int {
+	radius := ctx.CharacterCfg.Game.PickupRadius
+	itmType := i.Type()
+
+	switch {
+	case i.Name == "Gold":
+		return radius.Gold
+	case i.IsPotion():
+		if radius.Potions > 0 {
+			return radius.Potions
+		}
+		return maxDistance
+	case itmType.IsType(item.TypeRune):
+		return radius.Runes
+	case itmType.IsType(item.TypeGem):
+		return radius.Gems
+	}
+
+	return radius.Other
+}
+
+// sortItemsByPickupPriority orders the items by tier traded off against their distance, the caller priority goes first.
+func sortItemsByPickupPriority(items []data.Item, isPriority func(data.Item) bool) {
+	ctx := context.Get()
+
+	scores := make(map[data.UnitID]int, len(items))
+	for _, i := range items {
+		scores[i.UnitID] = pickupTier(i)*pickupTierDistance - ctx.PathFinder.DistanceFromMe(i.Position)
+	}
+
+	sort.SliceStable(items, func(a, b int) bool {
+		if isPriority != nil {
+			priorityA, priorityB := isPriority(items[a]), isPriority(items[b])
+			if priorityA != priorityB {
+				return priorityA
+			}
+		}
+
+		return scores[items[a].UnitID] > scores[items[b].UnitID]
+	})
+}
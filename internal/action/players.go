@@ -0,0 +1,40 @@
+package action
+
+import (
+	"fmt"
+
+	"github.com/hectorgimenez/koolo/internal/context"
+)
+
+// PlayersCountFor returns the /players count configured for the run, 0 when it shouldn't be changed.
+func PlayersCountFor(ctx *context.Status, run string) int {
+	cfg := ctx.CharacterCfg.Game.Players
+	if count, found := cfg.PerRun[run]; found {
+		return count
+	}
+
+	return cfg.Default
+}
+
+// SetPlayersCount types /players with the count configured for the run. The command only works in games created without
+// Battle.net, it's typed again only when the count changes since the game keeps it until it ends.
+func SetPlayersCount(run string) {
+	ctx := context.Get()
+	ctx.SetLastAction("SetPlayersCount")
+
+	if !ctx.CharacterCfg.Game.Players.Enabled || ctx.CharacterCfg.Flavor().Online() {
+		return
+	}
+
+	count := PlayersCountFor(ctx, run)
+	if count < 1 || count > 8 {
+		return
+	}
+	if ctx.CurrentGame.PlayersCount == count {
+		return
+	}
+
+	ctx.Logger.Info(fmt.Sprintf("Setting players count to %d for %s", count, run))
+	Say(fmt.Sprintf("/players %d", count))
+	ctx.CurrentGame.PlayersCount = count
+}
@@ -0,0 +1,81 @@
+package action
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/koolo/internal/context"
+)
+
+var potionGradePrefixes = []struct {
+	prefix string
+	grade  int
+}{
+	{"Minor", 1},
+	{"Light", 2},
+	{"Greater", 4},
+	{"Super", 5},
+	{"Full", 5},
+}
+
+// potionGrade returns how good the potion is within its type, from 1 (minor) to 5 (super or full rejuvenation).
+func potionGrade(i data.Item) int {
+	for _, p := range potionGradePrefixes {
+		if strings.HasPrefix(string(i.Name), p.prefix) {
+			return p.grade
+		}
+	}
+
+	return 3
+}
+
+func potionTypeOf(i data.Item) data.PotionType {
+	switch {
+	case i.IsHealingPotion():
+		return data.HealingPotion
+	case i.IsManaPotion():
+		return data.ManaPotion
+	default:
+		return data.RejuvenationPotion
+	}
+}
+
+// missingPotions returns how many potions of each type the belt layout and the inventory potion counts are missing.
+func missingPotions() map[data.PotionType]int {
+	ctx := context.Get()
+
+	missing := make(map[data.PotionType]int, 3)
+	for _, potionType := range []data.PotionType{data.HealingPotion, data.ManaPotion, data.RejuvenationPotion} {
+		missing[potionType] = ctx.BeltManager.GetMissingCount(potionType) + ctx.Data.MissingPotionCountInInventory(potionType)
+	}
+
+	return missing
+}
+
+// selectPotionsToPickup picks from the ground potions only as many as the belt and the inventory need, the best
+// graded and closest ones first, so we don't walk around dense drop areas for potions we don't need.
+func selectPotionsToPickup(potions []data.Item) []data.Item {
+	ctx := context.Get()
+
+	missing := missingPotions()
+	scores := make(map[data.UnitID]int, len(potions))
+	for _, p := range potions {
+		scores[p.UnitID] = potionGrade(p)*5 - ctx.PathFinder.DistanceFromMe(p.Position)
+	}
+	sort.SliceStable(potions, func(a, b int) bool {
+		return scores[potions[a].UnitID] > scores[potions[b].UnitID]
+	})
+
+	var selected []data.Item
+	for _, p := range potions {
+		potionType := potionTypeOf(p)
+		if missing[potionType] <= 0 {
+			continue
+		}
+		selected = append(selected, p)
+		missing[potionType]--
+	}
+
+	return selected
+}
@@ -0,0 +1,203 @@
+package action
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/area"
+	"github.com/hectorgimenez/d2go/pkg/data/item"
+	"github.com/hectorgimenez/d2go/pkg/data/npc"
+	"github.com/hectorgimenez/d2go/pkg/data/quest"
+	"github.com/hectorgimenez/koolo/internal/action/step"
+	"github.com/hectorgimenez/koolo/internal/context"
+	"github.com/hectorgimenez/koolo/internal/game"
+	"github.com/hectorgimenez/koolo/internal/ui"
+	"github.com/hectorgimenez/koolo/internal/utils"
+	"github.com/lxn/win"
+)
+
+// questReward is a reward waiting for the character in town once its quest is done.
+type questReward struct {
+	name  string
+	quest quest.Quest
+	town  area.ID
+	npc   npc.ID
+	// Item given with the reward, used right away. Empty when talking to the NPC grants it.
+	item item.Name
+}
+
+var questRewards = []questReward{
+	// Akara keeps the respec until it's used, claiming it only saves it
+	{name: "Den of Evil respec", quest: quest.Act1DenOfEvil, town: area.RogueEncampment, npc: npc.Akara},
+	{name: "Radament skill book", quest: quest.Act2RadamentsLair, town: area.LutGholein, npc: npc.Atma, item: "BookofSkill"},
+	{name: "Golden Bird life potion", quest: quest.Act3TheGoldenBird, town: area.KurastDocks, npc: npc.Alkor, item: "PotionOfLife"},
+	{name: "Izual skill points", quest: quest.Act4TheFallenAngel, town: area.ThePandemoniumFortress, npc: npc.Tyrael2},
+	{name: "Anya resistance scroll", quest: quest.Act5PrisonOfIce, town: area.Harrogath, npc: npc.Malah, item: "ScrollOfResistance"},
+}
+
+// QuestKeptOpen returns true while the quest is kept open on purpose for its quest drops (quest bug), the steps
+// completing it have to be skipped. Leveling characters need the quests done, they never keep them open.
+func QuestKeptOpen(q quest.Quest) bool {
+	ctx := context.Get()
+	if _, isLevelingChar := ctx.Char.(context.LevelingCharacter); isLevelingChar {
+		return false
+	}
+
+	bugs := ctx.CharacterCfg.Game.QuestBugs
+	kept := false
+	switch q {
+	case quest.Act1SistersToTheSlaughter:
+		kept = bugs.Andariel
+	case quest.Act3TheBlackenedTemple:
+		kept = bugs.Council
+	case quest.Act3TheGuardian:
+		kept = bugs.Mephisto
+	case quest.Act5SiegeOnHarrogath:
+		kept = bugs.Shenk
+	}
+
+	// A quest already finished can't be opened again
+	return kept && !ctx.Data.Quests[q].HasStatus(quest.StatusRewardGranted)
+}
+
+// ClaimQuestRewards goes to the towns holding a pending quest reward, talks to the NPC giving it and uses the reward
+// items still in the inventory. The character is left in the town of the last claimed reward.
+func ClaimQuestRewards() error {
+	ctx := context.Get()
+	ctx.SetLastAction("ClaimQuestRewards")
+
+	for _, r := range questRewards {
+		ctx.PauseIfNotPriority()
+
+		// The item may have been handed over already, it only needs to be used
+		if r.item != "" && useInventoryItem(r.item) {
+			ctx.Logger.Info("Quest reward used", "reward", r.name)
+			continue
+		}
+
+		if !ctx.Data.Quests[r.quest].HasStatus(quest.StatusRewardPending) {
+			continue
+		}
+
+		ctx.Logger.Info("Claiming quest reward", "reward", r.name)
+		if ctx.Data.PlayerUnit.Area != r.town {
+			if err := WayPoint(r.town); err != nil {
+				return fmt.Errorf("going to claim %s: %w", r.name, err)
+			}
+		}
+		if err := InteractNPC(r.npc); err != nil {
+			ctx.Logger.Warn("Failed claiming quest reward", "reward", r.name, "error", err)
+			continue
+		}
+		step.CloseAllMenus()
+		utils.PingSleep(utils.Medium, 500)
+		ctx.RefreshGameData()
+
+		if r.item != "" && !useInventoryItem(r.item) {
+			ctx.Logger.Warn("Quest reward item not found after claiming it", "reward", r.name, "item", r.item)
+		}
+	}
+
+	if name := ctx.CharacterCfg.Game.Quests.PersonalizeItem; name != "" && ctx.Data.Quests[quest.Act5BetrayalOfHarrogath].HasStatus(quest.StatusRewardPending) {
+		if err := personalizeItem(name); err != nil {
+			ctx.Logger.Warn("Failed personalizing item", "item", name, "error", err)
+		}
+	}
+
+	if err := ImbueIfAvailable(); err != nil {
+		ctx.Logger.Warn("Failed using Charsi's imbue", "error", err)
+	}
+	if err := SocketWithLarzuk(); err != nil {
+		ctx.Logger.Warn("Failed using Larzuk's socket", "error", err)
+	}
+
+	return nil
+}
+
+// personalizeItem has Anya personalize the first matching item of the inventory or the stash. Equipped items are left
+// alone, they would have to be taken off first.
+func personalizeItem(name string) error {
+	ctx := context.Get()
+	ctx.SetLastAction("personalizeItem")
+
+	var target data.Item
+	found := false
+	for _, i := range ctx.Data.Inventory.ByLocation(item.LocationInventory, item.LocationStash, item.LocationSharedStash) {
+		if !i.IsNamed && itemNameMatches(i, name) {
+			target, found = i, true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("no %q to personalize in the inventory or stash", name)
+	}
+
+	if ctx.Data.PlayerUnit.Area != area.Harrogath {
+		if err := WayPoint(area.Harrogath); err != nil {
+			return err
+		}
+	}
+
+	if target.Location.LocationType != item.LocationInventory {
+		if err := TakeItemsFromStash([]data.Item{target}); err != nil {
+			return err
+		}
+		step.CloseAllMenus()
+		ctx.RefreshGameData()
+		if target, found = ctx.Data.Inventory.FindByID(target.UnitID); !found || target.Location.LocationType != item.LocationInventory {
+			return errors.New("item not moved to the inventory")
+		}
+	}
+
+	ctx.Logger.Info("Claiming Anya's personalization", "item", target.IdentifiedName)
+	// Same Anya position fix as gambling
+	_ = MoveToCoords(data.Position{X: 5107, Y: 5119})
+	if err := InteractNPC(npc.Drehya); err != nil {
+		return err
+	}
+	// Personalize comes after talk, trade and gamble
+	ctx.HID.KeySequence(win.VK_HOME, win.VK_DOWN, win.VK_DOWN, win.VK_DOWN, win.VK_RETURN)
+	utils.PingSleep(utils.Medium, 500)
+
+	screenPos := ui.GetScreenCoordsForItem(target)
+	ctx.HID.Click(game.LeftButton, screenPos.X, screenPos.Y)
+	utils.PingSleep(utils.Medium, 800)
+	step.CloseAllMenus()
+
+	ctx.RefreshGameData()
+	if personalized, found := ctx.Data.Inventory.FindByID(target.UnitID); found && !personalized.IsNamed {
+		return errors.New("item wasn't personalized")
+	}
+
+	return nil
+}
+
+// itemNameMatches compares the name with the item code, its identified name and its base name, ignoring the case.
+func itemNameMatches(i data.Item, name string) bool {
+	return strings.EqualFold(string(i.Name), name) || strings.EqualFold(i.IdentifiedName, name) || strings.EqualFold(i.Desc().Name, name)
+}
+
+// useInventoryItem right clicks the item in the inventory, false when there's none.
+func useInventoryItem(name item.Name) bool {
+	ctx := context.Get()
+
+	itm, found := ctx.Data.Inventory.Find(name, item.LocationInventory)
+	if !found {
+		return false
+	}
+
+	if err := step.OpenInventory(); err != nil {
+		ctx.Logger.Warn("Failed opening inventory to use item", "item", name, "error", err)
+		return false
+	}
+
+	screenPos := ui.GetScreenCoordsForItem(itm)
+	ctx.HID.Click(game.RightButton, screenPos.X, screenPos.Y)
+	utils.PingSleep(utils.Medium, 500)
+	step.CloseAllMenus()
+	ctx.RefreshGameData()
+
+	return true
+}
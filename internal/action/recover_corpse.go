@@ -2,34 +2,144 @@ package action
 
 import (
 	"errors"
+	"sync"
 
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/item"
 	"github.com/hectorgimenez/koolo/internal/context"
 	"github.com/hectorgimenez/koolo/internal/game"
 	"github.com/hectorgimenez/koolo/internal/ui"
 	"github.com/hectorgimenez/koolo/internal/utils"
 )
 
+const (
+	// Dying again before recovering leaves one more corpse, the game only shows them one at a time
+	maxCorpses             = 5
+	corpseRecoveryAttempts = 15
+	corpseMaxClickDistance = 10
+)
+
+// equipmentSnapshots keeps the gear equipped the last time we left town alive, by supervisor. Unit IDs change
+// between games so the items are matched by name and quality.
+var equipmentSnapshots = struct {
+	mu    sync.Mutex
+	items map[string][]data.Item
+}{items: make(map[string][]data.Item)}
+
 func RecoverCorpse() error {
 	ctx := context.Get()
 	ctx.SetLastAction("RecoverCorpse")
 
+	if !ctx.Data.Corpse.Found {
+		return nil
+	}
+
+	ctx.Logger.Info("Corpse found, let's recover our stuff...")
+
+	recovered := 0
+	for ctx.Data.Corpse.Found && recovered < maxCorpses {
+		if err := recoverNextCorpse(); err != nil {
+			return err
+		}
+		recovered++
+		ctx.RefreshGameData()
+	}
 	if ctx.Data.Corpse.Found {
-		ctx.Logger.Info("Corpse found, let's recover our stuff...")
-
-		attempts := 0
-		for ctx.Data.Corpse.Found && attempts < 15 {
-			utils.Sleep(500)
-			x, y := ui.GameCoordsToScreenCords(
-				ctx.Data.Corpse.Position.X,
-				ctx.Data.Corpse.Position.Y,
-			)
-			ctx.HID.Click(game.LeftButton, x, y)
-			attempts++
+		return errors.New("could not recover all the corpses")
+	}
+	if recovered > 1 {
+		ctx.Logger.Info("Recovered multiple corpses", "corpses", recovered)
+	}
+
+	verifyRecoveredEquipment()
+
+	return nil
+}
+
+// recoverNextCorpse clicks the corpse shown by the game until it's gone or the game shows the next one. A corpse still
+// there once some of its items came back keeps the items we couldn't pick up, it isn't counted as recovered.
+func recoverNextCorpse() error {
+	ctx := context.Get()
+
+	corpse := ctx.Data.Corpse.Position
+	if ctx.PathFinder.DistanceFromMe(corpse) > corpseMaxClickDistance {
+		if err := MoveToCoords(corpse); err != nil {
+			return err
 		}
-		if ctx.Data.Corpse.Found {
-			return errors.New("could not recover corpse")
+	}
+
+	carried := len(ctx.Data.Inventory.ByLocation(item.LocationEquipped, item.LocationInventory))
+	for attempts := 0; attempts < corpseRecoveryAttempts; attempts++ {
+		utils.Sleep(500)
+		x, y := ui.GameCoordsToScreenCords(corpse.X, corpse.Y)
+		ctx.HID.Click(game.LeftButton, x, y)
+
+		ctx.RefreshGameData()
+		if !ctx.Data.Corpse.Found || ctx.Data.Corpse.Position != corpse {
+			return nil
 		}
 	}
 
-	return nil
+	if len(ctx.Data.Inventory.ByLocation(item.LocationEquipped, item.LocationInventory)) > carried {
+		return errors.New("corpse recovered partially, there is no room for the items left on it")
+	}
+
+	return errors.New("could not recover corpse")
+}
+
+// SnapshotEquipment remembers the equipped gear, compared with the gear we wear after recovering a corpse.
+func SnapshotEquipment() {
+	ctx := context.Get()
+
+	if ctx.Data.Corpse.Found {
+		return
+	}
+
+	equipmentSnapshots.mu.Lock()
+	defer equipmentSnapshots.mu.Unlock()
+	equipmentSnapshots.items[ctx.Name] = ctx.Data.Inventory.ByLocation(item.LocationEquipped)
+}
+
+// verifyRecoveredEquipment equips again the items of the last snapshot that came back from the corpse into the
+// inventory, this happens when the slot was taken when picking up the corpse.
+func verifyRecoveredEquipment() {
+	ctx := context.Get()
+
+	equipmentSnapshots.mu.Lock()
+	snapshot := equipmentSnapshots.items[ctx.Name]
+	equipmentSnapshots.mu.Unlock()
+	if len(snapshot) == 0 {
+		return
+	}
+
+	ctx.RefreshGameData()
+	for _, expected := range snapshot {
+		bodyLoc := expected.Location.BodyLocation
+		if sameGearItem(GetEquippedItem(ctx.Data.Inventory, bodyLoc), expected) {
+			continue
+		}
+
+		found := false
+		for _, itm := range ctx.Data.Inventory.ByLocation(item.LocationInventory) {
+			if !sameGearItem(itm, expected) {
+				continue
+			}
+			found = true
+
+			ctx.Logger.Info("Equipping item back after corpse recovery", "item", itm.Name, "location", bodyLoc)
+			if err := equip(itm, bodyLoc, item.LocationEquipped); err != nil {
+				ctx.Logger.Warn("Failed equipping item back", "item", itm.Name, "error", err)
+			}
+			ctx.RefreshGameData()
+			break
+		}
+
+		if !found {
+			ctx.Logger.Warn("Item equipped before dying is missing", "item", expected.Name, "location", bodyLoc)
+		}
+	}
+}
+
+func sameGearItem(a, b data.Item) bool {
+	return a.Name == b.Name && a.Quality == b.Quality && a.Ethereal == b.Ethereal && a.IdentifiedName == b.IdentifiedName
 }
@@ -26,6 +26,12 @@ func RepairTownRoutine() error {
 		return nil
 	}
 
+	if err := ReplenishJavelinsFromStash(); err != nil {
+		ctx.Logger.Warn("Failed to replenish javelins from stash", "error", err)
+	}
+
+	warnEtherealDurability(ctx)
+
 	force, reason := shouldForceRepairAllForJavazonDkQuantity(ctx)
 	if force {
 		ctx.Logger.Info(reason)
@@ -33,6 +39,11 @@ func RepairTownRoutine() error {
 		return repairAllAtNPC(repairNPC)
 	}
 
+	if chargesNeedRecharge(ctx) {
+		ctx.Logger.Info("Recharging item charges at the repair NPC")
+		return repairAllAtNPC(town.GetTownByArea(ctx.Data.PlayerUnit.Area).RepairNPC())
+	}
+
 	return Repair()
 }
 
@@ -167,80 +178,19 @@ func Repair() error {
 	ctx.SetLastAction("Repair")
 
 	for _, i := range ctx.Data.Inventory.ByLocation(item.LocationEquipped) {
-		triggerRepair := false
-		logMessage := ""
-
-		_, indestructible := i.FindStat(stat.Indestructible, 0)
-		quantity, quantityFound := i.FindStat(stat.Quantity, 0)
-
-		if indestructible && !quantityFound {
-			continue
-		}
-
-		if i.Ethereal && !quantityFound {
-			continue
-		}
-
-		if quantityFound {
-			if ctx.CharacterCfg.Character.Class == "javazon" &&
-				ctx.CharacterCfg.Character.Javazon.DensityKillerEnabled {
-				itmType := i.Type()
-				if itmType.IsType(item.TypeJavelin) || itmType.IsType(item.TypeAmazonJavelin) {
-					continue
-				}
-			}
-
-			if quantity.Value < 15 || i.IsBroken {
-				triggerRepair = true
-				logMessage = fmt.Sprintf("Replenishing %s, quantity is %d", i.Name, quantity.Value)
-			}
-		} else {
-			durability, found := i.FindStat(stat.Durability, 0)
-			maxDurability, maxDurabilityFound := i.FindStat(stat.MaxDurability, 0)
-			durabilityPercent := -1
-
-			if maxDurabilityFound && found {
-				durabilityPercent = int((float64(durability.Value) / float64(maxDurability.Value)) * 100)
-			}
-
-			if i.IsBroken || (durabilityPercent != -1 && durabilityPercent <= 20) {
-				triggerRepair = true
-				logMessage = fmt.Sprintf("Repairing %s, item durability is %d percent", i.Name, durabilityPercent)
+		if ctx.CharacterCfg.Character.Class == "javazon" && ctx.CharacterCfg.Character.Javazon.DensityKillerEnabled {
+			itmType := i.Type()
+			if itmType.IsType(item.TypeJavelin) || itmType.IsType(item.TypeAmazonJavelin) {
+				continue
 			}
 		}
 
+		triggerRepair, logMessage := itemNeedsRepair(ctx, i)
 		if triggerRepair {
 			ctx.Logger.Info(logMessage)
 
 			repairNPC := town.GetTownByArea(ctx.Data.PlayerUnit.Area).RepairNPC()
-			if repairNPC == npc.Larzuk {
-				MoveToCoords(data.Position{X: 5135, Y: 5046})
-			}
-			if repairNPC == npc.Hratli {
-				if err := FindHratliEverywhere(); err != nil {
-					return err
-				}
-			}
-
-			if err := InteractNPC(repairNPC); err != nil {
-				return err
-			}
-
-			if repairNPC != npc.Halbu {
-				ctx.HID.KeySequence(win.VK_HOME, win.VK_DOWN, win.VK_RETURN)
-			} else {
-				ctx.HID.KeySequence(win.VK_HOME, win.VK_RETURN)
-			}
-
-			utils.Sleep(100)
-			if ctx.Data.LegacyGraphics {
-				ctx.HID.Click(game.LeftButton, ui.RepairButtonXClassic, ui.RepairButtonYClassic)
-			} else {
-				ctx.HID.Click(game.LeftButton, ui.RepairButtonX, ui.RepairButtonY)
-			}
-			utils.Sleep(500)
-
-			return step.CloseAllMenus()
+			return repairAllAtNPC(repairNPC)
 		}
 	}
 
@@ -252,54 +202,136 @@ func RepairRequired() bool {
 	ctx.SetLastAction("RepairRequired")
 
 	for _, i := range ctx.Data.Inventory.ByLocation(item.LocationEquipped) {
-		_, indestructible := i.FindStat(stat.Indestructible, 0)
-		quantity, quantityFound := i.FindStat(stat.Quantity, 0)
+		if needsRepair, _ := itemNeedsRepair(ctx, i); needsRepair {
+			return true
+		}
+	}
 
-		if indestructible && !quantityFound {
+	return chargesNeedRecharge(ctx)
+}
+
+// chargesNeedRecharge tells if a configured charged skill is low on charges, repairing the item recharges it.
+func chargesNeedRecharge(ctx *context.Status) bool {
+	for _, cs := range ctx.CharacterCfg.Character.ChargedSkills {
+		skillID, found := step.SkillIDByName(cs.Skill)
+		if !found {
 			continue
 		}
-
-		if i.Ethereal && !quantityFound {
+		itm, charges, maxCharges, found := step.ItemCharges(skillID)
+		if !found || charges >= maxCharges {
 			continue
 		}
+		if charges == 0 || charges < cs.RechargeBelow {
+			ctx.Logger.Debug("Charged skill needs a recharge", "skill", cs.Skill, "item", itm.Name, "charges", charges, "max", maxCharges)
+			return true
+		}
+	}
 
-		if quantityFound {
-			if quantity.Value < 15 || i.IsBroken {
-				return true
-			}
-		} else {
-			durability, found := i.FindStat(stat.Durability, 0)
-			maxDurability, maxDurabilityFound := i.FindStat(stat.MaxDurability, 0)
+	return false
+}
 
-			if i.IsBroken || (maxDurabilityFound && !found) {
-				return true
-			}
+// selfRepairs returns true for items that never need a repair visit: indestructible or repairing their durability.
+// Throwing weapons still have to be replenished, and a broken item has to be repaired whatever it is.
+func selfRepairs(i data.Item) bool {
+	if i.IsBroken {
+		return false
+	}
+	if _, quantityFound := i.FindStat(stat.Quantity, 0); quantityFound {
+		return false
+	}
 
-			if found && maxDurabilityFound {
-				durabilityPercent := int((float64(durability.Value) / float64(maxDurability.Value)) * 100)
-				if durabilityPercent <= 20 {
-					return true
-				}
-			}
+	_, indestructible := i.FindStat(stat.Indestructible, 0)
+	_, replenish := i.FindStat(stat.ReplenishDurability, 0)
+
+	return indestructible || replenish
+}
+
+// durabilityPercent returns the current durability percent of the item, or -1 if it has no durability.
+func durabilityPercent(i data.Item) int {
+	durability, found := i.FindStat(stat.Durability, 0)
+	maxDurability, maxDurabilityFound := i.FindStat(stat.MaxDurability, 0)
+	if !maxDurabilityFound || maxDurability.Value <= 0 {
+		return -1
+	}
+	if !found {
+		return 0
+	}
+
+	return int((float64(durability.Value) / float64(maxDurability.Value)) * 100)
+}
+
+// itemNeedsRepair decides if the equipped item requires a repair visit, ethereal items are never repaired.
+func itemNeedsRepair(ctx *context.Status, i data.Item) (bool, string) {
+	if selfRepairs(i) {
+		return false, ""
+	}
+
+	quantity, quantityFound := i.FindStat(stat.Quantity, 0)
+	if quantityFound {
+		if quantity.Value < 15 || i.IsBroken {
+			return true, fmt.Sprintf("Replenishing %s, quantity is %d", i.Name, quantity.Value)
 		}
+		return false, ""
 	}
 
-	return false
+	if i.Ethereal {
+		return false, ""
+	}
+
+	repairPercent := ctx.CharacterCfg.BackToTown.RepairPercent
+	if repairPercent <= 0 {
+		repairPercent = 20
+	}
+
+	pct := durabilityPercent(i)
+	if i.IsBroken || (pct != -1 && pct <= repairPercent) {
+		return true, fmt.Sprintf("Repairing %s, item durability is %d percent", i.Name, pct)
+	}
+
+	return false, ""
+}
+
+// warnEtherealDurability warns once per item and game when an equipped ethereal item crosses the configured durability, they
+// can't be repaired so the user has to replace them.
+func warnEtherealDurability(ctx *context.Status) {
+	threshold := ctx.CharacterCfg.BackToTown.EtherealWarnPercent
+	if threshold <= 0 {
+		return
+	}
+
+	warned := ctx.CurrentGame.EtherealWarned
+	for _, i := range ctx.Data.Inventory.ByLocation(item.LocationEquipped) {
+		if !i.Ethereal || selfRepairs(i) || warned[i.UnitID] {
+			continue
+		}
+		if _, quantityFound := i.FindStat(stat.Quantity, 0); quantityFound {
+			continue
+		}
+
+		pct := durabilityPercent(i)
+		if pct == -1 || pct > threshold {
+			continue
+		}
+
+		warned[i.UnitID] = true
+		ctx.Logger.Warn("Ethereal item durability is low and it can't be repaired", "item", i.Name, "durability", pct)
+	}
 }
 
 func IsEquipmentBroken() bool {
 	ctx := context.Get()
 	ctx.SetLastAction("EquipmentBroken")
 
+	warnEtherealDurability(ctx)
+
 	for _, i := range ctx.Data.Inventory.ByLocation(item.LocationEquipped) {
-		_, indestructible := i.FindStat(stat.Indestructible, 0)
 		_, quantityFound := i.FindStat(stat.Quantity, 0)
 
 		if i.Ethereal && !quantityFound {
 			continue
 		}
 
-		if indestructible && !quantityFound {
+		if selfRepairs(i) {
 			continue
 		}
 
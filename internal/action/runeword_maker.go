@@ -272,12 +272,20 @@ func currentRunewordBaseTier(ctx *context.Status, recipe Runeword, baseType stri
 	return 0, false
 }
 
-func hasBaseForRunewordRecipe(items []data.Item, recipe Runeword) (data.Item, bool) {
-	ctx := context.Get()
+// runewordBaseFilter holds the base restrictions of a recipe with the character overrides applied.
+type runewordBaseFilter struct {
+	ethMode     string
+	qualityMode string
+	baseType    string
+	baseTier    string
+	baseName    string
+}
+
+// runewordBaseFilterFor builds the base filter of the recipe from the character overrides.
+func runewordBaseFilterFor(ctx *context.Status, recipe Runeword) runewordBaseFilter {
 	// Determine if this is a leveling character; overrides are ignored for leveling
 	// to keep the existing, simpler behavior.
 	_, isLevelingChar := ctx.Char.(context.LevelingCharacter)
-	isBarbLeveling := ctx.CharacterCfg.Character.Class == "barb_leveling"
 
 	// Look up any per-runeword overrides configured for this character.
 	overrides := ctx.CharacterCfg.Game.RunewordOverrides
@@ -285,165 +293,177 @@ func hasBaseForRunewordRecipe(items []data.Item, recipe Runeword) (data.Item, bo
 	useOverride := !isLevelingChar && hasOverride
 
 	// Runeword maker uses per-runeword overrides only; reroll rules apply during reroll checks.
-	effectiveEthMode := ""
-	effectiveQualityMode := ""
-	effectiveBaseType := ""
-	effectiveBaseTier := ""
-	effectiveBaseName := ""
+	f := runewordBaseFilter{}
 	if useOverride && ov.EthMode != "" {
-		effectiveEthMode = strings.ToLower(strings.TrimSpace(ov.EthMode))
-		if effectiveEthMode == "any" {
-			effectiveEthMode = ""
+		f.ethMode = strings.ToLower(strings.TrimSpace(ov.EthMode))
+		if f.ethMode == "any" {
+			f.ethMode = ""
 		}
 	}
 	if useOverride && ov.QualityMode != "" {
-		effectiveQualityMode = strings.ToLower(strings.TrimSpace(ov.QualityMode))
-		if effectiveQualityMode == "any" {
-			effectiveQualityMode = ""
+		f.qualityMode = strings.ToLower(strings.TrimSpace(ov.QualityMode))
+		if f.qualityMode == "any" {
+			f.qualityMode = ""
 		}
 	}
 	if useOverride && ov.BaseType != "" {
-		effectiveBaseType = strings.TrimSpace(ov.BaseType)
+		f.baseType = strings.TrimSpace(ov.BaseType)
 	}
 	if useOverride && ov.BaseTier != "" {
-		effectiveBaseTier = strings.ToLower(strings.TrimSpace(ov.BaseTier))
+		f.baseTier = strings.ToLower(strings.TrimSpace(ov.BaseTier))
 	}
 	if useOverride && ov.BaseName != "" {
-		effectiveBaseName = strings.TrimSpace(ov.BaseName)
+		f.baseName = strings.TrimSpace(ov.BaseName)
 	}
 
 	// Auto-select tier based on difficulty if enabled and no manual tier set
-	if effectiveBaseTier == "" && ctx.CharacterCfg.Game.RunewordMaker.AutoTierByDifficulty {
+	if f.baseTier == "" && ctx.CharacterCfg.Game.RunewordMaker.AutoTierByDifficulty {
 		switch ctx.CharacterCfg.Game.Difficulty {
 		case difficulty.Normal:
-			effectiveBaseTier = "normal"
+			f.baseTier = "normal"
 		case difficulty.Nightmare:
-			effectiveBaseTier = "exceptional"
+			f.baseTier = "exceptional"
 		case difficulty.Hell:
-			effectiveBaseTier = "elite"
+			f.baseTier = "elite"
 		}
 	}
 
-	var validBases []data.Item
-	for _, itm := range items {
-		itemType := itm.Type().Code
+	return f
+}
 
-		isValidType := false
-		for _, baseType := range recipe.BaseItemTypes {
-			if itemType == baseType {
-				isValidType = true
+// matches checks the base type, eth, quality, tier and name restrictions, the socket count is left to the caller.
+func (f runewordBaseFilter) matches(ctx *context.Status, itm data.Item, recipe Runeword) bool {
+	itemType := itm.Type().Code
+
+	isValidType := false
+	for _, baseType := range recipe.BaseItemTypes {
+		if itemType == baseType {
+			isValidType = true
+			break
+		}
+	}
+	if !isValidType {
+		return false
+	}
+
+	// Apply user-specified base type restriction when not leveling.
+	// Supports comma-separated list for multiple base types (e.g., "sword,shield" for Spirit)
+	if f.baseType != "" {
+		allowedTypes := strings.Split(f.baseType, ",")
+		typeAllowed := false
+		for _, t := range allowedTypes {
+			if strings.TrimSpace(t) == itemType {
+				typeAllowed = true
 				break
 			}
 		}
-		if !isValidType {
-			continue
+		if !typeAllowed {
+			return false
 		}
+	}
 
-		// Apply user-specified base type restriction when not leveling.
-		// Supports comma-separated list for multiple base types (e.g., "sword,shield" for Spirit)
-		if effectiveBaseType != "" {
-			allowedTypes := strings.Split(effectiveBaseType, ",")
-			typeAllowed := false
-			for _, t := range allowedTypes {
-				if strings.TrimSpace(t) == itemType {
-					typeAllowed = true
-					break
-				}
-			}
-			if !typeAllowed {
-				continue
-			}
+	// exception to use only 1-handed maces/clubs for steel/malice/strength for barb leveling
+	if ctx.CharacterCfg.Character.Class == "barb_leveling" && (recipe.Name == item.RunewordSteel || recipe.Name == item.RunewordMalice || recipe.Name == item.RunewordStrength) {
+		oneHandMaceTypes := []string{item.TypeMace, item.TypeClub}
+		if !slices.Contains(oneHandMaceTypes, itemType) {
+			return false
 		}
-
-		// exception to use only 1-handed maces/clubs for steel/malice/strength for barb leveling
-		if isBarbLeveling && (recipe.Name == item.RunewordSteel || recipe.Name == item.RunewordMalice || recipe.Name == item.RunewordStrength) {
-			oneHandMaceTypes := []string{item.TypeMace, item.TypeClub}
-			if !slices.Contains(oneHandMaceTypes, itemType) {
-				continue
-			}
-			_, hasTwoHandedMin := itm.BaseStats.FindStat(stat.TwoHandedMinDamage, 0)
-			_, hasTwoHandedMax := itm.BaseStats.FindStat(stat.TwoHandedMaxDamage, 0)
-			if hasTwoHandedMin || hasTwoHandedMax {
-				continue
-			}
+		_, hasTwoHandedMin := itm.BaseStats.FindStat(stat.TwoHandedMinDamage, 0)
+		_, hasTwoHandedMax := itm.BaseStats.FindStat(stat.TwoHandedMaxDamage, 0)
+		if hasTwoHandedMin || hasTwoHandedMax {
+			return false
 		}
+	}
 
-		sockets, found := itm.FindStat(stat.NumSockets, 0)
-		if !found || sockets.Value != len(recipe.Runes) {
-			continue
+	// Eth handling: reroll rules beat overrides; otherwise fall back to the recipe value.
+	switch f.ethMode {
+	case "eth":
+		if !itm.Ethereal {
+			return false
 		}
-
-		// Eth handling: reroll rules beat overrides; otherwise fall back to the recipe value.
-		switch effectiveEthMode {
-		case "eth":
-			if !itm.Ethereal {
-				continue
-			}
-		case "noneth":
-			if itm.Ethereal {
-				continue
-			}
-		default:
-			if itm.Ethereal && !recipe.AllowEth {
-				continue
-			}
+	case "noneth":
+		if itm.Ethereal {
+			return false
 		}
+	default:
+		if itm.Ethereal && !recipe.AllowEth {
+			return false
+		}
+	}
 
-		if itm.HasSocketedItems() {
-			continue
+	if itm.HasSocketedItems() {
+		return false
+	}
+
+	// Quality handling: reroll rules beat overrides; otherwise allow <= Superior.
+	switch f.qualityMode {
+	case "normal":
+		if itm.Quality != item.QualityNormal {
+			return false
+		}
+	case "superior":
+		if itm.Quality != item.QualitySuperior {
+			return false
 		}
+	default:
+		if itm.Quality > item.QualitySuperior {
+			return false
+		}
+	}
 
-		// Quality handling: reroll rules beat overrides; otherwise allow <= Superior.
-		switch effectiveQualityMode {
+	// Apply base tier restriction (normal/exceptional/elite) when not leveling.
+	if f.baseTier != "" {
+		itemTier := itm.Desc().Tier()
+		switch f.baseTier {
 		case "normal":
-			if itm.Quality != item.QualityNormal {
-				continue
+			if itemTier != item.TierNormal {
+				return false
 			}
-		case "superior":
-			if itm.Quality != item.QualitySuperior {
-				continue
+		case "exceptional":
+			if itemTier != item.TierExceptional {
+				return false
 			}
-		default:
-			if itm.Quality > item.QualitySuperior {
-				continue
+		case "elite":
+			if itemTier != item.TierElite {
+				return false
 			}
 		}
+	}
 
-		// Apply base tier restriction (normal/exceptional/elite) when not leveling.
-		if effectiveBaseTier != "" {
-			itemTier := itm.Desc().Tier()
-			switch effectiveBaseTier {
-			case "normal":
-				if itemTier != item.TierNormal {
-					continue
-				}
-			case "exceptional":
-				if itemTier != item.TierExceptional {
-					continue
-				}
-			case "elite":
-				if itemTier != item.TierElite {
-					continue
-				}
+	// BaseName (single NIP code or comma list) only applies outside leveling.
+	if f.baseName != "" {
+		baseCode := pickit.ToNIPName(itm.Desc().Name)
+		if baseCode == "" {
+			return false
+		}
+		allowed := false
+		for _, part := range strings.Split(f.baseName, ",") {
+			if strings.TrimSpace(part) == baseCode {
+				allowed = true
+				break
 			}
 		}
+		if !allowed {
+			return false
+		}
+	}
 
-		// BaseName (single NIP code or comma list) only applies outside leveling.
-		if effectiveBaseName != "" {
-			baseCode := pickit.ToNIPName(itm.Desc().Name)
-			if baseCode == "" {
-				continue
-			}
-			allowed := false
-			for _, part := range strings.Split(effectiveBaseName, ",") {
-				if strings.TrimSpace(part) == baseCode {
-					allowed = true
-					break
-				}
-			}
-			if !allowed {
-				continue
-			}
+	return true
+}
+
+func hasBaseForRunewordRecipe(items []data.Item, recipe Runeword) (data.Item, bool) {
+	ctx := context.Get()
+	filter := runewordBaseFilterFor(ctx, recipe)
+
+	var validBases []data.Item
+	for _, itm := range items {
+		if !filter.matches(ctx, itm, recipe) {
+			continue
+		}
+
+		sockets, found := itm.FindStat(stat.NumSockets, 0)
+		if !found || sockets.Value != len(recipe.Runes) {
+			continue
 		}
 
 		validBases = append(validBases, itm)
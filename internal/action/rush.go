@@ -0,0 +1,61 @@
+package action
+
+import (
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/koolo/internal/action/step"
+	"github.com/hectorgimenez/koolo/internal/context"
+	"github.com/hectorgimenez/koolo/internal/utils"
+)
+
+const rushDefaultWaitSeconds = 180
+
+// RushCheckpoint opens a portal and waits for the rushee to be in our area, the quest is only credited to the players
+// around when the boss dies. It does nothing unless we are rushing.
+func RushCheckpoint(checkpoint string) {
+	ctx := context.Get()
+	ctx.SetLastAction("RushCheckpoint")
+
+	rushee := ctx.CharacterCfg.Game.Rush.Rushee
+	if !ctx.CurrentGame.Rushing || rushee == "" {
+		return
+	}
+
+	if err := step.OpenPortal(); err != nil {
+		ctx.Logger.Warn("Failed opening a portal for the rushee", "error", err)
+	}
+	Say("tp " + checkpoint)
+
+	waitSeconds := ctx.CharacterCfg.Game.Rush.WaitSeconds
+	if waitSeconds <= 0 {
+		waitSeconds = rushDefaultWaitSeconds
+	}
+
+	ctx.Logger.Info("Waiting for the rushee", "rushee", rushee, "checkpoint", checkpoint)
+	deadline := time.Now().Add(time.Duration(waitSeconds) * time.Second)
+	for time.Now().Before(deadline) {
+		ctx.PauseIfNotPriority()
+		ctx.RefreshGameData()
+
+		if RusheeInArea() {
+			ctx.Logger.Info("Rushee arrived", "checkpoint", checkpoint)
+			return
+		}
+
+		ClearAreaAroundPlayer(15, data.MonsterAnyFilter())
+		AntiIdle()
+		utils.Sleep(1000)
+	}
+
+	ctx.Logger.Warn("Rushee didn't arrive in time, going on", "checkpoint", checkpoint)
+}
+
+// RusheeInArea returns true when the rushee is in our current area.
+func RusheeInArea() bool {
+	ctx := context.Get()
+
+	member, found := ctx.Data.Roster.FindByName(ctx.CharacterCfg.Game.Rush.Rushee)
+
+	return found && member.Area == ctx.Data.PlayerUnit.Area
+}
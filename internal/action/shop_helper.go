@@ -1,6 +1,7 @@
 package action
 
 import (
+	"github.com/hectorgimenez/d2go/pkg/data"
 	"github.com/hectorgimenez/d2go/pkg/data/area"
 	"github.com/hectorgimenez/d2go/pkg/data/item"
 	"github.com/hectorgimenez/d2go/pkg/data/npc"
@@ -59,10 +60,7 @@ func BuyAct2Flails(ctx *context.Status) error {
 		}
 		// Trade option for Fara (first option is repair, second is trade)
 		ctx.HID.KeySequence(win.VK_HOME, win.VK_DOWN, win.VK_RETURN)
-		utils.Sleep(1000)
-
-		ctx.GameReader.GetData()
-		if !ctx.Data.OpenMenus.NPCShop {
+		if !step.WaitForMenu(func(m data.OpenMenus) bool { return m.NPCShop }, true, 1000) {
 			ctx.Logger.Debug("Shop menu not open, closing and retrying")
 			step.CloseAllMenus()
 			continue
@@ -165,10 +163,7 @@ func BuyAct2BoneWands(ctx *context.Status) error {
 		}
 		// Trade option for Drognan (first option is trade)
 		ctx.HID.KeySequence(win.VK_HOME, win.VK_DOWN, win.VK_RETURN)
-		utils.Sleep(1000)
-
-		ctx.GameReader.GetData()
-		if !ctx.Data.OpenMenus.NPCShop {
+		if !step.WaitForMenu(func(m data.OpenMenus) bool { return m.NPCShop }, true, 1000) {
 			ctx.Logger.Debug("Shop menu not open, closing and retrying")
 			step.CloseAllMenus()
 			continue
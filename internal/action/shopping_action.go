@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"path/filepath"
 	"sort"
 
 	"github.com/hectorgimenez/d2go/pkg/data"
@@ -27,9 +28,13 @@ type ActionShoppingPlan struct {
 	Enabled         bool
 	RefreshesPerRun int
 	MinGoldReserve  int
+	MaxGoldToSpend  int // 0 means no limit
 	Vendors         []npc.ID
 	Rules           nip.Rules // optional override; if empty, shouldBePickedUp() is used
 	Types           []string  // optional allow-list of item types (string of item.Desc().Type)
+	UntilFound      bool      // stop refreshing as soon as an item is bought
+	// Prices paid by item name during the shopping run, the vendors don't show a price before the item is bought
+	Prices map[item.Name]int
 }
 
 // NewActionShoppingPlanFromConfig builds a runtime plan from the YAML-backed character config.
@@ -39,12 +44,25 @@ func NewActionShoppingPlanFromConfig(cfg config.ShoppingConfig) ActionShoppingPl
 		Enabled:         cfg.Enabled,
 		RefreshesPerRun: cfg.RefreshesPerRun,
 		MinGoldReserve:  cfg.MinGoldReserve,
+		MaxGoldToSpend:  cfg.MaxGoldToSpend,
 		Vendors:         vendorListFromConfig(cfg),
 		Rules:           nil,
-		Types:           nil,
+		Types:           cfg.ItemTypes,
+		UntilFound:      cfg.ShopUntilFound,
 	}
 }
 
+// loadShoppingRules reads the dedicated shopping NIP file, relative paths are resolved from the character config folder.
+
+func loadShoppingRules(rulesFile string) (nip.Rules, error) {
+	ctx := context.Get()
+	if !filepath.IsAbs(rulesFile) {
+		rulesFile = filepath.Join("config", ctx.CharacterCfg.ConfigFolderName, rulesFile)
+	}
+
+	return nip.ParseNIPFile(rulesFile)
+}
+
 // vendorListFromConfig returns the vendors selected in the config.
 
 func vendorListFromConfig(cfg config.ShoppingConfig) []npc.ID {
@@ -58,35 +76,46 @@ func vendorListFromConfig(cfg config.ShoppingConfig) []npc.ID {
 	return []npc.ID{}
 }
 
-// RunShoppingFromConfig runs the shopping routine using values from config.
+// RunShoppingFromConfig runs the shopping routine using values from config, it returns the amount of items bought.
 
-func RunShoppingFromConfig(cfg *config.ShoppingConfig) error {
+func RunShoppingFromConfig(cfg *config.ShoppingConfig) (int, error) {
 	if cfg == nil {
-		return fmt.Errorf("nil shopping config")
+		return 0, fmt.Errorf("nil shopping config")
+	}
+
+	plan := NewActionShoppingPlanFromConfig(*cfg)
+	if cfg.ShoppingRulesFile != "" {
+		rules, err := loadShoppingRules(cfg.ShoppingRulesFile)
+		if err != nil {
+			return 0, fmt.Errorf("failed loading shopping rules file %s: %w", cfg.ShoppingRulesFile, err)
+		}
+		plan.Rules = rules
 	}
-	return RunShopping(NewActionShoppingPlanFromConfig(*cfg))
+
+	return RunShopping(plan)
 }
 
 // RunShopping iterates towns and vendors, buying items according to the plan and performing optional town refreshes.
+// It returns the amount of items bought.
 
-func RunShopping(plan ActionShoppingPlan) error {
+func RunShopping(plan ActionShoppingPlan) (int, error) {
 	ctx := context.Get()
 	if !plan.Enabled {
 		ctx.Logger.Debug("Shopping disabled")
-		return nil
+		return 0, nil
 	}
 	if len(plan.Vendors) == 0 {
 		ctx.Logger.Warn("No vendors selected for shopping")
-		return nil
+		return 0, nil
 	}
 	if ctx.Drop != nil && ctx.Drop.Pending() != nil && ctx.Drop.Active() == nil {
-		return drop.ErrInterrupt
+		return 0, drop.ErrInterrupt
 	}
 
 	// Ensure enough adjacent space (two columns) before starting
 	if !ensureTwoFreeColumnsStrict() {
 		ctx.Logger.Warn("Not enough adjacent space (two full columns) even after stashing; aborting shopping")
-		return nil
+		return 0, nil
 	}
 
 	// Group vendors by town; iterate towns within each pass
@@ -105,15 +134,32 @@ func RunShopping(plan ActionShoppingPlan) error {
 		return nil
 	}
 
+	if plan.Prices == nil {
+		plan.Prices = make(map[item.Name]int)
+	}
+
+	totalSpent, totalPurchased := 0, 0
+	budgetExhausted := func() bool {
+		return plan.MaxGoldToSpend > 0 && totalSpent >= plan.MaxGoldToSpend
+	}
+
 	for pass := 0; pass <= passes; pass++ {
 		if err := checkDropInterrupt(); err != nil {
-			return err
+			return totalPurchased, err
+		}
+		if budgetExhausted() {
+			ctx.Logger.Info("Shopping budget exhausted", slog.Int("goldSpent", totalSpent))
+			return totalPurchased, nil
+		}
+		if plan.UntilFound && totalPurchased > 0 {
+			ctx.Logger.Info("Shopping item found, no more refreshes", slog.Int("items", totalPurchased))
+			return totalPurchased, nil
 		}
 		ctx.Logger.Info("Shopping pass", slog.Int("pass", pass))
 
 		for _, townID := range townOrder {
 			if err := checkDropInterrupt(); err != nil {
-				return err
+				return totalPurchased, err
 			}
 			vendors := vendorsByTown[townID]
 			if len(vendors) == 0 {
@@ -122,7 +168,7 @@ func RunShopping(plan ActionShoppingPlan) error {
 
 			if err := ensureInTown(townID); err != nil {
 				if errors.Is(err, drop.ErrInterrupt) {
-					return err
+					return totalPurchased, err
 				}
 				ctx.Logger.Warn("Skipping town; cannot reach", slog.String("town", townID.Area().Name), slog.Any("err", err))
 				continue
@@ -136,15 +182,25 @@ func RunShopping(plan ActionShoppingPlan) error {
 
 			for _, v := range vendors {
 				if err := checkDropInterrupt(); err != nil {
-					return err
+					return totalPurchased, err
 				}
 				if !ensureTwoFreeColumnsStrict() {
 					ctx.Logger.Warn("Skipping vendor due to inventory space (need two free columns)", slog.Int("vendor", int(v)))
 					break
 				}
-				if _, _, err := shopVendorSinglePass(v, plan); err != nil {
+				if budgetExhausted() {
+					break
+				}
+				vendorPlan := plan
+				if plan.MaxGoldToSpend > 0 {
+					vendorPlan.MaxGoldToSpend = plan.MaxGoldToSpend - totalSpent
+				}
+				spent, purchased, err := shopVendorSinglePass(v, vendorPlan)
+				totalSpent += spent
+				totalPurchased += purchased
+				if err != nil {
 					if errors.Is(err, drop.ErrInterrupt) {
-						return err
+						return totalPurchased, err
 					}
 					ctx.Logger.Warn("Vendor pass failed", slog.Int("vendor", int(v)), slog.Any("err", err))
 				}
@@ -154,28 +210,28 @@ func RunShopping(plan ActionShoppingPlan) error {
 		}
 
 		// Refresh town after visiting all selected vendors in this pass (if more passes remain)
-		if pass < passes {
+		if pass < passes && !(plan.UntilFound && totalPurchased > 0) {
 			if err := checkDropInterrupt(); err != nil {
-				return err
+				return totalPurchased, err
 			}
 			lastTown := townOrder[len(townOrder)-1]
 			vendorsLast := vendorsByTown[lastTown]
 			onlyAnya := len(vendorsLast) == 1 && vendorsLast[0] == npc.Drehya
 			if err := refreshTownPreferAnyaPortal(lastTown, onlyAnya); err != nil {
 				if errors.Is(err, drop.ErrInterrupt) {
-					return err
+					return totalPurchased, err
 				}
 				ctx.Logger.Warn("Town refresh failed; falling back to waypoint", slog.Any("err", err))
 				if err := refreshTownViaWaypoint(lastTown); err != nil {
 					if errors.Is(err, drop.ErrInterrupt) {
-						return err
+						return totalPurchased, err
 					}
 				}
 			}
 		}
 	}
 
-	return nil
+	return totalPurchased, nil
 }
 
 // ensureTwoFreeColumnsStrict ensures two adjacent inventory columns are free; stashes once if needed.
@@ -286,7 +342,7 @@ func scanAndPurchaseItems(vendorID npc.ID, plan ActionShoppingPlan) (itemsPurcha
 			if !typeMatch(it, plan.Types) {
 				continue
 			}
-			if !shouldMatchRulesOnly(it) {
+			if !shopItemMatches(it, plan) {
 				continue
 			}
 
@@ -345,9 +401,25 @@ func scanAndPurchaseItems(vendorID npc.ID, plan ActionShoppingPlan) (itemsPurcha
 			if !ok {
 				continue
 			}
-			if !typeMatch(it, plan.Types) || !shouldMatchRulesOnly(it) {
+			if !typeMatch(it, plan.Types) || !shopItemMatches(it, plan) {
 				continue
 			}
+			if plan.MaxGoldToSpend > 0 {
+				remaining := plan.MaxGoldToSpend - goldSpent
+				if remaining <= 0 {
+					ctx.Logger.Info("Shopping budget reached", slog.Int("goldSpent", goldSpent))
+					return itemsPurchased, goldSpent
+				}
+				// Only the price of an item already bought is known, a cheaper one may still fit in the budget
+				if price, known := plan.Prices[it.Name]; known && price > remaining {
+					ctx.Logger.Debug("Item price over the remaining shopping budget",
+						slog.String("item", string(it.Name)),
+						slog.Int("price", price),
+						slog.Int("remaining", remaining),
+					)
+					continue
+				}
+			}
 
 			prevGold := ctx.Data.PlayerUnit.TotalPlayerGold()
 
@@ -357,8 +429,12 @@ func scanAndPurchaseItems(vendorID npc.ID, plan ActionShoppingPlan) (itemsPurcha
 			utils.Sleep(40)
 			ctx.RefreshGameData()
 
+			price := prevGold - ctx.Data.PlayerUnit.TotalPlayerGold()
 			itemsPurchased++
-			goldSpent += prevGold - ctx.Data.PlayerUnit.TotalPlayerGold()
+			goldSpent += price
+			if price > 0 && plan.Prices != nil {
+				plan.Prices[it.Name] = price
+			}
 
 			// If space tight now, stash and resume same tab
 			if !hasTwoFreeColumns() {
@@ -407,6 +483,17 @@ func stashAndReturnToVendor(vendorID npc.ID, tab int) bool {
 	return true
 }
 
+// shopItemMatches evaluates the dedicated shopping rules when configured, otherwise the pickit rules.
+
+func shopItemMatches(it data.Item, plan ActionShoppingPlan) bool {
+	if len(plan.Rules) > 0 {
+		_, result := plan.Rules.EvaluateAll(it)
+		return result == nip.RuleResultFullMatch
+	}
+
+	return shouldMatchRulesOnly(it)
+}
+
 // typeMatch applies an optional allow-list by item type string; empty allow-list allows all.
 
 func typeMatch(it data.Item, allow []string) bool {
@@ -136,12 +136,14 @@ func ensureConfiguredSkillPoints(remainingPoints int) error {
 		skillNameToID[strings.ToLower(sk.Name)] = id
 	}
 
+	clvl, _ := ctx.Data.PlayerUnit.FindStat(stat.Level, 0)
+
 	usePacketMode := false
 	for _, entry := range ctx.CharacterCfg.Character.AutoStatSkill.Skills {
 		if remainingPoints <= 0 {
 			break
 		}
-		if entry.Target <= 0 {
+		if entry.Target <= 0 || clvl.Value < entry.MinLevel {
 			continue
 		}
 		skillID, ok := skillKeyToID[strings.ToLower(strings.TrimSpace(entry.Skill))]
@@ -309,6 +311,32 @@ func spendSkillPoint(skillID skill.ID, useBulk bool) int {
 	return spent
 }
 
+// configuredSkillsToBind returns the castable skills from the auto skill plan, so new skills get a key binding as
+// soon as they're learned.
+func configuredSkillsToBind() []skill.ID {
+	ctx := context.Get()
+
+	skillKeyToID := make(map[string]skill.ID, len(skill.SkillNames))
+	for id, name := range skill.SkillNames {
+		skillKeyToID[strings.ToLower(name)] = id
+	}
+
+	skills := make([]skill.ID, 0)
+	for _, entry := range ctx.CharacterCfg.Character.AutoStatSkill.Skills {
+		skillID, ok := skillKeyToID[strings.ToLower(strings.TrimSpace(entry.Skill))]
+		if !ok {
+			continue
+		}
+		// Passive skills can't be bound
+		if sk := skill.Skills[skillID]; !sk.LeftSkill && !sk.RightSkill {
+			continue
+		}
+		skills = append(skills, skillID)
+	}
+
+	return skills
+}
+
 // EnsureSkillBindings ensures that all required skills are bound to hotkeys and the main skill is set.
 func EnsureSkillBindings() error {
 	ctx := context.Get()
@@ -326,6 +354,9 @@ func EnsureSkillBindings() error {
 		mainSkill, skillsToBind = char.SkillsToBind()
 	} else {
 		skillsToBind = ctx.Char.CheckKeyBindings()
+		if ctx.CharacterCfg.Character.AutoStatSkill.Enabled {
+			skillsToBind = append(skillsToBind, configuredSkillsToBind()...)
+		}
 	}
 	notBoundSkills := make([]skill.ID, 0, len(skillsToBind))
 	for _, sk := range skillsToBind {
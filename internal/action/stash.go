@@ -7,16 +7,18 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hectorgimenez/d2go/pkg/data"
 	"github.com/hectorgimenez/d2go/pkg/data/area"
 	"github.com/hectorgimenez/d2go/pkg/data/item"
-	"github.com/hectorgimenez/d2go/pkg/data/object"
 	"github.com/hectorgimenez/d2go/pkg/nip"
 	"github.com/hectorgimenez/koolo/internal/action/step"
+	"github.com/hectorgimenez/koolo/internal/config"
 	"github.com/hectorgimenez/koolo/internal/context"
 	"github.com/hectorgimenez/koolo/internal/event"
 	"github.com/hectorgimenez/koolo/internal/game"
+	"github.com/hectorgimenez/koolo/internal/ocr"
 	"github.com/hectorgimenez/koolo/internal/ui"
 	"github.com/hectorgimenez/koolo/internal/utils"
 	"github.com/lxn/win"
@@ -48,12 +50,9 @@ func Stash(forceStash bool) error {
 		MoveToCoords(data.Position{X: 5130, Y: 5086})
 	}
 
-	bank, _ := ctx.Data.Objects.FindOne(object.Bank)
-	InteractObject(bank,
-		func() bool {
-			return ctx.Data.OpenMenus.Stash
-		},
-	)
+	if err := OpenStash(); err != nil {
+		return err
+	}
 	// Clear messages like TZ change or public game spam. Prevent bot from clicking on messages
 	ClearMessages()
 	stashGold()
@@ -61,10 +60,20 @@ func Stash(forceStash bool) error {
 	// Add call to dropExcessItems after stashing
 	dropExcessItems()
 	step.CloseAllMenus()
+	ReportStashUpdated()
 
 	return nil
 }
 
+// ReportStashUpdated notifies that the stash content changed, used to keep the item index up to date.
+func ReportStashUpdated() {
+	ctx := context.Get()
+	ctx.RefreshInventory()
+
+	event.Send(event.StashUpdated(event.Text(ctx.Name, ""), ctx.Data.Data))
+	ReportWantedBases()
+}
+
 func isStashingRequired(firstRun bool) bool {
 	ctx := context.Get()
 	ctx.SetLastStep("isStashingRequired")
@@ -83,13 +92,7 @@ func isStashingRequired(firstRun bool) bool {
 		}
 	}
 
-	isStashFull := true
-	for _, goldInStash := range ctx.Data.Inventory.StashedGold {
-		if goldInStash < maxGoldPerStashTab {
-			isStashFull = false
-			break // Optimization: No need to check further tabs if one has space
-		}
-	}
+	isStashFull := isStashGoldFull()
 
 	// Calculate total gold (inventory + stashed) for the new aggressive stashing rule
 	totalGold := ctx.Data.Inventory.Gold
@@ -106,9 +109,9 @@ func isStashingRequired(firstRun bool) bool {
 	}
 
 	// 2. STANDARD STASHING for all other cases (non-leveling, or leveling with sufficient total gold)
-	if ctx.Data.Inventory.Gold > ctx.Data.PlayerUnit.MaxGold()/3 && !isStashFull {
+	if threshold := goldStashThreshold(); ctx.Data.Inventory.Gold > threshold && !isStashFull {
 		ctx.Logger.Debug(fmt.Sprintf("Inventory gold (%.2fk) is above standard threshold (%.2fk). Stashing gold.",
-			float64(ctx.Data.Inventory.Gold)/1000, float64(ctx.Data.PlayerUnit.MaxGold())/3/1000))
+			float64(ctx.Data.Inventory.Gold)/1000, float64(threshold)/1000))
 		return true
 	}
 
@@ -189,6 +192,9 @@ func stashInventory(firstRun bool) {
 		}
 
 		stashed := stashItemAcrossTabs(i, matchedRule, ruleFile, firstRun)
+		if !stashed {
+			stashed = handleStashFull(i, matchedRule, ruleFile, firstRun)
+		}
 		if !stashed {
 			ctx.Logger.Warn(fmt.Sprintf("ERROR: Item %s [%s] could not be stashed into any tab. All stash tabs might be full.", i.Desc().Name, i.Quality.ToString()))
 		}
@@ -213,7 +219,7 @@ func stashItemAcrossTabs(i data.Item, matchedRule string, ruleFile string, first
 	}
 
 	itemStashed := false
-	maxTab := 4
+	maxTab := ctx.CharacterCfg.Flavor().StashTabs()
 
 	for tabAttempt := targetStartTab; tabAttempt <= maxTab; tabAttempt++ {
 		SwitchStashTab(tabAttempt)
@@ -267,6 +273,10 @@ func shouldStashIt(i data.Item, firstRun bool) (bool, bool, string, string) {
 		return false, false, "", "" // Explicitly do NOT stash the Horadric Staff
 	}
 
+	if isBackupTPTome(i) {
+		return true, false, "Backup TP Tome", ""
+	}
+
 	if i.Name == "TomeOfTownPortal" || i.Name == "TomeOfIdentify" || i.Name == "Key" || i.Name == "WirtsLeg" {
 		fmt.Printf("DEBUG: ABSOLUTELY PREVENTING stash for '%s' (Quest/Special item exclusion).\n", i.Name)
 		return false, false, "", ""
@@ -281,11 +291,24 @@ func shouldStashIt(i data.Item, firstRun bool) (bool, bool, string, string) {
 		return true, false, "FirstRun", ""
 	}
 
+	// Unidentified items kept for manual review or matching the unidentified stash rules
+	if ctx.CharacterCfg.KeepUnidentified(i) {
+		return true, false, "Kept unidentified", ""
+	}
+
 	// Stash items that are part of a recipe which are not covered by the NIP rules
 	if shouldKeepRecipeItem(i) {
 		return true, false, "Item is part of a enabled recipe", ""
 	}
 
+	if IsWantedRunewordBase(i) {
+		return true, false, "Wanted runeword base", ""
+	}
+
+	if ctx.CharacterCfg.Flavor().IsCurrency(string(i.Name)) {
+		return true, false, "Currency item", ""
+	}
+
 	// Location/position checks
 	if i.Position.Y >= len(ctx.CharacterCfg.Inventory.InventoryLock) || i.Position.X >= len(ctx.CharacterCfg.Inventory.InventoryLock[0]) {
 		return false, false, "", ""
@@ -415,16 +438,23 @@ func stashItemAction(i data.Item, rule string, ruleFile string, skipLogging bool
 	utils.PingSleep(utils.Medium, 170)        // Medium operation: Move pointer to item
 	screenshot := ctx.GameReader.Screenshot() // Take screenshot *before* attempting stash
 	utils.PingSleep(utils.Medium, 150)        // Medium operation: Wait for screenshot
+	if screenshot != nil && ocr.Enabled() && config.Koolo.OCR.VerifyStashedItems {
+		go verifyItemTooltip(ctx, i, screenshot, screenPos)
+	}
 	ctx.HID.ClickWithModifier(game.LeftButton, screenPos.X, screenPos.Y, game.CtrlKey)
-	utils.PingSleep(utils.Medium, 500) // Medium operation: Give game time to process the stash
 
 	// Verify if the item is no longer in inventory
-	ctx.RefreshGameData() // Crucial: Refresh data to see if item moved
-	for _, it := range ctx.Data.Inventory.ByLocation(item.LocationInventory) {
-		if it.UnitID == i.UnitID {
-			ctx.Logger.Debug(fmt.Sprintf("Failed to stash item %s (UnitID: %d), still in inventory.", i.Name, i.UnitID))
-			return false // Item is still in inventory, stash failed
+	stashed := ctx.AwaitCondition(func(d *game.Data) bool {
+		for _, it := range d.Inventory.ByLocation(item.LocationInventory) {
+			if it.UnitID == i.UnitID {
+				return false
+			}
 		}
+		return true
+	}, time.Duration(utils.PingMultiplier(utils.Medium, 800))*time.Millisecond)
+	if !stashed {
+		ctx.Logger.Debug(fmt.Sprintf("Failed to stash item %s (UnitID: %d), still in inventory.", i.Name, i.UnitID))
+		return false // Item is still in inventory, stash failed
 	}
 
 	dropLocation := "unknown"
@@ -446,9 +476,16 @@ func stashItemAction(i data.Item, rule string, ruleFile string, skipLogging bool
 		if dropItem.IsRuneword && dropItem.IdentifiedName == "" {
 			dropItem.IdentifiedName = displayName
 		}
+		// The name wasn't read in memory, the tooltip in the screenshot still shows it
+		if dropItem.IdentifiedName == "" && dropItem.Identified && dropItem.Quality >= item.QualityMagic && screenshot != nil && ocr.Enabled() {
+			if tooltip, err := readTooltipAt(ctx, screenshot, screenPos); err == nil && tooltip.Name() != "" {
+				dropItem.IdentifiedName = tooltip.Name()
+			}
+		}
 		event.Send(event.ItemStashed(
 			event.WithScreenshot(ctx.Name, fmt.Sprintf("Item %s [%d] stashed", displayName, i.Quality), screenshot),
 			data.Drop{Item: dropItem, Rule: rule, RuleFile: ruleFile, DropLocation: dropLocation},
+			ctx.CurrentGame.PickedUpAt[int(i.UnitID)],
 		))
 	}
 
@@ -580,6 +617,9 @@ func clickStashGoldBtn() {
 	}
 }
 
+// Tabs with a button in the stash, the personal one and the 3 shared ones
+const stashTabButtons = 4
+
 func SwitchStashTab(tab int) {
 	// Ensure any chat messages that could prevent clicking on the tab are cleared
 	ClearMessages()
@@ -588,6 +628,42 @@ func SwitchStashTab(tab int) {
 	ctx := context.Get()
 	ctx.SetLastStep("switchTab")
 
+	buttons := config.FlavorStashPageButtons(ctx.CharacterCfg.Flavor())
+	if tab > stashTabButtons && buttons.Enabled() {
+		switchStashPage(tab-stashTabButtons, buttons)
+		return
+	}
+	// The tab buttons only work from the tab pages, go back to the last tab page first
+	if ctx.CurrentGame.StashPage > 0 && buttons.Enabled() {
+		switchStashPage(0, buttons)
+	}
+
+	clickStashTabButton(tab)
+}
+
+// switchStashPage moves between the pages past the last tab button of an infinite stash, page 0 is the last tab page.
+// The pages are reached one by one from the last tab with the page buttons, the current one is kept for the game.
+func switchStashPage(page int, buttons config.StashPageButtons) {
+	ctx := context.Get()
+
+	if ctx.CurrentGame.StashPage == 0 && page > 0 {
+		clickStashTabButton(stashTabButtons)
+	}
+	for ctx.CurrentGame.StashPage < page {
+		ctx.HID.Click(game.LeftButton, buttons.NextX, buttons.NextY)
+		utils.PingSleep(utils.Light, 300)
+		ctx.CurrentGame.StashPage++
+	}
+	for ctx.CurrentGame.StashPage > page {
+		ctx.HID.Click(game.LeftButton, buttons.PrevX, buttons.PrevY)
+		utils.PingSleep(utils.Light, 300)
+		ctx.CurrentGame.StashPage--
+	}
+}
+
+func clickStashTabButton(tab int) {
+	ctx := context.Get()
+
 	if ctx.GameReader.LegacyGraphics() {
 		x := ui.SwitchStashTabBtnXClassic
 		y := ui.SwitchStashTabBtnYClassic
@@ -608,23 +684,6 @@ func SwitchStashTab(tab int) {
 
 }
 
-func OpenStash() error {
-	ctx := context.Get()
-	ctx.SetLastAction("OpenStash")
-
-	bank, found := ctx.Data.Objects.FindOne(object.Bank)
-	if !found {
-		return errors.New("stash not found")
-	}
-	InteractObject(bank,
-		func() bool {
-			return ctx.Data.OpenMenus.Stash
-		},
-	)
-
-	return nil
-}
-
 func CloseStash() error {
 	ctx := context.Get()
 	ctx.SetLastAction("CloseStash")
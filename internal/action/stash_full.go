@@ -0,0 +1,151 @@
+package action
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/item"
+	"github.com/hectorgimenez/d2go/pkg/nip"
+	"github.com/hectorgimenez/koolo/internal/action/step"
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/context"
+	"github.com/hectorgimenez/koolo/internal/game"
+	"github.com/hectorgimenez/koolo/internal/ui"
+)
+
+const maxEvictionAttempts = 3
+
+var muleRequests = struct {
+	mu    sync.Mutex
+	names map[string]bool
+}{names: make(map[string]bool)}
+
+func requestMule(name string) {
+	muleRequests.mu.Lock()
+	defer muleRequests.mu.Unlock()
+	muleRequests.names[name] = true
+}
+
+func consumeMuleRequest(name string) bool {
+	muleRequests.mu.Lock()
+	defer muleRequests.mu.Unlock()
+	requested := muleRequests.names[name]
+	delete(muleRequests.names, name)
+
+	return requested
+}
+
+// handleStashFull is the escalation path when the item doesn't fit in any stash tab. Returns true if the item ended
+// up in the stash.
+func handleStashFull(i data.Item, matchedRule string, ruleFile string, firstRun bool) bool {
+	ctx := context.Get()
+
+	switch ctx.CharacterCfg.Character.StashFullAction {
+	case config.StashFullActionEvict:
+		return evictForItem(i, matchedRule, ruleFile, firstRun)
+	case config.StashFullActionMule:
+		if !ctx.CharacterCfg.Muling.Enabled || len(ctx.CharacterCfg.Muling.MuleProfiles) == 0 {
+			ctx.Logger.Warn("Stash is full but muling is not enabled, keeping the item in the inventory", "item", i.Name)
+			return false
+		}
+		ctx.Logger.Info("Stash is full, switching to the mules on the next run", "item", i.Name)
+		requestMule(ctx.Name)
+	}
+
+	return false
+}
+
+// stashItemValue ranks the stashed items, the lowest value is evicted first. Runewords and higher qualities go last,
+// within the same quality tier rules and the item level requirement decide. Runes and gems are normal quality items,
+// they are ranked on their own scale by their level requirement, which grows with the rune and the gem grade: from
+// Pul up runes go after the uniques.
+func stashItemValue(i data.Item) float64 {
+	ctx := context.Get()
+
+	switch StashCategory(i) {
+	case "runes":
+		return float64(i.Desc().RequiredLevel) * 20
+	case "gems":
+		return float64(i.Desc().RequiredLevel) * 10
+	}
+
+	value := float64(i.Quality)*100 + float64(i.Desc().RequiredLevel)
+	if i.IsRuneword {
+		value += 1000
+	}
+	if tierRule, _ := ctx.CharacterCfg.Runtime.Rules.EvaluateTiers(i, ctx.CharacterCfg.Runtime.TierRules); tierRule.Tier() > 0 {
+		value += tierRule.Tier() * 10
+	}
+
+	return value
+}
+
+// evictionCandidates returns the stashed items matching the pickit rules with a lower value than the item, lowest
+// value first.
+func evictionCandidates(i data.Item) []data.Item {
+	ctx := context.Get()
+
+	itemValue := stashItemValue(i)
+	candidates := make([]data.Item, 0)
+	for _, stashed := range ctx.Data.Inventory.ByLocation(item.LocationStash, item.LocationSharedStash) {
+		if _, result := ctx.CharacterCfg.Runtime.Rules.EvaluateAll(stashed); result != nip.RuleResultFullMatch {
+			continue
+		}
+		if stashItemValue(stashed) >= itemValue || !itemFitsInventory(stashed) {
+			continue
+		}
+		candidates = append(candidates, stashed)
+	}
+
+	sort.SliceStable(candidates, func(a, b int) bool {
+		return stashItemValue(candidates[a]) < stashItemValue(candidates[b])
+	})
+
+	return candidates
+}
+
+// evictForItem takes the lowest value stashed item to the inventory, stashes the new item in the freed space and
+// drops the evicted one. If the new item still doesn't fit the evicted item goes back to the stash.
+func evictForItem(i data.Item, matchedRule string, ruleFile string, firstRun bool) bool {
+	ctx := context.Get()
+
+	candidates := evictionCandidates(i)
+	for attempt, candidate := range candidates {
+		if attempt >= maxEvictionAttempts {
+			break
+		}
+
+		tab := stashTab(candidate)
+		SwitchStashTab(tab)
+		screenPos := ui.GetScreenCoordsForItem(candidate)
+		ctx.HID.MovePointer(screenPos.X, screenPos.Y)
+		ctx.HID.ClickWithModifier(game.LeftButton, screenPos.X, screenPos.Y, game.CtrlKey)
+		step.WaitForItemMoved(candidate.UnitID, candidate.Location.LocationType, 500) // Wait for item to move to inventory
+
+		evicted, found := findInventoryItem(candidate.UnitID)
+		if !found {
+			continue
+		}
+
+		if stashItemAction(i, matchedRule, ruleFile, firstRun) {
+			ctx.Logger.Info(fmt.Sprintf("Stash full, dropping %s [%s] to make room for %s [%s]",
+				formatItemName(evicted), evicted.Quality.ToString(), formatItemName(i), i.Quality.ToString()))
+			blacklistItem(evicted)
+			DropItem(evicted)
+			// Dropping closes the stash, the caller keeps stashing the remaining items
+			if err := OpenStash(); err != nil {
+				ctx.Logger.Warn("Failed reopening the stash after evicting an item", "error", err)
+			}
+			return true
+		}
+
+		// Not enough room for the new item, put the evicted one back
+		if !stashItemAction(evicted, "", "", true) {
+			ctx.Logger.Warn("Evicted item could not be stashed back", "item", evicted.Name)
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,236 @@
+package action
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/item"
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+	"github.com/hectorgimenez/koolo/internal/action/step"
+	"github.com/hectorgimenez/koolo/internal/context"
+	"github.com/hectorgimenez/koolo/internal/game"
+	"github.com/hectorgimenez/koolo/internal/ui"
+	"github.com/hectorgimenez/koolo/internal/utils"
+)
+
+const stashManifestFile = "stash_manifest.json"
+
+// StashManifestEntry is a stashed item as listed in the stash manifest.
+type StashManifestEntry struct {
+	Name     string        `json:"name"`
+	Quality  string        `json:"quality"`
+	Category string        `json:"category"`
+	Tab      int           `json:"tab"`
+	Position data.Position `json:"position"`
+	Ethereal bool          `json:"ethereal"`
+	Sockets  int           `json:"sockets"`
+}
+
+// StashManifest is the content of the stash, written after every organizer pass. Tabs counts the stash pages of
+// the game flavor, the entries are indexed by their page.
+type StashManifest struct {
+	Character string               `json:"character"`
+	UpdatedAt time.Time            `json:"updatedAt"`
+	Tabs      int                  `json:"tabs"`
+	Items     []StashManifestEntry `json:"items"`
+}
+
+var stashOrganizerGames = struct {
+	mu    sync.Mutex
+	games map[string]int
+}{games: make(map[string]int)}
+
+// StashCategory returns the organizer category of the item, empty if it doesn't belong to any.
+func StashCategory(i data.Item) string {
+	itmType := i.Type()
+
+	switch {
+	case i.IsRuneword:
+		return ""
+	case itmType.IsType(item.TypeRune):
+		return "runes"
+	case strings.HasPrefix(i.Desc().Type, "gem"):
+		return "gems"
+	case itmType.IsType(item.TypeSmallCharm) || itmType.IsType(item.TypeMediumCharm) || itmType.IsType(item.TypeLargeCharm):
+		return "charms"
+	case itmType.IsType(item.TypeJewel):
+		return "jewels"
+	case i.Quality == item.QualityUnique:
+		return "uniques"
+	case i.Quality == item.QualitySet:
+		return "sets"
+	case (i.Quality == item.QualityNormal || i.Quality == item.QualitySuperior) && len(itmType.BodyLocs) > 0:
+		return "bases"
+	}
+
+	return ""
+}
+
+// stashTab returns the 1-indexed stash tab the item is in.
+func stashTab(i data.Item) int {
+	if i.Location.LocationType == item.LocationSharedStash {
+		return i.Location.Page + 1
+	}
+
+	return 1
+}
+
+// OrganizeStash moves the stashed items to the tab configured for their category and writes the stash manifest.
+// It runs once every configured amount of games, on the first town visit of the game.
+func OrganizeStash() error {
+	ctx := context.Get()
+	ctx.SetLastAction("OrganizeStash")
+
+	cfg := ctx.CharacterCfg.StashOrganizer
+	if !cfg.Enabled || ctx.CurrentGame.StashOrganizerCounted || len(cfg.Tabs) == 0 {
+		return nil
+	}
+	ctx.CurrentGame.StashOrganizerCounted = true
+
+	stashOrganizerGames.mu.Lock()
+	stashOrganizerGames.games[ctx.Name]++
+	due := stashOrganizerGames.games[ctx.Name] >= max(cfg.EveryGames, 1)
+	if due {
+		stashOrganizerGames.games[ctx.Name] = 0
+	}
+	stashOrganizerGames.mu.Unlock()
+	if !due {
+		return nil
+	}
+
+	if !ctx.Data.OpenMenus.Stash {
+		if err := OpenStash(); err != nil {
+			return err
+		}
+		utils.PingSleep(utils.Medium, 300) // Medium operation: Wait for stash to open
+	}
+	ClearMessages()
+
+	moved := 0
+	for _, itm := range ctx.Data.Inventory.ByLocation(item.LocationStash, item.LocationSharedStash) {
+		targetTab, found := cfg.Tabs[StashCategory(itm)]
+		if !found || targetTab < 1 || targetTab > ctx.CharacterCfg.Flavor().StashTabs() || targetTab == stashTab(itm) {
+			continue
+		}
+		if !itemFitsInventory(itm) {
+			continue
+		}
+
+		if moveStashedItemToTab(itm, targetTab) {
+			moved++
+		}
+	}
+
+	ctx.RefreshGameData()
+	if err := writeStashManifest(ctx); err != nil {
+		ctx.Logger.Warn("Failed to write the stash manifest", "error", err)
+	}
+	ReportStashUpdated()
+
+	ctx.Logger.Info("Stash organized", "moved", moved)
+
+	return step.CloseAllMenus()
+}
+
+// moveStashedItemToTab takes the item to the inventory and stashes it again on the target tab, the game places it in
+// the first free spot so tabs get compacted on the way. If the target tab is full the item goes back to its tab.
+func moveStashedItemToTab(itm data.Item, targetTab int) bool {
+	ctx := context.Get()
+
+	SwitchStashTab(stashTab(itm))
+	screenPos := ui.GetScreenCoordsForItem(itm)
+	ctx.HID.MovePointer(screenPos.X, screenPos.Y)
+	ctx.HID.ClickWithModifier(game.LeftButton, screenPos.X, screenPos.Y, game.CtrlKey)
+	ctx.AwaitCondition(func(*game.Data) bool {
+		_, found := findInventoryItem(itm.UnitID)
+		return found
+	}, time.Duration(utils.PingMultiplier(utils.Medium, 800))*time.Millisecond)
+
+	inInventory, found := findInventoryItem(itm.UnitID)
+	if !found {
+		ctx.Logger.Debug("Failed taking item from the stash", "item", itm.Name)
+		return false
+	}
+
+	for _, tab := range []int{targetTab, stashTab(itm)} {
+		SwitchStashTab(tab)
+		if stashItemAction(inInventory, "", "", true) {
+			if tab == targetTab {
+				ctx.Logger.Debug(fmt.Sprintf("Moved %s to stash tab %d", itm.Name, targetTab))
+				return true
+			}
+			return false
+		}
+	}
+
+	ctx.Logger.Warn("Item taken from the stash could not be stashed back", "item", itm.Name)
+
+	return false
+}
+
+func findInventoryItem(unitID data.UnitID) (data.Item, bool) {
+	ctx := context.Get()
+	for _, i := range ctx.Data.Inventory.ByLocation(item.LocationInventory) {
+		if i.UnitID == unitID {
+			return i, true
+		}
+	}
+
+	return data.Item{}, false
+}
+
+func writeStashManifest(ctx *context.Status) error {
+	manifest := StashManifest{
+		Character: ctx.Name,
+		UpdatedAt: time.Now(),
+		Tabs:      ctx.CharacterCfg.Flavor().StashTabs(),
+		Items:     make([]StashManifestEntry, 0),
+	}
+
+	for _, itm := range ctx.Data.Inventory.ByLocation(item.LocationStash, item.LocationSharedStash) {
+		sockets, _ := itm.FindStat(stat.NumSockets, 0)
+		manifest.Items = append(manifest.Items, StashManifestEntry{
+			Name:     formatItemName(itm),
+			Quality:  itm.Quality.ToString(),
+			Category: StashCategory(itm),
+			Tab:      stashTab(itm),
+			Position: itm.Position,
+			Ethereal: itm.Ethereal,
+			Sockets:  sockets.Value,
+		})
+	}
+
+	jsonData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join("config", ctx.Name, stashManifestFile), jsonData, 0644)
+}
+
+// LoadStashManifest reads the last stash manifest written for the character.
+func LoadStashManifest(characterName string) (*StashManifest, error) {
+	// The name comes from the API, keep it to a single folder name inside the config folder
+	characterName = filepath.Base(characterName)
+	if characterName == "." || characterName == ".." || characterName == string(filepath.Separator) {
+		return nil, fmt.Errorf("invalid character name %q", characterName)
+	}
+
+	jsonData, err := os.ReadFile(filepath.Join("config", characterName, stashManifestFile))
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &StashManifest{}
+	if err := json.Unmarshal(jsonData, manifest); err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
@@ -91,6 +91,7 @@ func EnsureAura(aura skill.ID) AttackOption {
 // PrimaryAttack initiates a primary (left-click) attack sequence
 func PrimaryAttack(target data.UnitID, numOfAttacks int, standStill bool, opts ...AttackOption) error {
 	ctx := context.Get()
+	defer ctx.Profiler.Enter(context.PhaseCombat)()
 
 	// Special handling for Berserker characters
 	if berserker, ok := ctx.Char.(interface{ PerformBerserkAttack(data.UnitID) }); ok {
@@ -115,6 +116,8 @@ func PrimaryAttack(target data.UnitID, numOfAttacks int, standStill bool, opts .
 
 // SecondaryAttack initiates a secondary (right-click) attack sequence with a specific skill
 func SecondaryAttack(skill skill.ID, target data.UnitID, numOfAttacks int, opts ...AttackOption) error {
+	defer context.Get().Profiler.Enter(context.PhaseCombat)()
+
 	settings := attackSettings{
 		target:           target,
 		numOfAttacks:     numOfAttacks,
@@ -155,6 +158,18 @@ func isValidEnemy(monster data.Monster, ctx *context.Status) bool {
 	return true
 }
 
+// ensureCombatWeaponSlot puts the main weapon set back before fighting, the swap can be left active by a buff or the
+// teleport staff. When the swap fails the attack goes on with the current set.
+func ensureCombatWeaponSlot(ctx *context.Status) {
+	if ctx.Data.ActiveWeaponSlot == MainWeaponSlot {
+		return
+	}
+	if err := EnsureWeaponSlot(MainWeaponSlot); err != nil {
+		ctx.Logger.Warn("Failed swapping to the main weapon before attacking", "error", err)
+	}
+	ctx.SetLastStep("Attack")
+}
+
 // Cleanup function to ensure proper state on exit
 func keyCleanup(ctx *context.Status) {
 	ctx.HID.KeyUp(ctx.Data.KeyBindings.StandStill)
@@ -164,12 +179,16 @@ func attack(settings attackSettings) error {
 	ctx := context.Get()
 	ctx.SetLastStep("Attack")
 	defer keyCleanup(ctx) // cleanup possible pressed keys/buttons
+	ensureCombatWeaponSlot(ctx)
+	castChargedSkills(ctx, settings.target)
 
 	numOfAttacksRemaining := settings.numOfAttacks
 	lastRunAt := time.Time{}
 
 	for {
-		ctx.PauseIfNotPriority()
+		if err := stepBoundary(ctx); err != nil {
+			return err
+		}
 		chicken.CheckForScaryAuraAndCurse()
 
 		if numOfAttacksRemaining <= 0 {
@@ -225,6 +244,8 @@ func burstAttack(settings attackSettings) error {
 	ctx := context.Get()
 	ctx.SetLastStep("BurstAttack")
 	defer keyCleanup(ctx) // cleanup possible pressed keys/buttons
+	ensureCombatWeaponSlot(ctx)
+	castChargedSkills(ctx, settings.target)
 
 	monster, found := ctx.Data.Monsters.FindByID(settings.target)
 	if !found || !isValidEnemy(monster, ctx) {
@@ -247,7 +268,9 @@ func burstAttack(settings attackSettings) error {
 
 	startedAt := time.Now()
 	for {
-		ctx.PauseIfNotPriority()
+		if err := stepBoundary(ctx); err != nil {
+			return err
+		}
 		chicken.CheckForScaryAuraAndCurse()
 
 		if !startedAt.IsZero() && time.Since(startedAt) > settings.timeout {
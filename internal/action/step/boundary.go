@@ -0,0 +1,11 @@
+package step
+
+import "github.com/hectorgimenez/koolo/internal/context"
+
+// stepBoundary is checked by the step loops on every iteration, it waits while the routine doesn't have the priority
+// and returns context.ErrActionDeadline once the deadline of the action running the step has passed.
+func stepBoundary(ctx *context.Status) error {
+	ctx.PauseIfNotPriority()
+
+	return ctx.ActionDeadlineExceeded()
+}
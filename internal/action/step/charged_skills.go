@@ -0,0 +1,113 @@
+package step
+
+import (
+	"strings"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/item"
+	"github.com/hectorgimenez/d2go/pkg/data/skill"
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+	"github.com/hectorgimenez/koolo/internal/context"
+	"github.com/hectorgimenez/koolo/internal/utils"
+)
+
+// SkillIDByName resolves a skill by its d2go key ("FrozenArmor") or display name ("Frozen Armor"), ignoring the case.
+func SkillIDByName(name string) (skill.ID, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for id, key := range skill.SkillNames {
+		if strings.ToLower(key) == name {
+			return id, true
+		}
+	}
+	for id, sk := range skill.Skills {
+		if sk.Name != "" && strings.ToLower(sk.Name) == name {
+			return id, true
+		}
+	}
+
+	return 0, false
+}
+
+// ItemCharges returns the charges left and the max charges of the skill on the equipped items, both weapon sets
+// included. The item with the most charges is the one reported.
+func ItemCharges(skillID skill.ID) (data.Item, int, int, bool) {
+	ctx := context.Get()
+
+	var best data.Item
+	current, maxCharges, found := 0, 0, false
+	for _, itm := range ctx.Data.Inventory.ByLocation(item.LocationEquipped) {
+		for _, s := range itm.Stats {
+			if s.ID != stat.ItemChargedSkill || skill.ID(s.Layer>>6) != skillID {
+				continue
+			}
+			// The value holds the charges left in the low byte and the max charges in the high one
+			if left := s.Value & 0xFF; !found || left > current {
+				best, current, maxCharges, found = itm, left, s.Value>>8, true
+			}
+		}
+	}
+
+	return best, current, maxCharges, found
+}
+
+// castChargedSkills casts the configured charged skills (curses mostly) on the target before attacking it. Items on
+// the second weapon set are swapped to and back, a failed swap skips the skill.
+func castChargedSkills(ctx *context.Status, target data.UnitID) {
+	if len(ctx.CharacterCfg.Character.ChargedSkills) == 0 {
+		return
+	}
+
+	monster, found := ctx.Data.Monsters.FindByID(target)
+	if !found {
+		return
+	}
+
+	if ctx.CurrentGame.ChargedSkillTargets[target] {
+		return
+	}
+	ctx.CurrentGame.ChargedSkillTargets[target] = true
+
+	for _, cs := range ctx.CharacterCfg.Character.ChargedSkills {
+		skillID, found := SkillIDByName(cs.Skill)
+		if !found || skillID == skill.Teleport {
+			continue
+		}
+
+		switch strings.ToLower(cs.Target) {
+		case "any":
+		case "elite":
+			if !monster.IsElite() {
+				continue
+			}
+		default:
+			if monster.Type != data.MonsterTypeUnique && monster.Type != data.MonsterTypeSuperUnique {
+				continue
+			}
+		}
+
+		itm, charges, _, found := ItemCharges(skillID)
+		if !found || charges <= 0 {
+			continue
+		}
+
+		slot := MainWeaponSlot
+		if itm.Location.BodyLocation == item.LocLeftArmSecondary || itm.Location.BodyLocation == item.LocRightArmSecondary {
+			slot = SwapWeaponSlot
+		}
+		if err := EnsureWeaponSlot(slot); err != nil {
+			ctx.Logger.Debug("Failed swapping to the charged item, skipping its skill", "skill", cs.Skill, "error", err)
+			continue
+		}
+
+		ctx.Logger.Debug("Casting charged skill", "skill", cs.Skill, "monster", monster.Name, "charges", charges)
+		if CastAtPosition(skillID, true, monster.Position) {
+			utils.Sleep(int(ctx.Data.PlayerCastDuration().Milliseconds()))
+		}
+	}
+
+	if ctx.Data.ActiveWeaponSlot != MainWeaponSlot {
+		if err := EnsureWeaponSlot(MainWeaponSlot); err != nil {
+			ctx.Logger.Warn("Failed swapping back to the main weapon after charged skills", "error", err)
+		}
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"errors"
 
 	"github.com/hectorgimenez/koolo/internal/context"
-	"github.com/hectorgimenez/koolo/internal/utils"
 	"github.com/lxn/win"
 )
 
@@ -15,14 +14,16 @@ func CloseAllMenus() error {
 	attempts := 0
 	for ctx.Data.OpenMenus.IsMenuOpen() {
 		// Pause the execution if the priority is not the same as the execution priority
-		ctx.PauseIfNotPriority()
+		if err := stepBoundary(ctx); err != nil {
+			return err
+		}
 
 		ctx.RefreshGameData()
 		if attempts > 10 {
 			return errors.New("failed closing game menu")
 		}
 		ctx.HID.PressKey(win.VK_ESCAPE)
-		utils.Sleep(200)
+		WaitForMenusClosed(200)
 		attempts++
 	}
 
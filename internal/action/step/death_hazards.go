@@ -0,0 +1,217 @@
+package step
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/area"
+	"github.com/hectorgimenez/d2go/pkg/data/npc"
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+	"github.com/hectorgimenez/koolo/internal/context"
+)
+
+const (
+	deathHazardLifePercent  = 20 // Below this life % the monster is considered about to die
+	deathHazardLinger       = 1500 * time.Millisecond
+	deathHazardTrackRange   = 25
+	deathHazardEscapeMargin = 2
+	deathHazardDirections   = 16
+)
+
+// DeathEffect describes what happens when a monster dies, and how far we should stay from it.
+type DeathEffect struct {
+	Name   string
+	Radius int
+}
+
+var (
+	deathEffectFireEnchanted = DeathEffect{Name: "fire enchanted explosion", Radius: 5}
+	deathEffectSuicide       = DeathEffect{Name: "suicide explosion", Radius: 6}
+	deathEffectCorpseNuke    = DeathEffect{Name: "corpse explosion", Radius: 7}
+
+	// Monsters that blow up on death or trigger corpse explosions around them regardless of mods.
+	deathEffectMonsters = map[npc.ID]DeathEffect{
+		npc.MinionExp:      deathEffectSuicide,
+		npc.SlayerExp:      deathEffectSuicide,
+		npc.OblivionKnight: deathEffectCorpseNuke,
+		npc.AbyssKnight:    deathEffectCorpseNuke,
+	}
+)
+
+type deathHazard struct {
+	position  data.Position
+	effect    DeathEffect
+	expiresAt time.Time
+}
+
+type trackedMonster struct {
+	maxLife  int
+	position data.Position
+	effect   DeathEffect
+}
+
+// deathHazardTracker remembers the monsters with dangerous death effects and the spots where they died.
+type deathHazardTracker struct {
+	area    area.ID
+	tracked map[data.UnitID]trackedMonster
+	hazards []deathHazard
+}
+
+var (
+	deathHazardsMu sync.Mutex
+	deathHazards   = make(map[string]*deathHazardTracker)
+)
+
+// DeathEffectFor returns the death effect of the monster, if any. Monster mods are not exposed by the game reader so
+// elites immune to fire are assumed to be Fire Enchanted, it's the most common reason for a fire immune elite.
+func DeathEffectFor(m data.Monster) (DeathEffect, bool) {
+	if effect, found := deathEffectMonsters[m.Name]; found {
+		return effect, true
+	}
+
+	if m.IsElite() && m.IsImmune(stat.FireImmune) {
+		return deathEffectFireEnchanted, true
+	}
+
+	return DeathEffect{}, false
+}
+
+func (t *deathHazardTracker) update(d *context.Context) {
+	if t.area != d.Data.PlayerUnit.Area {
+		t.area = d.Data.PlayerUnit.Area
+		t.tracked = make(map[data.UnitID]trackedMonster)
+		t.hazards = t.hazards[:0]
+	}
+
+	now := time.Now()
+	seen := make(map[data.UnitID]bool)
+	for _, m := range d.Data.Monsters {
+		if m.IsMerc() || m.IsPet() || m.IsGoodNPC() {
+			continue
+		}
+
+		previous, wasTracked := t.tracked[m.UnitID]
+		if !wasTracked {
+			if d.PathFinder.DistanceFromMe(m.Position) > deathHazardTrackRange {
+				continue
+			}
+			effect, found := DeathEffectFor(m)
+			if !found {
+				continue
+			}
+			previous = trackedMonster{effect: effect}
+		}
+
+		seen[m.UnitID] = true
+		life := m.Stats[stat.Life]
+		if life <= 0 {
+			// Died since the last update, the death effect triggers on its position
+			t.hazards = append(t.hazards, deathHazard{position: m.Position, effect: previous.effect, expiresAt: now.Add(deathHazardLinger)})
+			delete(t.tracked, m.UnitID)
+			continue
+		}
+
+		previous.maxLife = max(previous.maxLife, life)
+		previous.position = m.Position
+		t.tracked[m.UnitID] = previous
+	}
+
+	// Monsters gone from the list without being seen dead are handled as dead where we last saw them
+	for id, tm := range t.tracked {
+		if !seen[id] {
+			t.hazards = append(t.hazards, deathHazard{position: tm.position, effect: tm.effect, expiresAt: now.Add(deathHazardLinger)})
+			delete(t.tracked, id)
+		}
+	}
+
+	alive := t.hazards[:0]
+	for _, h := range t.hazards {
+		if now.Before(h.expiresAt) {
+			alive = append(alive, h)
+		}
+	}
+	t.hazards = alive
+}
+
+// active returns the current hazards: monsters about to die and the recent death spots.
+func (t *deathHazardTracker) active(d *context.Context) []deathHazard {
+	hazards := append([]deathHazard{}, t.hazards...)
+	for id, tm := range t.tracked {
+		m, found := d.Data.Monsters.FindByID(id)
+		if !found || tm.maxLife <= 0 {
+			continue
+		}
+		if m.Stats[stat.Life]*100/tm.maxLife <= deathHazardLifePercent {
+			hazards = append(hazards, deathHazard{position: m.Position, effect: tm.effect})
+		}
+	}
+
+	return hazards
+}
+
+func inDeathHazard(pos data.Position, hazards []deathHazard) bool {
+	for _, h := range hazards {
+		dx := pos.X - h.position.X
+		dy := pos.Y - h.position.Y
+		if dx*dx+dy*dy <= h.effect.Radius*h.effect.Radius {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AvoidDeathHazards steps out of the blast radius of dying monsters with dangerous death effects (Fire Enchanted,
+// suicide minions, corpse explosions). It's meant to be called between attacks, returns true if we moved.
+func AvoidDeathHazards() bool {
+	ctx := context.Get()
+	if !ctx.CharacterCfg.Character.AvoidDeathEffects {
+		return false
+	}
+
+	deathHazardsMu.Lock()
+	tracker, found := deathHazards[ctx.Name]
+	if !found {
+		tracker = &deathHazardTracker{tracked: make(map[data.UnitID]trackedMonster)}
+		deathHazards[ctx.Name] = tracker
+	}
+	tracker.update(ctx.Context)
+	hazards := tracker.active(ctx.Context)
+	deathHazardsMu.Unlock()
+
+	playerPos := ctx.Data.PlayerUnit.Position
+	if len(hazards) == 0 || !inDeathHazard(playerPos, hazards) {
+		return false
+	}
+
+	maxRadius := 0
+	for _, h := range hazards {
+		maxRadius = max(maxRadius, h.effect.Radius)
+	}
+
+	// Pick the closest safe spot, trying further rings if the closest one is still inside a hazard
+	for distance := deathHazardEscapeMargin + 1; distance <= maxRadius*2+deathHazardEscapeMargin; distance += 2 {
+		for i := 0; i < deathHazardDirections; i++ {
+			angle := 2 * math.Pi * float64(i) / deathHazardDirections
+			candidate := data.Position{
+				X: playerPos.X + int(math.Round(math.Cos(angle)*float64(distance))),
+				Y: playerPos.Y + int(math.Round(math.Sin(angle)*float64(distance))),
+			}
+
+			if !ctx.Data.AreaData.IsWalkable(candidate) || inDeathHazard(candidate, hazards) {
+				continue
+			}
+
+			ctx.Logger.Debug("Stepping away from a dangerous death effect", "effect", hazards[0].effect.Name)
+			if err := MoveTo(candidate, WithIgnoreMonsters(), WithDistanceToFinish(1)); err != nil {
+				return false
+			}
+
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,210 @@
+package step
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/area"
+	"github.com/hectorgimenez/d2go/pkg/data/mode"
+	"github.com/hectorgimenez/d2go/pkg/data/npc"
+	"github.com/hectorgimenez/koolo/internal/context"
+	"github.com/hectorgimenez/koolo/internal/pather"
+)
+
+const (
+	hazardScanRange       = 35
+	hazardDodgeDirections = 16
+
+	diabloHoseLength = 25
+	diabloHoseWidth  = 3
+	diabloHoseLinger = 2500 * time.Millisecond
+
+	groundEffectRadius = 4
+	groundEffectLinger = 3 * time.Second
+
+	listerPackRadius = 5
+)
+
+type timedHazard struct {
+	zone      pather.HazardZone
+	expiresAt time.Time
+}
+
+// hazardMap keeps the ground effects detected from the game data. Missiles are not exposed by the game reader, so
+// hazards are inferred from the monsters casting them and stay for a while after the cast.
+type hazardMap struct {
+	area    area.ID
+	timed   []timedHazard
+	casting map[data.UnitID]bool
+}
+
+var (
+	hazardsMu  sync.Mutex
+	hazardMaps = make(map[string]*hazardMap)
+)
+
+func isCastingMode(m data.Monster) bool {
+	switch m.Mode {
+	case mode.NpcCastingSpell, mode.NpcUsingSkill1, mode.NpcUsingSkill2, mode.NpcUsingSkill3, mode.NpcUsingSkill4:
+		return true
+	}
+
+	return false
+}
+
+// extendTowards returns the position at the given length from origin in the target direction.
+func extendTowards(origin, target data.Position, length int) data.Position {
+	dx := float64(target.X - origin.X)
+	dy := float64(target.Y - origin.Y)
+	norm := math.Hypot(dx, dy)
+	if norm == 0 {
+		return origin
+	}
+
+	return data.Position{
+		X: origin.X + int(math.Round(dx/norm*float64(length))),
+		Y: origin.Y + int(math.Round(dy/norm*float64(length))),
+	}
+}
+
+func (h *hazardMap) update(ctx *context.Status) []pather.HazardZone {
+	if h.area != ctx.Data.PlayerUnit.Area {
+		h.area = ctx.Data.PlayerUnit.Area
+		h.timed = h.timed[:0]
+		h.casting = make(map[data.UnitID]bool)
+	}
+
+	now := time.Now()
+	playerPos := ctx.Data.PlayerUnit.Position
+	zones := make([]pather.HazardZone, 0)
+
+	casting := make(map[data.UnitID]bool)
+	for _, m := range ctx.Data.Monsters.Enemies() {
+		if ctx.PathFinder.DistanceFromMe(m.Position) > hazardScanRange {
+			continue
+		}
+
+		// Lister's pack hits too hard to stand next to it
+		if m.Name == npc.BaalsMinion && m.Type == data.MonsterTypeSuperUnique {
+			zones = append(zones, pather.HazardZone{Name: "lister pack", Center: m.Position, Radius: listerPackRadius})
+		}
+
+		if !isCastingMode(m) {
+			continue
+		}
+		casting[m.UnitID] = true
+		// Only register the cast once, at the moment it starts, the spell is aimed where we were standing
+		if h.casting[m.UnitID] {
+			continue
+		}
+
+		switch {
+		case m.Name == npc.Diablo:
+			h.timed = append(h.timed, timedHazard{
+				zone: pather.HazardZone{
+					Name:   "diablo lightning",
+					Center: m.Position,
+					End:    extendTowards(m.Position, playerPos, diabloHoseLength),
+					Radius: diabloHoseWidth,
+				},
+				expiresAt: now.Add(diabloHoseLinger),
+			})
+		case m.IsElite() || m.Name == npc.CouncilMember || m.Name == npc.CouncilMember2 || m.Name == npc.CouncilMember3:
+			// Novas, fire waves and the other ground spells of the ranged uniques start from the caster
+			h.timed = append(h.timed, timedHazard{
+				zone:      pather.HazardZone{Name: "ground effect", Center: m.Position, Radius: groundEffectRadius},
+				expiresAt: now.Add(groundEffectLinger),
+			})
+		}
+	}
+	h.casting = casting
+
+	alive := h.timed[:0]
+	for _, th := range h.timed {
+		if now.Before(th.expiresAt) {
+			alive = append(alive, th)
+			zones = append(zones, th.zone)
+		}
+	}
+	h.timed = alive
+
+	return zones
+}
+
+// UpdateHazards refreshes the hazard map and feeds it to the path finder, so movement routes around hazards too.
+func UpdateHazards() []pather.HazardZone {
+	ctx := context.Get()
+	if !ctx.CharacterCfg.Character.DodgeHazards {
+		return nil
+	}
+
+	return updateHazards(ctx)
+}
+
+func updateHazards(ctx *context.Status) []pather.HazardZone {
+	hazardsMu.Lock()
+	hm, found := hazardMaps[ctx.Name]
+	if !found {
+		hm = &hazardMap{casting: make(map[data.UnitID]bool)}
+		hazardMaps[ctx.Name] = hm
+	}
+	zones := hm.update(ctx)
+	hazardsMu.Unlock()
+
+	ctx.PathFinder.SetHazards(zones)
+
+	return zones
+}
+
+// DodgeHazards steps out of the current hazards, it's meant to be called between casts. Returns true if we moved.
+func DodgeHazards() bool {
+	ctx := context.Get()
+	if !ctx.CharacterCfg.Character.DodgeHazards {
+		return false
+	}
+
+	return dodgeHazards(ctx)
+}
+
+// DodgeBossHazards is DodgeHazards for the boss scripts, the boss hazards (Diablo's lightning hose) are always dodged.
+func DodgeBossHazards() bool {
+	return dodgeHazards(context.Get())
+}
+
+func dodgeHazards(ctx *context.Status) bool {
+	zones := updateHazards(ctx)
+	playerPos := ctx.Data.PlayerUnit.Position
+	if len(zones) == 0 || !ctx.PathFinder.InHazard(playerPos) {
+		return false
+	}
+
+	maxRadius := 0
+	for _, z := range zones {
+		maxRadius = max(maxRadius, z.Radius)
+	}
+
+	for distance := maxRadius + 1; distance <= maxRadius*3+2; distance += 2 {
+		for i := 0; i < hazardDodgeDirections; i++ {
+			angle := 2 * math.Pi * float64(i) / hazardDodgeDirections
+			candidate := data.Position{
+				X: playerPos.X + int(math.Round(math.Cos(angle)*float64(distance))),
+				Y: playerPos.Y + int(math.Round(math.Sin(angle)*float64(distance))),
+			}
+
+			if !ctx.Data.AreaData.IsWalkable(candidate) || ctx.PathFinder.InHazard(candidate) {
+				continue
+			}
+
+			ctx.Logger.Debug("Dodging hazard", "hazard", zones[0].Name)
+			if err := MoveTo(candidate, WithIgnoreMonsters(), WithDistanceToFinish(1)); err != nil {
+				return false
+			}
+
+			return true
+		}
+	}
+
+	return false
+}
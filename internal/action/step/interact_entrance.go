@@ -63,7 +63,9 @@ func InteractEntranceMouse(targetArea area.ID) error {
 	ctx := context.Get()
 
 	for {
-		ctx.PauseIfNotPriority()
+		if err := stepBoundary(ctx); err != nil {
+			return err
+		}
 
 		if ctx.Data.AreaData.Area == targetArea && time.Since(lastRun) > time.Millisecond*500 && ctx.Data.AreaData.IsInside(ctx.Data.PlayerUnit.Position) {
 			return nil
@@ -27,7 +27,9 @@ func InteractNPC(npcID npc.ID) error {
 
 	for attempts := 0; attempts < maxAttempts; attempts++ {
 		// Pause the execution if the priority is not the same as the execution priority
-		ctx.PauseIfNotPriority()
+		if err := stepBoundary(ctx); err != nil {
+			return err
+		}
 
 		// Check if interaction succeeded and menu is open
 		if ctx.Data.OpenMenus.NPCInteract || ctx.Data.OpenMenus.NPCShop {
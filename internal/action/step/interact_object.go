@@ -89,7 +89,9 @@ func InteractObjectMouse(obj data.Object, isCompletedFn func() bool) error {
 	}
 
 	for !isCompletedFn() {
-		ctx.PauseIfNotPriority()
+		if err := stepBoundary(ctx); err != nil {
+			return err
+		}
 
 		if interactionAttempts >= maxInteractionAttempts || mouseOverAttempts >= 20 {
 			return fmt.Errorf("[%s] failed interacting with object [%v] in Area: [%s]", ctx.Name, obj.Name, ctx.Data.PlayerUnit.Area.Area().Name)
@@ -151,26 +153,19 @@ func InteractObjectMouse(obj data.Object, isCompletedFn func() bool) error {
 
 			// For portals with expected area, we need to wait for proper area sync
 			if expectedArea != 0 {
-				utils.PingSleep(utils.Medium, 500)
-
-				maxQuickChecks := 5
-				for attempts := 0; attempts < maxQuickChecks; attempts++ {
-					ctx.RefreshGameData()
-					if ctx.Data.PlayerUnit.Area == expectedArea {
-						if areaData, ok := ctx.Data.Areas[expectedArea]; ok {
-							if areaData.IsInside(ctx.Data.PlayerUnit.Position) {
-								if expectedArea.IsTown() {
-									return nil // For town areas, we can return immediately
-								}
-								// For special areas, ensure we have proper object data loaded
-								if len(ctx.Data.Objects) > 0 {
-									return nil
-								}
-							}
-						}
+				arrived := WaitFor(func(d *game.Data) bool {
+					if d.PlayerUnit.Area != expectedArea {
+						return false
 					}
-
-					utils.PingSleep(utils.Light, 100)
+					areaData, ok := d.Areas[expectedArea]
+					if !ok || !areaData.IsInside(d.PlayerUnit.Position) {
+						return false
+					}
+					// For town areas we can return immediately, special areas need the object data loaded
+					return expectedArea.IsTown() || len(d.Objects) > 0
+				}, 1000)
+				if arrived {
+					return nil
 				}
 
 				// Area transition didn't happen yet - reset hover state to retry portal click
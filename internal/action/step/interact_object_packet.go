@@ -71,7 +71,9 @@ func InteractObjectPacket(obj data.Object, isCompletedFn func() bool) error {
 	}
 
 	for !isCompletedFn() {
-		ctx.PauseIfNotPriority()
+		if err := stepBoundary(ctx); err != nil {
+			return err
+		}
 
 		if interactionAttempts >= maxPacketInteractionAttempts {
 			return fmt.Errorf("[%s] failed interacting with object via packet [%v] in Area: [%s]", ctx.Name, obj.Name, ctx.Data.PlayerUnit.Area.Area().Name)
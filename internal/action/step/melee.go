@@ -0,0 +1,229 @@
+package step
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/skill"
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+	"github.com/hectorgimenez/koolo/internal/context"
+)
+
+const (
+	defaultMeleeEngageDistance    = 2
+	defaultMeleeSurroundRadius    = 4
+	defaultMeleeSurroundThreshold = 5
+	defaultMeleeEscapeDistance    = 6
+	meleeEscapeCooldown           = 3 * time.Second
+	meleeEscapeDirections         = 16
+)
+
+var (
+	ErrMeleeTargetNotFound = errors.New("melee target not found")
+
+	meleeMu          sync.Mutex
+	lastMeleeEscapes = make(map[string]time.Time)
+)
+
+type meleeSettings struct {
+	skill             skill.ID // Skill used to attack, 0 means the left click attack
+	numOfAttacks      int
+	engageDistance    int
+	surroundRadius    int
+	surroundThreshold int
+	escapeDistance    int
+	disableEscape     bool
+}
+
+// MeleeOption configures the melee engagement
+type MeleeOption func(settings *meleeSettings)
+
+// WithMeleeSkill attacks with the given skill instead of the left click attack
+func WithMeleeSkill(sk skill.ID) MeleeOption {
+	return func(settings *meleeSettings) {
+		settings.skill = sk
+	}
+}
+
+// WithMeleeAttacks sets the amount of attacks performed once adjacent to the target
+func WithMeleeAttacks(numOfAttacks int) MeleeOption {
+	return func(settings *meleeSettings) {
+		settings.numOfAttacks = numOfAttacks
+	}
+}
+
+// WithEngageDistance sets how close we need to be to the target to consider it in melee range
+func WithEngageDistance(distance int) MeleeOption {
+	return func(settings *meleeSettings) {
+		settings.engageDistance = distance
+	}
+}
+
+// WithSurroundDetection sets how many enemies within radius are considered being surrounded
+func WithSurroundDetection(radius, threshold int) MeleeOption {
+	return func(settings *meleeSettings) {
+		settings.surroundRadius = radius
+		settings.surroundThreshold = threshold
+	}
+}
+
+// WithEscapeDistance sets how far we step away when surrounded
+func WithEscapeDistance(distance int) MeleeOption {
+	return func(settings *meleeSettings) {
+		settings.escapeDistance = distance
+	}
+}
+
+// WithoutEscape disables the escape repositioning, useful for builds that want to stay in the middle of the pack
+func WithoutEscape() MeleeOption {
+	return func(settings *meleeSettings) {
+		settings.disableEscape = true
+	}
+}
+
+func newMeleeSettings(opts ...MeleeOption) meleeSettings {
+	settings := meleeSettings{
+		numOfAttacks:      1,
+		engageDistance:    defaultMeleeEngageDistance,
+		surroundRadius:    defaultMeleeSurroundRadius,
+		surroundThreshold: defaultMeleeSurroundThreshold,
+		escapeDistance:    defaultMeleeEscapeDistance,
+	}
+	for _, o := range opts {
+		o(&settings)
+	}
+
+	return settings
+}
+
+// MeleeAttack gets adjacent to the target, steps out if we are surrounded and then attacks. It's meant to be called
+// in a loop from the character KillMonsterSequence, every call re-evaluates the target position.
+func MeleeAttack(target data.UnitID, opts ...MeleeOption) error {
+	ctx := context.Get()
+	ctx.SetLastStep("MeleeAttack")
+
+	settings := newMeleeSettings(opts...)
+
+	monster, found := ctx.Data.Monsters.FindByID(target)
+	if !found || monster.Stats[stat.Life] <= 0 {
+		return ErrMeleeTargetNotFound
+	}
+
+	if !settings.disableEscape && IsSurrounded(settings.surroundRadius, settings.surroundThreshold) {
+		if err := EscapeSurround(monster, opts...); err != nil {
+			ctx.Logger.Debug("Failed to escape while surrounded", "error", err)
+		}
+	}
+
+	if err := StickToTarget(target, opts...); err != nil {
+		return err
+	}
+
+	if settings.skill == 0 {
+		return PrimaryAttack(target, settings.numOfAttacks, false, Distance(1, settings.engageDistance))
+	}
+
+	return SecondaryAttack(settings.skill, target, settings.numOfAttacks, Distance(1, settings.engageDistance))
+}
+
+// StickToTarget moves next to the target if it's out of melee range, following it when it moves away.
+func StickToTarget(target data.UnitID, opts ...MeleeOption) error {
+	ctx := context.Get()
+	settings := newMeleeSettings(opts...)
+
+	monster, found := ctx.Data.Monsters.FindByID(target)
+	if !found {
+		return ErrMeleeTargetNotFound
+	}
+
+	if ctx.PathFinder.DistanceFromMe(monster.Position) <= settings.engageDistance {
+		return nil
+	}
+
+	return MoveTo(monster.Position, WithIgnoreMonsters(), WithDistanceToFinish(max(1, settings.engageDistance)))
+}
+
+// IsSurrounded returns true when at least threshold alive enemies are within radius from the player.
+func IsSurrounded(radius, threshold int) bool {
+	ctx := context.Get()
+
+	count := 0
+	for _, m := range ctx.Data.Monsters.Enemies() {
+		if m.Stats[stat.Life] <= 0 {
+			continue
+		}
+		if ctx.PathFinder.DistanceFromMe(m.Position) <= radius {
+			count++
+			if count >= threshold {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// EscapeSurround moves to the nearby walkable spot with the least enemies around, keeping the target close enough
+// to re-engage right after. There is a cooldown between escapes to avoid dancing around the pack.
+func EscapeSurround(target data.Monster, opts ...MeleeOption) error {
+	ctx := context.Get()
+	settings := newMeleeSettings(opts...)
+
+	meleeMu.Lock()
+	lastEscape := lastMeleeEscapes[ctx.Name]
+	meleeMu.Unlock()
+	if time.Since(lastEscape) < meleeEscapeCooldown {
+		return nil
+	}
+
+	playerPos := ctx.Data.PlayerUnit.Position
+	enemies := ctx.Data.Monsters.Enemies()
+
+	bestPos := playerPos
+	bestScore := math.MaxInt
+	for i := 0; i < meleeEscapeDirections; i++ {
+		angle := 2 * math.Pi * float64(i) / meleeEscapeDirections
+		candidate := data.Position{
+			X: playerPos.X + int(math.Round(math.Cos(angle)*float64(settings.escapeDistance))),
+			Y: playerPos.Y + int(math.Round(math.Sin(angle)*float64(settings.escapeDistance))),
+		}
+
+		if !ctx.Data.AreaData.IsWalkable(candidate) {
+			continue
+		}
+
+		crowd := 0
+		for _, m := range enemies {
+			if m.Stats[stat.Life] <= 0 {
+				continue
+			}
+			dx := m.Position.X - candidate.X
+			dy := m.Position.Y - candidate.Y
+			if dx*dx+dy*dy <= settings.surroundRadius*settings.surroundRadius {
+				crowd++
+			}
+		}
+
+		// Enemies around the spot are the main cost, distance to the target is the tiebreaker
+		dx := target.Position.X - candidate.X
+		dy := target.Position.Y - candidate.Y
+		score := crowd*1000 + dx*dx + dy*dy
+		if score < bestScore {
+			bestScore = score
+			bestPos = candidate
+		}
+	}
+
+	if bestPos == playerPos {
+		return errors.New("no escape position found")
+	}
+
+	meleeMu.Lock()
+	lastMeleeEscapes[ctx.Name] = time.Now()
+	meleeMu.Unlock()
+
+	return MoveTo(bestPos, WithIgnoreMonsters(), WithDistanceToFinish(1))
+}
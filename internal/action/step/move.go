@@ -160,8 +160,25 @@ func MoveTo(dest data.Position, options ...MoveOption) error {
 
 	startArea := ctx.Data.PlayerUnit.Area
 
+	// The teleport staff is on the second weapon set, it's taken once for the whole move and the main set is put back
+	// at the end so the fights after the move don't swap again
+	if ctx.CharacterCfg.Character.TeleportWithSwap && ctx.CharacterCfg.Character.UseTeleport &&
+		!ctx.Data.AreaData.Area.IsTown() && ctx.Data.ActiveWeaponSlot != SwapWeaponSlot {
+		if err := EnsureWeaponSlot(SwapWeaponSlot); err != nil {
+			ctx.Logger.Warn("Failed swapping to the teleport staff, walking instead", "error", err)
+		} else {
+			defer func() {
+				if err := EnsureWeaponSlot(MainWeaponSlot); err != nil {
+					ctx.Logger.Warn("Failed swapping back to the main weapon after moving", "error", err)
+				}
+			}()
+		}
+	}
+
 	for {
-		ctx.PauseIfNotPriority()
+		if err := stepBoundary(ctx); err != nil {
+			return err
+		}
 
 		// Check if a Drop request is pending and interrupt
 		// the current movement early so the Drop flow can take over
@@ -175,16 +192,12 @@ func MoveTo(dest data.Position, options ...MoveOption) error {
 		// This happens during portal interactions - area transition means objective achieved
 		if ctx.Data.PlayerUnit.Area != startArea {
 			// Wait for collision data to be loaded for the new area before returning
-			deadline := time.Now().Add(2 * time.Second)
-			for time.Now().Before(deadline) {
-				if ctx.Data.AreaData.Grid != nil &&
-					ctx.Data.AreaData.Grid.CollisionGrid != nil &&
-					len(ctx.Data.AreaData.Grid.CollisionGrid) > 0 {
-					// Area transitioned and collision data loaded - movement objective achieved
-					return nil
-				}
-				utils.Sleep(100)
-				ctx.RefreshGameData()
+			collisionLoaded := WaitFor(func(d *game.Data) bool {
+				return d.AreaData.Grid != nil && d.AreaData.Grid.CollisionGrid != nil && len(d.AreaData.Grid.CollisionGrid) > 0
+			}, 2000)
+			if collisionLoaded {
+				// Area transitioned and collision data loaded - movement objective achieved
+				return nil
 			}
 			// If we timeout waiting for collision data, return error
 			return fmt.Errorf("area transition detected but collision data failed to load for area %s", ctx.Data.PlayerUnit.Area.Area().Name)
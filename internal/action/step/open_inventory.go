@@ -3,8 +3,8 @@ package step
 import (
 	"errors"
 
+	"github.com/hectorgimenez/d2go/pkg/data"
 	"github.com/hectorgimenez/koolo/internal/context"
-	"github.com/hectorgimenez/koolo/internal/utils"
 )
 
 func OpenInventory() error {
@@ -14,13 +14,15 @@ func OpenInventory() error {
 	attempts := 0
 	for !ctx.Data.OpenMenus.Inventory {
 		// Pause the execution if the priority is not the same as the execution priority
-		ctx.PauseIfNotPriority()
+		if err := stepBoundary(ctx); err != nil {
+			return err
+		}
 		ctx.RefreshGameData()
 		if attempts > 10 {
 			return errors.New("failed opening inventory")
 		}
 		ctx.HID.PressKeyBinding(ctx.Data.KeyBindings.Inventory)
-		utils.Sleep(200)
+		WaitForMenu(func(m data.OpenMenus) bool { return m.Inventory }, true, 200)
 		attempts++
 	}
 
@@ -42,7 +42,9 @@ func OpenPortal() error {
 		}
 
 		// Pause the execution if the priority is not the same as the execution priority
-		ctx.PauseIfNotPriority()
+		if err := stepBoundary(ctx); err != nil {
+			return err
+		}
 
 		_, found := ctx.Data.Objects.FindOne(object.TownPortal)
 		if found {
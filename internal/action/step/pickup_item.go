@@ -104,7 +104,9 @@ func PickupItemMouse(it data.Item, itemPickupAttempt int) error {
 	startTime := time.Now()
 
 	for {
-		ctx.PauseIfNotPriority()
+		if err := stepBoundary(ctx); err != nil {
+			return err
+		}
 		ctx.RefreshGameData()
 
 		// Periodic monster check
@@ -122,6 +124,7 @@ func PickupItemMouse(it data.Item, itemPickupAttempt int) error {
 			ctx.Logger.Info(fmt.Sprintf("Picked up: %s [%s] | Item Pickup Attempt:%d | Spiral Attempt:%d", targetItem.Desc().Name, targetItem.Quality.ToString(), itemPickupAttempt, spiralAttempt))
 
 			ctx.CurrentGame.PickedUpItems[int(targetItem.UnitID)] = int(ctx.Data.PlayerUnit.Area.Area().ID)
+			ctx.CurrentGame.PickedUpAt[int(targetItem.UnitID)] = time.Now()
 
 			return nil // Success!
 		}
@@ -44,7 +44,9 @@ func PickupItemPacket(it data.Item, itemPickupAttempt int) error {
 
 	targetItem := it
 
-	ctx.PauseIfNotPriority()
+	if err := stepBoundary(ctx); err != nil {
+		return err
+	}
 	ctx.RefreshGameData()
 
 	if hasHostileMonstersNearby(it.Position) {
@@ -56,6 +58,7 @@ func PickupItemPacket(it data.Item, itemPickupAttempt int) error {
 	if !exists {
 		ctx.Logger.Info(fmt.Sprintf("Picked up (already gone): %s [%s] | Item Pickup Attempt:%d", targetItem.Desc().Name, targetItem.Quality.ToString(), itemPickupAttempt))
 		ctx.CurrentGame.PickedUpItems[int(targetItem.UnitID)] = int(ctx.Data.PlayerUnit.Area.Area().ID)
+		ctx.CurrentGame.PickedUpAt[int(targetItem.UnitID)] = time.Now()
 		return nil
 	}
 
@@ -75,6 +78,7 @@ func PickupItemPacket(it data.Item, itemPickupAttempt int) error {
 		if !stillExists {
 			ctx.Logger.Info(fmt.Sprintf("Picked up (packet): %s [%s] | Item Pickup Attempt:%d", targetItem.Desc().Name, targetItem.Quality.ToString(), itemPickupAttempt))
 			ctx.CurrentGame.PickedUpItems[int(targetItem.UnitID)] = int(ctx.Data.PlayerUnit.Area.Area().ID)
+			ctx.CurrentGame.PickedUpAt[int(targetItem.UnitID)] = time.Now()
 			return nil
 		}
 	}
@@ -0,0 +1,79 @@
+package step
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/item"
+	"github.com/hectorgimenez/d2go/pkg/data/skill"
+	"github.com/hectorgimenez/koolo/internal/context"
+	"github.com/hectorgimenez/koolo/internal/utils"
+)
+
+// telekinesisRange is the max distance to grab items with Telekinesis, they have to be on screen to be targeted
+const telekinesisRange = 15
+
+// CanTelekinesisPickup returns true if the item can be grabbed at range with Telekinesis: gold, potions and scrolls,
+// when the character has the skill and can select it
+func CanTelekinesisPickup(it data.Item) bool {
+	ctx := context.Get()
+
+	if it.Name != "Gold" && !it.IsPotion() && !it.Type().IsType(item.TypeScroll) {
+		return false
+	}
+
+	if sk, found := ctx.Data.PlayerUnit.Skills[skill.Telekinesis]; !found || sk.Level == 0 {
+		return false
+	}
+	if _, found := ctx.Data.KeyBindings.KeyBindingForSkill(skill.Telekinesis); !found && !ctx.CharacterCfg.PacketCasting.UseForSkillSelection {
+		return false
+	}
+
+	return ctx.PathFinder.DistanceFromMe(it.Position) <= telekinesisRange &&
+		ctx.PathFinder.LineOfSight(ctx.Data.PlayerUnit.Position, it.Position)
+}
+
+// PickupItemTelekinesis casts Telekinesis on the item, hovering it first like the mouse pickup does
+func PickupItemTelekinesis(it data.Item) error {
+	ctx := context.Get()
+	ctx.SetLastStep("PickupItemTelekinesis")
+
+	if hasHostileMonstersNearby(it.Position) {
+		return ErrMonsterAroundItem
+	}
+
+	button, selected := SelectSkill(skill.Telekinesis)
+	if !selected {
+		return fmt.Errorf("telekinesis could not be selected")
+	}
+
+	baseScreenX, baseScreenY := ctx.PathFinder.GameCoordsToScreenCords(it.Position.X-1, it.Position.Y-1)
+	startTime := time.Now()
+	for spiralAttempt := 0; spiralAttempt <= maxInteractions && time.Since(startTime) < pickupTimeout; spiralAttempt++ {
+		if err := stepBoundary(ctx); err != nil {
+			return err
+		}
+		ctx.RefreshGameData()
+
+		currentItem, exists := findItemOnGround(it.UnitID)
+		if !exists {
+			ctx.Logger.Debug(fmt.Sprintf("Picked up with Telekinesis: %s", it.Desc().Name))
+			ctx.CurrentGame.PickedUpItems[int(it.UnitID)] = int(ctx.Data.PlayerUnit.Area.Area().ID)
+			ctx.CurrentGame.PickedUpAt[int(it.UnitID)] = time.Now()
+			return nil
+		}
+
+		offsetX, offsetY := utils.ItemSpiral(spiralAttempt)
+		cursorX, cursorY := baseScreenX+offsetX, baseScreenY+offsetY
+		ctx.HID.MovePointer(cursorX, cursorY)
+		time.Sleep(spiralDelay)
+
+		if currentItem.UnitID == ctx.GameReader.GameReader.GetData().HoverData.UnitID {
+			ctx.HID.Click(button, cursorX, cursorY)
+			utils.PingSleep(utils.Light, 300)
+		}
+	}
+
+	return fmt.Errorf("failed to pick up %s with Telekinesis", it.Desc().Name)
+}
@@ -1,43 +1,79 @@
 package step
 
 import (
-	"time"
+	"fmt"
 
+	"github.com/hectorgimenez/d2go/pkg/data/item"
 	"github.com/hectorgimenez/d2go/pkg/data/skill"
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
 	"github.com/hectorgimenez/koolo/internal/context"
-	"github.com/hectorgimenez/koolo/internal/utils"
+	"github.com/hectorgimenez/koolo/internal/game"
 )
 
+// Weapon sets as reported by the game data
+const (
+	MainWeaponSlot = game.MainWeaponSlot
+	SwapWeaponSlot = game.SwapWeaponSlot
+)
+
+// Swaps tried before giving up, the key press is lost when it lands during an animation
+const weaponSwapAttempts = 3
+
 func SwapToMainWeapon() error {
-	return swapWeapon(false)
+	return EnsureWeaponSlot(MainWeaponSlot)
 }
 
+// SwapToCTA activates the weapon set holding the CTA, the second one when there's no CTA equipped.
 func SwapToCTA() error {
-	return swapWeapon(true)
+	return EnsureWeaponSlot(ctaWeaponSlot())
 }
 
-func swapWeapon(toCTA bool) error {
-	lastRun := time.Time{}
-
+// EnsureWeaponSlot swaps weapons until the weapon set is the active one, an error is returned when it's still not
+// active after a few swaps so the caller can go on with the current set.
+func EnsureWeaponSlot(slot int) error {
 	ctx := context.Get()
-	ctx.SetLastStep("SwapToCTA")
+	ctx.SetLastStep("EnsureWeaponSlot")
 
-	for {
-		// Pause the execution if the priority is not the same as the execution priority
-		ctx.PauseIfNotPriority()
+	if slot != MainWeaponSlot && slot != SwapWeaponSlot {
+		return fmt.Errorf("invalid weapon slot %d", slot)
+	}
 
-		if time.Since(lastRun) < time.Millisecond*500 {
-			continue
+	ctx.RefreshGameData()
+	for attempt := 0; attempt < weaponSwapAttempts; attempt++ {
+		if ctx.Data.ActiveWeaponSlot == slot {
+			return nil
 		}
 
-		_, found := ctx.Data.PlayerUnit.Skills[skill.BattleOrders]
-		if (toCTA && found) || (!toCTA && !found) {
-			return nil
+		// Pause the execution if the priority is not the same as the execution priority
+		if err := stepBoundary(ctx); err != nil {
+			return err
 		}
 
 		ctx.HID.PressKeyBinding(ctx.Data.KeyBindings.SwapWeapons)
-		utils.PingSleep(utils.Light, 150)
+		WaitFor(func(d *game.Data) bool { return d.ActiveWeaponSlot == slot }, 150)
+	}
 
-		lastRun = time.Now()
+	if ctx.Data.ActiveWeaponSlot == slot {
+		return nil
 	}
+
+	return fmt.Errorf("failed to switch to weapon slot %d after %d swaps", slot, weaponSwapAttempts)
+}
+
+func ctaWeaponSlot() int {
+	ctx := context.Get()
+
+	for _, itm := range ctx.Data.Inventory.ByLocation(item.LocationEquipped) {
+		if _, found := itm.FindStat(stat.NonClassSkill, int(skill.BattleOrders)); !found {
+			continue
+		}
+		switch itm.Location.BodyLocation {
+		case item.LocLeftArm, item.LocRightArm:
+			return MainWeaponSlot
+		case item.LocLeftArmSecondary, item.LocRightArmSecondary:
+			return SwapWeaponSlot
+		}
+	}
+
+	return SwapWeaponSlot
 }
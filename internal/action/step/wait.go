@@ -0,0 +1,42 @@
+package step
+
+import (
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/item"
+	"github.com/hectorgimenez/koolo/internal/context"
+	"github.com/hectorgimenez/koolo/internal/game"
+	"github.com/hectorgimenez/koolo/internal/utils"
+)
+
+// WaitFor waits until the condition is true, it's checked on every new game data snapshot instead of sleeping a fixed
+// time. The timeout is raised with the ping, false is returned when it expires first.
+func WaitFor(condition func(d *game.Data) bool, timeoutMS int) bool {
+	ctx := context.Get()
+
+	return ctx.AwaitCondition(condition, time.Duration(utils.PingMultiplier(utils.Light, timeoutMS))*time.Millisecond)
+}
+
+// WaitForMenu waits until the menu is open, or closed when open is false.
+func WaitForMenu(menu func(m data.OpenMenus) bool, open bool, timeoutMS int) bool {
+	return WaitFor(func(d *game.Data) bool { return menu(d.OpenMenus) == open }, timeoutMS)
+}
+
+// WaitForMenusClosed waits until every game menu is closed.
+func WaitForMenusClosed(timeoutMS int) bool {
+	return WaitFor(func(d *game.Data) bool { return !d.OpenMenus.IsMenuOpen() }, timeoutMS)
+}
+
+// WaitForCursorEmpty waits until there's no item held on the cursor.
+func WaitForCursorEmpty(timeoutMS int) bool {
+	return WaitFor(func(d *game.Data) bool { return len(d.Inventory.ByLocation(item.LocationCursor)) == 0 }, timeoutMS)
+}
+
+// WaitForItemMoved waits until the item is found out of the location.
+func WaitForItemMoved(unitID data.UnitID, from item.LocationType, timeoutMS int) bool {
+	return WaitFor(func(d *game.Data) bool {
+		itm, found := d.Inventory.FindByID(unitID)
+		return found && itm.Location.LocationType != from
+	}, timeoutMS)
+}
@@ -0,0 +1,66 @@
+package action
+
+import (
+	"time"
+
+	"github.com/hectorgimenez/koolo/internal/context"
+	"github.com/hectorgimenez/koolo/internal/event"
+)
+
+// SafeSuspend is called between two steps once a suspend is requested. It checkpoints the run, parks the character
+// in town and waits until the suspend is cleared, then goes back through the portal and to the checkpoint position so
+// the run goes on where it was. The time spent suspended doesn't count toward the game length.
+func SafeSuspend() {
+	ctx := context.Get()
+	ctx.SetLastAction("SafeSuspend")
+
+	ctx.RefreshGameData()
+	checkpoint := &context.SuspendCheckpoint{
+		Run:        ctx.CurrentGame.CurrentRun,
+		Area:       ctx.Data.PlayerUnit.Area,
+		Position:   ctx.Data.PlayerUnit.Position,
+		LastAction: ctx.ContextDebug[context.PriorityNormal].LastAction,
+		At:         time.Now(),
+	}
+	ctx.SuspendCheckpoint = checkpoint
+	ctx.Logger.Info("Suspending", "run", checkpoint.Run, "area", checkpoint.Area.Area().Name, "lastAction", checkpoint.LastAction)
+
+	if !checkpoint.Area.IsTown() {
+		if err := ReturnTown(); err != nil {
+			ctx.Logger.Warn("Failed to park in town, suspending in place", "error", err)
+		} else {
+			checkpoint.Parked = true
+		}
+	}
+
+	ctx.SwitchPriority(context.PriorityPause)
+	ctx.MemoryInjector.RestoreMemory()
+	event.Send(event.GamePaused(event.Text(ctx.Name, "Game suspended"), true))
+
+	suspendedAt := time.Now()
+	for ctx.SuspendRequested.Load() {
+		if ctx.ExecutionPriority == context.PriorityStop {
+			panic("Bot is stopped")
+		}
+		time.Sleep(250 * time.Millisecond)
+	}
+	ctx.SuspendedFor.Add(int64(time.Since(suspendedAt)))
+
+	ctx.MemoryInjector.Load()
+	ctx.SwitchPriority(context.PriorityNormal)
+	event.Send(event.GamePaused(event.Text(ctx.Name, "Game resumed"), false))
+	ctx.Logger.Info("Resuming", "run", checkpoint.Run, "area", checkpoint.Area.Area().Name)
+
+	ctx.RefreshGameData()
+	if checkpoint.Parked && ctx.Data.PlayerUnit.Area.IsTown() {
+		if err := UsePortalInTown(); err != nil {
+			ctx.Logger.Warn("Failed to go back through the portal after resuming", "error", err)
+		}
+	}
+	if !checkpoint.Area.IsTown() && ctx.Data.PlayerUnit.Area == checkpoint.Area {
+		if err := MoveToCoords(checkpoint.Position); err != nil {
+			ctx.Logger.Warn("Failed to go back to the checkpoint after resuming", "error", err)
+		}
+	}
+	ctx.SuspendCheckpoint = nil
+}
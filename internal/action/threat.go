@@ -0,0 +1,167 @@
+package action
+
+import (
+	"sort"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/npc"
+	"github.com/hectorgimenez/d2go/pkg/data/state"
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/context"
+)
+
+const (
+	defaultThreatPriorityWeight  = 100
+	defaultThreatRangedWeight    = 40
+	defaultThreatAuraWeight      = 30
+	defaultThreatEliteWeight     = 15
+	defaultThreatImmunityPenalty = 80
+	defaultThreatDistanceWeight  = 2
+
+	// Monsters this close are hitting us already, they keep their distance advantage over any priority target
+	threatMeleeDistance = 2
+)
+
+var rangedMonsters = map[npc.ID]bool{
+	npc.VileHunter:        true,
+	npc.Gloam:             true,
+	npc.VileArcher:        true,
+	npc.DarkArcher:        true,
+	npc.SkeletonArcher:    true,
+	npc.BoneArcher:        true,
+	npc.BurningDeadArcher: true,
+	npc.HorrorArcher:      true,
+	npc.ReturnedMage:      true,
+	npc.BoneMage:          true,
+	npc.BurningDeadMage:   true,
+	npc.HorrorMage:        true,
+	npc.StygianHag:        true,
+	npc.CorpseSpitter:     true,
+	npc.VileTemptress:     true,
+	npc.StygianHarlot:     true,
+	npc.HellTemptress:     true,
+	npc.BloodWitch:        true,
+	npc.OblivionKnight:    true,
+	npc.AbyssKnight:       true,
+	npc.Slinger:           true,
+	npc.NightSlinger:      true,
+	npc.HellSlinger:       true,
+	npc.CouncilMember:     true,
+	npc.CouncilMember2:    true,
+	npc.CouncilMember3:    true,
+	npc.GhoulLord:         true,
+	npc.NightLord:         true,
+	npc.DarkLord:          true,
+	npc.BloodLord:         true,
+}
+
+var dangerousAuraStates = []state.State{
+	state.Might,
+	state.Fanaticism,
+	state.Conviction,
+	state.Holyfire,
+	state.Holyshock,
+	state.Holywindcold,
+}
+
+// threatWeights returns the configured weights, zero values fall back to the defaults.
+func threatWeights(cfg config.ThreatScoringConfig) config.ThreatScoringConfig {
+	orDefault := func(v, def float64) float64 {
+		if v == 0 {
+			return def
+		}
+		return v
+	}
+
+	cfg.PriorityWeight = orDefault(cfg.PriorityWeight, defaultThreatPriorityWeight)
+	cfg.RangedWeight = orDefault(cfg.RangedWeight, defaultThreatRangedWeight)
+	cfg.AuraWeight = orDefault(cfg.AuraWeight, defaultThreatAuraWeight)
+	cfg.EliteWeight = orDefault(cfg.EliteWeight, defaultThreatEliteWeight)
+	cfg.ImmunityPenalty = orDefault(cfg.ImmunityPenalty, defaultThreatImmunityPenalty)
+	cfg.DistanceWeight = orDefault(cfg.DistanceWeight, defaultThreatDistanceWeight)
+
+	return cfg
+}
+
+func IsRangedMonster(m data.Monster) bool {
+	return rangedMonsters[m.Name]
+}
+
+func HasDangerousAura(m data.Monster) bool {
+	for _, st := range dangerousAuraStates {
+		if m.States.HasState(st) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ThreatScore ranks how urgent is to kill the given monster, higher is more dangerous.
+func ThreatScore(m data.Monster, distance int, weights config.ThreatScoringConfig) float64 {
+	score := 0.0
+
+	if IsPriorityMonster(m) {
+		score += weights.PriorityWeight
+	}
+	if IsRangedMonster(m) {
+		score += weights.RangedWeight
+	}
+	if HasDangerousAura(m) {
+		score += weights.AuraWeight
+	}
+	if m.IsElite() {
+		score += weights.EliteWeight
+	}
+
+	// Targets immune to our damage take long to kill, the penalty scales with the amount of damage types blocked
+	if len(weights.BuildDamageTypes) > 0 {
+		immune := 0
+		for _, resist := range weights.BuildDamageTypes {
+			if m.IsImmune(resist) {
+				immune++
+			}
+		}
+		score -= weights.ImmunityPenalty * float64(immune) / float64(len(weights.BuildDamageTypes))
+	}
+
+	score -= weights.DistanceWeight * float64(distance)
+
+	return score
+}
+
+// SortEnemiesByThreat sorts the enemies by threat score, monsters already in melee range are always handled first.
+func SortEnemiesByThreat(enemies *[]data.Monster) {
+	ctx := context.Get()
+	weights := threatWeights(ctx.CharacterCfg.Character.ThreatScoring)
+
+	type scoredMonster struct {
+		monster  data.Monster
+		distance int
+		score    float64
+	}
+
+	scored := make([]scoredMonster, 0, len(*enemies))
+	for _, m := range *enemies {
+		distance := ctx.PathFinder.DistanceFromMe(m.Position)
+		scored = append(scored, scoredMonster{
+			monster:  m,
+			distance: distance,
+			score:    ThreatScore(m, distance, weights),
+		})
+	}
+
+	sort.SliceStable(scored, func(i, j int) bool {
+		closeI := scored[i].distance <= threatMeleeDistance
+		closeJ := scored[j].distance <= threatMeleeDistance
+		if closeI != closeJ {
+			return closeI
+		}
+
+		return scored[i].score > scored[j].score
+	})
+
+	for i, sm := range scored {
+		(*enemies)[i] = sm.monster
+	}
+}
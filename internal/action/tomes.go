@@ -0,0 +1,57 @@
+package action
+
+import (
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/item"
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+	"github.com/hectorgimenez/koolo/internal/action/step"
+	"github.com/hectorgimenez/koolo/internal/context"
+)
+
+// RestoreTPTome takes the backup TP tome from the stash when there's none left in the inventory.
+func RestoreTPTome() error {
+	ctx := context.Get()
+	ctx.SetLastAction("RestoreTPTome")
+
+	if !ctx.CharacterCfg.Inventory.BackupTPTome {
+		return nil
+	}
+	if _, found := ctx.Data.Inventory.Find(item.TomeOfTownPortal, item.LocationInventory); found {
+		return nil
+	}
+
+	tome, found := ctx.Data.Inventory.Find(item.TomeOfTownPortal, item.LocationStash, item.LocationSharedStash)
+	if !found {
+		return nil
+	}
+
+	ctx.Logger.Info("TP Tome missing from the inventory, taking the backup one from the stash")
+	if err := TakeItemsFromStash([]data.Item{tome}); err != nil {
+		return err
+	}
+
+	return step.CloseAllMenus()
+}
+
+// isBackupTPTome tells if the tome is a spare one to keep in the stash, the inventory tome with the most scrolls stays.
+func isBackupTPTome(i data.Item) bool {
+	ctx := context.Get()
+	if i.Name != item.TomeOfTownPortal || !ctx.CharacterCfg.Inventory.BackupTPTome {
+		return false
+	}
+
+	var kept data.Item
+	keptQty := -1
+	for _, tome := range ctx.Data.Inventory.ByLocation(item.LocationInventory) {
+		if tome.Name != item.TomeOfTownPortal {
+			continue
+		}
+		qty, _ := tome.FindStat(stat.Quantity, 0)
+		if qty.Value > keptQty || qty.Value == keptQty && tome.UnitID < kept.UnitID {
+			kept = tome
+			keptQty = qty.Value
+		}
+	}
+
+	return keptQty >= 0 && kept.UnitID != i.UnitID
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/hectorgimenez/koolo/internal/action/step"
 	"github.com/hectorgimenez/koolo/internal/config"
 	"github.com/hectorgimenez/koolo/internal/context"
+	"github.com/hectorgimenez/koolo/internal/event"
 	"github.com/hectorgimenez/koolo/internal/utils"
 )
 
@@ -17,6 +18,11 @@ func StashFull() bool {
 	ctx := context.Get()
 	totalUsedSpace := 0
 
+	// An item couldn't be stashed in the previous town visit, no need to count the space
+	if consumeMuleRequest(ctx.Name) {
+		return true
+	}
+
 	// Stash tabs are 1-indexed, so we check tabs 2, 3, and 4.
 	// These correspond to the first three shared stash tabs.
 	tabsToCheck := []int{2, 3, 4}
@@ -38,6 +44,7 @@ func StashFull() bool {
 
 func PreRun(firstRun bool) error {
 	ctx := context.Get()
+	defer ctx.Profiler.Enter(context.PhaseTown)()
 
 	// Muling logic for the main farmer character
 	if ctx.CharacterCfg.Muling.Enabled && ctx.CharacterCfg.Muling.ReturnTo == "" {
@@ -84,9 +91,17 @@ func PreRun(firstRun bool) error {
 		}
 	}
 
+	ReportGold()
+	RequestRunBuff()
 	DropAndRecoverCursorItem()
+	if ctx.CharacterCfg.Companion.Enabled && ctx.CharacterCfg.Companion.Leader {
+		PartyWith()
+	}
 	step.SetSkill(skill.Vigor)
 	RecoverCorpse()
+	if err := RestoreTPTome(); err != nil {
+		ctx.Logger.Warn("Failed to take the backup TP Tome from the stash", "error", err)
+	}
 	ManageBelt()
 	// Just to make sure messages like TZ change or public game spam arent on the way
 	ClearMessages()
@@ -99,44 +114,37 @@ func PreRun(firstRun bool) error {
 
 	_, isLevelingChar := ctx.Char.(context.LevelingCharacter)
 	if firstRun && !isLevelingChar {
-		Stash(false)
+		RunAction("Stash", func() error { return Stash(false) })
 	}
 
 	if !isLevelingChar {
 		// Store items that need to be left unidentified
 		if HaveItemsToStashUnidentified() {
-			Stash(false)
+			RunAction("Stash", func() error { return Stash(false) })
 		}
 	}
 
 	// Identify - either via Cain or Tome
-	IdentifyAll(false)
+	RunAction("IdentifyAll", func() error { return IdentifyAll(false) })
 
-	if ctx.CharacterCfg.Game.Leveling.AutoEquip && isLevelingChar {
+	if AutoEquipEnabled() {
 		AutoEquip()
 	}
 
-	// Stash before vendor
-	Stash(false)
-
-	// Refill pots, sell, buy etc
-	VendorRefill(VendorRefillOpts{SellJunk: true, BuyConsumables: true})
-
-	// Gamble
-	Gamble()
-
-	// Stash again if needed
-	Stash(false)
+	// Heal, revive the merc, repair, stash, refill and gamble, walking the shortest route between the NPCs
+	if err := RunAction("TownServicesRoute", TownServicesRoute); err != nil {
+		return err
+	}
 
 	if ctx.CharacterCfg.CubeRecipes.PrioritizeRunewords {
-		MakeRunewords()
+		RunAction("MakeRunewords", func() error { return MakeRunewords() })
 		if !isLevelingChar {
 			RerollRunewords()
 		}
-		CubeRecipes()
+		RunAction("CubeRecipes", func() error { return CubeRecipes() })
 	} else {
-		CubeRecipes()
-		MakeRunewords()
+		RunAction("CubeRecipes", func() error { return CubeRecipes() })
+		RunAction("MakeRunewords", func() error { return MakeRunewords() })
 		if !isLevelingChar {
 			RerollRunewords()
 		}
@@ -144,9 +152,15 @@ func PreRun(firstRun bool) error {
 
 	// After creating or rerolling runewords, stash newly created bases/runewords
 	// so we don't carry them out to the next area unnecessarily.
-	Stash(false)
+	RunAction("Stash", func() error { return Stash(false) })
 
-	if ctx.CharacterCfg.Game.Leveling.AutoEquip && isLevelingChar {
+	if !isLevelingChar {
+		if err := OrganizeStash(); err != nil {
+			ctx.Logger.Warn("Failed organizing the stash", "error", err)
+		}
+	}
+
+	if AutoEquipEnabled() {
 		AutoEquip()
 	}
 
@@ -154,6 +168,10 @@ func PreRun(firstRun bool) error {
 		OptimizeInventory(item.LocationInventory)
 	}
 
+	if err := SwitchBuildIfNeeded(); errors.Is(err, ErrBuildSwitched) {
+		return err
+	}
+
 	// Leveling related checks
 	if ctx.CharacterCfg.Game.Leveling.EnsurePointsAllocation && isLevelingChar {
 		ResetStats()
@@ -174,19 +192,47 @@ func PreRun(firstRun bool) error {
 		EnsureSkillBindings()
 	}
 
-	HealAtNPC()
-	ReviveMerc()
+	event.Send(event.TownVisited(event.Text(ctx.Name, ""), ctx.Data.PlayerUnit.Area))
+	runTownRoutineHooks()
+
 	HireMerc()
+	SnapshotEquipment()
+
+	return nil
+}
 
-	return RepairTownRoutine()
+type townRoutineHook struct {
+	name string
+	fn   func() error
+}
+
+var townRoutineHooks []townRoutineHook
+
+// AddTownRoutineHook adds a step to the end of the town routines, before the merc is hired. It's meant to be called
+// on init, the plugins use it to add their town behaviors.
+func AddTownRoutineHook(name string, fn func() error) {
+	townRoutineHooks = append(townRoutineHooks, townRoutineHook{name: name, fn: fn})
+}
+
+// runTownRoutineHooks runs the hooks through the middleware, a failing hook doesn't stop the town routine.
+func runTownRoutineHooks() {
+	ctx := context.Get()
+
+	for _, hook := range townRoutineHooks {
+		if err := RunAction(hook.name, hook.fn); err != nil {
+			ctx.Logger.Warn("Town routine hook failed", "hook", hook.name, "error", err)
+		}
+		ctx.PauseIfNotPriority()
+	}
 }
 
 func InRunReturnTownRoutine() error {
 	ctx := context.Get()
+	defer ctx.Profiler.Enter(context.PhaseTown)()
 
 	ctx.PauseIfNotPriority()
 
-	if err := ReturnTown(); err != nil {
+	if err := RunAction("ReturnTown", ReturnTown); err != nil {
 		return fmt.Errorf("failed to return to town: %w", err)
 	}
 
@@ -198,6 +244,9 @@ func InRunReturnTownRoutine() error {
 	step.SetSkill(skill.Vigor)
 	RecoverCorpse()
 	ctx.PauseIfNotPriority() // Check after RecoverCorpse
+	if err := RestoreTPTome(); err != nil {
+		ctx.Logger.Warn("Failed to take the backup TP Tome from the stash", "error", err)
+	}
 	ManageBelt()
 	ctx.PauseIfNotPriority() // Check after ManageBelt
 	RefillBeltFromInventory()
@@ -205,40 +254,36 @@ func InRunReturnTownRoutine() error {
 
 	// Let's stash items that need to be left unidentified
 	if ctx.CharacterCfg.Game.UseCainIdentify && HaveItemsToStashUnidentified() {
-		Stash(false)
+		RunAction("Stash", func() error { return Stash(false) })
 		ctx.PauseIfNotPriority() // Check after Stash
 	}
 
-	IdentifyAll(false)
+	RunAction("IdentifyAll", func() error { return IdentifyAll(false) })
 
 	_, isLevelingChar := ctx.Char.(context.LevelingCharacter)
-	if ctx.CharacterCfg.Game.Leveling.AutoEquip && isLevelingChar {
+	if AutoEquipEnabled() {
 		AutoEquip()
 		ctx.PauseIfNotPriority() // Check after AutoEquip
 	}
 
-	VendorRefill(VendorRefillOpts{SellJunk: true, BuyConsumables: true})
-	ctx.PauseIfNotPriority() // Check after VendorRefill
-	Stash(false)
-	ctx.PauseIfNotPriority() // Check after Stash
-	Gamble()
-	ctx.PauseIfNotPriority() // Check after Gamble
-	Stash(false)
-	ctx.PauseIfNotPriority() // Check after Stash
+	if err := RunAction("TownServicesRoute", TownServicesRoute); err != nil {
+		return err
+	}
+	ctx.PauseIfNotPriority() // Check after TownServicesRoute
 	if ctx.CharacterCfg.CubeRecipes.PrioritizeRunewords {
-		MakeRunewords()
+		RunAction("MakeRunewords", func() error { return MakeRunewords() })
 		// Do not reroll runewords while running the leveling sequences.
 		// Leveling characters rely on simpler runeword behavior and base
 		// selection, and rerolling could consume resources unexpectedly.
 		if !isLevelingChar {
 			RerollRunewords()
 		}
-		CubeRecipes()
+		RunAction("CubeRecipes", func() error { return CubeRecipes() })
 		ctx.PauseIfNotPriority() // Check after CubeRecipes
 	} else {
-		CubeRecipes()
+		RunAction("CubeRecipes", func() error { return CubeRecipes() })
 		ctx.PauseIfNotPriority() // Check after CubeRecipes
-		MakeRunewords()
+		RunAction("MakeRunewords", func() error { return MakeRunewords() })
 
 		// Do not reroll runewords while running the leveling sequences.
 		// Leveling characters rely on simpler runeword behavior and base
@@ -250,14 +295,19 @@ func InRunReturnTownRoutine() error {
 
 	// Ensure any newly created or rerolled runewords/bases are stashed
 	// before leaving town.
-	Stash(false)
+	RunAction("Stash", func() error { return Stash(false) })
 	ctx.PauseIfNotPriority() // Check after post-reroll Stash
 
-	if ctx.CharacterCfg.Game.Leveling.AutoEquip && isLevelingChar {
+	if AutoEquipEnabled() {
 		AutoEquip()
 		ctx.PauseIfNotPriority() // Check after AutoEquip
 	}
 
+	if err := SwitchBuildIfNeeded(); errors.Is(err, ErrBuildSwitched) {
+		return err
+	}
+	ctx.PauseIfNotPriority() // Check after SwitchBuildIfNeeded
+
 	if ctx.CharacterCfg.Game.Leveling.EnsurePointsAllocation && isLevelingChar {
 		EnsureStatPoints()
 		ctx.PauseIfNotPriority() // Check after EnsureStatPoints
@@ -283,16 +333,13 @@ func InRunReturnTownRoutine() error {
 		ctx.PauseIfNotPriority() // Check after EnsureSkillBindings
 	}
 
-	HealAtNPC()
-	ctx.PauseIfNotPriority() // Check after HealAtNPC
-	ReviveMerc()
-	ctx.PauseIfNotPriority() // Check after ReviveMerc
+	event.Send(event.TownVisited(event.Text(ctx.Name, ""), ctx.Data.PlayerUnit.Area))
+	runTownRoutineHooks()
+
 	HireMerc()
 	ctx.PauseIfNotPriority() // Check after HireMerc
-	if err := RepairTownRoutine(); err != nil {
-		return err
-	}
-	ctx.PauseIfNotPriority() // Check after RepairTownRoutine
+	ReportGold()
+	SnapshotEquipment()
 
 	if ctx.CharacterCfg.Companion.Leader {
 		UsePortalInTown()
@@ -0,0 +1,226 @@
+package action
+
+import (
+	"strings"
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/npc"
+	"github.com/hectorgimenez/d2go/pkg/data/object"
+	"github.com/hectorgimenez/koolo/internal/context"
+	"github.com/hectorgimenez/koolo/internal/pather"
+	"github.com/hectorgimenez/koolo/internal/town"
+)
+
+// townService is an NPC or stash interaction of a town visit.
+type townService struct {
+	name string
+	// position is where the service is done, the NPC position or the stash one
+	position func() (data.Position, bool)
+	needed   func() bool
+	run      func() error
+}
+
+// TownServicesRoute visits the NPCs and the stash for healing, merc revive, repair, stashing, vendoring and gambling.
+// Only the services needed this visit are planned, and they are ordered by walking distance from the character so
+// each NPC is visited once, on the way.
+func TownServicesRoute() error {
+	ctx := context.Get()
+	ctx.SetLastAction("TownServicesRoute")
+
+	if !ctx.Data.PlayerUnit.Area.IsTown() {
+		return nil
+	}
+
+	start := time.Now()
+	tw := town.GetTownByArea(ctx.Data.PlayerUnit.Area)
+	npcPosition := func(id npc.ID) func() (data.Position, bool) {
+		return func() (data.Position, bool) { return getNPCPosition(id, ctx.Data) }
+	}
+	services := []townService{
+		{
+			name:     "heal",
+			position: npcPosition(tw.HealNPC()),
+			needed: func() bool {
+				healAt := townHealerAt()
+				return ctx.Data.PlayerUnit.HPPercent() < healAt || ctx.Data.PlayerUnit.MPPercent() < healAt || ctx.Data.PlayerUnit.HasDebuff()
+			},
+			run: HealAtNPC,
+		},
+		{
+			name:     "reviveMerc",
+			position: npcPosition(tw.MercContractorNPC()),
+			needed: func() bool {
+				return ctx.CharacterCfg.Character.UseMerc && ctx.Data.MercHPPercent() <= 0 && NeedsTPsToContinue(ctx.Context)
+			},
+			run: func() error {
+				ReviveMerc()
+				return nil
+			},
+		},
+		{
+			name:     "repair",
+			position: npcPosition(tw.RepairNPC()),
+			needed: func() bool {
+				force, _ := shouldForceRepairAllForJavazonDkQuantity(ctx)
+				return force || RepairRequired()
+			},
+			run: RepairTownRoutine,
+		},
+		{
+			name: "stash",
+			position: func() (data.Position, bool) {
+				bank, found := ctx.Data.Objects.FindOne(object.Bank)
+				return bank.Position, found
+			},
+			needed: func() bool { return isStashingRequired(false) },
+			run:    func() error { return Stash(false) },
+		},
+		{
+			name:     "vendor",
+			position: npcPosition(tw.RefillNPC()),
+			needed:   shouldVisitVendor,
+			run: func() error {
+				return VendorRefill(VendorRefillOpts{SellJunk: true, BuyConsumables: true})
+			},
+		},
+		{
+			name:     "gamble",
+			position: npcPosition(tw.GamblingNPC()),
+			needed: func() bool {
+				return ctx.CharacterCfg.Gambling.Enabled && ctx.Data.PlayerUnit.TotalPlayerGold() >= ctx.CharacterCfg.Gambling.StartGold
+			},
+			run: Gamble,
+		},
+	}
+
+	var planned []townService
+	for _, s := range services {
+		if s.needed() {
+			planned = append(planned, s)
+		}
+	}
+
+	shuffled := ctx.Humanizer.ShuffleTownRoute()
+	if shuffled {
+		ctx.Humanizer.Shuffle(len(planned), func(i, j int) { planned[i], planned[j] = planned[j], planned[i] })
+	}
+	route, distance := planTownRoute(ctx, planned, shuffled)
+	if len(route) > 0 {
+		names := make([]string, 0, len(route))
+		for _, s := range route {
+			names = append(names, s.name)
+		}
+		ctx.Logger.Debug("Town route planned", "route", strings.Join(names, " -> "), "distance", distance)
+	}
+
+	repaired := false
+	for _, s := range route {
+		// A previous stop can cover the next one, like healing when talking to the same NPC
+		ctx.RefreshGameData()
+		if !s.needed() {
+			continue
+		}
+		if err := s.run(); err != nil {
+			return err
+		}
+		repaired = repaired || s.name == "repair"
+		ctx.PauseIfNotPriority()
+	}
+
+	// The items bought or gambled after the stash stop are stashed on the way out
+	if err := Stash(false); err != nil {
+		return err
+	}
+
+	// Javelin replenish and durability warnings don't need the NPC, they run on every visit
+	if !repaired {
+		if err := RepairTownRoutine(); err != nil {
+			return err
+		}
+	}
+
+	if len(route) > 0 {
+		ctx.Logger.Debug("Town services done", "stops", len(route), "duration", time.Since(start))
+	}
+
+	return nil
+}
+
+// planTownRoute returns the services in the order walking the shortest distance, services sharing an NPC end up
+// next to each other. There are only a few of them, so every order is tried. keepOrder only measures the order given,
+// for the shuffled routes.
+func planTownRoute(ctx *context.Status, services []townService, keepOrder bool) ([]townService, int) {
+	if len(services) < 2 {
+		return services, 0
+	}
+
+	positions := make(map[string]data.Position)
+	for _, s := range services {
+		if pos, found := s.position(); found {
+			positions[s.name] = pos
+		}
+	}
+
+	distances := make(map[[2]data.Position]int)
+	walkDistance := func(from, to data.Position) int {
+		if from == to {
+			return 0
+		}
+		key := [2]data.Position{from, to}
+		if d, found := distances[key]; found {
+			return d
+		}
+		_, d, found := ctx.PathFinder.GetPathFrom(from, to)
+		if !found {
+			d = pather.DistanceFromPoint(from, to)
+		}
+		distances[key] = d
+		return d
+	}
+
+	routeDistance := func(order []townService) int {
+		total := 0
+		from := ctx.Data.PlayerUnit.Position
+		for _, s := range order {
+			// Unknown positions are left for the end, the NPC is searched for when it's interacted with
+			pos, found := positions[s.name]
+			if !found {
+				total += 1000
+				continue
+			}
+			total += walkDistance(from, pos)
+			from = pos
+		}
+		return total
+	}
+
+	if keepOrder {
+		return services, routeDistance(services)
+	}
+
+	best := services
+	bestDistance := -1
+	permuteTownServices(services, 0, func(order []townService) {
+		total := routeDistance(order)
+		if bestDistance < 0 || total < bestDistance {
+			bestDistance = total
+			best = append([]townService(nil), order...)
+		}
+	})
+
+	return best, bestDistance
+}
+
+func permuteTownServices(services []townService, k int, visit func([]townService)) {
+	if k == len(services) {
+		visit(services)
+		return
+	}
+
+	for i := k; i < len(services); i++ {
+		services[k], services[i] = services[i], services[k]
+		permuteTownServices(services, k+1, visit)
+		services[k], services[i] = services[i], services[k]
+	}
+}
@@ -4,12 +4,14 @@ package action
 import (
 	"errors"
 	"fmt"
-	"time"
 
 	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/item"
 	"github.com/hectorgimenez/d2go/pkg/data/object"
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
 	"github.com/hectorgimenez/koolo/internal/action/step"
 	"github.com/hectorgimenez/koolo/internal/context"
+	"github.com/hectorgimenez/koolo/internal/game"
 	"github.com/hectorgimenez/koolo/internal/health"
 	"github.com/hectorgimenez/koolo/internal/town"
 	"github.com/hectorgimenez/koolo/internal/utils"
@@ -41,9 +43,33 @@ func checkPlayerDeathForTP(ctx *context.Status) error {
 
 func ReturnTown() error {
 	ctx := context.Get()
+	defer ctx.Profiler.Enter(context.PhaseTown)()
 	ctx.SetLastAction("ReturnTown")
 	ctx.PauseIfNotPriority()
 
+	err := returnTown(ctx)
+	// Without scrolls left there's no other way back, the game is left while there's still a chance
+	if err != nil && ctx.CharacterCfg.Inventory.ChickenOnNoTPs && !ctx.Data.PlayerUnit.Area.IsTown() && tpTomeEmpty(ctx) {
+		return fmt.Errorf("%w: No town portal scrolls left to return to town: %v", health.ErrChicken, err)
+	}
+
+	return err
+}
+
+// tpTomeEmpty tells if the TP tome is in the inventory without scrolls left, characters without any tome yet are left
+// alone.
+func tpTomeEmpty(ctx *context.Status) bool {
+	tome, found := ctx.Data.Inventory.Find(item.TomeOfTownPortal, item.LocationInventory)
+	if !found {
+		return false
+	}
+
+	qty, _ := tome.FindStat(stat.Quantity, 0)
+
+	return qty.Value <= 0
+}
+
+func returnTown(ctx *context.Status) error {
 	// Proactive death check at the start of the action
 	if err := checkPlayerDeathForTP(ctx); err != nil {
 		return err
@@ -115,34 +141,33 @@ func ReturnTown() error {
 		}
 	}
 
-	// Wait for area transition and data sync
-	utils.PingSleep(utils.Critical, 1000) // Critical operation: Wait for portal transition
-	ctx.RefreshGameData()
-
-	// Wait for town area data to be fully loaded
-	deadline := time.Now().Add(2 * time.Second)
-	for time.Now().Before(deadline) {
-		// Check for death during the wait for town data
-		if errCheck := checkPlayerDeathForTP(ctx); errCheck != nil {
-			return errCheck
+	// Wait for the area transition and the town area data to be fully loaded, checking for death on the way
+	var deathErr error
+	inTown := step.WaitFor(func(d *game.Data) bool {
+		if deathErr = checkPlayerDeathForTP(ctx); deathErr != nil {
+			return true
 		}
-
-		if ctx.Data.PlayerUnit.Area.IsTown() {
-			// Verify area data exists and is loaded
-			if townData, ok := ctx.Data.Areas[ctx.Data.PlayerUnit.Area]; ok {
-				if townData.IsInside(ctx.Data.PlayerUnit.Position) {
-					return nil
-				}
-			}
+		if !d.PlayerUnit.Area.IsTown() {
+			return false
 		}
-		utils.PingSleep(utils.Light, 100) // Light operation: Polling for town area data
-		ctx.RefreshGameData()
+		townData, ok := d.Areas[d.PlayerUnit.Area]
+		return ok && townData.IsInside(d.PlayerUnit.Position)
+	}, 3000)
+	if deathErr != nil {
+		return deathErr
+	}
+	if inTown {
+		return nil
 	}
 
 	return fmt.Errorf("failed to verify town area data after portal transition")
 }
 
 func UsePortalInTown() error {
+	return RunAction("UsePortalInTown", func() error { return usePortalInTown() })
+}
+
+func usePortalInTown() error {
 	ctx := context.Get()
 	ctx.SetLastAction("UsePortalInTown")
 
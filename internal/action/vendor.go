@@ -75,18 +75,10 @@ func VendorRefill(opts VendorRefillOpts) (err error) {
 		}
 	}
 
-	err = InteractNPC(vendorNPC)
-	if err != nil {
+	if err = OpenNPCMenu(vendorNPC, tradeMenuKeys(vendorNPC)...); err != nil {
 		return err
 	}
 
-	// Jamella trade button is the first one
-	if vendorNPC == npc.Jamella {
-		ctx.HID.KeySequence(win.VK_HOME, win.VK_RETURN)
-	} else {
-		ctx.HID.KeySequence(win.VK_HOME, win.VK_DOWN, win.VK_RETURN)
-	}
-
 	if opts.SellJunk {
 		if len(opts.LockConfig) > 0 {
 			town.SellJunk(opts.LockConfig)
@@ -109,18 +101,10 @@ func BuyAtVendor(vendor npc.ID, items ...VendorItemRequest) error {
 	ctx := botCtx.Get()
 	ctx.SetLastAction("BuyAtVendor")
 
-	err := InteractNPC(vendor)
-	if err != nil {
+	if err := OpenNPCMenu(vendor, win.VK_HOME, win.VK_DOWN, win.VK_RETURN); err != nil {
 		return err
 	}
 
-	// Jamella trade button is the first one
-	if vendor == npc.Jamella {
-		ctx.HID.KeySequence(win.VK_HOME, win.VK_DOWN, win.VK_RETURN)
-	} else {
-		ctx.HID.KeySequence(win.VK_HOME, win.VK_DOWN, win.VK_RETURN)
-	}
-
 	for _, i := range items {
 		SwitchVendorTab(i.Tab)
 		itm, found := ctx.Data.Inventory.Find(i.Item, item.LocationVendor)
@@ -152,7 +136,7 @@ func shouldVisitVendor() bool {
 		return false
 	}
 
-	if ctx.BeltManager.ShouldBuyPotions() || town.ShouldBuyTPs() || town.ShouldBuyIDs() {
+	if ctx.BeltManager.ShouldBuyPotions() || town.ShouldBuyTPs() || town.ShouldBuyIDs() || needsKeysForChests() {
 		return true
 	}
 
@@ -0,0 +1,131 @@
+package action
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/item"
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+	"github.com/hectorgimenez/koolo/internal/context"
+	"github.com/hectorgimenez/koolo/internal/event"
+)
+
+// wantedBaseRecipes returns the runewords the character makes when the base collection is enabled.
+func wantedBaseRecipes(ctx *context.Status) []Runeword {
+	cfg := ctx.CharacterCfg.Game.RunewordMaker
+	if !cfg.Enabled || !cfg.CollectBases || len(cfg.EnabledRecipes) == 0 {
+		return nil
+	}
+
+	recipes := make([]Runeword, 0, len(cfg.EnabledRecipes))
+	for _, recipe := range Runewords {
+		for _, name := range cfg.EnabledRecipes {
+			if string(recipe.Name) == name {
+				recipes = append(recipes, recipe)
+				break
+			}
+		}
+	}
+
+	return recipes
+}
+
+// hasWantedSockets checks the socket count, unsocketed bases able to get enough sockets count when socketable bases
+// are collected too (Larzuk or the cube can socket them).
+func hasWantedSockets(ctx *context.Status, itm data.Item, recipe Runeword) bool {
+	if sockets, found := itm.FindStat(stat.NumSockets, 0); found {
+		return sockets.Value == len(recipe.Runes)
+	}
+
+	return ctx.CharacterCfg.Game.RunewordMaker.CollectSocketableBases && itm.Desc().MaxSockets >= len(recipe.Runes)
+}
+
+// ownsWantedBase returns true when one of the items is already a base for the recipe.
+func ownsWantedBase(ctx *context.Status, items []data.Item, recipe Runeword, filter runewordBaseFilter) bool {
+	for _, itm := range items {
+		if !itm.IsRuneword && filter.matches(ctx, itm, recipe) && hasWantedSockets(ctx, itm, recipe) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsWantedRunewordBase returns true for a base needed by one of the enabled runewords while no base for it is owned
+// yet, the eth/quality/tier overrides of the runeword apply. Used by the pickup and stash logic.
+func IsWantedRunewordBase(itm data.Item) bool {
+	ctx := context.Get()
+
+	recipes := wantedBaseRecipes(ctx)
+	if len(recipes) == 0 || itm.IsRuneword {
+		return false
+	}
+
+	owned := make([]data.Item, 0)
+	for _, i := range ctx.Data.Inventory.ByLocation(item.LocationStash, item.LocationSharedStash, item.LocationInventory) {
+		if i.UnitID != itm.UnitID {
+			owned = append(owned, i)
+		}
+	}
+
+	for _, recipe := range recipes {
+		filter := runewordBaseFilterFor(ctx, recipe)
+		if !filter.matches(ctx, itm, recipe) || !hasWantedSockets(ctx, itm, recipe) {
+			continue
+		}
+		if !ownsWantedBase(ctx, owned, recipe, filter) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ReportWantedBases sends the bases still missing for the enabled runewords to the stats.
+func ReportWantedBases() {
+	ctx := context.Get()
+
+	recipes := wantedBaseRecipes(ctx)
+	if len(recipes) == 0 {
+		return
+	}
+
+	owned := ctx.Data.Inventory.ByLocation(item.LocationStash, item.LocationSharedStash, item.LocationInventory)
+	missing := make([]string, 0)
+	for _, recipe := range recipes {
+		filter := runewordBaseFilterFor(ctx, recipe)
+		if !ownsWantedBase(ctx, owned, recipe, filter) {
+			missing = append(missing, describeWantedBase(recipe, filter))
+		}
+	}
+
+	event.Send(event.WantedBasesUpdated(event.Text(ctx.Name, ""), missing))
+}
+
+// describeWantedBase renders the base as "Spirit: 4 sockets sword/shield, noneth superior".
+func describeWantedBase(recipe Runeword, filter runewordBaseFilter) string {
+	types := make([]string, 0, len(recipe.BaseItemTypes))
+	for _, t := range recipe.BaseItemTypes {
+		types = append(types, PrettyRunewordBaseTypeLabel(t))
+	}
+	if filter.baseType != "" {
+		types = strings.Split(filter.baseType, ",")
+	}
+	if filter.baseName != "" {
+		types = strings.Split(filter.baseName, ",")
+	}
+
+	desc := fmt.Sprintf("%s: %d sockets %s", recipe.Name, len(recipe.Runes), strings.Join(types, "/"))
+	modifiers := make([]string, 0, 3)
+	for _, m := range []string{filter.ethMode, filter.qualityMode, filter.baseTier} {
+		if m != "" {
+			modifiers = append(modifiers, m)
+		}
+	}
+	if len(modifiers) > 0 {
+		desc += ", " + strings.Join(modifiers, " ")
+	}
+
+	return desc
+}
@@ -13,7 +13,12 @@ import (
 )
 
 func WayPoint(dest area.ID) error {
+	return RunAction("WayPoint", func() error { return wayPoint(dest) })
+}
+
+func wayPoint(dest area.ID) error {
 	ctx := context.Get()
+	defer ctx.Profiler.Enter(context.PhaseTravel)()
 	ctx.SetLastAction("WayPoint")
 
 	if !ctx.Data.PlayerUnit.Area.IsTown() {
@@ -69,13 +74,13 @@ func WayPoint(dest area.ID) error {
 		return fmt.Errorf("failed to reach destination area %s using waypoint", area.Areas[dest].Name)
 	}
 
-	// apply buffs after exiting a waypoint if configured
-	if ctx.CharacterCfg.Character.BuffAfterWP && !dest.IsTown() {
+	// apply buffs after exiting a waypoint if configured, or if the run start buff is still pending
+	if (ctx.CharacterCfg.Character.BuffAfterWP || ctx.CurrentGame.PendingRunBuff) && !dest.IsTown() {
 		utils.PingSleep(utils.Light, 250)
 		Buff()
 	}
 
-	return nil
+	return AssessDanger()
 }
 
 func FieldWayPoint(dest area.ID) error {
@@ -129,13 +134,13 @@ func FieldWayPoint(dest area.ID) error {
 		return fmt.Errorf("failed to reach destination area %s using waypoint", area.Areas[dest].Name)
 	}
 
-	// apply buffs after exiting a waypoint if configured
-	if ctx.CharacterCfg.Character.BuffAfterWP && !dest.IsTown() {
+	// apply buffs after exiting a waypoint if configured, or if the run start buff is still pending
+	if (ctx.CharacterCfg.Character.BuffAfterWP || ctx.CurrentGame.PendingRunBuff) && !dest.IsTown() {
 		utils.PingSleep(utils.Light, 250)
 		Buff()
 	}
 
-	return nil
+	return AssessDanger()
 }
 
 func useWP(dest area.ID) error {
@@ -73,6 +73,8 @@ type ArmoryCharacter struct {
 	Cube          []ArmoryItem `json:"cube"`
 	Belt          []ArmoryItem `json:"belt"`
 	Mercenary     []ArmoryItem `json:"mercenary"`
+	// Pages past the shared stash tabs of an infinite stash (private flavor), keyed by stash tab starting at 5
+	ExtraStashPages map[int][]ArmoryItem `json:"extraStashPages,omitempty"`
 }
 
 // classToString converts a data.Class to its string representation
@@ -319,6 +321,13 @@ func dumpArmoryData(characterName string, gameData *game.Data, gameName string)
 			case 3:
 				armory.SharedStash3 = append(armory.SharedStash3, armoryItem)
 			default:
+				if itm.Location.Page > 3 {
+					if armory.ExtraStashPages == nil {
+						armory.ExtraStashPages = make(map[int][]ArmoryItem)
+					}
+					armory.ExtraStashPages[itm.Location.Page+1] = append(armory.ExtraStashPages[itm.Location.Page+1], armoryItem)
+					break
+				}
 				armory.SharedStash1 = append(armory.SharedStash1, armoryItem)
 			}
 		case item.LocationCube:
@@ -0,0 +1,134 @@
+package bot
+
+import (
+	"github.com/hectorgimenez/d2go/pkg/data/difficulty"
+	"github.com/hectorgimenez/d2go/pkg/data/quest"
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/event"
+)
+
+const defaultAutoDifficultySampleGames = 10
+
+// difficultySample is the outcome of a game, used to decide if the character fits the current difficulty.
+type difficultySample struct {
+	died           bool
+	avgRunDuration float64 // Seconds, only successful runs are counted
+}
+
+var (
+	lowerDifficulty = map[difficulty.Difficulty]difficulty.Difficulty{
+		difficulty.Hell:      difficulty.Nightmare,
+		difficulty.Nightmare: difficulty.Normal,
+	}
+	higherDifficulty = map[difficulty.Difficulty]difficulty.Difficulty{
+		difficulty.Normal:    difficulty.Nightmare,
+		difficulty.Nightmare: difficulty.Hell,
+	}
+)
+
+// lastGameSample builds the sample of the game that just finished from the stats.
+func (s *SinglePlayerSupervisor) lastGameSample(died bool) difficultySample {
+	sample := difficultySample{died: died}
+
+	games := s.statsHandler.Stats().Games
+	if len(games) == 0 {
+		return sample
+	}
+
+	total, count := 0.0, 0
+	for _, r := range games[len(games)-1].Runs {
+		if r.Reason != event.FinishedOK || r.FinishedAt.IsZero() {
+			continue
+		}
+		total += r.FinishedAt.Sub(r.StartedAt).Seconds()
+		count++
+	}
+	if count > 0 {
+		sample.avgRunDuration = total / float64(count)
+	}
+
+	return sample
+}
+
+// adjustDifficulty samples the last game and moves the character to a lower difficulty when it dies too often or
+// kills too slow, or to a higher one when games are clean and fast. Leveling runs handle the difficulty themselves.
+// The difficulty is picked when the game is created, so the choice applies to every run of the next games. It's kept
+// in memory only, the config file keeps the configured difficulty.
+func (s *SinglePlayerSupervisor) adjustDifficulty(died bool) {
+	cfg := s.bot.ctx.CharacterCfg
+	autoCfg := cfg.Game.AutoDifficulty
+	if !autoCfg.Enabled {
+		return
+	}
+	for _, r := range cfg.Game.Runs {
+		if r == config.LevelingRun || r == config.LevelingSequenceRun {
+			return
+		}
+	}
+
+	s.difficultySamples = append(s.difficultySamples, s.lastGameSample(died))
+	sampleGames := autoCfg.SampleGames
+	if sampleGames <= 0 {
+		sampleGames = defaultAutoDifficultySampleGames
+	}
+	if len(s.difficultySamples) < sampleGames {
+		return
+	}
+
+	deaths := 0
+	totalDuration, durationSamples := 0.0, 0
+	for _, sample := range s.difficultySamples {
+		if sample.died {
+			deaths++
+		}
+		if sample.avgRunDuration > 0 {
+			totalDuration += sample.avgRunDuration
+			durationSamples++
+		}
+	}
+	deathPercent := deaths * 100 / len(s.difficultySamples)
+	avgDuration := 0.0
+	if durationSamples > 0 {
+		avgDuration = totalDuration / float64(durationSamples)
+	}
+	s.difficultySamples = s.difficultySamples[:0]
+
+	current := cfg.Game.Difficulty
+	next := current
+	tooSlow := autoCfg.MaxRunSeconds > 0 && avgDuration > float64(autoCfg.MaxRunSeconds)
+	fastEnough := autoCfg.MaxRunSeconds == 0 || avgDuration <= float64(autoCfg.MaxRunSeconds)/2
+
+	switch {
+	case deathPercent > autoCfg.MaxDeathPercent || tooSlow:
+		if lower, found := lowerDifficulty[current]; found {
+			next = lower
+		}
+	case deaths == 0 && fastEnough:
+		higher, found := higherDifficulty[current]
+		if !found || !s.bot.ctx.Data.Quests[quest.Act5EveOfDestruction].Completed() {
+			break
+		}
+		lvl, _ := s.bot.ctx.Data.PlayerUnit.FindStat(stat.Level, 0)
+		if (higher == difficulty.Nightmare && lvl.Value >= autoCfg.NightmareMinLevel) ||
+			(higher == difficulty.Hell && lvl.Value >= autoCfg.HellMinLevel) {
+			next = higher
+		}
+	}
+
+	if next == current {
+		return
+	}
+
+	s.bot.ctx.Logger.Info("Auto difficulty: changing difficulty",
+		"from", current,
+		"to", next,
+		"deathPercent", deathPercent,
+		"avgRunSeconds", int(avgDuration),
+	)
+	if cfg.Runtime.ConfiguredDifficulty == "" {
+		cfg.Runtime.ConfiguredDifficulty = current
+	}
+	cfg.Game.Difficulty = next
+	cfg.Runtime.AutoDifficulty = next
+}
@@ -14,21 +14,29 @@ import (
 	"github.com/hectorgimenez/d2go/pkg/data/stat"
 	"github.com/hectorgimenez/koolo/internal/action"
 	"github.com/hectorgimenez/koolo/internal/action/step"
+	"github.com/hectorgimenez/koolo/internal/config"
 	botCtx "github.com/hectorgimenez/koolo/internal/context"
 	"github.com/hectorgimenez/koolo/internal/drop"
 	"github.com/hectorgimenez/koolo/internal/event"
 	"github.com/hectorgimenez/koolo/internal/health"
+	"github.com/hectorgimenez/koolo/internal/replay"
 	"github.com/hectorgimenez/koolo/internal/run"
 	"github.com/hectorgimenez/koolo/internal/utils"
 	"golang.org/x/sync/errgroup"
 )
 
+// ErrClientDegraded ends the game in town to restart the client flagged by the client watchdog.
+var ErrClientDegraded = errors.New("client degraded, restart requested")
+
 type Bot struct {
 	ctx                   *botCtx.Context
 	lastActivityTimeMux   sync.Mutex
 	lastActivityTime      time.Time
 	lastKnownPosition     data.Position
 	lastPositionCheckTime time.Time
+	runBudget             *runBudget
+	runPolicy             *runPolicy
+	selfCheck             *selfCheck
 	MuleManager
 }
 
@@ -42,6 +50,7 @@ func (b *Bot) shouldReturnToTown(lvl int, needHealingPotionsRefill, needManaPoti
 		(b.ctx.Data.PlayerUnit.TotalPlayerGold() > 5000 && lvl >= 20) {
 		if (b.ctx.CharacterCfg.BackToTown.NoHpPotions && needHealingPotionsRefill ||
 			b.ctx.CharacterCfg.BackToTown.EquipmentBroken && action.IsEquipmentBroken() ||
+			action.CarryingTooMuchGold() ||
 			b.ctx.CharacterCfg.BackToTown.NoMpPotions && needManaPotionsRefill ||
 			townChicken ||
 			b.ctx.CharacterCfg.BackToTown.MercDied &&
@@ -63,6 +72,9 @@ func NewBot(ctx *botCtx.Context, mm MuleManager) *Bot {
 		lastActivityTime:      time.Now(),      // Initialize
 		lastKnownPosition:     data.Position{}, // Will be updated on first game data refresh
 		lastPositionCheckTime: time.Now(),      // Initialize
+		runBudget:             newRunBudget(),
+		runPolicy:             newRunPolicy(),
+		selfCheck:             newSelfCheck(),
 		MuleManager:           mm,
 	}
 }
@@ -90,7 +102,15 @@ func (b *Bot) Run(ctx context.Context, firstRun bool, runs []run.Run) error {
 	defer cancel()
 	g, ctx := errgroup.WithContext(ctx)
 
-	gameStartedAt := time.Now()
+	startedAt := time.Now()
+	b.ctx.SuspendedFor.Store(0)
+	// The time spent suspended doesn't count, the game start moves forward by it
+	gameStartedAt := func() time.Time {
+		return startedAt.Add(time.Duration(b.ctx.SuspendedFor.Load()))
+	}
+	// The humanization profile varies them every game
+	maxGameLength := b.ctx.Humanizer.GameLength(time.Duration(b.ctx.CharacterCfg.MaxGameLength) * time.Second)
+	pickupRadius := b.ctx.Humanizer.PickupRadius(30)
 	b.ctx.SwitchPriority(botCtx.PriorityNormal) // Restore priority to normal, in case it was stopped in previous game
 	b.ctx.CurrentGame = botCtx.NewGameHelper()  // Reset current game helper structure
 	// Drop: Initialize Drop manager and start watch context
@@ -103,6 +123,11 @@ func (b *Bot) Run(ctx context.Context, firstRun bool, runs []run.Run) error {
 		return err
 	}
 
+	if config.Koolo.Debug.RecordReplays {
+		b.startRecording()
+		defer b.stopRecording()
+	}
+
 	// Let's make sure we have updated game data also fully loaded before performing anything
 	b.ctx.WaitForGameToLoad()
 
@@ -114,11 +139,13 @@ func (b *Bot) Run(ctx context.Context, firstRun bool, runs []run.Run) error {
 	b.ctx.RefreshGameData()
 
 	b.updateActivityAndPosition() // Initial update for activity and position
+	b.selfCheck.startGame()
+	b.ctx.Profiler.Reset()
 
 	// This routine is in charge of refreshing the game data and handling cancellation, will work in parallel with any other execution
 	g.Go(func() error {
 		b.ctx.AttachRoutine(botCtx.PriorityBackground)
-		ticker := time.NewTicker(100 * time.Millisecond)
+		ticker := time.NewTicker(b.ctx.DataTick())
 		for {
 			select {
 			case <-ctx.Done():
@@ -199,12 +226,12 @@ func (b *Bot) Run(ctx context.Context, firstRun bool, runs []run.Run) error {
 				}
 
 				// Check for max game length (this is a separate check from idle)
-				if time.Since(gameStartedAt).Seconds() > float64(b.ctx.CharacterCfg.MaxGameLength) {
-					b.ctx.Logger.Info("Max game length reached, try to exit game", slog.Float64("duration", time.Since(gameStartedAt).Seconds()))
+				if time.Since(gameStartedAt()) > maxGameLength {
+					b.ctx.Logger.Info("Max game length reached, try to exit game", slog.Float64("duration", time.Since(gameStartedAt()).Seconds()))
 					b.Stop() // This will set PriorityStop and detach the context
 					return fmt.Errorf(
 						"max game length reached, try to exit game: %0.2f",
-						time.Since(gameStartedAt).Seconds(),
+						time.Since(gameStartedAt()).Seconds(),
 					)
 				}
 			}
@@ -239,6 +266,10 @@ func (b *Bot) Run(ctx context.Context, firstRun bool, runs []run.Run) error {
 				// Update activity for high-priority actions as they indicate bot is processing.
 				b.updateActivityAndPosition()
 
+				if err := b.checkProgress(); err != nil {
+					return err
+				}
+
 				// Merc check (Fast)
 				if b.ctx.CharacterCfg.BackToTown.MercDied && b.ctx.Data.MercHPPercent() <= 0 && b.ctx.CharacterCfg.Character.UseMerc {
 					time.Sleep(200 * time.Millisecond)
@@ -269,6 +300,9 @@ func (b *Bot) Run(ctx context.Context, firstRun bool, runs []run.Run) error {
 					return nil
 				}
 
+				// Diablo Clone detection (Fast, Read-only), it notifies and lets the dclone run hunt it
+				action.DetectDiabloClone()
+
 				// Check-Then-Lock Pattern
 				// We pre-calculate if we need to switch priority to High.
 				// This prevents locking the main thread (Low Priority Loop) when there is nothing to do.
@@ -276,7 +310,7 @@ func (b *Bot) Run(ctx context.Context, firstRun bool, runs []run.Run) error {
 				shouldPickup := false
 				if b.ctx.CurrentGame.PickupItems {
 					// Peek if there are items without locking
-					if len(action.GetItemsToPickup(30)) > 0 {
+					if len(action.GetItemsToPickup(pickupRadius)) > 0 {
 						shouldPickup = true
 					}
 				}
@@ -335,7 +369,7 @@ func (b *Bot) Run(ctx context.Context, firstRun bool, runs []run.Run) error {
 
 					// Execute Pickup
 					if shouldPickup {
-						action.ItemPickup(30)
+						action.ItemPickup(pickupRadius)
 					}
 
 					// Execute Buff
@@ -373,14 +407,22 @@ func (b *Bot) Run(ctx context.Context, firstRun bool, runs []run.Run) error {
 							reason = "Mercenary is dead"
 						} else if townChicken {
 							reason = "Town chicken"
+						} else if action.CarryingTooMuchGold() {
+							reason = "Carrying too much gold"
 						}
 
 						b.ctx.Logger.Info("Going back to town", "reason", reason)
 
+						if townChicken {
+							action.SnapshotLostLoot()
+						}
 						if err = action.InRunReturnTownRoutine(); err != nil {
 							b.ctx.Logger.Warn("Failed returning town. Returning error to stop game.", "error", err)
 							return err
 						}
+						if err = action.RecoverLostLoot(); err != nil {
+							b.ctx.Logger.Warn("Failed recovering items left on the ground", "error", err)
+						}
 					}
 
 					b.ctx.SwitchPriority(botCtx.PriorityNormal)
@@ -407,11 +449,29 @@ func (b *Bot) Run(ctx context.Context, firstRun bool, runs []run.Run) error {
 			case <-ctx.Done():
 				return nil
 			default:
+				if b.ctx.CharacterCfg.Game.RunTimeBudget &&
+					!b.runBudget.fits(r.Name(), gameStartedAt(), maxGameLength) {
+					b.ctx.Logger.Info("Not enough game time left for the run, skipping it",
+						slog.String("run", r.Name()),
+						slog.Float64("elapsed", time.Since(gameStartedAt()).Seconds()),
+						slog.Float64("expected", b.runBudget.expected(r.Name()).Seconds()),
+					)
+					continue
+				}
+
 				skipTownRoutines := false
 				if skipper, ok := r.(run.TownRoutineSkipper); ok && skipper.SkipTownRoutines() {
 					skipTownRoutines = true
 				}
 
+				if delay := b.ctx.Humanizer.ActionDelay(); delay > 0 {
+					time.Sleep(delay)
+				}
+
+				b.ctx.ApplyPendingPickit()
+				b.ctx.CharacterCfg.ApplyRunPickit(r.Name())
+				b.ctx.CurrentGame.CurrentRun = r.Name()
+				action.SetPlayersCount(r.Name())
 				event.Send(event.RunStarted(event.Text(b.ctx.Name, fmt.Sprintf("Starting run: %s", r.Name())), r.Name()))
 
 				// Update activity here because a new run sequence is starting.
@@ -425,9 +485,17 @@ func (b *Bot) Run(ctx context.Context, firstRun bool, runs []run.Run) error {
 					firstRun = false
 				}
 
+				if b.ctx.ClientDegraded.Load() && b.ctx.Data.PlayerUnit.Area.IsTown() {
+					return ErrClientDegraded
+				}
+
 				// Update activity before the main run logic is executed.
 				b.updateActivityAndPosition()
-				err = r.Run(nil)
+				runStartedAt := time.Now()
+				err = action.TrackExperience(r.Name(), func() error { return r.Run(nil) })
+				if err == nil {
+					b.runBudget.record(r.Name(), time.Since(runStartedAt))
+				}
 
 				// Drop: Handle Drop interrupt from step functions
 				if errors.Is(err, drop.ErrInterrupt) {
@@ -453,6 +521,8 @@ func (b *Bot) Run(ctx context.Context, firstRun bool, runs []run.Run) error {
 						runFinishReason = event.FinishedError
 					case errors.Is(err, action.ErrFailedToEquip): // This is the new line
 						runFinishReason = event.FinishedError
+					case errors.Is(err, action.ErrDangerousRun), errors.Is(err, action.ErrDangerousGame):
+						runFinishReason = event.FinishedSkipped
 					default:
 						runFinishReason = event.FinishedError
 					}
@@ -461,6 +531,30 @@ func (b *Bot) Run(ctx context.Context, firstRun bool, runs []run.Run) error {
 				}
 
 				event.Send(event.RunFinished(event.Text(b.ctx.Name, fmt.Sprintf("Finished run: %s", r.Name())), r.Name(), runFinishReason))
+				b.sendRunProfile(r.Name())
+
+				// Deaths and chickens aren't the run's fault, only errors count towards the blacklist
+				if runFinishReason == event.FinishedOK {
+					b.selfCheck.runFinished()
+				}
+
+				if runFinishReason == event.FinishedOK || runFinishReason == event.FinishedError {
+					failures := b.ctx.CharacterCfg.Game.RunFailures
+					if b.runPolicy.record(r.Name(), runFinishReason == event.FinishedError, failures.MaxFailures, failures.BlacklistGames) {
+						b.ctx.Logger.Warn("Run failed too many times in a row, skipping it for the next games",
+							slog.String("run", r.Name()),
+							slog.Int("failures", failures.MaxFailures),
+							slog.Int("games", failures.BlacklistGames),
+						)
+					}
+				}
+
+				// Dangerous roll, the run is left for the next one from town
+				if errors.Is(err, action.ErrDangerousRun) {
+					if err = action.ReturnTown(); err != nil {
+						return err
+					}
+				}
 
 				if err != nil {
 					return err
@@ -492,3 +586,44 @@ type MuleManager interface {
 type StatsReporter interface {
 	ReportStats()
 }
+
+// startRecording records the game data and the inputs of the game, their pathing is checked offline with cmd/replay.
+func (b *Bot) startRecording() {
+	path := replay.RecordingPath(b.ctx.Name)
+	recorder, err := replay.NewRecorder(path, replay.Header{
+		Supervisor:    b.ctx.Name,
+		GameAreaSizeX: b.ctx.GameReader.GameAreaSizeX,
+		GameAreaSizeY: b.ctx.GameReader.GameAreaSizeY,
+	})
+	if err != nil {
+		b.ctx.Logger.Warn("Failed to start the game recording", "error", err)
+		return
+	}
+
+	b.ctx.Recorder.Store(recorder)
+	b.ctx.HID.SetObserver(recorder.RecordHID)
+	b.ctx.Logger.Debug("Recording the game", "path", path)
+}
+
+func (b *Bot) stopRecording() {
+	recorder := b.ctx.Recorder.Swap(nil)
+	if recorder == nil {
+		return
+	}
+
+	b.ctx.HID.SetObserver(nil)
+	if err := recorder.Close(); err != nil {
+		b.ctx.Logger.Warn("Game recording failed", "error", err)
+	}
+}
+
+// sendRunProfile sends the time spent by phase since the previous run finished, so the town routines between the
+// runs and the game creation are counted in the run coming after them.
+func (b *Bot) sendRunProfile(runName string) {
+	totals := b.ctx.Profiler.Flush()
+	phases := make(map[string]time.Duration, len(totals))
+	for phase, d := range totals {
+		phases[string(phase)] = d
+	}
+	event.Send(event.RunProfiled(event.Text(b.ctx.Name, ""), runName, phases))
+}
@@ -0,0 +1,64 @@
+package bot
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/context"
+)
+
+const configWatchInterval = 3 * time.Second
+
+// ConfigWatcher polls the character config file while the supervisor runs. Once it changes the config is reloaded
+// and handed to the bot, which applies it between two games. The saves of the bot itself are skipped.
+type ConfigWatcher struct {
+	name     string
+	ctx      *context.Context
+	logger   *slog.Logger
+	stopChan chan struct{}
+	modTime  time.Time
+}
+
+func NewConfigWatcher(name string, ctx *context.Context, logger *slog.Logger) *ConfigWatcher {
+	return &ConfigWatcher{
+		name:     name,
+		ctx:      ctx,
+		logger:   logger,
+		stopChan: make(chan struct{}),
+		modTime:  config.CharacterConfigModTime(name),
+	}
+}
+
+func (cw *ConfigWatcher) Start() {
+	ticker := time.NewTicker(configWatchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-cw.stopChan:
+			return
+		case <-ticker.C:
+			modTime := config.CharacterConfigModTime(cw.name)
+			if modTime.IsZero() || modTime.Equal(cw.modTime) {
+				continue
+			}
+			cw.modTime = modTime
+			if config.IsOwnSave(cw.name, modTime) {
+				continue
+			}
+
+			cfg, err := config.ReloadCharacter(cw.name)
+			if err != nil {
+				cw.logger.Warn("Character config changed but it can't be loaded, keeping the current one", slog.Any("error", err))
+				continue
+			}
+			cw.ctx.PendingConfig.Store(cfg)
+			cw.logger.Debug("Character config changed, it will be applied at the next safe point")
+		}
+	}
+}
+
+func (cw *ConfigWatcher) Stop() {
+	close(cw.stopChan)
+}
@@ -0,0 +1,96 @@
+package bot
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	// Restarts are delayed once a supervisor crashes this many times in the window, one more step for every crash
+	crashBackoffThreshold = 3
+	crashBackoffWindow    = 15 * time.Minute
+	crashBackoffStep      = 2 * time.Minute
+	crashBackoffMax       = 10 * time.Minute
+)
+
+// crashHistory keeps the client crashes by supervisor, it outlives the supervisors restarted after the crash.
+var crashHistory = struct {
+	mu      sync.Mutex
+	total   map[string]int
+	recent  map[string][]time.Time
+	resumes map[string][]string
+}{
+	total:   make(map[string]int),
+	recent:  make(map[string][]time.Time),
+	resumes: make(map[string][]string),
+}
+
+// recordCrash notes the crash along with the runs already done in the game that crashed, skipped in the first game
+// after the restart. It returns the delay to apply before restarting the client.
+func recordCrash(supervisorName string, stats Stats) time.Duration {
+	crashHistory.mu.Lock()
+	defer crashHistory.mu.Unlock()
+
+	now := time.Now()
+	recent := slices.DeleteFunc(crashHistory.recent[supervisorName], func(t time.Time) bool {
+		return now.Sub(t) > crashBackoffWindow
+	})
+	recent = append(recent, now)
+	crashHistory.recent[supervisorName] = recent
+	crashHistory.total[supervisorName]++
+	crashHistory.resumes[supervisorName] = finishedRunsOfLastGame(stats)
+
+	if len(recent) < crashBackoffThreshold {
+		return 0
+	}
+
+	return min(time.Duration(len(recent)-crashBackoffThreshold+1)*crashBackoffStep, crashBackoffMax)
+}
+
+// finishedRunsOfLastGame returns the runs finished in the last game when the game didn't finish.
+func finishedRunsOfLastGame(stats Stats) []string {
+	if len(stats.Games) == 0 {
+		return nil
+	}
+
+	lastGame := stats.Games[len(stats.Games)-1]
+	if !lastGame.FinishedAt.IsZero() {
+		return nil
+	}
+
+	var runs []string
+	for _, r := range lastGame.Runs {
+		if !r.FinishedAt.IsZero() {
+			runs = append(runs, r.Name)
+		}
+	}
+
+	return runs
+}
+
+// takeResumeRuns returns the runs to skip after a crash, only once.
+func takeResumeRuns(supervisorName string) []string {
+	crashHistory.mu.Lock()
+	defer crashHistory.mu.Unlock()
+
+	runs := crashHistory.resumes[supervisorName]
+	delete(crashHistory.resumes, supervisorName)
+
+	return runs
+}
+
+// fillCrashStats adds the crash counters of the supervisor to its stats.
+func fillCrashStats(supervisorName string, stats *Stats) {
+	crashHistory.mu.Lock()
+	defer crashHistory.mu.Unlock()
+
+	stats.Crashes = crashHistory.total[supervisorName]
+	stats.RecentCrashes = 0
+	for _, t := range crashHistory.recent[supervisorName] {
+		if time.Since(t) <= crashBackoffWindow {
+			stats.RecentCrashes++
+		}
+		stats.LastCrashAt = t
+	}
+}
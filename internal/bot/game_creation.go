@@ -0,0 +1,69 @@
+package bot
+
+import (
+	"errors"
+	"log/slog"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/hectorgimenez/koolo/internal/config"
+)
+
+const gameCreationPollInterval = 500 * time.Millisecond
+
+// errWaitingForGameCreationSlot is returned by the menu flow while other characters are creating their games. The
+// supervisor loop retries, so the wait stops with the supervisor and doesn't count as a frozen menu flow.
+var errWaitingForGameCreationSlot = errors.New("waiting for a game creation slot")
+
+// gameCreationGate is shared by all the supervisors, it spaces their game creations and joins and limits how many
+// characters are creating a game at the same time.
+var gameCreationGate = struct {
+	mu       sync.Mutex
+	creating map[string]time.Time
+	waiting  map[string]time.Time
+	next     time.Time
+}{creating: make(map[string]time.Time), waiting: make(map[string]time.Time)}
+
+// tryGameCreationSlot takes a slot when the character is allowed to create or join a game now, the returned func
+// releases it.
+func tryGameCreationSlot(name string, logger *slog.Logger) (func(), bool) {
+	cfg := config.Koolo.GameCreation
+	if cfg.MaxConcurrent <= 0 && cfg.StaggerSeconds <= 0 && cfg.JitterSeconds <= 0 {
+		return func() {}, true
+	}
+
+	gameCreationGate.mu.Lock()
+	defer gameCreationGate.mu.Unlock()
+
+	now := time.Now()
+	if (cfg.MaxConcurrent > 0 && len(gameCreationGate.creating) >= cfg.MaxConcurrent) || now.Before(gameCreationGate.next) {
+		if _, waiting := gameCreationGate.waiting[name]; !waiting {
+			gameCreationGate.waiting[name] = now
+			logger.Info("Waiting for other characters to finish creating their games")
+		}
+		return nil, false
+	}
+
+	if waitingSince, waiting := gameCreationGate.waiting[name]; waiting {
+		logger.Debug("Game creation slot acquired", slog.Duration("waited", now.Sub(waitingSince)))
+		delete(gameCreationGate.waiting, name)
+	}
+	gameCreationGate.creating[name] = now
+	gameCreationGate.next = now.Add(gameCreationDelay(cfg.StaggerSeconds, cfg.JitterSeconds))
+
+	return func() {
+		gameCreationGate.mu.Lock()
+		delete(gameCreationGate.creating, name)
+		gameCreationGate.mu.Unlock()
+	}, true
+}
+
+func gameCreationDelay(staggerSeconds, jitterSeconds int) time.Duration {
+	delay := time.Duration(max(staggerSeconds, 0)) * time.Second
+	if jitterSeconds > 0 {
+		delay += time.Duration(rand.Int63n(int64(jitterSeconds) * int64(time.Second)))
+	}
+
+	return delay
+}
@@ -0,0 +1,106 @@
+package bot
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/game"
+)
+
+const (
+	GameVersionStop     = "stop"
+	GameVersionTownIdle = "town_idle"
+	GameVersionIgnore   = "ignore"
+)
+
+// GameVersionStatus is the D2R version installed compared to the supported one, shown in the dashboard.
+type GameVersionStatus struct {
+	Version     string
+	Supported   string
+	Unsupported bool
+	Mode        string
+	Error       string
+	CheckedAt   time.Time
+}
+
+var gameVersion = struct {
+	mu     sync.Mutex
+	status GameVersionStatus
+}{}
+
+// GameVersion returns the result of the last game version check.
+func GameVersion() GameVersionStatus {
+	gameVersion.mu.Lock()
+	defer gameVersion.mu.Unlock()
+
+	return gameVersion.status
+}
+
+// CheckGameVersion reads the version of the D2R install and compares it to the supported one, it's done every time
+// a supervisor starts so the game updates are caught when the client restarts. The supported version is the one of the
+// koolo config, or the one of the build. Without any, the version is only logged.
+func CheckGameVersion(logger *slog.Logger) GameVersionStatus {
+	mode := config.Koolo.GameVersion.OnUnsupported
+	if mode == "" {
+		mode = GameVersionStop
+	}
+	supported := config.Koolo.GameVersion.Supported
+	if supported == "" {
+		supported = config.SupportedGameVersion
+	}
+	status := GameVersionStatus{Supported: supported, Mode: mode, CheckedAt: time.Now()}
+	defer func() {
+		gameVersion.mu.Lock()
+		gameVersion.status = status
+		gameVersion.mu.Unlock()
+	}()
+
+	version, err := game.DetectVersion(config.Koolo.D2RPath)
+	if err != nil {
+		// Not knowing the version is not a reason to stop, the offsets may be fine
+		logger.Warn("Failed detecting the game version", slog.Any("error", err))
+		status.Error = err.Error()
+		return status
+	}
+	status.Version = version.String()
+
+	if status.Supported == "" {
+		// Trusting the version seen first would accept a client already updated past the offsets
+		logger.Warn("No supported game version is known, the game version is not checked", slog.String("version", status.Version))
+		return status
+	}
+
+	supportedVersion, err := game.ParseVersion(status.Supported)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	if version.Compare(supportedVersion) > 0 {
+		status.Unsupported = true
+		logger.Warn("The game version is newer than the supported one, the memory offsets may be broken",
+			slog.String("version", status.Version), slog.String("supported", status.Supported), slog.String("mode", mode))
+	}
+
+	return status
+}
+
+// AcceptGameVersion makes the installed game version the supported one, once the bot got updated for it.
+func AcceptGameVersion() error {
+	version := GameVersion().Version
+	if version == "" {
+		return fmt.Errorf("the game version was not detected yet")
+	}
+	if err := config.SaveSupportedGameVersion(version); err != nil {
+		return err
+	}
+
+	gameVersion.mu.Lock()
+	gameVersion.status.Supported = version
+	gameVersion.status.Unsupported = false
+	gameVersion.mu.Unlock()
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/hectorgimenez/koolo/internal/event"
+	"github.com/hectorgimenez/koolo/internal/game"
+	"github.com/hectorgimenez/koolo/internal/mule"
+)
+
+// ItemIndexEntry is an item found in the item index, with the place where it's stored.
+type ItemIndexEntry struct {
+	Character string     `json:"character"`
+	Mule      bool       `json:"mule"`
+	Location  string     `json:"location"`
+	StashTab  int        `json:"stashTab"`
+	Item      ArmoryItem `json:"item"`
+}
+
+// NewItemIndexHandler refreshes the armory dump of the character every time the stash content changes, the armory
+// dumps are the item index searched from the web UI.
+func NewItemIndexHandler(supervisorName string, gr *game.MemoryReader, logger *slog.Logger) event.Handler {
+	return func(_ context.Context, e event.Event) error {
+		evt, ok := e.(event.StashUpdatedEvent)
+		if !ok || !strings.EqualFold(evt.Supervisor(), supervisorName) {
+			return nil
+		}
+
+		if err := dumpArmoryData(supervisorName, &game.Data{Data: evt.Data}, gr.LastGameName()); err != nil {
+			logger.Warn("Failed to update the item index", slog.Any("error", err))
+		}
+
+		return nil
+	}
+}
+
+// SearchItemIndex returns the items of the given characters whose name or stats contain the query.
+func SearchItemIndex(characters []string, query string) []ItemIndexEntry {
+	query = strings.ToLower(strings.TrimSpace(query))
+	results := make([]ItemIndexEntry, 0)
+
+	for _, name := range characters {
+		armory, err := LoadArmoryData(name)
+		if err != nil {
+			continue
+		}
+
+		isMule := mule.IsMuleCharacter(name)
+		locations := []struct {
+			items    []ArmoryItem
+			location string
+			tab      int
+		}{
+			{armory.Equipped, "Equipped", 0},
+			{armory.Inventory, "Inventory", 0},
+			{armory.Stash, "Personal Stash", 1},
+			{armory.SharedStash1, "Shared Stash 1", 2},
+			{armory.SharedStash2, "Shared Stash 2", 3},
+			{armory.SharedStash3, "Shared Stash 3", 4},
+			{armory.Cube, "Cube", 0},
+			{armory.Belt, "Belt", 0},
+		}
+		for _, tab := range slices.Sorted(maps.Keys(armory.ExtraStashPages)) {
+			locations = append(locations, struct {
+				items    []ArmoryItem
+				location string
+				tab      int
+			}{armory.ExtraStashPages[tab], fmt.Sprintf("Shared Stash %d", tab-1), tab})
+		}
+		for _, loc := range locations {
+			for _, itm := range loc.items {
+				if query != "" && !itemMatchesQuery(itm, query) {
+					continue
+				}
+				results = append(results, ItemIndexEntry{
+					Character: name,
+					Mule:      isMule,
+					Location:  loc.location,
+					StashTab:  loc.tab,
+					Item:      itm,
+				})
+			}
+		}
+	}
+
+	return results
+}
+
+func itemMatchesQuery(itm ArmoryItem, query string) bool {
+	for _, field := range []string{itm.Name, itm.IdentifiedName, itm.RunewordName} {
+		if strings.Contains(strings.ToLower(field), query) {
+			return true
+		}
+	}
+	for _, s := range itm.Stats {
+		if strings.Contains(strings.ToLower(s.String), query) {
+			return true
+		}
+	}
+
+	return false
+}
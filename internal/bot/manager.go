@@ -4,11 +4,13 @@ import (
 	"fmt"
 	"log/slog"
 	"strconv"
+	"sync"
 	"syscall"
 	"time"
 	"unsafe"
 
 	"github.com/hectorgimenez/koolo/cmd/koolo/log"
+	"github.com/hectorgimenez/koolo/internal/action"
 	"github.com/hectorgimenez/koolo/internal/character"
 	"github.com/hectorgimenez/koolo/internal/config"
 	"github.com/hectorgimenez/koolo/internal/context"
@@ -16,6 +18,7 @@ import (
 	"github.com/hectorgimenez/koolo/internal/event"
 	"github.com/hectorgimenez/koolo/internal/game"
 	"github.com/hectorgimenez/koolo/internal/health"
+	"github.com/hectorgimenez/koolo/internal/humanize"
 	"github.com/hectorgimenez/koolo/internal/mule"
 	"github.com/hectorgimenez/koolo/internal/pather"
 	"github.com/hectorgimenez/koolo/internal/utils"
@@ -23,10 +26,38 @@ import (
 	"github.com/lxn/win"
 )
 
+// Clients are launched by game.StartGame when a supervisor starts, the auto start sequence starts every marked
+// supervisor with a single button. Launches go one at a time, see buildSupervisor.
+var clientLaunchMu sync.Mutex
+
+const (
+	windowPlacementTimeout  = 30 * time.Second
+	windowPlacementInterval = 2 * time.Second
+)
+
+// applySavedWindow places the game window at the saved bounds. The client moves and resizes its window a few times
+// while starting, so the bounds are applied again until they stayed in place for two checks in a row.
+func applySavedWindow(sp Supervisor, x, y, width, height int) {
+	stable := 0
+	deadline := time.Now().Add(windowPlacementTimeout)
+	for stable < 2 && time.Now().Before(deadline) {
+		curX, curY, curWidth, curHeight := sp.WindowBounds()
+		if curX == x && curY == y && (width <= 0 || height <= 0 || (curWidth == width && curHeight == height)) {
+			stable++
+		} else {
+			stable = 0
+			sp.SetWindowBounds(x, y, width, height)
+		}
+		time.Sleep(windowPlacementInterval)
+	}
+}
+
 type SupervisorManager struct {
 	logger         *slog.Logger
 	supervisors    map[string]Supervisor
 	crashDetectors map[string]*game.CrashDetector
+	watchdogs      map[string]*game.ClientWatchdog
+	configWatchers map[string]*ConfigWatcher
 	eventListener  *event.Listener
 	Drop           *drop.Service // Drop: Service façade to manage Drop domain
 }
@@ -37,6 +68,8 @@ func NewSupervisorManager(logger *slog.Logger, eventListener *event.Listener) *S
 		logger:         logger,
 		supervisors:    make(map[string]Supervisor),
 		crashDetectors: make(map[string]*game.CrashDetector),
+		watchdogs:      make(map[string]*game.ClientWatchdog),
+		configWatchers: make(map[string]*ConfigWatcher),
 		eventListener:  eventListener,
 		Drop:           drop.NewService(logger),
 	}
@@ -70,6 +103,11 @@ func (mng *SupervisorManager) Start(supervisorName string, attachToExisting bool
 		return err
 	}
 
+	gameVersion := CheckGameVersion(supervisorLogger)
+	if gameVersion.Unsupported && gameVersion.Mode == GameVersionStop {
+		return fmt.Errorf("D2R version %s is newer than the supported %s, accept it from the dashboard once the bot is updated for it", gameVersion.Version, gameVersion.Supported)
+	}
+
 	var optionalPID uint32
 	var optionalHWND win.HWND
 
@@ -91,6 +129,8 @@ func (mng *SupervisorManager) Start(supervisorName string, attachToExisting bool
 	// Set manual mode flag
 	ctx := supervisor.GetContext()
 	if ctx != nil {
+		ctx.ResumeSkipRuns = takeResumeRuns(supervisorName)
+		ctx.TownIdleOnly = gameVersion.Unsupported && gameVersion.Mode == GameVersionTownIdle
 		if manualMode {
 			ctx.ManualModeActive = true
 			supervisorLogger.Info("Manual mode enabled")
@@ -107,7 +147,24 @@ func (mng *SupervisorManager) Start(supervisorName string, attachToExisting bool
 	mng.supervisors[supervisorName] = supervisor
 	mng.crashDetectors[supervisorName] = crashDetector
 
-	if config.Koolo.GameWindowArrangement {
+	if ctx != nil {
+		watchdog := game.NewClientWatchdog(supervisorName, ctx.GameReader.GetPID(), ctx.GameReader, supervisorLogger, func(reason string) {
+			ctx.ClientDegraded.Store(true)
+		})
+		mng.watchdogs[supervisorName] = watchdog
+		go watchdog.Start()
+
+		if oldWatcher, exists := mng.configWatchers[supervisorName]; exists {
+			oldWatcher.Stop()
+		}
+		configWatcher := NewConfigWatcher(supervisorName, ctx, supervisorLogger)
+		mng.configWatchers[supervisorName] = configWatcher
+		go configWatcher.Start()
+	}
+
+	if cfg, found := config.GetCharacter(supervisorName); found && cfg.Window.Enabled {
+		go applySavedWindow(supervisor, cfg.Window.X, cfg.Window.Y, cfg.Window.Width, cfg.Window.Height)
+	} else if config.Koolo.GameWindowArrangement {
 		go func() {
 			// When the game starts, its doing some weird stuff like repositioning and resizing window automatically
 			// we need to wait until this is done in order to reposition, or it will be overridden
@@ -183,6 +240,18 @@ func (mng *SupervisorManager) Stop(supervisor string) {
 			delete(mng.crashDetectors, supervisor)
 		}
 
+		if cw, ok := mng.watchdogs[supervisor]; ok {
+			cw.Stop()
+			delete(mng.watchdogs, supervisor)
+		}
+
+		if cw, ok := mng.configWatchers[supervisor]; ok {
+			cw.Stop()
+			delete(mng.configWatchers, supervisor)
+		}
+
+		mng.eventListener.Unregister(supervisor)
+
 		// The logic to start the next character has been removed from here.
 		// The restartFunc is now the single source of truth for this,
 		// preventing the mule from restarting itself.
@@ -196,14 +265,45 @@ func (mng *SupervisorManager) TogglePause(supervisor string) {
 	}
 }
 
+// Suspend asks the supervisor to park the character and wait at the next safe point, see action.SafeSuspend.
+func (mng *SupervisorManager) Suspend(supervisor string) {
+	if sup, found := mng.supervisors[supervisor]; found {
+		sup.Suspend()
+	}
+}
+
+// ClearRunBlacklist puts the runs blacklisted after repeated failures back in the rotation.
+func (mng *SupervisorManager) ClearRunBlacklist(supervisor string) {
+	if sup, found := mng.supervisors[supervisor]; found {
+		sup.ClearRunBlacklist()
+	}
+}
+
+// Resume takes the supervisor out of a safe suspend.
+func (mng *SupervisorManager) Resume(supervisor string) {
+	if sup, found := mng.supervisors[supervisor]; found {
+		sup.Resume()
+	}
+}
+
 func (mng *SupervisorManager) Status(characterName string) Stats {
+	stats := Stats{}
 	for name, supervisor := range mng.supervisors {
 		if name == characterName {
-			return supervisor.Stats()
+			stats = supervisor.Stats()
+			stats.Suspended = supervisor.Suspended()
+			if ctx := supervisor.GetContext(); ctx != nil {
+				stats.SuspendCheckpoint = ctx.SuspendCheckpoint
+			}
+			break
 		}
 	}
+	fillCrashStats(characterName, &stats)
+	if cw, ok := mng.watchdogs[characterName]; ok {
+		stats.ClientHealth = cw.Health()
+	}
 
-	return Stats{}
+	return stats
 }
 
 func (mng *SupervisorManager) GetData(characterName string) *game.Data {
@@ -256,7 +356,11 @@ func (mng *SupervisorManager) buildSupervisor(supervisorName string, logger *slo
 		} else if kbResult.Missing {
 			logger.Info("Key binding file missing; will bootstrap in-game", slog.String("character", cfg.CharacterName))
 		}
+		// Starting the client kills the handles of the other instances, launches go one at a time so a client
+		// starting never kills the handle another one is still waiting for
+		clientLaunchMu.Lock()
 		pid, hwnd, err = game.StartGame(cfg.Username, cfg.Password, cfg.AuthMethod, cfg.AuthToken, cfg.Realm, cfg.CommandLineArgs, config.Koolo.UseCustomSettings)
+		clientLaunchMu.Unlock()
 		if err != nil {
 			return nil, nil, fmt.Errorf("error starting game: %w", err)
 		}
@@ -281,6 +385,9 @@ func (mng *SupervisorManager) buildSupervisor(supervisorName string, logger *slo
 	hm := health.NewHealthManager(bm, ctx.Data)
 
 	ctx.CharacterCfg = cfg
+	ctx.CharacterCfg.Runtime.Running = true
+	// An item found with shop until found was bought during the previous session, this one shops again
+	ctx.CharacterCfg.Runtime.ShoppingFound = false
 	ctx.EventListener = mng.eventListener
 	ctx.HID = hidM
 	ctx.PacketSender = game.NewPacketSender(gr.Process)
@@ -292,6 +399,11 @@ func (mng *SupervisorManager) buildSupervisor(supervisorName string, logger *slo
 	pf.SetPacketSender(ctx.PacketSender)
 	ctx.BeltManager = bm
 	ctx.HealthManager = hm
+	ctx.SuspendHandler = action.SafeSuspend
+	ctx.Humanizer = humanize.New(cfg.Humanization)
+	if cfg.Humanization.Enabled {
+		logger.Info("Humanization profile enabled", slog.Int64("seed", ctx.Humanizer.Seed()))
+	}
 	char, err := character.BuildCharacter(ctx.Context)
 	if err != nil {
 		return nil, nil, fmt.Errorf("error creating character: %w", err)
@@ -302,7 +414,12 @@ func (mng *SupervisorManager) buildSupervisor(supervisorName string, logger *slo
 	bot := NewBot(ctx.Context, muleManager)
 
 	statsHandler := NewStatsHandler(supervisorName, logger)
-	mng.eventListener.Register(statsHandler.Handle)
+	// Drop the handlers of a previous start, they hold the old context and counters
+	mng.eventListener.Unregister(supervisorName)
+	mng.eventListener.RegisterFor(supervisorName, statsHandler.Handle)
+	mng.eventListener.RegisterFor(supervisorName, NewItemIndexHandler(supervisorName, gr, logger))
+	mng.eventListener.RegisterFor(supervisorName, NewSessionGoalHandler(supervisorName, ctx.Context, logger).Handle)
+	mng.eventListener.RegisterFor(supervisorName, NewScriptHookHandler(supervisorName, ctx.Context, logger).Handle)
 	supervisor, err := NewSinglePlayerSupervisor(supervisorName, bot, statsHandler)
 
 	if err != nil {
@@ -352,9 +469,20 @@ func (mng *SupervisorManager) buildSupervisor(supervisorName string, logger *slo
 			return
 		}
 
-		mng.logger.Info("Restarting supervisor after crash", slog.String("supervisor", supervisorName))
+		var backoff time.Duration
+		if ctx.PlannedRestart {
+			mng.logger.Info("Restarting degraded client", slog.String("supervisor", supervisorName))
+		} else {
+			backoff = recordCrash(supervisorName, supervisor.Stats())
+			mng.logger.Info("Restarting supervisor after crash", slog.String("supervisor", supervisorName))
+		}
 		mng.Stop(supervisorName)
-		time.Sleep(5 * time.Second) // Wait a bit before restarting
+		if backoff > 0 {
+			mng.logger.Warn("Client keeps crashing, waiting before restarting", slog.String("supervisor", supervisorName), slog.Duration("wait", backoff))
+			time.Sleep(backoff)
+		} else {
+			time.Sleep(5 * time.Second) // Wait a bit before restarting
+		}
 
 		// Get a list of all available Supervisors
 		supervisorList := mng.AvailableSupervisors()
@@ -447,7 +575,12 @@ func (mng *SupervisorManager) rearrangeWindows() {
 	)
 
 	var column, row int32
-	for _, sp := range mng.supervisors {
+	for name, sp := range mng.supervisors {
+		// Supervisors with a saved window keep it
+		if cfg, found := config.GetCharacter(name); found && cfg.Window.Enabled {
+			continue
+		}
+
 		// reminder that columns are vertical (they go up and down) and rows are horizontal (they go left and right)
 		if column > maxColumns {
 			column = 0
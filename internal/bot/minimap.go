@@ -0,0 +1,122 @@
+package bot
+
+import (
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/item"
+)
+
+const (
+	// minimapRadius is the distance in tiles around the player sent to the web UI.
+	minimapRadius = 60
+	// minimapPathAge is how long the path stays on the minimap after the last movement.
+	minimapPathAge = 3 * time.Second
+)
+
+// Minimap is the tactical view of a supervisor sent to the web UI every tick, the positions are relative to the
+// player to keep the payload small.
+type Minimap struct {
+	Supervisor string            `json:"supervisor"`
+	Area       string            `json:"area"`
+	X          int               `json:"x"`
+	Y          int               `json:"y"`
+	Monsters   []MinimapMonster  `json:"monsters"`
+	Path       []MinimapPosition `json:"path"`
+	Loot       []MinimapLoot     `json:"loot"`
+}
+
+type MinimapPosition struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+type MinimapMonster struct {
+	X     int  `json:"x"`
+	Y     int  `json:"y"`
+	Elite bool `json:"e,omitempty"`
+}
+
+type MinimapLoot struct {
+	X       int    `json:"x"`
+	Y       int    `json:"y"`
+	Name    string `json:"n"`
+	Quality string `json:"q"`
+}
+
+// Minimap returns the minimap of the supervisor, false when it's not running or not in game. It's built from the game
+// data published by the bot, ctx.Data is being replaced by the bot goroutine meanwhile.
+func (mng *SupervisorManager) Minimap(supervisor string) (Minimap, bool) {
+	sup, found := mng.supervisors[supervisor]
+	if !found || sup.Stats().SupervisorStatus != InGame {
+		return Minimap{}, false
+	}
+
+	ctx := sup.GetContext()
+	if ctx == nil {
+		return Minimap{}, false
+	}
+	d, found := ctx.PublishedData()
+	if !found || d.PlayerUnit.Area == 0 {
+		return Minimap{}, false
+	}
+
+	player := d.PlayerUnit.Position
+	relative := func(pos data.Position) (int, int, bool) {
+		x, y := pos.X-player.X, pos.Y-player.Y
+		return x, y, x >= -minimapRadius && x <= minimapRadius && y >= -minimapRadius && y <= minimapRadius
+	}
+
+	minimap := Minimap{
+		Supervisor: supervisor,
+		Area:       d.PlayerUnit.Area.Area().Name,
+		X:          player.X,
+		Y:          player.Y,
+		Monsters:   []MinimapMonster{},
+		Path:       []MinimapPosition{},
+		Loot:       []MinimapLoot{},
+	}
+
+	for _, m := range d.Monsters.Enemies() {
+		if x, y, visible := relative(m.Position); visible {
+			minimap.Monsters = append(minimap.Monsters, MinimapMonster{X: x, Y: y, Elite: m.IsElite()})
+		}
+	}
+
+	if ctx.PathFinder != nil {
+		for _, pos := range ctx.PathFinder.FollowedPath(minimapPathAge) {
+			if x, y, visible := relative(pos); visible {
+				minimap.Path = append(minimap.Path, MinimapPosition{X: x, Y: y})
+			}
+		}
+	}
+
+	for _, itm := range d.Inventory.ByLocation(item.LocationGround) {
+		if itm.IsPotion() || itm.Name == "Gold" {
+			continue
+		}
+		x, y, visible := relative(itm.Position)
+		if !visible {
+			continue
+		}
+		name := itm.IdentifiedName
+		if name == "" {
+			name = string(itm.Name)
+		}
+		minimap.Loot = append(minimap.Loot, MinimapLoot{X: x, Y: y, Name: name, Quality: itm.Quality.ToString()})
+	}
+
+	return minimap, true
+}
+
+// Minimaps returns the minimap of every supervisor in game.
+func (mng *SupervisorManager) Minimaps() []Minimap {
+	minimaps := make([]Minimap, 0, len(mng.supervisors))
+	for name := range mng.supervisors {
+		if minimap, found := mng.Minimap(name); found {
+			minimaps = append(minimaps, minimap)
+		}
+	}
+
+	return minimaps
+}
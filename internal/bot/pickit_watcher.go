@@ -0,0 +1,56 @@
+package bot
+
+import (
+	"context"
+	"os"
+	"time"
+
+	botCtx "github.com/hectorgimenez/koolo/internal/context"
+)
+
+const pickitWatchInterval = 5 * time.Second
+
+// watchPickitFiles queues a reload of the pickit rules when any of the NIP files changes, so edits are live without
+// restarting the supervisor. The bot recompiles them between two runs, see ApplyPendingPickit.
+func watchPickitFiles(ctx context.Context, bctx *botCtx.Context) {
+	lastChange, lastCount := pickitFilesLastChange(bctx)
+
+	ticker := time.NewTicker(pickitWatchInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		change, count := pickitFilesLastChange(bctx)
+		if change.Equal(lastChange) && count == lastCount {
+			continue
+		}
+		lastChange, lastCount = change, count
+
+		bctx.PickitReloadPending.Store(true)
+		bctx.Logger.Debug("Pickit files changed, the rules will be reloaded at the next safe point")
+	}
+}
+
+// pickitFilesLastChange returns the latest modification time and the amount of NIP files, removing a file doesn't
+// touch the modification time of the others. The files are listed from a config snapshot, a reload may change them.
+func pickitFilesLastChange(bctx *botCtx.Context) (time.Time, int) {
+	cfg := bctx.ConfigSnapshot()
+	var lastChange time.Time
+	count := 0
+	for _, file := range cfg.PickitFiles() {
+		info, err := os.Stat(file)
+		if err != nil {
+			continue
+		}
+		count++
+		if info.ModTime().After(lastChange) {
+			lastChange = info.ModTime()
+		}
+	}
+
+	return lastChange, count
+}
@@ -0,0 +1,47 @@
+package bot
+
+import (
+	"sync"
+	"time"
+)
+
+// runBudget keeps the average duration of every run, so the bot can tell if the next run fits in the remaining game
+// time instead of being cut in the middle by the max game length.
+type runBudget struct {
+	mu        sync.Mutex
+	durations map[string]time.Duration
+}
+
+func newRunBudget() *runBudget {
+	return &runBudget{durations: make(map[string]time.Duration)}
+}
+
+// expected returns the average duration of the run, zero when it never ran yet.
+func (rb *runBudget) expected(name string) time.Duration {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+
+	return rb.durations[name]
+}
+
+// record updates the average with the last run duration, recent runs weight more as the character gear changes.
+func (rb *runBudget) record(name string, d time.Duration) {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+
+	previous, found := rb.durations[name]
+	if !found {
+		rb.durations[name] = d
+		return
+	}
+	rb.durations[name] = (previous*2 + d) / 3
+}
+
+// fits returns true if a run with the given name can still be completed before the game length limit.
+func (rb *runBudget) fits(name string, gameStartedAt time.Time, maxGameLength time.Duration) bool {
+	if maxGameLength <= 0 {
+		return true
+	}
+
+	return time.Since(gameStartedAt)+rb.expected(name) < maxGameLength
+}
@@ -0,0 +1,99 @@
+package bot
+
+import (
+	"slices"
+	"sync"
+)
+
+// BlacklistedRun is a run skipped for a few games after failing too many times in a row.
+type BlacklistedRun struct {
+	Run       string `json:"run"`
+	Failures  int    `json:"failures"`
+	GamesLeft int    `json:"gamesLeft"`
+}
+
+// runPolicy counts the consecutive failures of every run, a run failing too often (pathing dead ends, bugged boss...)
+// is left out of the rotation for some games instead of ending every game on the same error.
+type runPolicy struct {
+	mu          sync.Mutex
+	failures    map[string]int
+	blacklisted map[string]int
+}
+
+func newRunPolicy() *runPolicy {
+	return &runPolicy{
+		failures:    make(map[string]int),
+		blacklisted: make(map[string]int),
+	}
+}
+
+// record updates the failures of the run, it returns true when the run just got blacklisted.
+func (rp *runPolicy) record(name string, failed bool, maxFailures, blacklistGames int) bool {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+
+	if !failed {
+		delete(rp.failures, name)
+		return false
+	}
+
+	rp.failures[name]++
+	if maxFailures <= 0 || blacklistGames <= 0 || rp.failures[name] < maxFailures {
+		return false
+	}
+
+	rp.blacklisted[name] = blacklistGames
+	return true
+}
+
+// startGame counts one more game for the blacklisted runs and returns the runs to do in this game. The runs are kept
+// as they are when all of them are blacklisted, there would be nothing left to do.
+func (rp *runPolicy) startGame(runs []string) []string {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+
+	skipped := make(map[string]bool)
+	for name, gamesLeft := range rp.blacklisted {
+		if gamesLeft <= 0 {
+			delete(rp.blacklisted, name)
+			delete(rp.failures, name)
+			continue
+		}
+		skipped[name] = true
+		rp.blacklisted[name] = gamesLeft - 1
+	}
+
+	remaining := slices.DeleteFunc(slices.Clone(runs), func(r string) bool {
+		return skipped[r]
+	})
+	if len(remaining) == 0 {
+		return runs
+	}
+
+	return remaining
+}
+
+// state returns the runs currently blacklisted.
+func (rp *runPolicy) state() []BlacklistedRun {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+
+	blacklisted := make([]BlacklistedRun, 0, len(rp.blacklisted))
+	for name, gamesLeft := range rp.blacklisted {
+		blacklisted = append(blacklisted, BlacklistedRun{Run: name, Failures: rp.failures[name], GamesLeft: gamesLeft})
+	}
+	slices.SortFunc(blacklisted, func(a, b BlacklistedRun) int {
+		return b.GamesLeft - a.GamesLeft
+	})
+
+	return blacklisted
+}
+
+// clear lifts every blacklist, used when the runs are changed by hand.
+func (rp *runPolicy) clear() {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+
+	clear(rp.failures)
+	clear(rp.blacklisted)
+}
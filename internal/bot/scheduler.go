@@ -67,6 +67,9 @@ type Scheduler struct {
 	// Duration mode state (per supervisor)
 	durationState map[string]*DurationState
 	stateMux      sync.RWMutex
+
+	// Time slots mode play sessions (per supervisor)
+	slotSessions map[string]*slotSession
 }
 
 func NewScheduler(manager *SupervisorManager, logger *slog.Logger) *Scheduler {
@@ -75,6 +78,7 @@ func NewScheduler(manager *SupervisorManager, logger *slog.Logger) *Scheduler {
 		logger:        logger,
 		stop:          make(chan struct{}),
 		durationState: make(map[string]*DurationState),
+		slotSessions:  make(map[string]*slotSession),
 	}
 
 	// Load persisted state for all characters
@@ -158,7 +162,28 @@ func (s *Scheduler) checkTimeSlotsSchedule(supervisorName string, cfg *config.Ch
 			end := time.Date(now.Year(), now.Month(), now.Day(), timeRange.End.Hour(), timeRange.End.Minute(), 0, 0, now.Location())
 			end = end.Add(time.Duration(endOffset) * time.Minute)
 
-			if now.After(start) && now.Before(end) && s.supervisorNotStarted(supervisorName) {
+			inRange := now.After(start) && now.Before(end)
+			if inRange && s.timeSlotBreakDue(supervisorName, cfg, now) {
+				if !s.supervisorNotStarted(supervisorName) {
+					s.logger.Info("Taking a break based on schedule",
+						"supervisor", supervisorName,
+						"timeRange", start.Format("15:04")+" - "+end.Format("15:04"))
+					s.pauseForBreak(supervisorName, cfg)
+				}
+				actionTaken = true
+				break
+			}
+
+			if inRange && s.supervisorSuspended(supervisorName) {
+				s.logger.Info("Resuming supervisor based on schedule",
+					"supervisor", supervisorName,
+					"timeRange", start.Format("15:04")+" - "+end.Format("15:04"))
+				s.manager.Resume(supervisorName)
+				actionTaken = true
+				break
+			}
+
+			if inRange && s.supervisorNotStarted(supervisorName) {
 				s.logger.Info("Starting supervisor based on schedule",
 					"supervisor", supervisorName,
 					"timeRange", start.Format("15:04")+" - "+end.Format("15:04"))
@@ -170,6 +195,7 @@ func (s *Scheduler) checkTimeSlotsSchedule(supervisorName string, cfg *config.Ch
 					"supervisor", supervisorName,
 					"timeRange", start.Format("15:04")+" - "+end.Format("15:04"))
 				s.stopSupervisor(supervisorName)
+				s.resetSlotSession(supervisorName)
 				actionTaken = true
 				break
 			}
@@ -509,7 +535,9 @@ func (s *Scheduler) transitionToPlaying(supervisorName string, state *DurationSt
 		"supervisor", supervisorName,
 		"playedMinutes", state.PlayedMinutes)
 
-	if s.supervisorNotStarted(supervisorName) {
+	if s.supervisorSuspended(supervisorName) {
+		s.manager.Resume(supervisorName)
+	} else if s.supervisorNotStarted(supervisorName) {
 		go s.startSupervisor(supervisorName)
 	}
 
@@ -530,7 +558,11 @@ func (s *Scheduler) transitionToBreak(supervisorName string, state *DurationStat
 		"duration", brk.Duration,
 		"resumeAt", state.PhaseEndTime.Format("15:04"))
 
-	s.stopSupervisor(supervisorName)
+	if cfg, found := config.GetCharacter(supervisorName); found {
+		s.pauseForBreak(supervisorName, cfg)
+	} else {
+		s.stopSupervisor(supervisorName)
+	}
 	s.saveState(supervisorName, state)
 }
 
@@ -794,6 +826,21 @@ func (s *Scheduler) stopSupervisor(name string) {
 	}
 }
 
+// pauseForBreak safely suspends the supervisor when the character is set to, so the run goes on after the break,
+// otherwise the supervisor is stopped.
+func (s *Scheduler) pauseForBreak(name string, cfg *config.CharacterCfg) {
+	if cfg.Scheduler.SuspendOnBreaks && !s.supervisorNotStarted(name) {
+		s.manager.Suspend(name)
+		return
+	}
+	s.stopSupervisor(name)
+}
+
+func (s *Scheduler) supervisorSuspended(name string) bool {
+	sup := s.manager.GetSupervisor(name)
+	return sup != nil && sup.Suspended()
+}
+
 func contains(slice []int, val int) bool {
 	for _, item := range slice {
 		if item == val {
@@ -0,0 +1,64 @@
+package bot
+
+import (
+	"time"
+
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/humanize"
+)
+
+// slotSession is the current play session inside a time slot, followed by its break.
+type slotSession struct {
+	PlayUntil  time.Time
+	BreakUntil time.Time
+}
+
+// timeSlotBreakDue returns true while the supervisor should be on a break inside an active time slot. Sessions and
+// breaks get a random length every time so the play pattern doesn't repeat.
+func (s *Scheduler) timeSlotBreakDue(supervisorName string, cfg *config.CharacterCfg, now time.Time) bool {
+	breaks := cfg.Scheduler.Breaks
+	if breaks.SessionMinutes <= 0 || breaks.BreakMinutes <= 0 {
+		return false
+	}
+
+	s.stateMux.Lock()
+	defer s.stateMux.Unlock()
+
+	session, found := s.slotSessions[supervisorName]
+	if !found || !now.Before(session.BreakUntil) {
+		session = s.newSlotSession(supervisorName, cfg, now)
+		s.slotSessions[supervisorName] = session
+	}
+
+	return !now.Before(session.PlayUntil)
+}
+
+func (s *Scheduler) newSlotSession(supervisorName string, cfg *config.CharacterCfg, now time.Time) *slotSession {
+	breaks := cfg.Scheduler.Breaks
+	playMinutes := max(s.randomInRange(breaks.SessionMinutes-breaks.SessionVariance, breaks.SessionMinutes+breaks.SessionVariance), 1)
+	playMinutes = s.humanizer(supervisorName, cfg).SessionLength(playMinutes)
+	breakMinutes := max(s.randomInRange(breaks.BreakMinutes-breaks.BreakVariance, breaks.BreakMinutes+breaks.BreakVariance), 1)
+
+	playUntil := now.Add(time.Duration(playMinutes) * time.Minute)
+	return &slotSession{
+		PlayUntil:  playUntil,
+		BreakUntil: playUntil.Add(time.Duration(breakMinutes) * time.Minute),
+	}
+}
+
+// resetSlotSession starts a fresh session next time the supervisor enters a time slot.
+func (s *Scheduler) resetSlotSession(supervisorName string) {
+	s.stateMux.Lock()
+	defer s.stateMux.Unlock()
+
+	delete(s.slotSessions, supervisorName)
+}
+
+// humanizer returns the humanization profile of the running supervisor, a new one from the config when it's stopped.
+func (s *Scheduler) humanizer(supervisorName string, cfg *config.CharacterCfg) *humanize.Profile {
+	if ctx := s.manager.GetContext(supervisorName); ctx != nil && ctx.Humanizer != nil {
+		return ctx.Humanizer
+	}
+
+	return humanize.New(cfg.Humanization)
+}
@@ -0,0 +1,140 @@
+package bot
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/expr-lang/expr/vm"
+	botCtx "github.com/hectorgimenez/koolo/internal/context"
+	"github.com/hectorgimenez/koolo/internal/event"
+	"github.com/hectorgimenez/koolo/internal/script"
+)
+
+// ScriptHookHandler runs the hook scripts of the character on the lifecycle events. The scripts are compiled once and
+// a script failing to compile is reported once and skipped.
+type ScriptHookHandler struct {
+	name     string
+	ctx      *botCtx.Context
+	logger   *slog.Logger
+	programs map[string]*vm.Program
+	// Run being played, kept from the run events instead of read from the bot routine state
+	run string
+	// Set by stopSession, the supervisor is stopped once the game is finished
+	stopBy string
+}
+
+func NewScriptHookHandler(name string, ctx *botCtx.Context, logger *slog.Logger) *ScriptHookHandler {
+	return &ScriptHookHandler{
+		name:     name,
+		ctx:      ctx,
+		logger:   logger,
+		programs: make(map[string]*vm.Program),
+	}
+}
+
+func (h *ScriptHookHandler) Handle(_ context.Context, e event.Event) error {
+	if !strings.EqualFold(e.Supervisor(), h.name) {
+		return nil
+	}
+
+	switch evt := e.(type) {
+	case event.RunStartedEvent:
+		h.run = evt.RunName
+		h.fire(script.OnRunStart, script.Env{Run: evt.RunName})
+
+	case event.ItemStashedEvent:
+		h.fire(script.OnItemStashed, script.Env{
+			Run: h.run,
+			Item: script.Item{
+				Name:           string(evt.Item.Item.Name),
+				IdentifiedName: evt.Item.Item.IdentifiedName,
+				Quality:        evt.Item.Item.Quality.ToString(),
+				Ethereal:       evt.Item.Item.Ethereal,
+				Rule:           evt.Item.Rule,
+			},
+		})
+
+	case event.RunFinishedEvent:
+		if evt.Reason == event.FinishedDied {
+			h.fire(script.OnDeath, script.Env{Run: evt.RunName, Reason: string(evt.Reason)})
+		}
+
+	case event.TownVisitedEvent:
+		h.fire(script.OnTownVisit, script.Env{Area: evt.Area.Area().Name})
+
+	case event.GameFinishedEvent:
+		if h.stopBy == "" {
+			return nil
+		}
+
+		h.logger.Info("Stopping, requested by a hook script", slog.String("hook", h.stopBy))
+		h.stopBy = ""
+		h.ctx.StopSupervisor()
+	}
+
+	return nil
+}
+
+func (h *ScriptHookHandler) fire(hook string, env script.Env) {
+	env.Hook = hook
+	env.Character = h.name
+	env.Log = func(msg string) bool {
+		h.logger.Info(msg, slog.String("hook", hook))
+		return true
+	}
+	env.Notify = func(msg string) bool {
+		// Handlers run on the listener routine, the event can't be sent from it
+		go event.Send(event.ScriptNotification(event.Text(h.name, msg), hook))
+		return true
+	}
+	env.StopSession = func() bool {
+		h.stopBy = hook
+		return true
+	}
+
+	// The handler runs on the listener routine, the config may be reloaded meanwhile
+	charCfg := h.ctx.ConfigSnapshot()
+	for _, cfg := range charCfg.Hooks {
+		if cfg.On != hook {
+			continue
+		}
+
+		if cfg.When != "" {
+			when := h.program(cfg.When, script.CompileCondition)
+			if when == nil {
+				continue
+			}
+			matched, err := script.Run(when, env)
+			if err != nil {
+				h.logger.Warn("Hook condition failed", slog.String("hook", hook), slog.String("when", cfg.When), slog.Any("error", err))
+				continue
+			}
+			if ok, _ := matched.(bool); !ok {
+				continue
+			}
+		}
+
+		if do := h.program(cfg.Do, script.CompileAction); do != nil {
+			if _, err := script.Run(do, env); err != nil {
+				h.logger.Warn("Hook script failed", slog.String("hook", hook), slog.String("do", cfg.Do), slog.Any("error", err))
+			}
+		}
+	}
+}
+
+// program returns the compiled script, nil when it doesn't compile. The scripts of a reloaded config are compiled on
+// their first use.
+func (h *ScriptHookHandler) program(src string, compile func(string) (*vm.Program, error)) *vm.Program {
+	if p, found := h.programs[src]; found {
+		return p
+	}
+
+	p, err := compile(src)
+	if err != nil {
+		h.logger.Error("Hook script doesn't compile", slog.String("script", src), slog.Any("error", err))
+	}
+	h.programs[src] = p
+
+	return p
+}
@@ -0,0 +1,214 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/koolo/internal/action"
+	"github.com/hectorgimenez/koolo/internal/config"
+	botCtx "github.com/hectorgimenez/koolo/internal/context"
+	"github.com/hectorgimenez/koolo/internal/event"
+)
+
+const selfCheckInterval = 5 * time.Second
+
+var (
+	// ErrSelfCheckLeaveGame ends the game when the bot is still not making progress after the town return.
+	ErrSelfCheckLeaveGame = errors.New("self-check: no progress, leaving game")
+	// ErrSelfCheckRestart restarts the client when the bot got stuck again after leaving the game.
+	ErrSelfCheckRestart = errors.New("self-check: no progress, restarting client")
+)
+
+// Recovery steps of the self-check, every step is given some time before escalating to the next one.
+const (
+	RecoveryResync        = "resync"
+	RecoveryReturnTown    = "return_town"
+	RecoveryLeaveGame     = "leave_game"
+	RecoveryRestartClient = "restart_client"
+)
+
+var recoveryLadder = []string{RecoveryResync, RecoveryReturnTown, RecoveryLeaveGame, RecoveryRestartClient}
+
+// selfCheck watches the no progress conditions: the same action for too long, no position change and the same action
+// error returned again and again. The ladder goes back to the start once the bot makes progress, except after leaving
+// the game: getting stuck again before finishing a run restarts the client.
+type selfCheck struct {
+	mu            sync.Mutex
+	lastCheck     time.Time
+	action        string
+	actionSince   time.Time
+	position      data.Position
+	positionSince time.Time
+	step          int // Index of the next step of the ladder
+	stepAt        time.Time
+	leftGame      bool
+}
+
+func newSelfCheck() *selfCheck {
+	return &selfCheck{}
+}
+
+// startGame resets the progress timers, the game creation and the loading screens don't count.
+func (sc *selfCheck) startGame() {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	sc.action, sc.actionSince = "", time.Now()
+	sc.position, sc.positionSince = data.Position{}, time.Now()
+	sc.step, sc.stepAt = 0, time.Time{}
+	if sc.leftGame {
+		sc.step = len(recoveryLadder) - 1
+	}
+}
+
+// runFinished is called when a run finishes without error, the bot is healthy again.
+func (sc *selfCheck) runFinished() {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	sc.leftGame = false
+}
+
+// next returns the recovery step to walk and why, empty when the bot is making progress or the last step still has
+// time to work. The ladder only advances once the step was walked, see walked.
+func (sc *selfCheck) next(ctx *botCtx.Context) (string, string) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	cfg := ctx.CharacterCfg.SelfCheck
+	if time.Since(sc.lastCheck) < selfCheckInterval {
+		return "", ""
+	}
+	sc.lastCheck = time.Now()
+
+	reason := sc.noProgress(ctx)
+	if reason == "" {
+		if sc.step > 0 && !sc.leftGame {
+			ctx.Logger.Info("Self-check: progress detected, recovery ladder reset")
+			sc.step = 0
+		}
+		return "", ""
+	}
+
+	escalateAfter := time.Duration(cfg.EscalateSeconds) * time.Second
+	if escalateAfter == 0 {
+		escalateAfter = 30 * time.Second
+	}
+	if !sc.stepAt.IsZero() && time.Since(sc.stepAt) < escalateAfter {
+		return "", ""
+	}
+
+	index := min(sc.step, len(recoveryLadder)-1)
+	// Going back to town can't help a character stuck in town, the next step is walked instead
+	if recoveryLadder[index] == RecoveryReturnTown && ctx.Data.PlayerUnit.Area.IsTown() {
+		index++
+	}
+
+	return recoveryLadder[index], reason
+}
+
+// walked advances the ladder past the step once it was walked.
+func (sc *selfCheck) walked(step string) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	sc.step = slices.Index(recoveryLadder, step) + 1
+	sc.stepAt = time.Now()
+	switch step {
+	case RecoveryLeaveGame:
+		sc.leftGame = true
+	case RecoveryRestartClient:
+		sc.leftGame = false
+	}
+}
+
+// expectedWait tells if the character is waiting on purpose: following the companion leader, waiting for the rushee or
+// idling in town on an unsupported game version. Standing still or doing the same action is expected then.
+func expectedWait(ctx *botCtx.Context) bool {
+	return ctx.CurrentGame.CurrentRun == string(config.CompanionRun) || ctx.CurrentGame.Rushing || ctx.TownIdleOnly
+}
+
+func (sc *selfCheck) noProgress(ctx *botCtx.Context) string {
+	cfg := ctx.CharacterCfg.SelfCheck
+	now := time.Now()
+
+	lastAction := ""
+	if debug := ctx.ContextDebug[botCtx.PriorityNormal]; debug != nil {
+		lastAction = debug.LastAction
+	}
+	waiting := expectedWait(ctx)
+	if lastAction != sc.action || waiting {
+		sc.action, sc.actionSince = lastAction, now
+	}
+
+	// Standing still is expected while managing the items or the stats
+	menus := ctx.Data.OpenMenus
+	busy := menus.Stash || menus.Cube || menus.NPCShop || menus.Character || menus.SkillTree || ctx.IsAllocatingStatsOrSkills.Load() || waiting
+	if position := ctx.Data.PlayerUnit.Position; position != sc.position || busy {
+		sc.position, sc.positionSince = position, now
+	}
+
+	stuckAction := time.Duration(cfg.StuckActionMinutes) * time.Minute
+	if stuckAction == 0 {
+		stuckAction = 5 * time.Minute
+	}
+	stuckPosition := time.Duration(cfg.StuckPositionSeconds) * time.Second
+	if stuckPosition == 0 {
+		stuckPosition = 60 * time.Second
+	}
+	repeatedErrors := cfg.RepeatedErrors
+	if repeatedErrors == 0 {
+		repeatedErrors = 5
+	}
+
+	switch {
+	case sc.action != "" && now.Sub(sc.actionSince) > stuckAction:
+		return fmt.Sprintf("same action %s for %s", sc.action, now.Sub(sc.actionSince).Round(time.Second))
+	case now.Sub(sc.positionSince) > stuckPosition:
+		return fmt.Sprintf("no position change for %s", now.Sub(sc.positionSince).Round(time.Second))
+	case ctx.CurrentGame.ActionErrorRepeats >= repeatedErrors:
+		return fmt.Sprintf("same error %d times in a row: %s", ctx.CurrentGame.ActionErrorRepeats, ctx.CurrentGame.LastActionError)
+	}
+
+	return ""
+}
+
+// checkProgress walks the recovery ladder when the bot stopped making progress, it's called by the high priority loop.
+// Leaving the game and restarting the client are returned as errors, ending the game.
+func (b *Bot) checkProgress() error {
+	if !b.ctx.CharacterCfg.SelfCheck.Enabled {
+		return nil
+	}
+
+	step, reason := b.selfCheck.next(b.ctx)
+	if step == "" {
+		return nil
+	}
+
+	b.ctx.Logger.Warn("Self-check: no progress, walking the recovery ladder", slog.String("step", step), slog.String("reason", reason))
+	event.Send(event.SelfCheckRecovery(event.Text(b.ctx.Name, fmt.Sprintf("Self-check recovery (%s): %s", step, reason)), step, reason))
+
+	b.selfCheck.walked(step)
+	switch step {
+	case RecoveryResync:
+		b.ctx.RefreshGameData()
+		b.ctx.RefreshInventory()
+	case RecoveryReturnTown:
+		b.ctx.SwitchPriority(botCtx.PriorityHigh)
+		if err := action.ReturnTown(); err != nil {
+			b.ctx.Logger.Warn("Self-check: failed returning to town", slog.Any("error", err))
+		}
+		b.ctx.SwitchPriority(botCtx.PriorityNormal)
+	case RecoveryLeaveGame:
+		return ErrSelfCheckLeaveGame
+	case RecoveryRestartClient:
+		return ErrSelfCheckRestart
+	}
+
+	return nil
+}
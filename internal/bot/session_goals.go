@@ -0,0 +1,102 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	botCtx "github.com/hectorgimenez/koolo/internal/context"
+	"github.com/hectorgimenez/koolo/internal/event"
+)
+
+// SessionGoalHandler stops the supervisor, or switches to another one, once any of the configured session goals is
+// reached. The goal is checked as events come in and applied when the current game finishes.
+type SessionGoalHandler struct {
+	name      string
+	ctx       *botCtx.Context
+	logger    *slog.Logger
+	startedAt time.Time
+	runs      int
+	reached   string
+}
+
+func NewSessionGoalHandler(name string, ctx *botCtx.Context, logger *slog.Logger) *SessionGoalHandler {
+	return &SessionGoalHandler{
+		name:      name,
+		ctx:       ctx,
+		logger:    logger,
+		startedAt: time.Now(),
+	}
+}
+
+func (h *SessionGoalHandler) Handle(_ context.Context, e event.Event) error {
+	if !strings.EqualFold(e.Supervisor(), h.name) {
+		return nil
+	}
+
+	goals := h.ctx.CharacterCfg.SessionGoals
+	if !goals.Enabled {
+		return nil
+	}
+
+	switch evt := e.(type) {
+	case event.RunFinishedEvent:
+		h.runs++
+		if goals.MaxRuns > 0 && h.runs >= goals.MaxRuns {
+			h.reach(fmt.Sprintf("%d runs done", h.runs))
+		}
+		if goals.MaxMinutes > 0 && time.Since(h.startedAt) >= time.Duration(goals.MaxMinutes)*time.Minute {
+			h.reach(fmt.Sprintf("%d minutes played", goals.MaxMinutes))
+		}
+
+	case event.ExperienceGainedEvent:
+		if goals.Level > 0 && evt.Level >= goals.Level {
+			h.reach(fmt.Sprintf("level %d reached", evt.Level))
+		}
+
+	case event.ItemStashedEvent:
+		for _, name := range goals.Items {
+			if sessionGoalItemMatches(name, string(evt.Item.Item.Name)) || sessionGoalItemMatches(name, evt.Item.Item.IdentifiedName) {
+				h.reach(fmt.Sprintf("%s found", name))
+				break
+			}
+		}
+
+	case event.GameFinishedEvent:
+		if h.reached == "" {
+			return nil
+		}
+
+		if goals.SwitchTo != "" {
+			h.logger.Info("Session goal reached, switching supervisor", slog.String("goal", h.reached), slog.String("to", goals.SwitchTo))
+			h.ctx.RestartWithCharacter = goals.SwitchTo
+		} else {
+			h.logger.Info("Session goal reached, stopping", slog.String("goal", h.reached))
+		}
+		h.reached = ""
+		h.ctx.StopSupervisor()
+	}
+
+	return nil
+}
+
+// reach records the first goal reached and notifies it, the supervisor stops once the game is finished.
+func (h *SessionGoalHandler) reach(goal string) {
+	if h.reached != "" {
+		return
+	}
+
+	h.reached = goal
+	// Handlers run on the listener routine, the event can't be sent from it
+	go event.Send(event.SessionGoalReached(event.Text(h.name, fmt.Sprintf("Session goal reached: %s", goal)), goal))
+}
+
+func sessionGoalItemMatches(goal, name string) bool {
+	normalize := func(s string) string {
+		return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(s), " ", ""))
+	}
+
+	return name != "" && normalize(goal) == normalize(name)
+}
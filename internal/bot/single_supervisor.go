@@ -5,7 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
-	"math/rand"
+	"slices"
 	"strings"
 	"time"
 
@@ -36,6 +36,7 @@ const (
 
 type SinglePlayerSupervisor struct {
 	*baseSupervisor
+	difficultySamples []difficultySample
 }
 
 func (s *SinglePlayerSupervisor) GetData() *game.Data {
@@ -59,6 +60,12 @@ func NewSinglePlayerSupervisor(name string, bot *Bot, statsHandler *StatsHandler
 
 var ErrUnrecoverableClientState = errors.New("unrecoverable client state, forcing restart")
 
+// errWaitingInQueue is returned by the menu flow while we are in the realm queue, restarting the client loses the spot.
+var errWaitingInQueue = errors.New("waiting in queue")
+
+// errWaitingForRealm is returned by the menu flow while the realm is down, the wait doesn't count as a stuck client.
+var errWaitingForRealm = errors.New("waiting for the realm")
+
 func (s *SinglePlayerSupervisor) orderRuns(runs []string) []string {
 
 	if s.bot.ctx.CharacterCfg.Game.Difficulty == "Nightmare" {
@@ -130,6 +137,12 @@ func (s *SinglePlayerSupervisor) shouldSkipKeybindingsForRespec() bool {
 	if ctx == nil || ctx.CharacterCfg == nil {
 		return false
 	}
+	// The build switch rebinds the skills of the new build, the current bindings are about to change
+	if plan := ctx.CharacterCfg.Character.BuildSwitch; plan.Enabled && !plan.Applied && plan.Level > 0 {
+		if level, ok := ctx.Data.PlayerUnit.FindStat(stat.Level, 0); ok && level.Value >= plan.Level {
+			return true
+		}
+	}
 	if _, isLevelingChar := ctx.Char.(ct.LevelingCharacter); isLevelingChar {
 		return false
 	}
@@ -197,6 +210,8 @@ func (s *SinglePlayerSupervisor) Start() error {
 	}
 
 	// NORMAL MODE: Original code unchanged from here
+	go watchPickitFiles(ctx, s.bot.ctx)
+
 	firstRun := true
 	var timeSpentNotInGameStart = time.Now()
 	const maxTimeNotInGame = 3 * time.Minute
@@ -237,7 +252,15 @@ func (s *SinglePlayerSupervisor) Start() error {
 		}
 
 		// LOGIC OUTSIDE OF GAME (MENUS)
+		if !s.bot.ctx.Manager.InGame() && s.bot.ctx.SuspendRequested.Load() {
+			// Suspended between games, wait without creating a new one
+			utils.Sleep(1000)
+			timeSpentNotInGameStart = time.Now()
+			continue
+		}
 		if !s.bot.ctx.Manager.InGame() {
+			s.bot.ctx.Profiler.SetBase(ct.PhaseGameCreate)
+
 			// This outer timer is the ultimate watchdog. If the bot is out of game for too long,
 			// for any reason (including a frozen state read), this will trigger.
 			if time.Since(timeSpentNotInGameStart) > maxTimeNotInGame {
@@ -263,6 +286,15 @@ func (s *SinglePlayerSupervisor) Start() error {
 						s.bot.ctx.Logger.Error(fmt.Sprintf("Unrecoverable client state detected: %s. Forcing client restart.", err.Error()))
 						return err
 					}
+					if errors.Is(err, errWaitingInQueue) || errors.Is(err, errWaitingForRealm) {
+						timeSpentNotInGameStart = time.Now()
+						continue
+					}
+					if errors.Is(err, errWaitingForGameCreationSlot) {
+						timeSpentNotInGameStart = time.Now()
+						time.Sleep(gameCreationPollInterval)
+						continue
+					}
 					if err.Error() == "loading screen" || err.Error() == "" || err.Error() == "idle" {
 						utils.Sleep(100)
 						continue
@@ -283,7 +315,10 @@ func (s *SinglePlayerSupervisor) Start() error {
 
 		// In-game logic
 		timeSpentNotInGameStart = time.Now()
+		s.bot.ctx.Profiler.SetBase(ct.PhaseOther)
 
+		s.bot.ctx.ApplyPendingConfig()
+		s.bot.ctx.ApplyPendingPickit()
 		stringRuns := make([]string, len(s.bot.ctx.CharacterCfg.Game.Runs))
 		for i, r := range s.bot.ctx.CharacterCfg.Game.Runs {
 			stringRuns[i] = string(r)
@@ -292,13 +327,30 @@ func (s *SinglePlayerSupervisor) Start() error {
 		if orderedRuns == nil {
 			return nil
 		}
+		if len(s.bot.ctx.ResumeSkipRuns) > 0 {
+			if remaining := slices.DeleteFunc(slices.Clone(orderedRuns), func(r string) bool {
+				return slices.Contains(s.bot.ctx.ResumeSkipRuns, r)
+			}); len(remaining) > 0 {
+				s.bot.ctx.Logger.Info("Resuming the runs of the game that crashed", slog.Any("skipped", s.bot.ctx.ResumeSkipRuns))
+				orderedRuns = remaining
+			}
+			s.bot.ctx.ResumeSkipRuns = nil
+		}
+
+		if remaining := s.bot.runPolicy.startGame(orderedRuns); len(remaining) < len(orderedRuns) {
+			s.bot.ctx.Logger.Info("Skipping blacklisted runs", slog.Any("blacklisted", s.bot.runPolicy.state()))
+			orderedRuns = remaining
+		}
 
 		runs := run.BuildRuns(s.bot.ctx.CharacterCfg, orderedRuns)
+		if s.bot.ctx.TownIdleOnly {
+			runs = []run.Run{run.NewTownIdle()}
+		}
 		gameStart := time.Now()
 		cfg, _ := config.GetCharacter(s.name)
 
-		if cfg.Game.RandomizeRuns {
-			rand.Shuffle(len(runs), func(i, j int) { runs[i], runs[j] = runs[j], runs[i] })
+		if cfg.Game.RandomizeRuns || s.bot.ctx.Humanizer.ShuffleRuns() {
+			s.bot.ctx.Humanizer.Shuffle(len(runs), func(i, j int) { runs[i], runs[j] = runs[j], runs[i] })
 		}
 
 		event.Send(event.GameCreated(event.Text(s.name, "New game created"), s.bot.ctx.GameReader.LastGameName(), s.bot.ctx.GameReader.LastGamePass()))
@@ -433,6 +485,7 @@ func (s *SinglePlayerSupervisor) Start() error {
 
 					// Check for stat/skill allocation activities
 					isAllocating := lastAction == "AutoRespecIfNeeded" ||
+						lastAction == "SwitchBuildIfNeeded" ||
 						lastAction == "EnsureStatPoints" ||
 						lastAction == "EnsureSkillPoints" ||
 						lastAction == "EnsureSkillBindings" ||
@@ -530,12 +583,32 @@ func (s *SinglePlayerSupervisor) Start() error {
 				timeSpentNotInGameStart = time.Now()
 				continue
 			}
+			if errors.Is(err, ErrClientDegraded) {
+				s.bot.ctx.Logger.Info("Client degraded, restarting it from town")
+				s.bot.ctx.Manager.ExitGame()
+				s.bot.ctx.PlannedRestart = true
+				if killErr := s.KillClient(); killErr != nil {
+					s.bot.ctx.Logger.Error(fmt.Sprintf("Failed to kill degraded client: %s", killErr.Error()))
+				}
+				return ErrUnrecoverableClientState
+			}
+			if errors.Is(err, ErrSelfCheckRestart) {
+				s.bot.ctx.Logger.Warn("Self-check recovery ladder reached the client restart")
+				if killErr := s.KillClient(); killErr != nil {
+					s.bot.ctx.Logger.Error(fmt.Sprintf("Failed to kill client for the self-check restart: %s", killErr.Error()))
+				}
+				return ErrUnrecoverableClientState
+			}
 			if errors.Is(err, context.DeadlineExceeded) {
 				// We don't log the generic "Bot run finished with error" message if it was a planned timeout
 			} else {
 				s.bot.ctx.Logger.Info(fmt.Sprintf("Bot run finished with error: %s. Initiating game exit and cooldown.", err.Error()))
 			}
 
+			if errors.Is(err, health.ErrDied) || errors.Is(err, health.ErrChicken) || errors.Is(err, health.ErrMercChicken) {
+				action.ReportLostLoot(err.Error())
+			}
+
 			if exitErr := s.bot.ctx.Manager.ExitGame(); exitErr != nil {
 				s.bot.ctx.Logger.Error(fmt.Sprintf("Error trying to exit game: %s", exitErr.Error()))
 				return ErrUnrecoverableClientState
@@ -575,16 +648,24 @@ func (s *SinglePlayerSupervisor) Start() error {
 				gameFinishReason = event.FinishedMercChicken
 			case errors.Is(err, health.ErrDied):
 				gameFinishReason = event.FinishedDied
+			case errors.Is(err, action.ErrDangerousGame):
+				gameFinishReason = event.FinishedSkipped
 			default:
 				gameFinishReason = event.FinishedError
 			}
-			event.Send(event.GameFinished(event.WithScreenshot(s.name, err.Error(), s.bot.ctx.GameReader.Screenshot()), gameFinishReason))
+			if gameFinishReason == event.FinishedSkipped {
+				// Leaving a dangerous map roll is expected, there's nothing to see in a screenshot
+				event.Send(event.GameFinished(event.Text(s.name, err.Error()), gameFinishReason))
+			} else {
+				event.Send(event.GameFinished(event.WithScreenshot(s.name, err.Error(), s.bot.ctx.GameReader.Screenshot()), gameFinishReason))
+			}
 
 			s.bot.ctx.Logger.Warn(
 				fmt.Sprintf("Game finished with errors, reason: %s. Game total time: %0.2fs", err.Error(), time.Since(gameStart).Seconds()),
 				slog.String("supervisor", s.name),
 				slog.Uint64("mapSeed", uint64(s.bot.ctx.GameReader.MapSeed())),
 			)
+			s.adjustDifficulty(gameFinishReason == event.FinishedDied)
 			continue
 		}
 
@@ -609,6 +690,7 @@ func (s *SinglePlayerSupervisor) Start() error {
 		s.bot.ctx.Data.Areas = nil          // Clear context's map reference to allow GC
 		s.bot.ctx.Data.AreaData = game.AreaData{}
 		timeSpentNotInGameStart = time.Now()
+		s.adjustDifficulty(false)
 	}
 }
 
@@ -663,7 +745,11 @@ func (s *SinglePlayerSupervisor) ensureSkillKeyBindingsReady() error {
 			if errors.Is(err, ErrUnrecoverableClientState) {
 				return err
 			}
-			if err.Error() == "loading screen" || err.Error() == "" || err.Error() == "idle" {
+			if errors.Is(err, errWaitingForGameCreationSlot) {
+				time.Sleep(gameCreationPollInterval)
+				continue
+			}
+			if errors.Is(err, errWaitingInQueue) || err.Error() == "loading screen" || err.Error() == "" || err.Error() == "idle" {
 				utils.Sleep(100)
 				continue
 			}
@@ -709,7 +795,13 @@ func (s *SinglePlayerSupervisor) ensureSkillKeyBindingsReady() error {
 }
 
 // NEW HELPER FUNCTION that wraps a blocking operation with a timeout
+// Game creations and joins of all the supervisors are spaced by the game creation gate.
 func (s *SinglePlayerSupervisor) callManagerWithTimeout(fn func() error) error {
+	release, acquired := tryGameCreationSlot(s.name, s.bot.ctx.Logger)
+	if !acquired {
+		return errWaitingForGameCreationSlot
+	}
+
 	errChan := make(chan error, 1)
 	go func() {
 		errChan <- fn()
@@ -717,8 +809,14 @@ func (s *SinglePlayerSupervisor) callManagerWithTimeout(fn func() error) error {
 
 	select {
 	case err := <-errChan:
+		release()
 		return err
 	case <-time.After(menuActionTimeout):
+		// The slot is kept until the call really returns, it may still be creating the game
+		go func() {
+			<-errChan
+			release()
+		}()
 		return fmt.Errorf("menu action timed out after %s", menuActionTimeout)
 	}
 }
@@ -747,8 +845,26 @@ func (s *SinglePlayerSupervisor) HandleMenuFlow() error {
 		return s.bot.ctx.Manager.ExitGame()
 	}
 
-	isDismissableModalPresent, text := s.bot.ctx.GameReader.IsDismissableModalPresent()
-	if isDismissableModalPresent {
+	screen, text := s.bot.ctx.GameReader.ClassifyMenuScreen()
+	switch screen {
+	case game.MenuScreenLoading:
+		utils.Sleep(500)
+		return fmt.Errorf("loading screen")
+	case game.MenuScreenQueue:
+		if err := s.waitInQueue(text); err != nil {
+			return err
+		}
+		// Through the queue but still in the menus, the menu flow runs again from the screen we are on now
+		return fmt.Errorf("idle")
+	case game.MenuScreenRealmDown:
+		return s.waitForRealm(text)
+	case game.MenuScreenUnknown:
+		return s.recoverUnknownMenuScreen(text)
+	}
+	s.bot.ctx.CurrentGame.RealmDownRetries = 0
+	s.bot.ctx.CurrentGame.FailedMenuAttempts = 0
+
+	if screen == game.MenuScreenModal {
 		s.bot.ctx.Logger.Debug("[Menu Flow]: Detected dismissable modal with text: " + text)
 		s.bot.ctx.HID.PressKey(0x1B)
 		time.Sleep(1000 * time.Millisecond)
@@ -770,6 +886,59 @@ func (s *SinglePlayerSupervisor) HandleMenuFlow() error {
 		s.bot.ctx.CurrentGame.FailedToCreateGameAttempts = 0
 	}
 
+	return s.menuFlow()
+}
+
+// waitForRealm dismisses the realm down message and waits a bit longer every time before trying again, restarting
+// the client doesn't help while the realm is unavailable.
+func (s *SinglePlayerSupervisor) waitForRealm(text string) error {
+	s.bot.ctx.CurrentGame.RealmDownRetries++
+	wait := time.Duration(min(s.bot.ctx.CurrentGame.RealmDownRetries*10, 60)) * time.Second
+	s.bot.ctx.Logger.Warn("[Menu Flow]: Realm unavailable, waiting before trying again",
+		slog.String("message", text),
+		slog.Int("retries", s.bot.ctx.CurrentGame.RealmDownRetries),
+		slog.Duration("wait", wait),
+	)
+
+	s.bot.ctx.HID.PressKey(0x1B)
+	time.Sleep(wait)
+
+	return errWaitingForRealm
+}
+
+// recoverUnknownMenuScreen handles the screens we don't recognize. It's logged along with a screenshot and the usual
+// menu flow is tried from there, most of these screens are menus we just don't classify. The client is restarted when
+// the flow keeps failing.
+func (s *SinglePlayerSupervisor) recoverUnknownMenuScreen(text string) error {
+	if s.bot.ctx.CurrentGame.FailedMenuAttempts == 0 {
+		s.bot.ctx.Logger.Warn("[Menu Flow]: Unknown menu screen, trying the menu flow from it", slog.String("text", text))
+		event.Send(event.WithScreenshot(s.name, "Unknown menu screen", s.bot.ctx.GameReader.Screenshot()))
+	}
+
+	err := s.menuFlow()
+	if errors.Is(err, ErrUnrecoverableClientState) {
+		return err
+	}
+	if err == nil || errors.Is(err, errWaitingInQueue) || errors.Is(err, errWaitingForGameCreationSlot) {
+		s.bot.ctx.CurrentGame.FailedMenuAttempts = 0
+		return err
+	}
+
+	s.bot.ctx.CurrentGame.FailedMenuAttempts++
+	if s.bot.ctx.CurrentGame.FailedMenuAttempts >= s.bot.ctx.CharacterCfg.Game.MaxFailedMenuAttempts {
+		s.bot.ctx.Logger.Error(fmt.Sprintf("[Menu Flow]: Stuck in an unknown screen after %d attempts. Forcing client restart.", s.bot.ctx.CurrentGame.FailedMenuAttempts))
+		event.Send(event.WithScreenshot(s.name, "Stuck in an unknown menu screen", s.bot.ctx.GameReader.Screenshot()))
+		s.bot.ctx.CurrentGame.FailedMenuAttempts = 0
+		return ErrUnrecoverableClientState
+	}
+	s.bot.ctx.Logger.Debug("[Menu Flow]: Menu flow failed from an unknown menu screen", slog.Int("attempt", s.bot.ctx.CurrentGame.FailedMenuAttempts), slog.Any("error", err))
+	time.Sleep(2000 * time.Millisecond)
+
+	return err
+}
+
+// menuFlow runs the menu flow of the character, from the character selection screen to a game.
+func (s *SinglePlayerSupervisor) menuFlow() error {
 	if s.bot.ctx.CharacterCfg.Companion.Enabled && !s.bot.ctx.CharacterCfg.Companion.Leader {
 		return s.HandleCompanionMenuFlow()
 	}
@@ -780,7 +949,8 @@ func (s *SinglePlayerSupervisor) HandleMenuFlow() error {
 func (s *SinglePlayerSupervisor) HandleStandardMenuFlow() error {
 	atCharacterSelectionScreen := s.bot.ctx.GameReader.IsInCharacterSelectionScreen()
 
-	if atCharacterSelectionScreen && s.bot.ctx.CharacterCfg.AuthMethod != "None" && !s.bot.ctx.CharacterCfg.Game.CreateLobbyGames {
+	online := s.bot.ctx.CharacterCfg.Flavor().Online()
+	if atCharacterSelectionScreen && online && !s.bot.ctx.CharacterCfg.Game.CreateLobbyGames {
 		s.bot.ctx.Logger.Debug("[Menu Flow]: We're at the character selection screen, ensuring we're online ...")
 
 		err := s.ensureOnline()
@@ -793,7 +963,17 @@ func (s *SinglePlayerSupervisor) HandleStandardMenuFlow() error {
 		// USE THE NEW TIMEOUT FUNCTION
 		return s.callManagerWithTimeout(s.bot.ctx.Manager.NewGame)
 
-	} else if atCharacterSelectionScreen && s.bot.ctx.CharacterCfg.AuthMethod == "None" {
+	} else if atCharacterSelectionScreen && !online {
+		// TCP/IP games go through the host and join screens instead of the offline Play button
+		if s.bot.ctx.CharacterCfg.Flavor().Name() == config.FlavorTCP {
+			if host := s.bot.ctx.CharacterCfg.Game.Flavor.TCPHost; host != "" {
+				s.bot.ctx.Logger.Debug("[Menu Flow]: Joining the TCP/IP game ...", slog.String("host", host))
+				return s.callManagerWithTimeout(func() error { return s.bot.ctx.Manager.JoinTCPGame(host) })
+			}
+
+			s.bot.ctx.Logger.Debug("[Menu Flow]: Hosting a TCP/IP game ...")
+			return s.callManagerWithTimeout(s.bot.ctx.Manager.HostTCPGame)
+		}
 
 		s.bot.ctx.Logger.Debug("[Menu Flow]: Creating new game ...")
 		return s.callManagerWithTimeout(s.bot.ctx.Manager.NewGame)
@@ -904,9 +1084,21 @@ func (s *SinglePlayerSupervisor) createLobbyGame() error {
 		return err
 	}
 	err := s.callManagerWithTimeout(createGameFunc)
+	if errors.Is(err, errWaitingForGameCreationSlot) {
+		return err
+	}
+
+	if errors.Is(err, game.ErrGameNameTaken) && s.bot.ctx.CurrentGame.GameNameCollisions < s.bot.ctx.CharacterCfg.Companion.NameCollisionRetries {
+		// Someone else took the name, retry right away with the next one
+		s.bot.ctx.CharacterCfg.Game.PublicGameCounter++
+		s.bot.ctx.CurrentGame.GameNameCollisions++
+		s.bot.ctx.Logger.Info("[Menu Flow]: Game name already in use, retrying with a new name", slog.Int("collisions", s.bot.ctx.CurrentGame.GameNameCollisions))
+		return s.createLobbyGame()
+	}
 
 	if err != nil {
 		s.bot.ctx.CharacterCfg.Game.PublicGameCounter++
+		s.bot.ctx.CurrentGame.GameNameCollisions = 0
 		s.bot.ctx.CurrentGame.FailedToCreateGameAttempts++
 		const MAX_GAME_CREATE_ATTEMPTS = 5
 		if s.bot.ctx.CurrentGame.FailedToCreateGameAttempts >= MAX_GAME_CREATE_ATTEMPTS {
@@ -937,6 +1129,7 @@ func (s *SinglePlayerSupervisor) createLobbyGame() error {
 	s.bot.ctx.Logger.Debug("[Menu Flow]: Lobby game created successfully")
 	s.bot.ctx.CharacterCfg.Game.PublicGameCounter++
 	s.bot.ctx.CurrentGame.FailedToCreateGameAttempts = 0
+	s.bot.ctx.CurrentGame.GameNameCollisions = 0
 	return nil
 }
 
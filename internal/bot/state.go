@@ -0,0 +1,86 @@
+package bot
+
+import (
+	"time"
+
+	ct "github.com/hectorgimenez/koolo/internal/context"
+)
+
+// SupervisorState is where the supervisor is in its state machine: the run, the action and the step being executed
+// and since when, so the UI can show what the bot is doing and where it's stuck.
+type SupervisorState struct {
+	Supervisor  string           `json:"supervisor"`
+	Status      SupervisorStatus `json:"status"`
+	Priority    string           `json:"priority"`
+	Run         string           `json:"run"`
+	RunSince    time.Time        `json:"runSince"`
+	Action      string           `json:"action"`
+	ActionSince time.Time        `json:"actionSince"`
+	Step        string           `json:"step"`
+	StepSince   time.Time        `json:"stepSince"`
+	// InStateSeconds is the time since the last run/action/step change
+	InStateSeconds float64 `json:"inStateSeconds"`
+}
+
+// Changed compares the states without the time in state, used to only publish the changes.
+func (s SupervisorState) Changed(other SupervisorState) bool {
+	s.InStateSeconds, other.InStateSeconds = 0, 0
+	return s != other
+}
+
+// State returns the state of the supervisor, false when it's not running.
+func (mng *SupervisorManager) State(supervisor string) (SupervisorState, bool) {
+	sup, found := mng.supervisors[supervisor]
+	if !found {
+		return SupervisorState{}, false
+	}
+
+	stats := sup.Stats()
+	state := SupervisorState{Supervisor: supervisor, Status: stats.SupervisorStatus}
+	if len(stats.Games) > 0 {
+		if runs := stats.Games[len(stats.Games)-1].Runs; len(runs) > 0 && runs[len(runs)-1].FinishedAt.IsZero() {
+			state.Run = runs[len(runs)-1].Name
+			state.RunSince = runs[len(runs)-1].StartedAt
+		}
+	}
+
+	ctx := sup.GetContext()
+	if ctx == nil {
+		return state, true
+	}
+	state.Priority = ct.Priority(ctx.ExecutionPriority).String()
+
+	// The high priority routine (chicken, potions) is shown while it's in charge, the main routine otherwise
+	debug := ctx.ContextDebug[ct.PriorityNormal]
+	if d := ctx.ContextDebug[ctx.ExecutionPriority]; d != nil && d.LastAction != "" {
+		debug = d
+	}
+	if debug != nil {
+		state.Action, state.ActionSince = debug.LastAction, debug.ActionSince
+		state.Step, state.StepSince = debug.LastStep, debug.StepSince
+	}
+
+	since := state.RunSince
+	for _, t := range []time.Time{state.ActionSince, state.StepSince} {
+		if t.After(since) {
+			since = t
+		}
+	}
+	if !since.IsZero() {
+		state.InStateSeconds = time.Since(since).Seconds()
+	}
+
+	return state, true
+}
+
+// States returns the state of every running supervisor.
+func (mng *SupervisorManager) States() []SupervisorState {
+	states := make([]SupervisorState, 0, len(mng.supervisors))
+	for name := range mng.supervisors {
+		if state, found := mng.State(name); found {
+			states = append(states, state)
+		}
+	}
+
+	return states
+}
@@ -3,11 +3,15 @@ package bot
 import (
 	"context"
 	"log/slog"
+	"maps"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/hectorgimenez/d2go/pkg/data"
+	ct "github.com/hectorgimenez/koolo/internal/context"
 	"github.com/hectorgimenez/koolo/internal/event"
+	"github.com/hectorgimenez/koolo/internal/game"
 )
 
 const (
@@ -21,6 +25,8 @@ const (
 type SupervisorStatus string
 
 type StatsHandler struct {
+	// mu guards the stats, they're updated on the event listener routine and read by the web UI
+	mu     sync.Mutex
 	stats  *Stats
 	name   string
 	logger *slog.Logger
@@ -43,12 +49,17 @@ func (h *StatsHandler) Handle(_ context.Context, e event.Event) error {
 		return nil
 	}
 
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
 	switch evt := e.(type) {
 	case event.GameCreatedEvent:
 		h.stats.Games = append(h.stats.Games, GameStats{
 			StartedAt: evt.OccurredAt(),
 		})
 		h.stats.SupervisorStatus = InGame
+		h.stats.InQueue = false
+		h.stats.QueuePosition = 0
 
 	case event.GameFinishedEvent:
 		if len(h.stats.Games) > 0 {
@@ -78,6 +89,41 @@ func (h *StatsHandler) Handle(_ context.Context, e event.Event) error {
 			h.stats.SupervisorStatus = InGame
 		}
 
+	case event.QueueUpdatedEvent:
+		h.stats.InQueue = evt.InQueue
+		h.stats.QueuePosition = evt.Position
+
+	case event.GoldUpdatedEvent:
+		h.stats.Gold.update(evt.InventoryGold, evt.StashedGold, evt.MaxGold)
+
+	case event.WantedBasesUpdatedEvent:
+		h.stats.MissingBases = evt.Missing
+
+	case event.ExperienceGainedEvent:
+		h.stats.Experience.update(evt.RunName, evt.Gained, evt.Duration, evt.Level, evt.Nested, time.Since(h.stats.StartedAt))
+
+	case event.ActionFinishedEvent:
+		h.stats.Actions.update(evt.Action, evt.Duration, evt.Attempts, evt.Failed, evt.TimedOut)
+
+	case event.RunProfiledEvent:
+		h.stats.Profile.update(evt.RunName, evt.Phases)
+
+	case event.SelfCheckRecoveryEvent:
+		if h.stats.Recoveries == nil {
+			h.stats.Recoveries = make(map[string]int)
+		}
+		h.stats.Recoveries[evt.Step]++
+
+	case event.LootLostEvent:
+		for _, i := range evt.Items {
+			h.stats.LostLoot = append(h.stats.LostLoot, LostItem{
+				Name:   string(i.Name),
+				Area:   evt.Area.Area().Name,
+				Reason: evt.Reason,
+				LostAt: evt.OccurredAt(),
+			})
+		}
+
 	case event.ItemStashedEvent:
 		h.stats.Drops = append(h.stats.Drops, evt.Item)
 
@@ -91,8 +137,19 @@ func (h *StatsHandler) Handle(_ context.Context, e event.Event) error {
 	return nil
 }
 
+// Stats returns a copy of the stats, the maps are copied too so the caller can read them while the events update the
+// handler ones.
 func (h *StatsHandler) Stats() Stats {
-	return *h.stats
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s := *h.stats
+	s.Experience = h.stats.Experience.clone()
+	s.Actions = h.stats.Actions.clone()
+	s.Recoveries = maps.Clone(h.stats.Recoveries)
+	s.Profile = h.stats.Profile.clone()
+
+	return s
 }
 
 type Stats struct {
@@ -106,6 +163,221 @@ type Stats struct {
 	UI               CharacterOverview
 	MuleEnabled      bool `json:"muleEnabled"`
 	ManualModeActive bool `json:"manualModeActive"`
+	Gold             GoldFlow
+	Experience       ExperienceFlow
+	// LostLoot lists the valuable items left on the ground by a death or chicken and never recovered.
+	LostLoot []LostItem
+	// InQueue is set while waiting in the realm queue, QueuePosition is 0 when the game doesn't show it.
+	InQueue       bool
+	QueuePosition int
+	// Crashes counts the client crashes of the session, RecentCrashes the ones that count towards the restart backoff.
+	Crashes       int
+	RecentCrashes int
+	LastCrashAt   time.Time
+	// ClientHealth is the last resources sample of the client, Degraded clients are restarted at the next town visit.
+	ClientHealth game.ClientHealth
+	// Suspended is set while a safe suspend is requested, SuspendCheckpoint once the run got parked.
+	Suspended         bool
+	SuspendCheckpoint *ct.SuspendCheckpoint
+	// BlacklistedRuns are left out of the rotation after failing too many times in a row
+	BlacklistedRuns []BlacklistedRun
+	// MissingBases lists the bases still wanted for the enabled runewords, empty when the base collection is off
+	MissingBases []string
+	// Actions are the duration and outcome metrics of the actions run through the action middleware
+	Actions ActionMetrics
+	// Recoveries counts the self-check recovery steps walked, by step
+	Recoveries map[string]int
+	// Profile is the time spent by phase in every run type
+	Profile RunProfiles
+}
+
+// ExperienceFlow tracks the experience gained during the session, in total and by run type.
+type ExperienceFlow struct {
+	Gained   int64
+	PerHour  float64
+	Level    int
+	LevelUps int
+	ByRun    map[string]*RunExperienceStats
+}
+
+type RunExperienceStats struct {
+	Runs     int
+	Gained   int64
+	Duration time.Duration
+	PerHour  float64
+}
+
+func (e *ExperienceFlow) update(runName string, gained int64, duration time.Duration, level int, nested bool, elapsed time.Duration) {
+	if e.ByRun == nil {
+		e.ByRun = make(map[string]*RunExperienceStats)
+	}
+	if e.Level > 0 && level > e.Level {
+		e.LevelUps += level - e.Level
+	}
+	e.Level = level
+
+	r, found := e.ByRun[runName]
+	if !found {
+		r = &RunExperienceStats{}
+		e.ByRun[runName] = r
+	}
+	r.Runs++
+	r.Gained += gained
+	r.Duration += duration
+	if r.Duration > 0 {
+		r.PerHour = float64(r.Gained) / r.Duration.Hours()
+	}
+
+	if nested {
+		return
+	}
+	e.Gained += gained
+	if elapsed > 0 {
+		e.PerHour = float64(e.Gained) / elapsed.Hours()
+	}
+}
+
+func (e ExperienceFlow) clone() ExperienceFlow {
+	if e.ByRun == nil {
+		return e
+	}
+
+	byRun := make(map[string]*RunExperienceStats, len(e.ByRun))
+	for name, r := range e.ByRun {
+		rc := *r
+		byRun[name] = &rc
+	}
+	e.ByRun = byRun
+
+	return e
+}
+
+// ActionMetrics aggregates the action middleware results by action name.
+type ActionMetrics map[string]*ActionStats
+
+type ActionStats struct {
+	Count         int
+	Failures      int
+	Timeouts      int
+	Retries       int
+	TotalDuration time.Duration
+	MaxDuration   time.Duration
+}
+
+func (m *ActionMetrics) update(action string, duration time.Duration, attempts int, failed, timedOut bool) {
+	if *m == nil {
+		*m = make(ActionMetrics)
+	}
+
+	a, found := (*m)[action]
+	if !found {
+		a = &ActionStats{}
+		(*m)[action] = a
+	}
+	a.Count++
+	if failed {
+		a.Failures++
+	}
+	if timedOut {
+		a.Timeouts++
+	}
+	if attempts > 1 {
+		a.Retries += attempts - 1
+	}
+	a.TotalDuration += duration
+	a.MaxDuration = max(a.MaxDuration, duration)
+}
+
+func (m ActionMetrics) clone() ActionMetrics {
+	if m == nil {
+		return nil
+	}
+
+	c := make(ActionMetrics, len(m))
+	for name, a := range m {
+		ac := *a
+		c[name] = &ac
+	}
+
+	return c
+}
+
+// RunProfiles is the time spent by phase (travel, combat, pickup, town, game creation) by run type.
+type RunProfiles map[string]*RunProfile
+
+type RunProfile struct {
+	Runs   int
+	Total  time.Duration
+	Phases map[string]time.Duration
+}
+
+func (p *RunProfiles) update(runName string, phases map[string]time.Duration) {
+	if *p == nil {
+		*p = make(RunProfiles)
+	}
+
+	r, found := (*p)[runName]
+	if !found {
+		r = &RunProfile{Phases: make(map[string]time.Duration)}
+		(*p)[runName] = r
+	}
+	r.Runs++
+	for phase, d := range phases {
+		r.Phases[phase] += d
+		r.Total += d
+	}
+}
+
+func (p RunProfiles) clone() RunProfiles {
+	if p == nil {
+		return nil
+	}
+
+	c := make(RunProfiles, len(p))
+	for name, r := range p {
+		rc := *r
+		rc.Phases = maps.Clone(r.Phases)
+		c[name] = &rc
+	}
+
+	return c
+}
+
+type LostItem struct {
+	Name   string
+	Area   string
+	Reason string
+	LostAt time.Time
+}
+
+// GoldFlow tracks the total gold (inventory and stash) of the session, updated on every town visit.
+type GoldFlow struct {
+	Start   int
+	Current int
+	Earned  int
+	Spent   int
+	Carried int
+	// MaxCarried is the max gold the character can carry at its current level.
+	MaxCarried int
+	tracked    bool
+}
+
+func (g *GoldFlow) update(inventoryGold, stashedGold, maxGold int) {
+	total := inventoryGold + stashedGold
+	if !g.tracked {
+		g.Start = total
+		g.Current = total
+		g.tracked = true
+	}
+
+	if diff := total - g.Current; diff > 0 {
+		g.Earned += diff
+	} else {
+		g.Spent -= diff
+	}
+	g.Current = total
+	g.Carried = inventoryGold
+	g.MaxCarried = maxGold
 }
 
 type GameStats struct {
@@ -145,6 +417,8 @@ type CharacterOverview struct {
 	LightningResist int
 	PoisonResist    int
 	Gold            int
+	// Names of the active terror zones
+	TerrorZones []string
 }
 
 func (s Stats) TotalGames() int {
@@ -163,6 +437,11 @@ func (s Stats) TotalErrors() int {
 	return s.totalRunsByReason(event.FinishedError)
 }
 
+// TotalSkipped counts the runs left because of the danger rules.
+func (s Stats) TotalSkipped() int {
+	return s.totalRunsByReason(event.FinishedSkipped)
+}
+
 func (s Stats) totalRunsByReason(reason event.FinishReason) int {
 	total := 0
 	for _, g := range s.Games {
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hectorgimenez/koolo/internal/action"
 	"github.com/hectorgimenez/koolo/internal/config"
 	ct "github.com/hectorgimenez/koolo/internal/context"
 	"github.com/hectorgimenez/koolo/internal/event"
@@ -18,13 +19,24 @@ import (
 	"github.com/lxn/win"
 )
 
+const (
+	queueWaitTimeout     = 2 * time.Minute
+	queueRefreshInterval = 5 * time.Second
+)
+
 type Supervisor interface {
 	Start() error
 	Name() string
 	Stop()
 	Stats() Stats
 	TogglePause()
+	Suspend()
+	Resume()
+	Suspended() bool
+	ClearRunBlacklist()
 	SetWindowPosition(x, y int)
+	SetWindowBounds(x, y, width, height int)
+	WindowBounds() (x, y, width, height int)
 	GetData() *game.Data
 	GetContext() *ct.Context
 }
@@ -57,9 +69,15 @@ func (s *baseSupervisor) Stats() Stats {
 	if s.bot.ctx != nil {
 		stats.ManualModeActive = s.bot.ctx.ManualModeActive
 	}
+	stats.BlacklistedRuns = s.bot.runPolicy.state()
 	return stats
 }
 
+// ClearRunBlacklist puts the blacklisted runs back in the rotation and resets their failures.
+func (s *baseSupervisor) ClearRunBlacklist() {
+	s.bot.runPolicy.clear()
+}
+
 func (s *baseSupervisor) TogglePause() {
 	if s.bot.ctx.ExecutionPriority == ct.PriorityPause {
 		s.bot.ctx.MemoryInjector.Load()
@@ -74,6 +92,24 @@ func (s *baseSupervisor) TogglePause() {
 	}
 }
 
+// Suspend requests a safe suspend, the run finishes its current step, parks the character in town and waits. Out of
+// game no new game is created until resumed.
+func (s *baseSupervisor) Suspend() {
+	if s.bot.ctx.SuspendRequested.CompareAndSwap(false, true) {
+		s.bot.ctx.Logger.Info("Suspend requested", slog.String("configuration", s.name))
+	}
+}
+
+func (s *baseSupervisor) Resume() {
+	if s.bot.ctx.SuspendRequested.CompareAndSwap(true, false) {
+		s.bot.ctx.Logger.Info("Resume requested", slog.String("configuration", s.name))
+	}
+}
+
+func (s *baseSupervisor) Suspended() bool {
+	return s.bot.ctx.SuspendRequested.Load()
+}
+
 func (s *baseSupervisor) Stop() {
 	s.bot.ctx.Logger.Info("Stopping...", slog.String("configuration", s.name))
 	if s.cancelFn != nil {
@@ -135,7 +171,14 @@ func (s *baseSupervisor) waitUntilCharacterSelectionScreen() error {
 	s.bot.ctx.Logger.Info("Character selection screen found")
 	disconnected := false
 
-	if err := s.ensureOnline(); err != nil {
+	err := s.ensureOnline()
+	for errors.Is(err, errWaitingInQueue) {
+		_, text := s.bot.ctx.GameReader.ClassifyMenuScreen()
+		if err = s.waitInQueue(text); err == nil {
+			err = s.ensureOnline()
+		}
+	}
+	if err != nil {
 		s.bot.ctx.Logger.Error("[Ensure Online]: Failed to prepare for character selection, will kill client ...")
 		if err := s.KillClient(); err != nil {
 			s.bot.ctx.Logger.Error("[Ensure Online]: Failed to kill client", slog.String("error", err.Error()))
@@ -261,6 +304,49 @@ func (s *baseSupervisor) SetWindowPosition(x, y int) {
 	win.SetWindowPos(s.bot.ctx.GameReader.HWND, 0, int32(x), int32(y), 0, 0, uint32(uFlags))
 }
 
+// SetWindowBounds moves and resizes the game window, a zero width or height keeps the current size.
+func (s *baseSupervisor) SetWindowBounds(x, y, width, height int) {
+	uFlags := win.SWP_NOZORDER | win.SWP_NOACTIVATE
+	if width <= 0 || height <= 0 {
+		uFlags |= win.SWP_NOSIZE
+	}
+	win.SetWindowPos(s.bot.ctx.GameReader.HWND, 0, int32(x), int32(y), int32(width), int32(height), uint32(uFlags))
+}
+
+// WindowBounds returns the position and size of the game window.
+func (s *baseSupervisor) WindowBounds() (x, y, width, height int) {
+	var rect win.RECT
+	win.GetWindowRect(s.bot.ctx.GameReader.HWND, &rect)
+
+	return int(rect.Left), int(rect.Top), int(rect.Right - rect.Left), int(rect.Bottom - rect.Top)
+}
+
+// waitInQueue keeps the client in the realm queue, refreshing the position shown in the status, until we are through
+// or queueWaitTimeout goes by. It returns errWaitingInQueue while still queued so the caller can check again without
+// taking it as a failure.
+func (s *baseSupervisor) waitInQueue(text string) error {
+	deadline := time.Now().Add(queueWaitTimeout)
+	for {
+		position := game.QueuePosition(text)
+		s.bot.ctx.Logger.Info("Waiting in the realm queue", slog.Int("position", position))
+		event.Send(event.QueueUpdated(event.Text(s.name, "Waiting in the realm queue"), true, position))
+
+		action.AntiIdle()
+		time.Sleep(queueRefreshInterval)
+
+		var screen game.MenuScreen
+		screen, text = s.bot.ctx.GameReader.ClassifyMenuScreen()
+		if screen != game.MenuScreenQueue {
+			s.bot.ctx.Logger.Info("Through the realm queue")
+			event.Send(event.QueueUpdated(event.Text(s.name, "Through the realm queue"), false, 0))
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return errWaitingInQueue
+		}
+	}
+}
+
 func (s *baseSupervisor) ensureOnline() error {
 	if !s.bot.ctx.GameReader.IsInCharacterSelectionScreen() {
 		return fmt.Errorf("[Ensure Online]: We're not in the character selection screen")
@@ -289,6 +375,12 @@ func (s *baseSupervisor) ensureOnline() error {
 				}
 
 				if popuPanel.PanelName != "" && popuPanel.PanelEnabled && popuPanel.PanelVisible {
+					if screen, text := s.bot.ctx.GameReader.ClassifyMenuScreen(); screen == game.MenuScreenQueue {
+						if err := s.waitInQueue(text); err != nil {
+							return err
+						}
+						break
+					}
 					s.bot.ctx.Logger.Debug("[Ensure Online]: Dismissable modal detected, dismissing it and trying to connect again ...")
 					s.bot.ctx.HID.PressKey(0x1B)
 					time.Sleep(1000 * time.Millisecond)
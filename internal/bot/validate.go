@@ -0,0 +1,30 @@
+package bot
+
+import (
+	"github.com/hectorgimenez/koolo/internal/character"
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/context"
+)
+
+// ValidateConfigs reports every problem found in the configuration, including the character builds that can't be
+// created, without starting any client.
+func ValidateConfigs() []config.ValidationIssue {
+	return config.ValidateConfigs(validateCharacterBuild)
+}
+
+func validateCharacterBuild(cfg *config.CharacterCfg) []config.ValidationIssue {
+	if cfg.Character.Class == "" {
+		return nil
+	}
+
+	if _, err := character.BuildCharacter(&context.Context{CharacterCfg: cfg}); err != nil {
+		return []config.ValidationIssue{{
+			Character: cfg.ConfigFolderName,
+			Severity:  config.SeverityError,
+			Field:     "character.class",
+			Message:   err.Error(),
+		}}
+	}
+
+	return nil
+}
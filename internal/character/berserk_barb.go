@@ -2,7 +2,6 @@ package character
 
 import (
 	"log/slog"
-	"sort"
 	"sync/atomic"
 	"time"
 
@@ -12,7 +11,6 @@ import (
 	"github.com/hectorgimenez/d2go/pkg/data/npc"
 	"github.com/hectorgimenez/d2go/pkg/data/skill"
 	"github.com/hectorgimenez/d2go/pkg/data/stat"
-	"github.com/hectorgimenez/d2go/pkg/data/state"
 	"github.com/hectorgimenez/koolo/internal/action/step"
 	"github.com/hectorgimenez/koolo/internal/context"
 	"github.com/hectorgimenez/koolo/internal/game"
@@ -400,70 +398,11 @@ func (s *Berserker) FindItemOnNearbyCorpses(maxRange int) {
 }
 
 func (s *Berserker) getHorkableCorpses(corpses data.Monsters, maxRange int) []data.Monster {
-	type corpseWithDistance struct {
-		corpse   data.Monster
-		distance int
-	}
-	var horkableCorpses []corpseWithDistance
-	maxCorpsesToCheck := 30
-	corpsesToCheck := corpses
-	if len(corpsesToCheck) > maxCorpsesToCheck {
-		corpsesToCheck = corpsesToCheck[:maxCorpsesToCheck]
-	}
-
-	for _, corpse := range corpsesToCheck {
-		if !s.isCorpseHorkable(corpse) {
-			continue
-		}
-		distance := s.PathFinder.DistanceFromMe(corpse.Position)
-		if distance <= maxRange {
-			horkableCorpses = append(horkableCorpses, corpseWithDistance{corpse: corpse, distance: distance})
-		}
-	}
-
-	if len(horkableCorpses) > 1 {
-		sort.Slice(horkableCorpses, func(i, j int) bool {
-			return horkableCorpses[i].distance < horkableCorpses[j].distance
-		})
-	}
-
-	result := make([]data.Monster, len(horkableCorpses))
-	for i, cwd := range horkableCorpses {
-		result[i] = cwd.corpse
-	}
-
-	return result
+	return findHorkableCorpses(s.PathFinder, corpses, maxRange, s.CharacterCfg.Character.BerserkerBarb.HorkNormalMonsters)
 }
 
 func (s *Berserker) isCorpseHorkable(corpse data.Monster) bool {
-	unhorkableStates := []state.State{
-		state.CorpseNoselect,
-		state.CorpseNodraw,
-		state.Revive,
-		state.Redeemed,
-		state.Shatter,
-		state.Freeze,
-		state.Restinpeace,
-	}
-
-	for _, st := range unhorkableStates {
-		if corpse.States.HasState(st) {
-			return false
-		}
-	}
-
-	if corpse.Type == data.MonsterTypeMinion ||
-		corpse.Type == data.MonsterTypeChampion ||
-		corpse.Type == data.MonsterTypeUnique ||
-		corpse.Type == data.MonsterTypeSuperUnique {
-		return true
-	}
-
-	if s.CharacterCfg.Character.BerserkerBarb.HorkNormalMonsters {
-		return true
-	}
-
-	return false
+	return corpseHorkable(corpse, s.CharacterCfg.Character.BerserkerBarb.HorkNormalMonsters)
 }
 
 func (s *Berserker) getOptimalClickPosition(corpse data.Monster) data.Position {
@@ -8,6 +8,8 @@ import (
 	"github.com/hectorgimenez/d2go/pkg/data"
 	"github.com/hectorgimenez/d2go/pkg/data/npc"
 	"github.com/hectorgimenez/d2go/pkg/data/stat"
+	"github.com/hectorgimenez/koolo/internal/action"
+	"github.com/hectorgimenez/koolo/internal/action/step"
 	"github.com/hectorgimenez/koolo/internal/context"
 )
 
@@ -62,16 +64,24 @@ func BuildCharacter(ctx *context.Context) (context.Character, error) {
 		return Trapsin{BaseCharacter: bc}, nil
 	case "mosaic":
 		return MosaicSin{BaseCharacter: bc}, nil
+	case "hybrid_trapsin":
+		return &HybridTrapsin{BaseCharacter: bc}, nil
 	case "winddruid":
 		return WindDruid{BaseCharacter: bc}, nil
 	case "javazon":
-		return Javazon{BaseCharacter: bc}, nil
+		return Javazon{BaseCharacter: bc, pets: &jzPetState{}}, nil
 	case "berserker":
 		return &Berserker{BaseCharacter: bc}, nil // Return a pointer to Berserker
 	case "warcry_barb":
 		return &WarcryBarb{BaseCharacter: bc}, nil
 	case "whirlwind_barb":
 		return &WhirlwindBarb{BaseCharacter: bc}, nil
+	case "frenzy_barb":
+		return &FrenzyBarb{BaseCharacter: bc}, nil
+	case "poison_bone_necro":
+		return &PoisonBoneNecro{BaseCharacter: bc}, nil
+	case "custom_rotation":
+		return newRotationCharacter(bc)
 	case "development":
 		return DevelopmentCharacter{BaseCharacter: bc}, nil
 	}
@@ -145,5 +155,9 @@ func (bc BaseCharacter) preBattleChecks(id data.UnitID, skipOnImmunities []stat.
 		}
 	}
 
-	return true
+	// Step out of Fire Enchanted/suicide explosions before attacking again
+	step.AvoidDeathHazards()
+	step.DodgeHazards()
+
+	return action.BossFightTick(monster)
 }
@@ -0,0 +1,375 @@
+package character
+
+import (
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/npc"
+	"github.com/hectorgimenez/d2go/pkg/data/skill"
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+	"github.com/hectorgimenez/d2go/pkg/data/state"
+	"github.com/hectorgimenez/koolo/internal/action"
+	"github.com/hectorgimenez/koolo/internal/action/step"
+	"github.com/hectorgimenez/koolo/internal/context"
+	"github.com/hectorgimenez/koolo/internal/game"
+	"github.com/hectorgimenez/koolo/internal/utils"
+)
+
+const (
+	frenzyBarbWhirlwindRadius       = 5
+	frenzyBarbWhirlwindOvershoot    = 4
+	frenzyBarbDefaultWWMinMonsters  = 3
+	frenzyBarbPartyBuffRange        = 15
+	frenzyBarbPartyBuffInterval     = 60 * time.Second
+	frenzyBarbDefaultHorkCheckRange = 7
+)
+
+var _ context.Character = (*FrenzyBarb)(nil)
+
+// FrenzyBarb uses Frenzy on single targets and Whirlwind through packs, horking elite and boss corpses afterward.
+type FrenzyBarb struct {
+	BaseCharacter
+	horkedCorpses   map[data.UnitID]bool
+	lastPartyBuffAt time.Time
+}
+
+func (s *FrenzyBarb) isBound(sk skill.ID) bool {
+	_, found := s.Data.KeyBindings.KeyBindingForSkill(sk)
+	return found
+}
+
+func (s *FrenzyBarb) ShouldIgnoreMonster(m data.Monster) bool {
+	return false
+}
+
+func (s *FrenzyBarb) CheckKeyBindings() []skill.ID {
+	requireKeybindings := []skill.ID{skill.Frenzy, skill.Whirlwind, skill.BattleOrders, skill.FindItem, skill.TomeOfTownPortal}
+	missingKeybindings := []skill.ID{}
+
+	for _, cskill := range requireKeybindings {
+		if !s.isBound(cskill) {
+			missingKeybindings = append(missingKeybindings, cskill)
+		}
+	}
+
+	if len(missingKeybindings) > 0 {
+		s.Logger.Debug("There are missing required key bindings.", slog.Any("Bindings", missingKeybindings))
+	}
+
+	return missingKeybindings
+}
+
+func (s *FrenzyBarb) BuffSkills() []skill.ID {
+	skillsList := make([]skill.ID, 0)
+	for _, sk := range []skill.ID{skill.BattleCommand, skill.BattleOrders, skill.Shout} {
+		if s.isBound(sk) {
+			skillsList = append(skillsList, sk)
+		}
+	}
+
+	return skillsList
+}
+
+func (s *FrenzyBarb) PreCTABuffSkills() []skill.ID {
+	return []skill.ID{}
+}
+
+// ensureWarcries recasts Battle Orders/Shout when they dropped, and periodically when party members are around so
+// they also get the buffs.
+func (s *FrenzyBarb) ensureWarcries() {
+	partyNearby := false
+	if s.CharacterCfg.Character.FrenzyBarb.BuffParty && time.Since(s.lastPartyBuffAt) > frenzyBarbPartyBuffInterval {
+		for _, member := range s.Data.Roster {
+			if member.Name == s.Data.PlayerUnit.Name || member.Area != s.Data.PlayerUnit.Area {
+				continue
+			}
+			if s.PathFinder.DistanceFromMe(member.Position) <= frenzyBarbPartyBuffRange {
+				partyNearby = true
+				break
+			}
+		}
+	}
+
+	warcries := []struct {
+		skill skill.ID
+		state state.State
+	}{
+		{skill.BattleOrders, state.Battleorders},
+		{skill.Shout, state.Shout},
+	}
+
+	casted := false
+	for _, wc := range warcries {
+		if !s.isBound(wc.skill) {
+			continue
+		}
+		if s.Data.PlayerUnit.States.HasState(wc.state) && !partyNearby {
+			continue
+		}
+
+		if step.CastAtPosition(wc.skill, true, s.Data.PlayerUnit.Position) {
+			casted = true
+			utils.Sleep(300)
+		}
+	}
+
+	if casted && partyNearby {
+		s.lastPartyBuffAt = time.Now()
+	}
+}
+
+func (s *FrenzyBarb) enemiesAround(pos data.Position, radius int) []data.Monster {
+	enemies := make([]data.Monster, 0)
+	for _, m := range s.Data.Monsters.Enemies() {
+		if m.Stats[stat.Life] <= 0 {
+			continue
+		}
+		if gridDistance(pos, m.Position) <= radius {
+			enemies = append(enemies, m)
+		}
+	}
+
+	return enemies
+}
+
+// whirlwindThrough casts Whirlwind to a spot past the pack center, so we go through the pack instead of stopping
+// in front of it.
+func (s *FrenzyBarb) whirlwindThrough(pack []data.Monster) bool {
+	center := centroidOf(pack)
+	playerPos := s.Data.PlayerUnit.Position
+
+	dx := float64(center.X - playerPos.X)
+	dy := float64(center.Y - playerPos.Y)
+	length := math.Hypot(dx, dy)
+	if length == 0 {
+		dx, length = 1, 1
+	}
+
+	dest := data.Position{
+		X: center.X + int(math.Round(dx/length*frenzyBarbWhirlwindOvershoot)),
+		Y: center.Y + int(math.Round(dy/length*frenzyBarbWhirlwindOvershoot)),
+	}
+	if !s.Data.AreaData.IsWalkable(dest) {
+		dest = center
+	}
+
+	return step.CastAtPosition(skill.Whirlwind, false, dest)
+}
+
+func (s *FrenzyBarb) KillMonsterSequence(
+	monsterSelector func(d game.Data) (data.UnitID, bool),
+	skipOnImmunities []stat.Resist,
+) error {
+	monsterDetected := false
+	var previousEnemyId data.UnitID
+
+	minPack := s.CharacterCfg.Character.FrenzyBarb.WhirlwindMinMonsters
+	if minPack <= 0 {
+		minPack = frenzyBarbDefaultWWMinMonsters
+	}
+
+	for attackAttempts := 0; attackAttempts < maxAttackAttempts; attackAttempts++ {
+		context.Get().PauseIfNotPriority()
+
+		id, found := monsterSelector(*s.Data)
+		if !found {
+			break
+		}
+
+		if id != previousEnemyId {
+			previousEnemyId = id
+			attackAttempts = 0
+		}
+
+		monsterDetected = true
+
+		if !s.preBattleChecks(id, skipOnImmunities) {
+			return nil
+		}
+
+		monster, found := s.Data.Monsters.FindByID(id)
+		if !found || monster.Stats[stat.Life] <= 0 {
+			continue
+		}
+
+		s.ensureWarcries()
+
+		pack := s.enemiesAround(monster.Position, frenzyBarbWhirlwindRadius)
+		if len(pack) >= minPack && s.isBound(skill.Whirlwind) {
+			if s.whirlwindThrough(pack) {
+				time.Sleep(50 * time.Millisecond)
+				continue
+			}
+		}
+
+		if err := step.MeleeAttack(id, step.WithMeleeSkill(skill.Frenzy), step.WithMeleeAttacks(2)); err != nil {
+			s.Logger.Debug("Frenzy attack failed", slog.String("error", err.Error()))
+		}
+	}
+
+	if monsterDetected {
+		s.horkAfterFight()
+	}
+
+	return nil
+}
+
+// horkAfterFight uses Find Item on elite/boss corpses once the area is safe, and picks up whatever they dropped
+// since the regular pickup already ran for the original drops.
+func (s *FrenzyBarb) horkAfterFight() {
+	checkRange := s.CharacterCfg.Character.FrenzyBarb.HorkMonsterCheckRange
+	if checkRange <= 0 {
+		checkRange = frenzyBarbDefaultHorkCheckRange
+	}
+
+	if len(s.enemiesAround(s.Data.PlayerUnit.Position, checkRange)) > safeMonstersForHork {
+		return
+	}
+
+	if s.findItemOnCorpses(maxHorkRange) == 0 {
+		return
+	}
+
+	if err := action.ItemPickup(maxHorkRange); err != nil {
+		s.Logger.Warn("Failed to pick up items after Find Item", slog.String("error", err.Error()))
+	}
+}
+
+// findItemOnCorpses returns the amount of corpses horked.
+func (s *FrenzyBarb) findItemOnCorpses(maxRange int) int {
+	ctx := context.Get()
+
+	if !s.isBound(skill.FindItem) {
+		return 0
+	}
+
+	if s.horkedCorpses == nil {
+		s.horkedCorpses = make(map[data.UnitID]bool)
+	}
+
+	corpses := findHorkableCorpses(s.PathFinder, s.Data.Corpses, maxRange, s.CharacterCfg.Character.FrenzyBarb.HorkNormalMonsters)
+
+	horked := 0
+	for _, corpse := range corpses {
+		ctx.PauseIfNotPriority()
+		if s.horkedCorpses[corpse.UnitID] {
+			continue
+		}
+
+		if s.PathFinder.DistanceFromMe(corpse.Position) > findItemRange {
+			if err := step.MoveTo(corpse.Position, step.WithIgnoreMonsters(), step.WithDistanceToFinish(findItemRange)); err != nil {
+				continue
+			}
+		}
+
+		// Click slightly below the corpse position, same as the other barbs
+		clickPos := data.Position{X: corpse.Position.X, Y: corpse.Position.Y + 1}
+		if step.CastAtPosition(skill.FindItem, true, clickPos) {
+			horked++
+		}
+
+		s.horkedCorpses[corpse.UnitID] = true
+		time.Sleep(200 * time.Millisecond)
+	}
+
+	return horked
+}
+
+func (s *FrenzyBarb) killMonster(npc npc.ID, t data.MonsterType) error {
+	return s.KillMonsterSequence(func(d game.Data) (data.UnitID, bool) {
+		m, found := d.Monsters.FindOne(npc, t)
+		if !found {
+			return 0, false
+		}
+
+		return m.UnitID, true
+	}, nil)
+}
+
+func (s *FrenzyBarb) KillCountess() error {
+	return s.killMonster(npc.DarkStalker, data.MonsterTypeSuperUnique)
+}
+
+func (s *FrenzyBarb) KillAndariel() error {
+	return s.killMonster(npc.Andariel, data.MonsterTypeUnique)
+}
+
+func (s *FrenzyBarb) KillSummoner() error {
+	return s.killMonster(npc.Summoner, data.MonsterTypeUnique)
+}
+
+func (s *FrenzyBarb) KillDuriel() error {
+	return s.killMonster(npc.Duriel, data.MonsterTypeUnique)
+}
+
+func (s *FrenzyBarb) KillCouncil() error {
+	return s.KillMonsterSequence(func(d game.Data) (data.UnitID, bool) {
+		var closest data.Monster
+		closestDistance := 0
+		for _, m := range d.Monsters.Enemies() {
+			if m.Name != npc.CouncilMember && m.Name != npc.CouncilMember2 && m.Name != npc.CouncilMember3 {
+				continue
+			}
+
+			distance := s.PathFinder.DistanceFromMe(m.Position)
+			if closest.UnitID == 0 || distance < closestDistance {
+				closest = m
+				closestDistance = distance
+			}
+		}
+
+		return closest.UnitID, closest.UnitID != 0
+	}, nil)
+}
+
+func (s *FrenzyBarb) KillMephisto() error {
+	return s.killMonster(npc.Mephisto, data.MonsterTypeUnique)
+}
+
+func (s *FrenzyBarb) KillIzual() error {
+	return s.killMonster(npc.Izual, data.MonsterTypeUnique)
+}
+
+func (s *FrenzyBarb) KillDiablo() error {
+	timeout := time.Second * 20
+	startTime := time.Now()
+	diabloFound := false
+
+	for {
+		if time.Since(startTime) > timeout && !diabloFound {
+			s.Logger.Error("Diablo was not found, timeout reached")
+			return nil
+		}
+
+		diablo, found := s.Data.Monsters.FindOne(npc.Diablo, data.MonsterTypeUnique)
+		if !found || diablo.Stats[stat.Life] <= 0 {
+			// Already dead
+			if diabloFound {
+				return nil
+			}
+
+			// Keep waiting...
+			time.Sleep(200 * time.Millisecond)
+			continue
+		}
+
+		diabloFound = true
+		s.Logger.Info("Diablo detected, attacking")
+
+		return s.killMonster(npc.Diablo, data.MonsterTypeUnique)
+	}
+}
+
+func (s *FrenzyBarb) KillPindle() error {
+	return s.killMonster(npc.DefiledWarrior, data.MonsterTypeSuperUnique)
+}
+
+func (s *FrenzyBarb) KillNihlathak() error {
+	return s.killMonster(npc.Nihlathak, data.MonsterTypeSuperUnique)
+}
+
+func (s *FrenzyBarb) KillBaal() error {
+	return s.killMonster(npc.BaalCrab, data.MonsterTypeUnique)
+}
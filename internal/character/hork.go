@@ -0,0 +1,74 @@
+package character
+
+import (
+	"sort"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/state"
+	"github.com/hectorgimenez/koolo/internal/pather"
+)
+
+// maxCorpsesToCheck caps the corpses looked at for Find Item, a full screen of corpses is plenty.
+const maxCorpsesToCheck = 30
+
+var unhorkableStates = []state.State{
+	state.CorpseNoselect,
+	state.CorpseNodraw,
+	state.Revive,
+	state.Redeemed,
+	state.Shatter,
+	state.Freeze,
+	state.Restinpeace,
+}
+
+// corpseHorkable tells if Find Item can be used on the corpse, the normal monsters only when horkNormal is set.
+func corpseHorkable(corpse data.Monster, horkNormal bool) bool {
+	for _, st := range unhorkableStates {
+		if corpse.States.HasState(st) {
+			return false
+		}
+	}
+
+	if corpse.Type == data.MonsterTypeMinion ||
+		corpse.Type == data.MonsterTypeChampion ||
+		corpse.Type == data.MonsterTypeUnique ||
+		corpse.Type == data.MonsterTypeSuperUnique {
+		return true
+	}
+
+	return horkNormal
+}
+
+// findHorkableCorpses returns the horkable corpses within maxRange of the player, closest first.
+func findHorkableCorpses(pf *pather.PathFinder, corpses data.Monsters, maxRange int, horkNormal bool) []data.Monster {
+	type corpseWithDistance struct {
+		corpse   data.Monster
+		distance int
+	}
+	var horkableCorpses []corpseWithDistance
+	corpsesToCheck := corpses
+	if len(corpsesToCheck) > maxCorpsesToCheck {
+		corpsesToCheck = corpsesToCheck[:maxCorpsesToCheck]
+	}
+
+	for _, corpse := range corpsesToCheck {
+		if !corpseHorkable(corpse, horkNormal) {
+			continue
+		}
+		distance := pf.DistanceFromMe(corpse.Position)
+		if distance <= maxRange {
+			horkableCorpses = append(horkableCorpses, corpseWithDistance{corpse: corpse, distance: distance})
+		}
+	}
+
+	sort.Slice(horkableCorpses, func(i, j int) bool {
+		return horkableCorpses[i].distance < horkableCorpses[j].distance
+	})
+
+	result := make([]data.Monster, len(horkableCorpses))
+	for i, cwd := range horkableCorpses {
+		result[i] = cwd.corpse
+	}
+
+	return result
+}
@@ -0,0 +1,371 @@
+package character
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/area"
+	"github.com/hectorgimenez/d2go/pkg/data/npc"
+	"github.com/hectorgimenez/d2go/pkg/data/skill"
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+	"github.com/hectorgimenez/d2go/pkg/data/state"
+	"github.com/hectorgimenez/koolo/internal/action/step"
+	"github.com/hectorgimenez/koolo/internal/context"
+	"github.com/hectorgimenez/koolo/internal/game"
+	"github.com/hectorgimenez/koolo/internal/utils"
+)
+
+const (
+	hybridTrapsinMaxAttacksLoop   = 15
+	hybridTrapsinMaxTraps         = 5
+	hybridTrapsinTrapLifetime     = 10 * time.Second
+	hybridTrapsinTrapMinDistance  = 5
+	hybridTrapsinTrapMaxDistance  = 20
+	hybridTrapsinKickDistance     = 3
+	hybridTrapsinDragonFlightMax  = 15
+	hybridTrapsinDeathSentryRange = 8
+
+	// Default amount of corpses around the target needed before preferring Death Sentry over Lightning Sentry.
+	hybridTrapsinDefaultDeathSentryMinCorpses = 2
+)
+
+var _ context.Character = (*HybridTrapsin)(nil)
+
+// HybridTrapsin lays traps before engaging and finishes the targets with Dragon Talon kicks.
+type HybridTrapsin struct {
+	BaseCharacter
+	traps              trapTracker
+	lastDragonFlightAt time.Time
+}
+
+// trapTracker counts the traps laid by us. d2go filters sentries out of the monster list, so the active traps are
+// tracked from our own casts and expired after the trap lifetime or when changing areas.
+type trapTracker struct {
+	area   area.ID
+	placed []time.Time
+}
+
+func (t *trapTracker) active(currentArea area.ID) int {
+	if t.area != currentArea {
+		t.area = currentArea
+		t.placed = t.placed[:0]
+		return 0
+	}
+
+	alive := t.placed[:0]
+	for _, placedAt := range t.placed {
+		if time.Since(placedAt) < hybridTrapsinTrapLifetime {
+			alive = append(alive, placedAt)
+		}
+	}
+	t.placed = alive
+
+	return len(t.placed)
+}
+
+func (t *trapTracker) add(currentArea area.ID) {
+	if t.area != currentArea {
+		t.area = currentArea
+		t.placed = t.placed[:0]
+	}
+
+	t.placed = append(t.placed, time.Now())
+	// Oldest trap disappears when a new one is laid over the limit
+	if len(t.placed) > hybridTrapsinMaxTraps {
+		t.placed = t.placed[len(t.placed)-hybridTrapsinMaxTraps:]
+	}
+}
+
+func (s *HybridTrapsin) isBound(sk skill.ID) bool {
+	_, found := s.Data.KeyBindings.KeyBindingForSkill(sk)
+	return found
+}
+
+func (s *HybridTrapsin) ShouldIgnoreMonster(m data.Monster) bool {
+	return false
+}
+
+func (s *HybridTrapsin) CheckKeyBindings() []skill.ID {
+	requireKeybindings := []skill.ID{skill.DragonTalon, skill.LightningSentry, skill.DeathSentry, skill.TomeOfTownPortal}
+	missingKeybindings := []skill.ID{}
+
+	for _, cskill := range requireKeybindings {
+		if !s.isBound(cskill) {
+			missingKeybindings = append(missingKeybindings, cskill)
+		}
+	}
+
+	if s.CharacterCfg.Character.HybridTrapsin.UseDragonFlight && !s.isBound(skill.DragonFlight) {
+		missingKeybindings = append(missingKeybindings, skill.DragonFlight)
+	}
+
+	if len(missingKeybindings) > 0 {
+		s.Logger.Debug("There are missing required key bindings.", slog.Any("Bindings", missingKeybindings))
+	}
+
+	return missingKeybindings
+}
+
+// speedBuff returns the configured Fade/Burst of Speed buff, they don't stack so only one of them is used.
+func (s *HybridTrapsin) speedBuff() (skill.ID, state.State, bool) {
+	if s.CharacterCfg.Character.HybridTrapsin.UseBurstOfSpeed && s.isBound(skill.BurstOfSpeed) {
+		return skill.BurstOfSpeed, state.Quickness, true
+	}
+	if s.isBound(skill.Fade) {
+		return skill.Fade, state.Fade, true
+	}
+	if s.isBound(skill.BurstOfSpeed) {
+		return skill.BurstOfSpeed, state.Quickness, true
+	}
+
+	return 0, 0, false
+}
+
+func (s *HybridTrapsin) BuffSkills() []skill.ID {
+	buffs := []skill.ID{}
+	if buff, _, found := s.speedBuff(); found {
+		buffs = append(buffs, buff)
+	}
+	if s.isBound(skill.BladeShield) {
+		buffs = append(buffs, skill.BladeShield)
+	}
+
+	return buffs
+}
+
+func (s *HybridTrapsin) PreCTABuffSkills() []skill.ID {
+	for _, shadow := range []skill.ID{skill.ShadowMaster, skill.ShadowWarrior} {
+		if s.isBound(shadow) {
+			return []skill.ID{shadow}
+		}
+	}
+
+	return []skill.ID{}
+}
+
+// ensureSpeedBuff recasts Fade/Burst of Speed if it expired during the fight.
+func (s *HybridTrapsin) ensureSpeedBuff() {
+	buff, buffState, found := s.speedBuff()
+	if !found || s.Data.PlayerUnit.States.HasState(buffState) {
+		return
+	}
+
+	if step.CastAtPosition(buff, true, s.Data.PlayerUnit.Position) {
+		utils.Sleep(200)
+	}
+}
+
+func (s *HybridTrapsin) corpsesNear(pos data.Position, radius int) int {
+	count := 0
+	for _, c := range s.Data.Corpses {
+		if c.IsMerc() || c.States.HasState(state.CorpseNoselect) {
+			continue
+		}
+		if gridDistance(pos, c.Position) <= radius {
+			count++
+		}
+	}
+
+	return count
+}
+
+// layTraps tops up the traps around the target, Death Sentry is preferred when there are enough corpses to chain
+// explosions, Lightning Sentry otherwise. Returns true if any trap was laid.
+func (s *HybridTrapsin) layTraps(monster data.Monster) bool {
+	missing := hybridTrapsinMaxTraps - s.traps.active(s.Data.PlayerUnit.Area)
+	if missing <= 0 {
+		return false
+	}
+
+	minCorpses := s.CharacterCfg.Character.HybridTrapsin.DeathSentryMinCorpses
+	if minCorpses <= 0 {
+		minCorpses = hybridTrapsinDefaultDeathSentryMinCorpses
+	}
+
+	trap := skill.LightningSentry
+	if s.corpsesNear(monster.Position, hybridTrapsinDeathSentryRange) >= minCorpses && s.isBound(skill.DeathSentry) {
+		trap = skill.DeathSentry
+	}
+
+	opts := step.Distance(hybridTrapsinTrapMinDistance, hybridTrapsinTrapMaxDistance)
+	if err := step.SecondaryAttack(trap, monster.UnitID, missing, opts); err != nil {
+		return false
+	}
+
+	for i := 0; i < missing; i++ {
+		s.traps.add(s.Data.PlayerUnit.Area)
+	}
+
+	return true
+}
+
+// dragonFlight jumps next to targets that are too far to kick, it saves the walk through the pack.
+func (s *HybridTrapsin) dragonFlight(monster data.Monster) bool {
+	if !s.CharacterCfg.Character.HybridTrapsin.UseDragonFlight || !s.isBound(skill.DragonFlight) {
+		return false
+	}
+
+	// Dragon Flight has a 2 seconds cooldown
+	if time.Since(s.lastDragonFlightAt) < 2*time.Second {
+		return false
+	}
+
+	distance := s.PathFinder.DistanceFromMe(monster.Position)
+	if distance <= hybridTrapsinKickDistance || distance > hybridTrapsinDragonFlightMax {
+		return false
+	}
+
+	if err := step.SecondaryAttack(skill.DragonFlight, monster.UnitID, 1, step.Distance(0, hybridTrapsinDragonFlightMax)); err != nil {
+		return false
+	}
+	s.lastDragonFlightAt = time.Now()
+
+	return true
+}
+
+func (s *HybridTrapsin) KillMonsterSequence(
+	monsterSelector func(d game.Data) (data.UnitID, bool),
+	skipOnImmunities []stat.Resist,
+) error {
+	completedAttackLoops := 0
+	previousUnitID := 0
+
+	for {
+		context.Get().PauseIfNotPriority()
+
+		id, found := monsterSelector(*s.Data)
+		if !found {
+			return nil
+		}
+		if previousUnitID != int(id) {
+			completedAttackLoops = 0
+		}
+
+		if !s.preBattleChecks(id, skipOnImmunities) {
+			return nil
+		}
+
+		if completedAttackLoops >= hybridTrapsinMaxAttacksLoop {
+			return nil
+		}
+
+		monster, found := s.Data.Monsters.FindByID(id)
+		if !found {
+			return nil
+		}
+
+		s.ensureSpeedBuff()
+
+		// Traps first, the kicks are only used to finish the job once the traps are in place
+		if s.layTraps(monster) {
+			completedAttackLoops++
+			previousUnitID = int(id)
+			continue
+		}
+
+		s.dragonFlight(monster)
+
+		step.SecondaryAttack(skill.DragonTalon, id, 2, step.Distance(1, hybridTrapsinKickDistance))
+
+		completedAttackLoops++
+		previousUnitID = int(id)
+	}
+}
+
+func (s *HybridTrapsin) killMonster(npc npc.ID, t data.MonsterType) error {
+	return s.KillMonsterSequence(func(d game.Data) (data.UnitID, bool) {
+		m, found := d.Monsters.FindOne(npc, t)
+		if !found {
+			return 0, false
+		}
+
+		return m.UnitID, true
+	}, nil)
+}
+
+func (s *HybridTrapsin) KillCountess() error {
+	return s.killMonster(npc.DarkStalker, data.MonsterTypeSuperUnique)
+}
+
+func (s *HybridTrapsin) KillAndariel() error {
+	return s.killMonster(npc.Andariel, data.MonsterTypeUnique)
+}
+
+func (s *HybridTrapsin) KillSummoner() error {
+	return s.killMonster(npc.Summoner, data.MonsterTypeUnique)
+}
+
+func (s *HybridTrapsin) KillDuriel() error {
+	return s.killMonster(npc.Duriel, data.MonsterTypeUnique)
+}
+
+func (s *HybridTrapsin) KillCouncil() error {
+	return s.KillMonsterSequence(func(d game.Data) (data.UnitID, bool) {
+		var closest data.Monster
+		closestDistance := 0
+		for _, m := range d.Monsters.Enemies() {
+			if m.Name != npc.CouncilMember && m.Name != npc.CouncilMember2 && m.Name != npc.CouncilMember3 {
+				continue
+			}
+
+			distance := s.PathFinder.DistanceFromMe(m.Position)
+			if closest.UnitID == 0 || distance < closestDistance {
+				closest = m
+				closestDistance = distance
+			}
+		}
+
+		return closest.UnitID, closest.UnitID != 0
+	}, nil)
+}
+
+func (s *HybridTrapsin) KillMephisto() error {
+	return s.killMonster(npc.Mephisto, data.MonsterTypeUnique)
+}
+
+func (s *HybridTrapsin) KillIzual() error {
+	return s.killMonster(npc.Izual, data.MonsterTypeUnique)
+}
+
+func (s *HybridTrapsin) KillDiablo() error {
+	timeout := time.Second * 20
+	startTime := time.Now()
+	diabloFound := false
+
+	for {
+		if time.Since(startTime) > timeout && !diabloFound {
+			s.Logger.Error("Diablo was not found, timeout reached")
+			return nil
+		}
+
+		diablo, found := s.Data.Monsters.FindOne(npc.Diablo, data.MonsterTypeUnique)
+		if !found || diablo.Stats[stat.Life] <= 0 {
+			// Already dead
+			if diabloFound {
+				return nil
+			}
+
+			// Keep waiting...
+			time.Sleep(200 * time.Millisecond)
+			continue
+		}
+
+		diabloFound = true
+		s.Logger.Info("Diablo detected, attacking")
+
+		return s.killMonster(npc.Diablo, data.MonsterTypeUnique)
+	}
+}
+
+func (s *HybridTrapsin) KillPindle() error {
+	return s.killMonster(npc.DefiledWarrior, data.MonsterTypeSuperUnique)
+}
+
+func (s *HybridTrapsin) KillNihlathak() error {
+	return s.killMonster(npc.Nihlathak, data.MonsterTypeSuperUnique)
+}
+
+func (s *HybridTrapsin) KillBaal() error {
+	return s.killMonster(npc.BaalCrab, data.MonsterTypeUnique)
+}
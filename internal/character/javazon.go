@@ -40,6 +40,7 @@ const (
 
 type Javazon struct {
 	BaseCharacter
+	pets *jzPetState
 }
 
 func (s Javazon) densityKillerEnabled() bool {
@@ -289,7 +290,9 @@ func (s Javazon) killMonsterSequenceSafe(
 			}
 		}
 
-		if closeMonsters >= 3 {
+		s.petMicro()
+
+		if closeMonsters >= 3 && s.hasJavelinsForFury() {
 			step.SecondaryAttack(skill.LightningFury, id, numOfAttacks, step.Distance(minJavazonDistance, maxJavazonDistance))
 		} else {
 			if s.Data.PlayerUnit.Skills[skill.ChargedStrike].Level > 0 {
@@ -325,6 +328,7 @@ func (s Javazon) killMonsterSequenceDensity(
 	for {
 		ctx.PauseIfNotPriority()
 		ctx.RefreshGameData()
+		s.petMicro()
 
 		meNow := s.Data.PlayerUnit.Position
 		if meNow == lastMePos {
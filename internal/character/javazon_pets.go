@@ -0,0 +1,202 @@
+package character
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/item"
+	"github.com/hectorgimenez/d2go/pkg/data/npc"
+	"github.com/hectorgimenez/d2go/pkg/data/skill"
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+	"github.com/hectorgimenez/koolo/internal/action"
+	"github.com/hectorgimenez/koolo/internal/action/step"
+	"github.com/hectorgimenez/koolo/internal/pather"
+	"github.com/hectorgimenez/koolo/internal/utils"
+)
+
+const (
+	jzPetRecastCooldown     = 3 * time.Second
+	jzDecoyRangedPackRadius = 8
+	jzDecoyMinRangedMonster = 2
+	jzDecoyMaxCastDistance  = 25
+
+	// Default amount of javelins we keep before stopping Lightning Fury and switching to Charged Strike.
+	jzDefaultMinFuryJavelins = 10
+)
+
+// jzPetState keeps the last pet casts and the javelin tracking, Javazon is a value receiver so it holds a pointer to it.
+type jzPetState struct {
+	mu            sync.Mutex
+	lastValkyrie  time.Time
+	lastDecoy     time.Time
+	lastJavelins  int
+	lowJavLogged  bool
+	lastJavUnitID data.UnitID
+}
+
+func (s Javazon) hasPet(name npc.ID) bool {
+	for _, m := range s.Data.Monsters {
+		if m.IsPet() && m.Name == name && m.Stats[stat.Life] > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ensureValkyrie recasts the Valkyrie in the middle of the fight when she dies, instead of waiting for the next buff.
+func (s Javazon) ensureValkyrie() {
+	if _, found := s.Data.KeyBindings.KeyBindingForSkill(skill.Valkyrie); !found || s.hasPet(npc.Valkyrie) {
+		return
+	}
+
+	s.pets.mu.Lock()
+	recent := time.Since(s.pets.lastValkyrie) < jzPetRecastCooldown
+	s.pets.mu.Unlock()
+	if recent {
+		return
+	}
+
+	if step.CastAtPosition(skill.Valkyrie, true, s.Data.PlayerUnit.Position) {
+		s.pets.mu.Lock()
+		s.pets.lastValkyrie = time.Now()
+		s.pets.mu.Unlock()
+		utils.Sleep(200)
+	}
+}
+
+// bestDecoyPosition returns the center of the biggest ranged pack around, so the decoy pulls the archers and casters
+// away from us.
+func (s Javazon) bestDecoyPosition() (data.Position, bool) {
+	ranged := make([]data.Monster, 0)
+	for _, m := range s.Data.Monsters.Enemies() {
+		if action.IsRangedMonster(m) && s.PathFinder.DistanceFromMe(m.Position) <= jzDecoyMaxCastDistance {
+			ranged = append(ranged, m)
+		}
+	}
+
+	bestCount := 0
+	var best data.Monster
+	for _, m := range ranged {
+		count := 0
+		for _, other := range ranged {
+			if pather.DistanceFromPoint(m.Position, other.Position) <= jzDecoyRangedPackRadius {
+				count++
+			}
+		}
+		if count > bestCount {
+			bestCount = count
+			best = m
+		}
+	}
+
+	if bestCount < jzDecoyMinRangedMonster {
+		return data.Position{}, false
+	}
+
+	pack := make([]data.Monster, 0, bestCount)
+	for _, other := range ranged {
+		if pather.DistanceFromPoint(best.Position, other.Position) <= jzDecoyRangedPackRadius {
+			pack = append(pack, other)
+		}
+	}
+
+	return centroidOf(pack), true
+}
+
+// ensureDecoy drops a decoy on top of ranged packs, recasting it once the previous one is gone.
+func (s Javazon) ensureDecoy() {
+	if !s.CharacterCfg.Character.Javazon.UseDecoy {
+		return
+	}
+	if _, found := s.Data.KeyBindings.KeyBindingForSkill(skill.Decoy); !found || s.hasPet(npc.Decoy) {
+		return
+	}
+
+	s.pets.mu.Lock()
+	recent := time.Since(s.pets.lastDecoy) < jzPetRecastCooldown
+	s.pets.mu.Unlock()
+	if recent {
+		return
+	}
+
+	pos, found := s.bestDecoyPosition()
+	if !found {
+		return
+	}
+
+	if step.CastAtPosition(skill.Decoy, false, pos) {
+		s.pets.mu.Lock()
+		s.pets.lastDecoy = time.Now()
+		s.pets.mu.Unlock()
+		utils.Sleep(150)
+	}
+}
+
+// petMicro keeps the Valkyrie and the Decoy up during the fight.
+func (s Javazon) petMicro() {
+	if s.Data.PlayerUnit.Area.IsTown() {
+		return
+	}
+
+	s.ensureValkyrie()
+	s.ensureDecoy()
+}
+
+// equippedJavelins returns the quantity of the equipped javelin stack.
+func (s Javazon) equippedJavelins() (data.Item, int, bool) {
+	for _, itm := range s.Data.Inventory.ByLocation(item.LocationEquipped) {
+		if itm.Location.BodyLocation != item.LocLeftArm && itm.Location.BodyLocation != item.LocRightArm {
+			continue
+		}
+		itmType := itm.Type()
+		if !itmType.IsType(item.TypeJavelin) && !itmType.IsType(item.TypeAmazonJavelin) {
+			continue
+		}
+		qty, found := itm.FindStat(stat.Quantity, 0)
+		if !found {
+			continue
+		}
+
+		return itm, max(0, qty.Value), true
+	}
+
+	return data.Item{}, 0, false
+}
+
+// hasJavelinsForFury tracks the javelin count while throwing Lightning Fury, when the stack is running out we stop
+// throwing and melee with Charged Strike until the next town visit replenishes them.
+func (s Javazon) hasJavelinsForFury() bool {
+	itm, qty, found := s.equippedJavelins()
+	if !found {
+		// Throwing without quantity (e.g. replenishing items not reporting it), nothing to track
+		return true
+	}
+
+	minJavelins := s.CharacterCfg.Character.Javazon.MinFuryJavelins
+	if minJavelins <= 0 {
+		minJavelins = jzDefaultMinFuryJavelins
+	}
+
+	s.pets.mu.Lock()
+	defer s.pets.mu.Unlock()
+
+	if itm.UnitID != s.pets.lastJavUnitID || qty > s.pets.lastJavelins {
+		// New stack equipped or replenished
+		s.pets.lowJavLogged = false
+	}
+	s.pets.lastJavUnitID = itm.UnitID
+	s.pets.lastJavelins = qty
+
+	if qty > minJavelins {
+		return true
+	}
+
+	if !s.pets.lowJavLogged {
+		s.Logger.Info("Javelin stack running out, switching to Charged Strike", "quantity", qty)
+		s.pets.lowJavLogged = true
+	}
+
+	return false
+}
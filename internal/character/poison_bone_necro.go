@@ -0,0 +1,383 @@
+package character
+
+import (
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/npc"
+	"github.com/hectorgimenez/d2go/pkg/data/skill"
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+	"github.com/hectorgimenez/d2go/pkg/data/state"
+	"github.com/hectorgimenez/koolo/internal/action/step"
+	"github.com/hectorgimenez/koolo/internal/context"
+	"github.com/hectorgimenez/koolo/internal/game"
+	"github.com/hectorgimenez/koolo/internal/utils"
+)
+
+const (
+	PoisonBoneNecroModePoison = "poison"
+	PoisonBoneNecroModeBone   = "bone"
+
+	poisonBoneNecroMaxAttacksLoop   = 20
+	poisonBoneNecroCurseRange       = 25
+	poisonBoneNecroBoneSpearRange   = 25
+	poisonBoneNecroPoisonNovaRadius = 9
+	poisonBoneNecroCorpseRange      = 25
+	poisonBoneNecroCurseCooldown    = 3 * time.Second
+	poisonBoneNecroBoneArmorRefresh = 30 * time.Second
+
+	// Default amount of enemies that must be standing around a corpse before exploding it.
+	poisonBoneNecroDefaultCEMinMonsters = 3
+	// Max number of Corpse Explosions chained back to back before going back to the main skill.
+	poisonBoneNecroMaxCEChain = 3
+)
+
+var _ context.Character = (*PoisonBoneNecro)(nil)
+
+type PoisonBoneNecro struct {
+	BaseCharacter
+	lastCurseCast     map[data.UnitID]time.Time
+	lastBoneArmorCast time.Time
+}
+
+func (n *PoisonBoneNecro) mode() string {
+	if strings.EqualFold(n.CharacterCfg.Character.PoisonBoneNecro.Mode, PoisonBoneNecroModeBone) {
+		return PoisonBoneNecroModeBone
+	}
+
+	return PoisonBoneNecroModePoison
+}
+
+func (n *PoisonBoneNecro) mainSkill() skill.ID {
+	if n.mode() == PoisonBoneNecroModeBone {
+		return skill.BoneSpear
+	}
+
+	return skill.PoisonNova
+}
+
+func (n *PoisonBoneNecro) isBound(sk skill.ID) bool {
+	_, found := n.Data.KeyBindings.KeyBindingForSkill(sk)
+	return found
+}
+
+func (n *PoisonBoneNecro) ShouldIgnoreMonster(m data.Monster) bool {
+	return false
+}
+
+func (n *PoisonBoneNecro) CheckKeyBindings() []skill.ID {
+	requireKeybindings := []skill.ID{n.mainSkill(), skill.TomeOfTownPortal}
+	missingKeybindings := []skill.ID{}
+
+	for _, cskill := range requireKeybindings {
+		if !n.isBound(cskill) {
+			missingKeybindings = append(missingKeybindings, cskill)
+		}
+	}
+
+	// At least one curse is needed for the curse rotation.
+	if !n.isBound(skill.LowerResist) && !n.isBound(skill.AmplifyDamage) {
+		missingKeybindings = append(missingKeybindings, skill.LowerResist)
+	}
+
+	if len(missingKeybindings) > 0 {
+		n.Logger.Debug("There are missing required key bindings.", slog.Any("Bindings", missingKeybindings))
+	}
+
+	return missingKeybindings
+}
+
+func (n *PoisonBoneNecro) BuffSkills() []skill.ID {
+	if n.isBound(skill.BoneArmor) {
+		return []skill.ID{skill.BoneArmor}
+	}
+
+	return []skill.ID{}
+}
+
+func (n *PoisonBoneNecro) PreCTABuffSkills() []skill.ID {
+	return []skill.ID{}
+}
+
+// selectCurse picks the curse for the given monster. Lower Resist is used to break poison immunities and to boost
+// Poison Nova, Amplify Damage is used when the main skill can't hurt the monster so the merc can take care of it.
+func (n *PoisonBoneNecro) selectCurse(m data.Monster) (skill.ID, bool) {
+	hasLR := n.isBound(skill.LowerResist)
+	hasAmp := n.isBound(skill.AmplifyDamage)
+
+	preferred := skill.AmplifyDamage
+	switch {
+	case m.IsImmune(stat.PoisonImmune) && n.mode() == PoisonBoneNecroModePoison:
+		preferred = skill.LowerResist
+	case m.IsImmune(stat.MagicImmune) && n.mode() == PoisonBoneNecroModeBone:
+		preferred = skill.AmplifyDamage
+	case n.mode() == PoisonBoneNecroModePoison:
+		preferred = skill.LowerResist
+	}
+
+	if preferred == skill.LowerResist && hasLR {
+		return skill.LowerResist, true
+	}
+	if preferred == skill.AmplifyDamage && hasAmp {
+		return skill.AmplifyDamage, true
+	}
+
+	// Fallback to whatever curse we have available
+	if hasLR {
+		return skill.LowerResist, true
+	}
+	if hasAmp {
+		return skill.AmplifyDamage, true
+	}
+
+	return 0, false
+}
+
+func (n *PoisonBoneNecro) castCurse(m data.Monster) {
+	if m.States.HasState(state.Lowerresist) || m.States.HasState(state.Amplifydamage) {
+		return
+	}
+
+	if n.lastCurseCast == nil {
+		n.lastCurseCast = make(map[data.UnitID]time.Time)
+	}
+	if lastCast, found := n.lastCurseCast[m.UnitID]; found && time.Since(lastCast) < poisonBoneNecroCurseCooldown {
+		return
+	}
+
+	curse, found := n.selectCurse(m)
+	if !found {
+		return
+	}
+
+	step.SecondaryAttack(curse, m.UnitID, 1, step.RangedDistance(0, poisonBoneNecroCurseRange))
+	n.lastCurseCast[m.UnitID] = time.Now()
+	utils.Sleep(100)
+}
+
+// ensureBoneArmor recasts Bone Armor when the buff is gone or it's time to refresh the absorb charges.
+func (n *PoisonBoneNecro) ensureBoneArmor() {
+	if !n.isBound(skill.BoneArmor) {
+		return
+	}
+
+	if n.Data.PlayerUnit.States.HasState(state.Bonearmor) && time.Since(n.lastBoneArmorCast) < poisonBoneNecroBoneArmorRefresh {
+		return
+	}
+
+	if step.CastAtPosition(skill.BoneArmor, true, n.Data.PlayerUnit.Position) {
+		n.lastBoneArmorCast = time.Now()
+		utils.Sleep(200)
+	}
+}
+
+// explodeCorpses chains Corpse Explosion on corpses surrounded by enough enemies, returns the amount of casts done.
+func (n *PoisonBoneNecro) explodeCorpses() int {
+	cfg := n.CharacterCfg.Character.PoisonBoneNecro
+	if !cfg.UseCorpseExplosion || !n.isBound(skill.CorpseExplosion) {
+		return 0
+	}
+
+	minMonsters := cfg.CorpseExplosionMinMonsters
+	if minMonsters <= 0 {
+		minMonsters = poisonBoneNecroDefaultCEMinMonsters
+	}
+
+	radius := 3 + int(n.Data.PlayerUnit.Skills[skill.CorpseExplosion].Level)/3
+	enemies := n.Data.Monsters.Enemies()
+	casts := 0
+	usedCorpses := make(map[data.UnitID]bool)
+
+	for casts < poisonBoneNecroMaxCEChain {
+		var bestCorpse data.Monster
+		bestHits := 0
+
+		for _, c := range n.Data.Corpses {
+			if c.IsMerc() || usedCorpses[c.UnitID] || c.States.HasState(state.CorpseNoselect) || c.States.HasState(state.Revive) {
+				continue
+			}
+			if n.PathFinder.DistanceFromMe(c.Position) > poisonBoneNecroCorpseRange {
+				continue
+			}
+
+			hits := 0
+			for _, e := range enemies {
+				if e.Stats[stat.Life] <= 0 {
+					continue
+				}
+				if gridDistance(c.Position, e.Position) <= radius {
+					hits++
+				}
+			}
+
+			if hits > bestHits {
+				bestHits = hits
+				bestCorpse = c
+			}
+		}
+
+		if bestHits < minMonsters {
+			break
+		}
+
+		if !step.CastAtPosition(skill.CorpseExplosion, true, bestCorpse.Position) {
+			break
+		}
+
+		usedCorpses[bestCorpse.UnitID] = true
+		casts++
+		utils.Sleep(150)
+	}
+
+	return casts
+}
+
+func (n *PoisonBoneNecro) KillMonsterSequence(
+	monsterSelector func(d game.Data) (data.UnitID, bool),
+	skipOnImmunities []stat.Resist,
+) error {
+	completedAttackLoops := 0
+	previousUnitID := 0
+
+	for {
+		context.Get().PauseIfNotPriority()
+
+		id, found := monsterSelector(*n.Data)
+		if !found {
+			return nil
+		}
+		if previousUnitID != int(id) {
+			completedAttackLoops = 0
+		}
+
+		if !n.preBattleChecks(id, skipOnImmunities) {
+			return nil
+		}
+
+		if completedAttackLoops >= poisonBoneNecroMaxAttacksLoop {
+			return nil
+		}
+
+		monster, found := n.Data.Monsters.FindByID(id)
+		if !found {
+			return nil
+		}
+
+		n.ensureBoneArmor()
+		n.castCurse(monster)
+
+		if n.explodeCorpses() > 0 {
+			completedAttackLoops++
+			previousUnitID = int(id)
+			continue
+		}
+
+		if n.mode() == PoisonBoneNecroModeBone {
+			step.SecondaryAttack(skill.BoneSpear, id, 3, step.Distance(1, poisonBoneNecroBoneSpearRange))
+		} else {
+			step.SecondaryAttack(skill.PoisonNova, id, 1, step.Distance(1, poisonBoneNecroPoisonNovaRadius))
+		}
+
+		completedAttackLoops++
+		previousUnitID = int(id)
+	}
+}
+
+func (n *PoisonBoneNecro) killMonster(npc npc.ID, t data.MonsterType) error {
+	return n.KillMonsterSequence(func(d game.Data) (data.UnitID, bool) {
+		m, found := d.Monsters.FindOne(npc, t)
+		if !found {
+			return 0, false
+		}
+
+		return m.UnitID, true
+	}, nil)
+}
+
+func (n *PoisonBoneNecro) KillCountess() error {
+	return n.killMonster(npc.DarkStalker, data.MonsterTypeSuperUnique)
+}
+
+func (n *PoisonBoneNecro) KillAndariel() error {
+	return n.killMonster(npc.Andariel, data.MonsterTypeUnique)
+}
+
+func (n *PoisonBoneNecro) KillSummoner() error {
+	return n.killMonster(npc.Summoner, data.MonsterTypeUnique)
+}
+
+func (n *PoisonBoneNecro) KillDuriel() error {
+	return n.killMonster(npc.Duriel, data.MonsterTypeUnique)
+}
+
+func (n *PoisonBoneNecro) KillCouncil() error {
+	return n.KillMonsterSequence(func(d game.Data) (data.UnitID, bool) {
+		var closest data.Monster
+		closestDistance := 0
+		for _, m := range d.Monsters.Enemies() {
+			if m.Name != npc.CouncilMember && m.Name != npc.CouncilMember2 && m.Name != npc.CouncilMember3 {
+				continue
+			}
+
+			distance := n.PathFinder.DistanceFromMe(m.Position)
+			if closest.UnitID == 0 || distance < closestDistance {
+				closest = m
+				closestDistance = distance
+			}
+		}
+
+		return closest.UnitID, closest.UnitID != 0
+	}, nil)
+}
+
+func (n *PoisonBoneNecro) KillMephisto() error {
+	return n.killMonster(npc.Mephisto, data.MonsterTypeUnique)
+}
+
+func (n *PoisonBoneNecro) KillIzual() error {
+	return n.killMonster(npc.Izual, data.MonsterTypeUnique)
+}
+
+func (n *PoisonBoneNecro) KillDiablo() error {
+	timeout := time.Second * 20
+	startTime := time.Now()
+	diabloFound := false
+
+	for {
+		if time.Since(startTime) > timeout && !diabloFound {
+			n.Logger.Error("Diablo was not found, timeout reached")
+			return nil
+		}
+
+		diablo, found := n.Data.Monsters.FindOne(npc.Diablo, data.MonsterTypeUnique)
+		if !found || diablo.Stats[stat.Life] <= 0 {
+			// Already dead
+			if diabloFound {
+				return nil
+			}
+
+			// Keep waiting...
+			time.Sleep(200 * time.Millisecond)
+			continue
+		}
+
+		diabloFound = true
+		n.Logger.Info("Diablo detected, attacking")
+
+		return n.killMonster(npc.Diablo, data.MonsterTypeUnique)
+	}
+}
+
+func (n *PoisonBoneNecro) KillPindle() error {
+	return n.killMonster(npc.DefiledWarrior, data.MonsterTypeSuperUnique)
+}
+
+func (n *PoisonBoneNecro) KillNihlathak() error {
+	return n.killMonster(npc.Nihlathak, data.MonsterTypeSuperUnique)
+}
+
+func (n *PoisonBoneNecro) KillBaal() error {
+	return n.killMonster(npc.BaalCrab, data.MonsterTypeUnique)
+}
@@ -0,0 +1,343 @@
+package character
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/npc"
+	"github.com/hectorgimenez/d2go/pkg/data/skill"
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+	"github.com/hectorgimenez/koolo/internal/action/step"
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/context"
+	"github.com/hectorgimenez/koolo/internal/game"
+	"github.com/hectorgimenez/koolo/internal/utils"
+)
+
+const (
+	rotationMaxAttacksLoop = 20
+
+	rotationTargetAny    = "any"
+	rotationTargetNormal = "normal"
+	rotationTargetElite  = "elite"
+	rotationTargetBoss   = "boss"
+	rotationTargetSelf   = "self"
+)
+
+var _ context.Character = (*RotationCharacter)(nil)
+
+// RotationCharacter fights using the combat rotation defined in the character profile instead of hardcoded logic.
+type RotationCharacter struct {
+	BaseCharacter
+	steps    []rotationStep
+	lastCast map[int]time.Time
+}
+
+type rotationStep struct {
+	config.RotationStep
+	skillID skill.ID
+}
+
+// newRotationCharacter validates the configured rotation, unknown skills or targets are reported as errors.
+func newRotationCharacter(bc BaseCharacter) (*RotationCharacter, error) {
+	steps, err := compileRotation(bc.CharacterCfg.Character.CombatRotation)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RotationCharacter{
+		BaseCharacter: bc,
+		steps:         steps,
+		lastCast:      make(map[int]time.Time),
+	}, nil
+}
+
+func compileRotation(entries []config.RotationStep) ([]rotationStep, error) {
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("combat rotation is empty, add at least one step to combatRotation")
+	}
+
+	steps := make([]rotationStep, 0, len(entries))
+	for i, entry := range entries {
+		// Display names ("Frozen Armor") are accepted as well as the internal ones ("FrozenArmor")
+		skillID, found := step.SkillIDByName(entry.Skill)
+		if !found {
+			return nil, fmt.Errorf("combat rotation step %d: unknown skill %q", i+1, entry.Skill)
+		}
+
+		switch strings.ToLower(entry.Target) {
+		case "", rotationTargetAny, rotationTargetNormal, rotationTargetElite, rotationTargetBoss, rotationTargetSelf:
+		default:
+			return nil, fmt.Errorf("combat rotation step %d: unknown target %q", i+1, entry.Target)
+		}
+
+		if entry.MaxDistance > 0 && entry.MinDistance > entry.MaxDistance {
+			return nil, fmt.Errorf("combat rotation step %d: minDistance is greater than maxDistance", i+1)
+		}
+
+		steps = append(steps, rotationStep{RotationStep: entry, skillID: skillID})
+	}
+
+	return steps, nil
+}
+
+func (s *RotationCharacter) ShouldIgnoreMonster(m data.Monster) bool {
+	return false
+}
+
+func (s *RotationCharacter) CheckKeyBindings() []skill.ID {
+	missingKeybindings := []skill.ID{}
+	if _, found := s.Data.KeyBindings.KeyBindingForSkill(skill.TomeOfTownPortal); !found {
+		missingKeybindings = append(missingKeybindings, skill.TomeOfTownPortal)
+	}
+
+	for _, st := range s.steps {
+		// Attack is always available with the left click
+		if st.skillID == skill.AttackSkill {
+			continue
+		}
+		if _, found := s.Data.KeyBindings.KeyBindingForSkill(st.skillID); !found {
+			missingKeybindings = append(missingKeybindings, st.skillID)
+		}
+	}
+
+	if len(missingKeybindings) > 0 {
+		s.Logger.Debug("There are missing required key bindings.", slog.Any("Bindings", missingKeybindings))
+	}
+
+	return missingKeybindings
+}
+
+func (s *RotationCharacter) BuffSkills() []skill.ID {
+	return []skill.ID{}
+}
+
+func (s *RotationCharacter) PreCTABuffSkills() []skill.ID {
+	return []skill.ID{}
+}
+
+// matches checks all the step conditions against the current target.
+func (s *RotationCharacter) matches(idx int, st rotationStep, m data.Monster) bool {
+	if st.CooldownMs > 0 && time.Since(s.lastCast[idx]) < time.Duration(st.CooldownMs)*time.Millisecond {
+		return false
+	}
+
+	if st.MinManaPercent > 0 && s.Data.PlayerUnit.MPPercent() < st.MinManaPercent {
+		return false
+	}
+
+	switch strings.ToLower(st.Target) {
+	case rotationTargetNormal:
+		if m.IsElite() {
+			return false
+		}
+	case rotationTargetElite:
+		if !m.IsElite() {
+			return false
+		}
+	case rotationTargetBoss:
+		if m.Type != data.MonsterTypeUnique && m.Type != data.MonsterTypeSuperUnique {
+			return false
+		}
+	}
+
+	for _, resist := range st.SkipOnImmunities {
+		if m.IsImmune(resist) {
+			return false
+		}
+	}
+
+	distance := s.PathFinder.DistanceFromMe(m.Position)
+	if distance < st.MinDistance {
+		return false
+	}
+	if st.MaxDistance > 0 && distance > st.MaxDistance+5 {
+		// Allow a small margin, the attack step will move us into range
+		return false
+	}
+
+	return true
+}
+
+func (s *RotationCharacter) cast(st rotationStep, m data.Monster) error {
+	attacks := max(1, st.Attacks)
+
+	if strings.ToLower(st.Target) == rotationTargetSelf {
+		for i := 0; i < attacks; i++ {
+			if !step.CastAtPosition(st.skillID, true, s.Data.PlayerUnit.Position) {
+				return fmt.Errorf("could not cast %s", skill.SkillNames[st.skillID])
+			}
+			utils.Sleep(150)
+		}
+		return nil
+	}
+
+	maxDistance := st.MaxDistance
+	if maxDistance <= 0 {
+		maxDistance = 30
+	}
+	opts := step.Distance(st.MinDistance, maxDistance)
+
+	if st.skillID == skill.AttackSkill {
+		return step.PrimaryAttack(m.UnitID, attacks, false, opts)
+	}
+
+	return step.SecondaryAttack(st.skillID, m.UnitID, attacks, opts)
+}
+
+func (s *RotationCharacter) KillMonsterSequence(
+	monsterSelector func(d game.Data) (data.UnitID, bool),
+	skipOnImmunities []stat.Resist,
+) error {
+	completedAttackLoops := 0
+	previousUnitID := 0
+
+	for {
+		context.Get().PauseIfNotPriority()
+
+		id, found := monsterSelector(*s.Data)
+		if !found {
+			return nil
+		}
+		if previousUnitID != int(id) {
+			completedAttackLoops = 0
+		}
+
+		if !s.preBattleChecks(id, skipOnImmunities) {
+			return nil
+		}
+
+		if completedAttackLoops >= rotationMaxAttacksLoop {
+			return nil
+		}
+
+		monster, found := s.Data.Monsters.FindByID(id)
+		if !found {
+			return nil
+		}
+
+		casted := false
+		for idx, st := range s.steps {
+			if !s.matches(idx, st, monster) {
+				continue
+			}
+
+			if err := s.cast(st, monster); err != nil {
+				s.Logger.Debug("Rotation step failed", slog.String("skill", skill.SkillNames[st.skillID]), slog.String("error", err.Error()))
+				continue
+			}
+
+			s.lastCast[idx] = time.Now()
+			casted = true
+			break
+		}
+
+		if !casted {
+			// Nothing matched, don't spin the CPU waiting for cooldowns
+			utils.Sleep(100)
+		}
+
+		completedAttackLoops++
+		previousUnitID = int(id)
+	}
+}
+
+func (s *RotationCharacter) killMonster(npc npc.ID, t data.MonsterType) error {
+	return s.KillMonsterSequence(func(d game.Data) (data.UnitID, bool) {
+		m, found := d.Monsters.FindOne(npc, t)
+		if !found {
+			return 0, false
+		}
+
+		return m.UnitID, true
+	}, nil)
+}
+
+func (s *RotationCharacter) KillCountess() error {
+	return s.killMonster(npc.DarkStalker, data.MonsterTypeSuperUnique)
+}
+
+func (s *RotationCharacter) KillAndariel() error {
+	return s.killMonster(npc.Andariel, data.MonsterTypeUnique)
+}
+
+func (s *RotationCharacter) KillSummoner() error {
+	return s.killMonster(npc.Summoner, data.MonsterTypeUnique)
+}
+
+func (s *RotationCharacter) KillDuriel() error {
+	return s.killMonster(npc.Duriel, data.MonsterTypeUnique)
+}
+
+func (s *RotationCharacter) KillCouncil() error {
+	return s.KillMonsterSequence(func(d game.Data) (data.UnitID, bool) {
+		var closest data.Monster
+		closestDistance := 0
+		for _, m := range d.Monsters.Enemies() {
+			if m.Name != npc.CouncilMember && m.Name != npc.CouncilMember2 && m.Name != npc.CouncilMember3 {
+				continue
+			}
+
+			distance := s.PathFinder.DistanceFromMe(m.Position)
+			if closest.UnitID == 0 || distance < closestDistance {
+				closest = m
+				closestDistance = distance
+			}
+		}
+
+		return closest.UnitID, closest.UnitID != 0
+	}, nil)
+}
+
+func (s *RotationCharacter) KillMephisto() error {
+	return s.killMonster(npc.Mephisto, data.MonsterTypeUnique)
+}
+
+func (s *RotationCharacter) KillIzual() error {
+	return s.killMonster(npc.Izual, data.MonsterTypeUnique)
+}
+
+func (s *RotationCharacter) KillDiablo() error {
+	timeout := time.Second * 20
+	startTime := time.Now()
+	diabloFound := false
+
+	for {
+		if time.Since(startTime) > timeout && !diabloFound {
+			s.Logger.Error("Diablo was not found, timeout reached")
+			return nil
+		}
+
+		diablo, found := s.Data.Monsters.FindOne(npc.Diablo, data.MonsterTypeUnique)
+		if !found || diablo.Stats[stat.Life] <= 0 {
+			// Already dead
+			if diabloFound {
+				return nil
+			}
+
+			// Keep waiting...
+			time.Sleep(200 * time.Millisecond)
+			continue
+		}
+
+		diabloFound = true
+		s.Logger.Info("Diablo detected, attacking")
+
+		return s.killMonster(npc.Diablo, data.MonsterTypeUnique)
+	}
+}
+
+func (s *RotationCharacter) KillPindle() error {
+	return s.killMonster(npc.DefiledWarrior, data.MonsterTypeSuperUnique)
+}
+
+func (s *RotationCharacter) KillNihlathak() error {
+	return s.killMonster(npc.Nihlathak, data.MonsterTypeSuperUnique)
+}
+
+func (s *RotationCharacter) KillBaal() error {
+	return s.killMonster(npc.BaalCrab, data.MonsterTypeUnique)
+}
@@ -892,43 +892,7 @@ func (s SorceressLeveling) KillMephisto() error {
 			ctx.ForceAttack = false
 		}()
 
-		type positionAndWaitTime struct {
-			x        int
-			y        int
-			duration int
-		}
-
-		// Move to initial position
-		utils.Sleep(350)
-		err := step.MoveTo(data.Position{X: 17563, Y: 8072}, step.WithIgnoreMonsters())
-		if err != nil {
-			return err
-		}
-
-		utils.Sleep(350)
-
-		// Initial movement sequence
-		initialPositions := []positionAndWaitTime{
-			{17575, 8086, 350}, {17584, 8088, 1200},
-			{17600, 8090, 550}, {17609, 8090, 2500},
-		}
-
-		for _, pos := range initialPositions {
-			err := step.MoveTo(data.Position{X: pos.x, Y: pos.y}, step.WithIgnoreMonsters())
-			if err != nil {
-				return err
-			}
-			utils.Sleep(pos.duration)
-		}
-
-		// Clear area around position
-		err = action.ClearAreaAroundPosition(data.Position{X: 17609, Y: 8090}, 10, data.MonsterAnyFilter())
-		if err != nil {
-			return err
-		}
-
-		err = step.MoveTo(data.Position{X: 17609, Y: 8090}, step.WithIgnoreMonsters())
-		if err != nil {
+		if err := action.PrepareBossFight(npc.Mephisto, action.MephistoMoat{}); err != nil {
 			return err
 		}
 
@@ -3,7 +3,6 @@ package character
 import (
 	"log/slog"
 	"slices"
-	"sort"
 	"sync/atomic"
 	"time"
 
@@ -11,7 +10,6 @@ import (
 	"github.com/hectorgimenez/d2go/pkg/data/npc"
 	"github.com/hectorgimenez/d2go/pkg/data/skill"
 	"github.com/hectorgimenez/d2go/pkg/data/stat"
-	"github.com/hectorgimenez/d2go/pkg/data/state"
 	"github.com/hectorgimenez/koolo/internal/action"
 	"github.com/hectorgimenez/koolo/internal/action/step"
 	"github.com/hectorgimenez/koolo/internal/context"
@@ -536,70 +534,11 @@ func (s *WarcryBarb) horkCorpses(maxRange int) {
 }
 
 func (s *WarcryBarb) horkableCorpses(corpses data.Monsters, maxRange int) []data.Monster {
-	type corpseWithDistance struct {
-		corpse   data.Monster
-		distance int
-	}
-	var horkableCorpses []corpseWithDistance
-	maxCorpsesToCheck := 30
-	corpsesToCheck := corpses
-	if len(corpsesToCheck) > maxCorpsesToCheck {
-		corpsesToCheck = corpsesToCheck[:maxCorpsesToCheck]
-	}
-
-	for _, corpse := range corpsesToCheck {
-		if !s.isHorkable(corpse) {
-			continue
-		}
-		distance := s.PathFinder.DistanceFromMe(corpse.Position)
-		if distance <= maxRange {
-			horkableCorpses = append(horkableCorpses, corpseWithDistance{corpse: corpse, distance: distance})
-		}
-	}
-
-	if len(horkableCorpses) > 1 {
-		sort.Slice(horkableCorpses, func(i, j int) bool {
-			return horkableCorpses[i].distance < horkableCorpses[j].distance
-		})
-	}
-
-	result := make([]data.Monster, len(horkableCorpses))
-	for i, cwd := range horkableCorpses {
-		result[i] = cwd.corpse
-	}
-
-	return result
+	return findHorkableCorpses(s.PathFinder, corpses, maxRange, s.CharacterCfg.Character.WarcryBarb.HorkNormalMonsters)
 }
 
 func (s *WarcryBarb) isHorkable(corpse data.Monster) bool {
-	unhorkableStates := []state.State{
-		state.CorpseNoselect,
-		state.CorpseNodraw,
-		state.Revive,
-		state.Redeemed,
-		state.Shatter,
-		state.Freeze,
-		state.Restinpeace,
-	}
-
-	for _, st := range unhorkableStates {
-		if corpse.States.HasState(st) {
-			return false
-		}
-	}
-
-	if corpse.Type == data.MonsterTypeMinion ||
-		corpse.Type == data.MonsterTypeChampion ||
-		corpse.Type == data.MonsterTypeUnique ||
-		corpse.Type == data.MonsterTypeSuperUnique {
-		return true
-	}
-
-	if s.CharacterCfg.Character.WarcryBarb.HorkNormalMonsters {
-		return true
-	}
-
-	return false
+	return corpseHorkable(corpse, s.CharacterCfg.Character.WarcryBarb.HorkNormalMonsters)
 }
 
 func (s *WarcryBarb) SwapToSlot(slot int) bool {
@@ -30,18 +30,29 @@ var (
 	Koolo      *KooloCfg
 	Characters map[string]*CharacterCfg
 	Version    = "dev"
+	// SupportedGameVersion is the D2R version the memory offsets of this build were written for, set at build time
+	// with -ldflags. Used when the koolo config doesn't set one.
+	SupportedGameVersion = ""
 
 	// NIP rules cache - stores compiled rules by path to avoid recompiling for multiple characters
 	nipRulesCacheMux sync.RWMutex
 	nipRulesCache    = make(map[string]nip.Rules)
 )
 
+// Lobby game password policies, the fixed policy (empty) uses the configured game password.
+const (
+	PasswordPolicyFixed  = ""
+	PasswordPolicyRandom = "random"
+	PasswordPolicyNone   = "none"
+)
+
 type KooloCfg struct {
 	Debug struct {
 		Log                       bool `yaml:"log"`
 		Screenshots               bool `yaml:"screenshots"`
 		RenderMap                 bool `yaml:"renderMap"`
 		OpenOverlayMapOnGameStart bool `yaml:"openOverlayMapOnGameStart"`
+		RecordReplays             bool `yaml:"recordReplays"` // Records the game data and the inputs of every game in replays/
 	} `yaml:"debug"`
 	FirstRun              bool   `yaml:"firstRun"`
 	UseCustomSettings     bool   `yaml:"useCustomSettings"`
@@ -92,10 +103,56 @@ type KooloCfg struct {
 		Enabled      bool `yaml:"enabled"`
 		DelaySeconds int  `yaml:"delaySeconds"`
 	} `yaml:"autoStart"`
+	// GameCreation staggers the game creations and joins of all the supervisors, many characters hitting the realm
+	// at the same time end up in the queue or temporarily restricted.
+	GameCreation struct {
+		MaxConcurrent  int `yaml:"maxConcurrent"`  // Characters creating or joining a game at the same time, 0 = no limit
+		StaggerSeconds int `yaml:"staggerSeconds"` // Minimum time between two game creations of any character
+		JitterSeconds  int `yaml:"jitterSeconds"`  // Random extra delay added to the stagger
+	} `yaml:"gameCreation"`
+	// ClientWatchdog watches the resources used by every client, a degraded client is restarted at the next town visit.
+	ClientWatchdog struct {
+		Enabled          bool `yaml:"enabled"`
+		MaxCPUPercent    int  `yaml:"maxCpuPercent"`    // Share of the whole machine, 0 = not checked
+		MaxMemoryMB      int  `yaml:"maxMemoryMB"`      // 0 = not checked
+		MaxReadLatencyMs int  `yaml:"maxReadLatencyMs"` // Time to read the game data from memory, 0 = not checked
+		Samples          int  `yaml:"samples"`          // Consecutive samples over a limit before the client is degraded
+	} `yaml:"clientWatchdog"`
+	// UI maps the UI coordinates, tuned for a 1280x720 window, to the window size of the clients. Scale is the in-game
+	// UI scale in percent, Calibrations add or replace the built-in maps of the common resolutions.
+	UI struct {
+		Scale        int             `yaml:"scale"`
+		Calibrations []UICalibration `yaml:"calibrations"`
+	} `yaml:"ui"`
+	// GameVersion is the D2R version the memory offsets are known to work with, the one of the build when empty. A
+	// newer client is handled as set by OnUnsupported: stop (don't start the supervisors), town_idle (stay in
+	// town without running anything) or ignore.
+	GameVersion struct {
+		Supported     string `yaml:"supported"`
+		OnUnsupported string `yaml:"onUnsupported"`
+	} `yaml:"gameVersion"`
+	// OCR reads the item tooltips with Tesseract (installed apart) to cross-check the stats read in memory, a mismatch
+	// usually means the memory offsets broke after a game patch.
+	OCR struct {
+		Enabled            bool   `yaml:"enabled"`
+		TesseractPath      string `yaml:"tesseractPath"`      // tesseract in the PATH when empty
+		VerifyStashedItems bool   `yaml:"verifyStashedItems"` // Cross-check every stashed item
+	} `yaml:"ocr"`
 	RunewordFavoriteRecipes []string `yaml:"runewordFavoriteRecipes"`
 	RunFavoriteRuns         []string `yaml:"runFavoriteRuns"`
 }
 
+// UICalibration maps the 1280x720 UI coordinates to a window size and UI scale: x*ScaleX+OffsetX, y*ScaleY+OffsetY.
+type UICalibration struct {
+	Width   int     `yaml:"width"`
+	Height  int     `yaml:"height"`
+	Scale   int     `yaml:"scale"` // UI scale in percent, 0 is 100
+	ScaleX  float64 `yaml:"scaleX"`
+	ScaleY  float64 `yaml:"scaleY"`
+	OffsetX int     `yaml:"offsetX"`
+	OffsetY int     `yaml:"offsetY"`
+}
+
 type Day struct {
 	DayOfWeek  int         `yaml:"dayOfWeek"`
 	TimeRanges []TimeRange `yaml:"timeRange"`
@@ -136,6 +193,10 @@ type Scheduler struct {
 	// Time Slots Mode (existing)
 	Days              []Day `yaml:"days"`
 	GlobalVarianceMin int   `yaml:"globalVarianceMin,omitempty"` // Default variance for all ranges (+/- minutes)
+	// Breaks splits the time slots in play sessions of random length with a random break between them
+	Breaks SlotBreaks `yaml:"breaks,omitempty"`
+	// SuspendOnBreaks parks the character in town during breaks instead of stopping the supervisor
+	SuspendOnBreaks bool `yaml:"suspendOnBreaks,omitempty"`
 
 	// Duration Mode
 	Duration DurationSchedule `yaml:"duration,omitempty"`
@@ -169,6 +230,14 @@ type DurationSchedule struct {
 	JitterMax int `yaml:"jitterMax"` // Max jitter multiplier % (e.g., 150)
 }
 
+// SlotBreaks configures the sessions played inside the time slots, a zero session length plays the whole slot.
+type SlotBreaks struct {
+	SessionMinutes  int `yaml:"sessionMinutes"`  // Base play session length (e.g., 90)
+	SessionVariance int `yaml:"sessionVariance"` // +/- minutes for the session length (e.g., 30)
+	BreakMinutes    int `yaml:"breakMinutes"`    // Base break length (e.g., 10)
+	BreakVariance   int `yaml:"breakVariance"`   // +/- minutes for the break length (e.g., 5)
+}
+
 type TimeRange struct {
 	Start            time.Time `yaml:"start"`
 	End              time.Time `yaml:"end"`
@@ -193,6 +262,56 @@ type AutoStatSkillStat struct {
 type AutoStatSkillSkill struct {
 	Skill  string `yaml:"skill"`
 	Target int    `yaml:"target"`
+	// MinLevel delays the step until the character reaches this level, allowing to plan the skill order by level.
+	MinLevel int `yaml:"minLevel,omitempty"`
+}
+
+// ThreatScoringConfig holds the weights used to rank enemies when picking the next target. Zero weights fall back
+// to the defaults, negative values can be used to disable a factor.
+type ThreatScoringConfig struct {
+	Enabled          bool          `yaml:"enabled"`
+	PriorityWeight   float64       `yaml:"priorityWeight,omitempty"` // Shamans, reanimators and other summoners
+	RangedWeight     float64       `yaml:"rangedWeight,omitempty"`
+	AuraWeight       float64       `yaml:"auraWeight,omitempty"` // Might, Fanaticism, Conviction...
+	EliteWeight      float64       `yaml:"eliteWeight,omitempty"`
+	ImmunityPenalty  float64       `yaml:"immunityPenalty,omitempty"`
+	DistanceWeight   float64       `yaml:"distanceWeight,omitempty"`
+	BuildDamageTypes []stat.Resist `yaml:"buildDamageTypes,omitempty"`
+}
+
+// RotationStep is one entry of a data-driven combat rotation. Steps are evaluated in order and the first one whose
+// conditions are met is cast, so the list works as a priority list.
+type RotationStep struct {
+	Skill            string        `yaml:"skill"`                      // Skill key as in d2go skill names, e.g. "BlessedHammer"
+	Target           string        `yaml:"target,omitempty"`           // any (default), normal, elite, boss or self
+	MinDistance      int           `yaml:"minDistance,omitempty"`      // Min distance to the target in yards
+	MaxDistance      int           `yaml:"maxDistance,omitempty"`      // Max distance to the target in yards, 0 means no limit
+	CooldownMs       int           `yaml:"cooldownMs,omitempty"`       // Time to wait before casting this step again
+	MinManaPercent   int           `yaml:"minManaPercent,omitempty"`   // Skip this step when mana is below this %
+	SkipOnImmunities []stat.Resist `yaml:"skipOnImmunities,omitempty"` // Skip this step against monsters immune to any
+	Attacks          int           `yaml:"attacks,omitempty"`          // Number of casts per step, defaults to 1
+}
+
+// BuildSwitchConfig respecs the character into another build once it reaches Level, e.g. leveling as
+// sorceress_leveling and farming as a Blizzard sorceress from level 60. Class and Runs replace the current ones when
+// set, the stat and skill targets replace the auto stat/skill targets.
+type BuildSwitchConfig struct {
+	Enabled    bool                 `yaml:"enabled"`
+	Level      int                  `yaml:"level"`
+	Class      string               `yaml:"class,omitempty"`
+	Runs       []Run                `yaml:"runs,omitempty"`
+	Stats      []AutoStatSkillStat  `yaml:"stats,omitempty"`
+	Skills     []AutoStatSkillSkill `yaml:"skills,omitempty"`
+	TokenFirst bool                 `yaml:"tokenFirst,omitempty"`
+	Applied    bool                 `yaml:"applied,omitempty"`
+}
+
+// ChargedSkill is a skill cast from the charges of an equipped item, like Lower Resist from a wand or Teleport from a
+// staff. It has to be bound in game unless packet skill selection is enabled.
+type ChargedSkill struct {
+	Skill         string `yaml:"skill"`                   // Skill key as in d2go skill names, e.g. "LowerResist"
+	Target        string `yaml:"target,omitempty"`        // boss (default), elite or any, cast once per monster
+	RechargeBelow int    `yaml:"rechargeBelow,omitempty"` // Recharge at the repair NPC below these charges, 0 once empty
 }
 
 type AutoRespecConfig struct {
@@ -202,6 +321,63 @@ type AutoRespecConfig struct {
 	Applied     bool `yaml:"applied,omitempty"`
 }
 
+// RunCondition restricts a run to the characters meeting all the set criteria, empty values are ignored.
+type RunCondition struct {
+	MinLevel         int                     `yaml:"minLevel,omitempty"`
+	MaxLevel         int                     `yaml:"maxLevel,omitempty"`
+	RequiredSkill    string                  `yaml:"requiredSkill,omitempty"`
+	RequiredEquipped []string                `yaml:"requiredEquipped,omitempty"`
+	Difficulties     []difficulty.Difficulty `yaml:"difficulties,omitempty"`
+}
+
+// AreaOverride tunes the behavior inside an area, the zero value keeps the character settings.
+type AreaOverride struct {
+	SkipPickup       bool          `yaml:"skipPickup,omitempty"`       // Items are never picked up in the area
+	NoClear          bool          `yaml:"noClear,omitempty"`          // Level clears are skipped in the area
+	ChickenAt        int           `yaml:"chickenAt,omitempty"`        // Replaces health.chickenAt in the area, 0 keeps it
+	SkipOnImmunities []stat.Resist `yaml:"skipOnImmunities,omitempty"` // Monsters immune to any of these are left alone
+}
+
+// DangerRule scores the elite packs having all the immunities, auras and physical resist of the rule.
+type DangerRule struct {
+	Name             string        `yaml:"name"`
+	Immunities       []stat.Resist `yaml:"immunities,omitempty"`
+	Auras            []string      `yaml:"auras,omitempty"`            // Aura states of the pack, e.g. fanaticism, conviction, might
+	PhysicalResistAt int           `yaml:"physicalResistAt,omitempty"` // Stone skin packs, 100 is physical immune
+	Areas            []area.ID     `yaml:"areas,omitempty"`            // Empty matches every area
+	Runs             []string      `yaml:"runs,omitempty"`             // Empty matches every run
+	Score            int           `yaml:"score"`
+}
+
+// ActionPolicy is the middleware policy of an action, the zero value runs it once without timeout.
+type ActionPolicy struct {
+	TimeoutSeconds int `yaml:"timeoutSeconds"`
+	Retries        int `yaml:"retries"`   // Extra attempts after a failure
+	BackoffMS      int `yaml:"backoffMs"` // Delay before the first retry, doubled on every retry
+}
+
+// Humanization varies the behavior between games. Seed makes the variance reproducible when debugging, 0 picks a new
+// one every start, logged by the supervisor.
+type Humanization struct {
+	Enabled              bool  `yaml:"enabled"`
+	Seed                 int64 `yaml:"seed"`
+	ShuffleRuns          bool  `yaml:"shuffleRuns"`
+	ShuffleTownRoute     bool  `yaml:"shuffleTownRoute"`
+	PickupRadiusVariance int   `yaml:"pickupRadiusVariance"` // Yards added or removed to the pickup radius every game
+	ActionDelayMinMS     int   `yaml:"actionDelayMinMs"`     // Pause before the actions and the runs
+	ActionDelayMaxMS     int   `yaml:"actionDelayMaxMs"`
+	GameLengthVariance   int   `yaml:"gameLengthVariance"` // Max game length shortened by up to this percent every game
+	// SessionLengthVariance varies the length of the scheduler play sessions by up to this percent
+	SessionLengthVariance int `yaml:"sessionLengthVariance"`
+}
+
+// ScriptHook runs a script on a lifecycle event of the character, see the script package for what the scripts can use.
+type ScriptHook struct {
+	On   string `yaml:"on"`   // on_run_start, on_item_stashed, on_death or on_town_visit
+	When string `yaml:"when"` // Condition, Do runs every time when it's empty
+	Do   string `yaml:"do"`
+}
+
 type CharacterCfg struct {
 	MaxGameLength        int    `yaml:"maxGameLength"`
 	Username             string `yaml:"username"`
@@ -217,6 +393,50 @@ type CharacterCfg struct {
 	UseCentralizedPickit bool   `yaml:"useCentralizedPickit"`
 	HidePortraits        bool   `yaml:"hidePortraits"`
 	AutoStart            bool   `yaml:"autoStart"`
+	// Extends is the profile the settings are inherited from, a yaml file in the config folder. base.yaml is used when
+	// empty and present, "none" inherits nothing. Only the settings differing from the profile are saved.
+	Extends string `yaml:"extends,omitempty"`
+	// Window is the game window position and size saved for the supervisor, applied every time the client starts.
+	Window struct {
+		Enabled bool `yaml:"enabled"`
+		X       int  `yaml:"x"`
+		Y       int  `yaml:"y"`
+		Width   int  `yaml:"width"`
+		Height  int  `yaml:"height"`
+	} `yaml:"window"`
+	// GameData tunes the memory reads: the background routine reads a snapshot every TickMS and the refreshes closer
+	// than CoalesceMS to the last read reuse it instead of reading the memory again, unless an input was sent since. 0
+	// keeps 100ms and no coalescing.
+	GameData struct {
+		TickMS     int `yaml:"tickMs"`
+		CoalesceMS int `yaml:"coalesceMs"`
+	} `yaml:"gameData"`
+	// ActionPolicies sets the timeout and the retries of the actions run through the action middleware, keyed by the
+	// action name (Stash, VendorRefill...), "default" applies to the actions without their own policy.
+	ActionPolicies map[string]ActionPolicy `yaml:"actionPolicies,omitempty"`
+	// SelfCheck walks a recovery ladder (re-sync, return to town, leave game, restart client) when the bot stops making
+	// progress: the same action for StuckActionMinutes, no position change for StuckPositionSeconds or the same action
+	// error RepeatedErrors times in a row. Every step gets EscalateSeconds to work before the next one, 0 uses defaults.
+	SelfCheck struct {
+		Enabled              bool `yaml:"enabled"`
+		StuckActionMinutes   int  `yaml:"stuckActionMinutes"`
+		StuckPositionSeconds int  `yaml:"stuckPositionSeconds"`
+		RepeatedErrors       int  `yaml:"repeatedErrors"`
+		EscalateSeconds      int  `yaml:"escalateSeconds"`
+	} `yaml:"selfCheck"`
+	// AntiIdle does small humanlike behaviors every MinSeconds to MaxSeconds during the long waits (leader, rushee,
+	// leechers, realm queue), disabled by default.
+	AntiIdle struct {
+		Enabled         bool `yaml:"enabled"`
+		MinSeconds      int  `yaml:"minSeconds"`
+		MaxSeconds      int  `yaml:"maxSeconds"`
+		MouseMovement   bool `yaml:"mouseMovement"`
+		Reposition      bool `yaml:"reposition"`
+		ToggleInventory bool `yaml:"toggleInventory"`
+	} `yaml:"antiIdle"`
+	Humanization Humanization `yaml:"humanization"`
+	// Hooks are the scripts run on the lifecycle events, e.g. stopping the session once a Jah rune is stashed
+	Hooks []ScriptHook `yaml:"hooks,omitempty"`
 
 	ConfigFolderName string `yaml:"-"`
 
@@ -240,7 +460,16 @@ type CharacterCfg struct {
 		ChickenAt           int `yaml:"chickenAt"`
 		TownChickenAt       int `yaml:"townChickenAt"`
 		MercChickenAt       int `yaml:"mercChickenAt"`
+		// FreeHealing uses the town healer and the wells near the path instead of the potions
+		FreeHealing struct {
+			Enabled         bool `yaml:"enabled"`
+			TownHealerAt    int  `yaml:"townHealerAt"`
+			WellAt          int  `yaml:"wellAt"`
+			WellMaxDistance int  `yaml:"wellMaxDistance"`
+		} `yaml:"freeHealing"`
 	} `yaml:"health"`
+	// AreaOverrides tunes the behavior inside specific areas, keyed by area ID
+	AreaOverrides   map[area.ID]AreaOverride `yaml:"areaOverrides,omitempty"`
 	ChickenOnCurses struct {
 		AmplifyDamage bool `yaml:"amplifyDamage"`
 		Decrepify     bool `yaml:"decrepify"`
@@ -262,19 +491,37 @@ type CharacterCfg struct {
 		HealingPotionCount int         `yaml:"healingPotionCount"`
 		ManaPotionCount    int         `yaml:"manaPotionCount"`
 		RejuvPotionCount   int         `yaml:"rejuvPotionCount"`
+		// Scrolls kept in the tomes, refilled in town once below. 0 keeps the defaults, refilling under 5 TPs and 10 IDs
+		TPScrollTarget int `yaml:"tpScrollTarget"`
+		IDScrollTarget int `yaml:"idScrollTarget"`
+		// BackupTPTome keeps a spare TP tome in the stash, taken back when the inventory one is gone
+		BackupTPTome bool `yaml:"backupTPTome"`
+		// ChickenOnNoTPs leaves the game when going back to town failed with the TP tome empty, instead of going on
+		// without a way back
+		ChickenOnNoTPs bool `yaml:"chickenOnNoTPs"`
 	} `yaml:"inventory"`
 	Character struct {
 		Class                        string              `yaml:"class"`
 		UseMerc                      bool                `yaml:"useMerc"`
 		StashToShared                bool                `yaml:"stashToShared"`
+		StashFullAction              string              `yaml:"stashFullAction"`
 		UseTeleport                  bool                `yaml:"useTeleport"`
 		ClearPathDist                int                 `yaml:"clearPathDist"`
 		ShouldHireAct2MercFrozenAura bool                `yaml:"shouldHireAct2MercFrozenAura"`
 		UseExtraBuffs                bool                `yaml:"useExtraBuffs"`
 		UseSwapForBuffs              bool                `yaml:"use_swap_for_buffs"`
+		TeleportWithSwap             bool                `yaml:"teleportWithSwap"` // Teleport charges on the second weapon set
 		BuffOnNewArea                bool                `yaml:"buffOnNewArea"`
 		BuffAfterWP                  bool                `yaml:"buffAfterWP"`
+		BuffOnRunStart               bool                `yaml:"buffOnRunStart"`
+		RebuffIntervalSeconds        int                 `yaml:"rebuffIntervalSeconds"`
 		AutoStatSkill                AutoStatSkillConfig `yaml:"autoStatSkill"`
+		BuildSwitch                  BuildSwitchConfig   `yaml:"buildSwitch"`
+		CombatRotation               []RotationStep      `yaml:"combatRotation,omitempty"`
+		ChargedSkills                []ChargedSkill      `yaml:"chargedSkills,omitempty"`
+		ThreatScoring                ThreatScoringConfig `yaml:"threatScoring"`
+		AvoidDeathEffects            bool                `yaml:"avoidDeathEffects"`
+		DodgeHazards                 bool                `yaml:"dodgeHazards"`
 		BerserkerBarb                struct {
 			FindItemSwitch              bool `yaml:"find_item_switch"`
 			SkipPotionPickupInTravincal bool `yaml:"skip_potion_pickup_in_travincal"`
@@ -292,6 +539,12 @@ type CharacterCfg struct {
 			HorkNormalMonsters          bool `yaml:"hork_normal_monsters"`
 			HorkMonsterCheckRange       int  `yaml:"hork_monster_check_range"`
 		} `yaml:"whirlwind_barb"`
+		FrenzyBarb struct {
+			WhirlwindMinMonsters  int  `yaml:"whirlwind_min_monsters"`
+			BuffParty             bool `yaml:"buff_party"`
+			HorkNormalMonsters    bool `yaml:"hork_normal_monsters"`
+			HorkMonsterCheckRange int  `yaml:"hork_monster_check_range"`
+		} `yaml:"frenzy_barb"`
 		BlizzardSorceress struct {
 			UseMoatTrick        bool `yaml:"use_moat_trick"`
 			UseStaticOnMephisto bool `yaml:"use_static_on_mephisto"`
@@ -332,6 +585,11 @@ type CharacterCfg struct {
 			UseBladesOfIce    bool `yaml:"useBladesOfIce"`
 			UseFistsOfFire    bool `yaml:"useFistsOfFire"`
 		} `yaml:"mosaic_sin"`
+		HybridTrapsin struct {
+			UseDragonFlight       bool `yaml:"use_dragon_flight"`
+			UseBurstOfSpeed       bool `yaml:"use_burst_of_speed"`
+			DeathSentryMinCorpses int  `yaml:"death_sentry_min_corpses"`
+		} `yaml:"hybrid_trapsin"`
 		AssassinLeveling struct {
 			UsePacketLearning bool `yaml:"use_packet_learning"`
 		} `yaml:"assassin_leveling"`
@@ -343,7 +601,10 @@ type CharacterCfg struct {
 			DensityKillerIgnoreWhitesBelow int  `yaml:"density_killer_ignore_whites_below"`
 			// Force a vendor "Repair All" to replenish javelins in town when quantity is below this % threshold.
 			// Only applied for the Javazon build when DensityKillerEnabled is true.
-			DensityKillerForceRefillBelowPercent int `yaml:"density_killer_force_refill_below_percent"`
+			DensityKillerForceRefillBelowPercent int  `yaml:"density_killer_force_refill_below_percent"`
+			UseDecoy                             bool `yaml:"use_decoy"`
+			MinFuryJavelins                      int  `yaml:"min_fury_javelins"`
+			ReplenishFromStash                   bool `yaml:"replenish_from_stash"`
 		} `yaml:"javazon"`
 		DruidLeveling struct {
 			UsePacketLearning bool `yaml:"use_packet_learning"`
@@ -351,6 +612,12 @@ type CharacterCfg struct {
 		NecromancerLeveling struct {
 			UsePacketLearning bool `yaml:"use_packet_learning"`
 		} `yaml:"necromancer_leveling"`
+		PoisonBoneNecro struct {
+			// Mode selects the main skill, either "poison" (Poison Nova) or "bone" (Bone Spear).
+			Mode                       string `yaml:"mode"`
+			UseCorpseExplosion         bool   `yaml:"use_corpse_explosion"`
+			CorpseExplosionMinMonsters int    `yaml:"corpse_explosion_min_monsters"`
+		} `yaml:"poison_bone_necro"`
 		PaladinLeveling struct {
 			UsePacketLearning bool `yaml:"use_packet_learning"`
 		} `yaml:"paladin_leveling"`
@@ -386,15 +653,41 @@ type CharacterCfg struct {
 		ClearTPArea             bool                  `yaml:"clearTPArea"`
 		Difficulty              difficulty.Difficulty `yaml:"difficulty"`
 		RandomizeRuns           bool                  `yaml:"randomizeRuns"`
+		RunTimeBudget           bool                  `yaml:"runTimeBudget"`
 		Runs                    []Run                 `yaml:"runs"`
+		RunConditions           map[Run]RunCondition  `yaml:"runConditions,omitempty"`
 		CreateLobbyGames        bool                  `yaml:"createLobbyGames"`
 		PublicGameCounter       int                   `yaml:"-"`
 		MaxFailedMenuAttempts   int                   `yaml:"maxFailedMenuAttempts"`
 		Pindleskin              struct {
 			SkipOnImmunities []stat.Resist `yaml:"skipOnImmunities"`
 		} `yaml:"pindleskin"`
+		// RunFailures skips a run for BlacklistGames games once it fails MaxFailures games in a row, 0 disables it
+		RunFailures struct {
+			MaxFailures    int `yaml:"maxFailures"`
+			BlacklistGames int `yaml:"blacklistGames"`
+		} `yaml:"runFailures"`
+		// PickupRadius is the max distance the items of a class are fetched from, 0 keeps the default: the radius
+		// given by the caller for the potions and no limit for the rest
+		PickupRadius struct {
+			Gold    int `yaml:"gold"`
+			Potions int `yaml:"potions"`
+			Runes   int `yaml:"runes"`
+			Gems    int `yaml:"gems"`
+			Other   int `yaml:"other"`
+		} `yaml:"pickupRadius"`
+		// DangerScoring scores the elite packs met when entering an area, a run is skipped or the game rerolled when
+		// the score reaches the threshold
+		DangerScoring struct {
+			Enabled   bool         `yaml:"enabled"`
+			Action    string       `yaml:"action"` // skip (next run) or reroll (next game)
+			Threshold int          `yaml:"threshold"`
+			Radius    int          `yaml:"radius"` // Max distance of the packs from the player, 0 scores every pack loaded
+			Rules     []DangerRule `yaml:"rules"`
+		} `yaml:"dangerScoring"`
 		Cows struct {
 			OpenChests bool `yaml:"openChests"`
+			AvoidKing  bool `yaml:"avoidKing"`
 		} `yaml:"cows"`
 		Pit struct {
 			MoveThroughBlackMarsh bool `yaml:"moveThroughBlackMarsh"`
@@ -428,6 +721,8 @@ type CharacterCfg struct {
 		} `yaml:"ancient_tunnels"`
 		Summoner struct {
 			KillFireEye bool `yaml:"killFireEye"`
+			// ClearPath kills what is on the way to the Summoner, ghosts over the void are skipped.
+			ClearPath bool `yaml:"clearPath"`
 		} `yaml:"summoner"`
 		DrifterCavern struct {
 			OpenChests        bool `yaml:"openChests"`
@@ -467,7 +762,115 @@ type CharacterCfg struct {
 			SoulQuit    bool `yaml:"soulQuit"`
 			ClearFloors bool `yaml:"clearFloors"`
 			OnlyElites  bool `yaml:"onlyElites"`
+			// Leader hosts public Baal runs: it opens a portal at the throne, waits for the leechers, announces the
+			// progress in chat and moves on to the next game once the game lasted NextGameSeconds.
+			Leader struct {
+				Enabled         bool   `yaml:"enabled"`
+				TPMessage       string `yaml:"tpMessage"`
+				GoMessage       string `yaml:"goMessage"`
+				Leechers        int    `yaml:"leechers"`
+				WaitAtThrone    bool   `yaml:"waitAtThrone"`
+				WaitBeforeBaal  bool   `yaml:"waitBeforeBaal"`
+				WaitSeconds     int    `yaml:"waitSeconds"`
+				NextGameSeconds int    `yaml:"nextGameSeconds"`
+			} `yaml:"leader"`
 		} `yaml:"baal"`
+		TalRashaTombs struct {
+			OnlyElites   bool `yaml:"onlyElites"`
+			SkipTrueTomb bool `yaml:"skipTrueTomb"`
+		} `yaml:"talRashaTombs"`
+		AutoDifficulty struct {
+			Enabled bool `yaml:"enabled"`
+			// SampleGames is the amount of games evaluated before deciding, the samples are reset after each decision.
+			SampleGames       int `yaml:"sampleGames"`
+			MaxDeathPercent   int `yaml:"maxDeathPercent"`
+			MaxRunSeconds     int `yaml:"maxRunSeconds"`
+			NightmareMinLevel int `yaml:"nightmareMinLevel"`
+			HellMinLevel      int `yaml:"hellMinLevel"`
+		} `yaml:"autoDifficulty"`
+		// PickitOverrides maps a run name to a NIP file merged over the base pickit rules during that run, paths are
+		// relative to the character config folder.
+		PickitOverrides map[string]string `yaml:"pickitOverrides,omitempty"`
+		Identify        struct {
+			// Policy is "town" (identify with Cain or the tome in town) or "stash", keeping every unidentified item in
+			// the stash for manual review.
+			Policy string `yaml:"policy"`
+			// InField identifies with the tome when the inventory is full, dropping the junk instead of going to town.
+			InField bool `yaml:"inField"`
+			// NeverIdentify lists the item names sold unidentified, identifying them only lowers their price.
+			NeverIdentify []string `yaml:"neverIdentify"`
+			// UnidStashRules is a NIP file, items matching it are stashed without identifying them.
+			UnidStashRules string `yaml:"unidStashRules"`
+		} `yaml:"identify"`
+		// Imbue uses Charsi's quest reward on the first base matching BaseRules, a NIP file relative to the character
+		// config folder. The imbued item is kept or sold like any other item.
+		Imbue struct {
+			Enabled   bool   `yaml:"enabled"`
+			BaseRules string `yaml:"baseRules"`
+		} `yaml:"imbue"`
+		// Larzuk sockets the first item of Queue found in the inventory or stash with his quest reward, names are
+		// matched like the personalized item. Items left waiting take the reward of the next difficulty.
+		Larzuk struct {
+			Enabled bool     `yaml:"enabled"`
+			Queue   []string `yaml:"queue"`
+		} `yaml:"larzuk"`
+		// QuestBugs keeps the boss quests open for their quest drops: the boss is farmed while the quest isn't done and
+		// the steps completing it (Warriv, the red portal to Act 4, talking to Larzuk) are skipped. Ignored when leveling.
+		QuestBugs struct {
+			Andariel bool `yaml:"andariel"` // Sisters to the Slaughter
+			Council  bool `yaml:"council"`  // The Blackened Temple
+			Mephisto bool `yaml:"mephisto"` // The Guardian
+			Shenk    bool `yaml:"shenk"`    // Siege on Harrogath, farmed with Eldritch
+		} `yaml:"questBugs"`
+		// Players sets the /players count before each run of the games created without Battle.net: Default while
+		// leveling or farming runes, PerRun overrides it for the boss MF kills, e.g. {mephisto: 1, baal: 1}. 0 leaves
+		// the count as it is.
+		Players struct {
+			Enabled bool           `yaml:"enabled"`
+			Default int            `yaml:"default"`
+			PerRun  map[string]int `yaml:"perRun,omitempty"`
+		} `yaml:"players"`
+		// SinglePlayer options, only used in offline games (authMethod None).
+		SinglePlayer struct {
+			// ReuseLayouts stores the map data per seed in the layouts folder of the character, the seed of a
+			// single-player character doesn't change between games so the layouts (Lower Kurast huts, the Hellforge
+			// position...) are generated once. Only the last 12 seeds played are kept.
+			ReuseLayouts bool `yaml:"reuseLayouts"`
+		} `yaml:"singlePlayer"`
+		// Flavor selects the game interaction assumptions (lobby, stash tabs, currency items), see GameFlavor.
+		Flavor struct {
+			Name string `yaml:"name"` // standard, tcp or private
+			// TCPHost is the address of the TCP/IP game to join with the tcp flavor, empty to host the game
+			TCPHost string `yaml:"tcpHost"`
+			// StashTabs, StashPageButtons and CurrencyItems are only used by the private flavor
+			StashTabs        int              `yaml:"stashTabs"`
+			StashPageButtons StashPageButtons `yaml:"stashPageButtons"`
+			CurrencyItems    []string         `yaml:"currencyItems,omitempty"`
+		} `yaml:"flavor"`
+		Ubers struct {
+			// FarmKeys runs Countess, Summoner and Nihlathak until the stash holds 3 keys of each type.
+			FarmKeys bool `yaml:"farmKeys"`
+			// Gear requirements, runs are skipped when the character doesn't meet them. Resists are Hell values,
+			// checked when CheckResists is set so a requirement of 0 can be expressed.
+			MinLife        int  `yaml:"minLife"`
+			CheckResists   bool `yaml:"checkResists"`
+			MinResists     int  `yaml:"minResists"`
+			RequireLifeTap bool `yaml:"requireLifeTap"`
+		} `yaml:"ubers"`
+		DiabloClone struct {
+			// OnlyWhenSeen skips the run unless Diablo Clone was already spotted in the current game.
+			OnlyWhenSeen bool `yaml:"onlyWhenSeen"`
+			MinLife      int  `yaml:"minLife"`
+			CheckResists bool `yaml:"checkResists"`
+			MinResists   int  `yaml:"minResists"`
+		} `yaml:"diabloClone"`
+		// Rush takes the rushee through the quests, it waits for the rushee at each quest boss before killing it. A
+		// bot rushee follows us with the companion follower mode.
+		Rush struct {
+			Rushee      string   `yaml:"rushee"`
+			Quests      []string `yaml:"quests"`
+			WaitSeconds int      `yaml:"waitSeconds"`
+		} `yaml:"rush"`
 		Eldritch struct {
 			KillShenk bool `yaml:"killShenk"`
 		} `yaml:"eldritch"`
@@ -480,6 +883,8 @@ type CharacterCfg struct {
 			SkipOtherRuns     bool          `yaml:"skipOtherRuns"`
 			Areas             []area.ID     `yaml:"areas"`
 			OpenChests        bool          `yaml:"openChests"`
+			// PrioritizeMatchingRuns moves the runs clearing the active terror zone to the front of the rotation.
+			PrioritizeMatchingRuns bool `yaml:"prioritizeMatchingRuns"`
 		} `yaml:"terror_zone"`
 		Leveling struct {
 			EnsurePointsAllocation   bool     `yaml:"ensurePointsAllocation"`
@@ -492,6 +897,24 @@ type CharacterCfg struct {
 			HellRequiredFireRes      int      `yaml:"hellRequiredFireRes"`
 			HellRequiredLightRes     int      `yaml:"hellRequiredLightRes"`
 			EnabledRunewordRecipes   []string `yaml:"enabledRunewordRecipes"`
+			// Character level required to leave Act 2 and Act 4, zero uses the defaults.
+			Act3RequiredLevel          int `yaml:"act3RequiredLevel"`
+			NormalAct5RequiredLevel    int `yaml:"normalAct5RequiredLevel"`
+			NightmareAct5RequiredLevel int `yaml:"nightmareAct5RequiredLevel"`
+			// Do the reward quests (Den, Radament, Izual, Anya) left behind in the completed acts
+			BackfillQuests bool `yaml:"backfillQuests"`
+			// Collect the missing waypoints of the accessible acts before going on
+			CollectWaypoints bool `yaml:"collectWaypoints"`
+			// Minimum XP/hour of a farming run by character level, the threshold of the highest level not above the
+			// character level applies. Farming runs below it are swapped for the other farming runs. It only applies
+			// to the farming done before Diablo and Baal, not to the low gold farms or the quest runs.
+			MinXPPerHour map[int]int `yaml:"minXPPerHour,omitempty"`
+			// AutoEquipNonLeveling runs the auto equip on town visits for the characters not running a leveling class.
+			AutoEquipNonLeveling bool `yaml:"autoEquipNonLeveling"`
+			// AutoEquipMinUpgrade is the score gain in percent an item needs over the equipped one to be equipped.
+			AutoEquipMinUpgrade int `yaml:"autoEquipMinUpgrade"`
+			// AutoEquipStatWeights overrides the auto equip stat weights, keyed by stat name (e.g. "fasterhitrecovery").
+			AutoEquipStatWeights map[string]float64 `yaml:"autoEquipStatWeights,omitempty"`
 		} `yaml:"leveling"`
 		RunewordMaker struct {
 			Enabled              bool     `yaml:"enabled"`
@@ -499,6 +922,10 @@ type CharacterCfg struct {
 			AutoUpgrade          bool     `yaml:"autoUpgrade"`          // Upgrade when better tier base found
 			OnlyIfWearable       bool     `yaml:"onlyIfWearable"`       // Only make if character meets str/dex requirements
 			AutoTierByDifficulty bool     `yaml:"autoTierByDifficulty"` // Auto-select tier based on difficulty
+			// CollectBases picks up and stashes the bases the enabled runewords need until one is owned,
+			// CollectSocketableBases takes unsocketed bases able to get enough sockets too.
+			CollectBases           bool `yaml:"collectBases"`
+			CollectSocketableBases bool `yaml:"collectSocketableBases"`
 		} `yaml:"runewordMaker"`
 		LevelingSequence struct {
 			SequenceFile string `yaml:"sequenceFile"`
@@ -514,6 +941,9 @@ type CharacterCfg struct {
 			KillShenk      bool `yaml:"killShenk"`
 			RescueAnya     bool `yaml:"rescueAnya"`
 			KillAncients   bool `yaml:"killAncients"`
+
+			// PersonalizeItem is the item Anya personalizes once Nihlathak is dead, by name. Empty leaves the reward
+			PersonalizeItem string `yaml:"personalizeItem"`
 		} `yaml:"quests"`
 		Utility struct {
 			ParkingAct int `yaml:"parkingAct"`
@@ -528,13 +958,51 @@ type CharacterCfg struct {
 		LeaderName            string `yaml:"leaderName"`
 		GameNameTemplate      string `yaml:"gameNameTemplate"`
 		GamePassword          string `yaml:"gamePassword"`
+		GameCounterDigits     int    `yaml:"gameCounterDigits"` // Pads the game counter with zeros, 3 turns game-1 into game-001
+		GameNameSuffixLength  int    `yaml:"gameNameSuffixLength"`
+		PasswordPolicy        string `yaml:"passwordPolicy"`
+		PasswordLength        int    `yaml:"passwordLength"`
+		NameCollisionRetries  int    `yaml:"nameCollisionRetries"` // Games retried with a new name when the name is taken, not counted as failures
 		CompanionGameName     string `yaml:"companionGameName"`
 		CompanionGamePassword string `yaml:"companionGamePassword"`
+		// FollowerMode makes the follower stay with the leader instead of doing its own runs: "assist" fights next to
+		// the leader, "leech" keeps a safe distance to share the experience. Empty keeps the configured runs.
+		FollowerMode   string `yaml:"followerMode"`
+		FollowDistance int    `yaml:"followDistance"`
 	} `yaml:"companion"`
 	Gambling struct {
 		Enabled bool     `yaml:"enabled"`
 		Items   []string `yaml:"items,omitempty"`
+		// StartGold is the total gold needed to start gambling, then it goes on until the gold drops below GoldFloor.
+		StartGold int `yaml:"startGold,omitempty"`
+		GoldFloor int `yaml:"goldFloor,omitempty"`
 	} `yaml:"gambling"`
+	Gold struct {
+		// StashAbove deposits the inventory gold when above this amount, 0 keeps up to a third of the max gold by level.
+		StashAbove int `yaml:"stashAbove"`
+		// TownVisitPercent goes back to town when carrying more than this percent of the max gold by level, dying
+		// loses part of the carried gold. It lowers the stash threshold to match, 0 disables it.
+		TownVisitPercent int `yaml:"townVisitPercent"`
+	} `yaml:"gold"`
+	StashOrganizer struct {
+		Enabled bool `yaml:"enabled"`
+		// EveryGames runs the organizer once every N games, it takes a while with a full stash.
+		EveryGames int `yaml:"everyGames"`
+		// Tabs maps the item categories (runes, gems, charms, jewels, uniques, sets, bases) to a stash tab, 1 is the
+		// personal stash and 2-4 the shared ones. Items without a category are left where they are.
+		Tabs map[string]int `yaml:"tabs"`
+	} `yaml:"stashOrganizer"`
+	// SessionGoals ends the session when any of the goals is reached, zero or empty values disable a goal.
+	SessionGoals struct {
+		Enabled    bool `yaml:"enabled"`
+		MaxRuns    int  `yaml:"maxRuns"`
+		MaxMinutes int  `yaml:"maxMinutes"`
+		// Items matches the item name or its unique/set name, e.g. Shako or Harlequin Crest.
+		Items []string `yaml:"items,omitempty"`
+		Level int      `yaml:"level"`
+		// SwitchTo starts this supervisor once the goal is reached, empty just stops.
+		SwitchTo string `yaml:"switchTo"`
+	} `yaml:"sessionGoals"`
 	Muling struct {
 		Enabled      bool     `yaml:"enabled"`
 		SwitchToMule string   `yaml:"switchToMule"`
@@ -557,12 +1025,36 @@ type CharacterCfg struct {
 		NoMpPotions     bool `yaml:"noMpPotions"`
 		MercDied        bool `yaml:"mercDied"`
 		EquipmentBroken bool `yaml:"equipmentBroken"`
+		// RepairPercent is the durability percent below which the equipment is repaired.
+		RepairPercent int `yaml:"repairPercent"`
+		// EtherealWarnPercent warns once when an equipped ethereal item drops below it, they can't be repaired.
+		EtherealWarnPercent int `yaml:"etherealWarnPercent"`
 	} `yaml:"backtotown"`
 	Shopping ShoppingConfig `yaml:"shopping"`
 	Runtime  struct {
-		Rules     nip.Rules   `yaml:"-"`
-		TierRules []int       `yaml:"-"`
-		Drops     []data.Item `yaml:"-"`
+		// Rules are the base rules plus every per-run override, used to decide what to keep.
+		Rules     nip.Rules `yaml:"-"`
+		TierRules []int     `yaml:"-"`
+		// PickupRules are the base rules plus the override of the current run, used to decide what to pick up.
+		PickupRules     nip.Rules            `yaml:"-"`
+		PickupTierRules []int                `yaml:"-"`
+		BaseRules       nip.Rules            `yaml:"-"`
+		RunRules        map[string]nip.Rules `yaml:"-"`
+		PickitRun       string               `yaml:"-"`
+		UnidStashRules  nip.Rules            `yaml:"-"`
+		ImbueBaseRules  nip.Rules            `yaml:"-"`
+		Drops           []data.Item          `yaml:"-"`
+		// ConfiguredDifficulty is the difficulty of the config file while the auto difficulty plays another one, it's
+		// the one written when the config is saved.
+		ConfiguredDifficulty difficulty.Difficulty `yaml:"-"`
+		// AutoDifficulty is the difficulty picked by the auto difficulty, a saved config with another one was changed
+		// by hand and keeps it.
+		AutoDifficulty difficulty.Difficulty `yaml:"-"`
+		// ShoppingFound is set once the shopping run bought an item with ShopUntilFound enabled, it's reset when the
+		// supervisor starts or the shopping settings change.
+		ShoppingFound bool `yaml:"-"`
+		// Running is set on the config used by a running supervisor, its saves aren't hot reloaded.
+		Running bool `yaml:"-"`
 	} `yaml:"-"`
 }
 
@@ -585,6 +1077,22 @@ func GetCharacters() map[string]*CharacterCfg {
 	return copy
 }
 
+// AreaOverride returns the overrides of the area, the zero value when it has none.
+func (c *CharacterCfg) AreaOverride(areaID area.ID) AreaOverride {
+	return c.AreaOverrides[areaID]
+}
+
+// SkipsMonster tells if the overrides ask to leave the monster alone because of its immunities.
+func (o AreaOverride) SkipsMonster(m data.Monster) bool {
+	for _, resist := range o.SkipOnImmunities {
+		if m.IsImmune(resist) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (bm BeltColumns) Total(potionType data.PotionType) int {
 	typeString := ""
 	switch potionType {
@@ -642,83 +1150,63 @@ func Load() error {
 			continue
 		}
 
-		charCfg := CharacterCfg{}
-
-		charConfigPath := getAbsPath(filepath.Join("config", entry.Name(), "config.yaml"))
-		r, err = os.Open(charConfigPath)
+		charCfg, err := readCharacterCfg(entry.Name())
 		if err != nil {
-			return fmt.Errorf("error loading config.yaml: %w", err)
+			return err
 		}
 
-		d := yaml.NewDecoder(r)
-		if err = d.Decode(&charCfg); err != nil {
-			_ = r.Close()
-			return fmt.Errorf("error reading %s character config: %w", charConfigPath, err)
+		if _, centralizedMissing := pickitDir(charCfg); centralizedMissing {
+			utils.ShowDialog("Error loading pickit rules for "+entry.Name(), "The centralized pickit path does not exist: "+Koolo.CentralizedPickitPath+"\nPlease check your Koolo settings.\nFalling back to local pickit.")
 		}
-		_ = r.Close()
-
-		// Deprecated: kept for backwards compatibility with older configs; can be removed in the future.
-		if !charCfg.Game.Andariel.UseAntidotes && charCfg.Game.Andariel.UseAntidoesDeprecated {
-			charCfg.Game.Andariel.UseAntidotes = true
+		if err := charCfg.loadPickitRules(); err != nil {
+			return err
 		}
-		charCfg.Game.Andariel.UseAntidoesDeprecated = false
 
-		charCfg.ConfigFolderName = entry.Name()
-
-		if charCfg.Game.MaxFailedMenuAttempts == 0 {
-			charCfg.Game.MaxFailedMenuAttempts = 10
-		}
-
-		if len(charCfg.Gambling.Items) == 0 {
-			charCfg.Gambling.Items = []string{"coronet", "circlet", "amulet"}
-		}
+		Characters[entry.Name()] = charCfg
+	}
 
-		var pickitPath string
-		if Koolo.CentralizedPickitPath != "" && charCfg.UseCentralizedPickit {
-			if _, err := os.Stat(Koolo.CentralizedPickitPath); os.IsNotExist(err) {
-				utils.ShowDialog("Error loading pickit rules for "+entry.Name(), "The centralized pickit path does not exist: "+Koolo.CentralizedPickitPath+"\nPlease check your Koolo settings.\nFalling back to local pickit.")
-				pickitPath = getAbsPath(filepath.Join("config", entry.Name(), "pickit")) + "\\"
-			} else {
-				pickitPath = Koolo.CentralizedPickitPath + "\\"
-			}
-		} else {
-			pickitPath = getAbsPath(filepath.Join("config", entry.Name(), "pickit")) + "\\"
-		}
+	for _, charCfg := range Characters {
+		charCfg.Validate()
+	}
 
-		rules, err := getCachedRulesDir(pickitPath)
-		if err != nil {
-			return fmt.Errorf("error reading pickit directory %s: %w", pickitPath, err)
-		}
+	return nil
+}
 
-		// Load the leveling pickit rules
+// readCharacterCfg decodes the config of the character folder and fills the defaults, pickit rules aren't loaded.
+func readCharacterCfg(name string) (*CharacterCfg, error) {
+	charCfg := CharacterCfg{}
 
-		if len(charCfg.Game.Runs) > 0 && (charCfg.Game.Runs[0] == "leveling" || charCfg.Game.Runs[0] == "leveling_sequence") {
-			nips := getLevelingNipFiles(&charCfg, entry.Name())
+	charConfigPath := getAbsPath(filepath.Join("config", name, "config.yaml"))
+	if err := decodeProfile(&charCfg, charConfigPath, false, 0); err != nil {
+		return nil, err
+	}
 
-			for _, nipFile := range nips {
-				classRules, err := readSinglePickitFile(nipFile)
-				if err != nil {
-					return err
-				}
-				rules = append(rules, classRules...)
-			}
-		}
+	// Deprecated: kept for backwards compatibility with older configs; can be removed in the future.
+	if !charCfg.Game.Andariel.UseAntidotes && charCfg.Game.Andariel.UseAntidoesDeprecated {
+		charCfg.Game.Andariel.UseAntidotes = true
+	}
+	charCfg.Game.Andariel.UseAntidoesDeprecated = false
 
-		charCfg.Runtime.Rules = rules
+	charCfg.ConfigFolderName = name
 
-		for ruleIndex, rule := range rules {
-			if rule.Tier() > 0 || rule.MercTier() > 0 {
-				charCfg.Runtime.TierRules = append(charCfg.Runtime.TierRules, ruleIndex)
-			}
-		}
-		Characters[entry.Name()] = &charCfg
+	if charCfg.Game.MaxFailedMenuAttempts == 0 {
+		charCfg.Game.MaxFailedMenuAttempts = 10
 	}
 
-	for _, charCfg := range Characters {
-		charCfg.Validate()
+	if len(charCfg.Gambling.Items) == 0 {
+		charCfg.Gambling.Items = []string{"coronet", "circlet", "amulet"}
+	}
+	if charCfg.BackToTown.RepairPercent == 0 {
+		charCfg.BackToTown.RepairPercent = 20
+	}
+	if charCfg.Gambling.StartGold == 0 {
+		charCfg.Gambling.StartGold = 2480000
+	}
+	if charCfg.Gambling.GoldFloor == 0 {
+		charCfg.Gambling.GoldFloor = 500000
 	}
 
-	return nil
+	return &charCfg, nil
 }
 
 func sanitizeDiscordConfig(cfg *KooloCfg) {
@@ -878,9 +1366,31 @@ func SaveKooloConfig(config *KooloCfg) error {
 	return nil
 }
 
+// SaveSupportedGameVersion writes the supported game version to the koolo config, under the config lock so it doesn't
+// race with a reload.
+func SaveSupportedGameVersion(version string) error {
+	cfgMux.Lock()
+	defer cfgMux.Unlock()
+
+	cfg := *Koolo
+	cfg.GameVersion.Supported = version
+	if err := SaveKooloConfig(&cfg); err != nil {
+		return err
+	}
+	Koolo.GameVersion.Supported = version
+
+	return nil
+}
+
 func SaveSupervisorConfig(supervisorName string, config *CharacterCfg) error {
 	filePath := filepath.Join("config", supervisorName, "config.yaml")
-	d, err := yaml.Marshal(config)
+	toSave := config
+	if config.Runtime.ConfiguredDifficulty != "" && config.Game.Difficulty == config.Runtime.AutoDifficulty {
+		saved := *config
+		saved.Game.Difficulty = config.Runtime.ConfiguredDifficulty
+		toSave = &saved
+	}
+	d, err := marshalCharacterCfg(toSave)
 	config.Validate()
 	if err != nil {
 		return err
@@ -890,6 +1400,9 @@ func SaveSupervisorConfig(supervisorName string, config *CharacterCfg) error {
 	if err != nil {
 		return fmt.Errorf("error writing supervisor config: %w", err)
 	}
+	if config.Runtime.Running {
+		recordOwnSave(supervisorName)
+	}
 
 	return Load()
 }
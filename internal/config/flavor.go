@@ -0,0 +1,139 @@
+package config
+
+import (
+	"strings"
+	"sync"
+)
+
+// Game flavors shipped with the bot, more can be added with RegisterGameFlavor.
+const (
+	FlavorStandard = "standard" // Official servers, or the offline game when authMethod is None
+	FlavorTCP      = "tcp"      // TCP/IP games, created without Battle.net
+	FlavorPrivate  = "private"  // Community servers with a bigger stash or their own currency items
+)
+
+// GameFlavor holds the game interaction assumptions that change between the official game and the community servers,
+// the core actions ask the flavor instead of hardcoding them so a server can be supported without forking them.
+type GameFlavor interface {
+	Name() string
+	// Online is true when the games are created through Battle.net, the online check and the lobby are skipped
+	// otherwise.
+	Online() bool
+	// StashTabs is the amount of stash tabs, the personal one included. Tabs past the tab buttons are pages reached
+	// with the stash page buttons, see StashPager.
+	StashTabs() int
+	// IsCurrency returns true for the items used as currency by the server, they are always picked up and stashed.
+	IsCurrency(itemName string) bool
+}
+
+// StashPager is implemented by the flavors with stash pages past the tab buttons, it's optional so the flavors
+// written before it keep working.
+type StashPager interface {
+	// StashPageButtons returns the screen position of the previous/next stash page buttons, zero without pages.
+	StashPageButtons() StashPageButtons
+}
+
+// FlavorStashPageButtons returns the stash page buttons of the flavor, zero when it has no pages.
+func FlavorStashPageButtons(f GameFlavor) StashPageButtons {
+	if pager, ok := f.(StashPager); ok {
+		return pager.StashPageButtons()
+	}
+
+	return StashPageButtons{}
+}
+
+// StashPageButtons are the screen positions of the page buttons of an infinite stash (PlugY, PD2 shared pages).
+type StashPageButtons struct {
+	PrevX int `yaml:"prevX"`
+	PrevY int `yaml:"prevY"`
+	NextX int `yaml:"nextX"`
+	NextY int `yaml:"nextY"`
+}
+
+// Enabled returns true when both buttons are set.
+func (b StashPageButtons) Enabled() bool {
+	return (b.PrevX != 0 || b.PrevY != 0) && (b.NextX != 0 || b.NextY != 0)
+}
+
+var gameFlavors = struct {
+	mu           sync.RWMutex
+	constructors map[string]func(cfg *CharacterCfg) GameFlavor
+}{constructors: map[string]func(cfg *CharacterCfg) GameFlavor{
+	FlavorStandard: func(cfg *CharacterCfg) GameFlavor {
+		return basicFlavor{name: FlavorStandard, online: cfg.AuthMethod != "None", stashTabs: 4}
+	},
+	FlavorTCP: func(cfg *CharacterCfg) GameFlavor {
+		return basicFlavor{name: FlavorTCP, stashTabs: 4}
+	},
+	FlavorPrivate: func(cfg *CharacterCfg) GameFlavor {
+		stashTabs := cfg.Game.Flavor.StashTabs
+		if stashTabs < 1 {
+			stashTabs = 4
+		}
+
+		return basicFlavor{
+			name:        FlavorPrivate,
+			online:      cfg.AuthMethod != "None",
+			stashTabs:   stashTabs,
+			pageButtons: cfg.Game.Flavor.StashPageButtons,
+			currency:    cfg.Game.Flavor.CurrencyItems,
+		}
+	},
+}}
+
+// RegisterGameFlavor adds a flavor selectable with game.flavor.name, the constructor is called with the character
+// config every time the flavor is used so it can read its own options.
+func RegisterGameFlavor(name string, constructor func(cfg *CharacterCfg) GameFlavor) {
+	gameFlavors.mu.Lock()
+	defer gameFlavors.mu.Unlock()
+
+	gameFlavors.constructors[strings.ToLower(name)] = constructor
+}
+
+// GameFlavorNames returns the registered flavor names.
+func GameFlavorNames() []string {
+	gameFlavors.mu.RLock()
+	defer gameFlavors.mu.RUnlock()
+
+	names := make([]string, 0, len(gameFlavors.constructors))
+	for name := range gameFlavors.constructors {
+		names = append(names, name)
+	}
+
+	return names
+}
+
+// Flavor returns the game flavor of the character, the standard one when it's not set or unknown.
+func (c *CharacterCfg) Flavor() GameFlavor {
+	gameFlavors.mu.RLock()
+	constructor, found := gameFlavors.constructors[strings.ToLower(c.Game.Flavor.Name)]
+	if !found {
+		constructor = gameFlavors.constructors[FlavorStandard]
+	}
+	gameFlavors.mu.RUnlock()
+
+	return constructor(c)
+}
+
+type basicFlavor struct {
+	name        string
+	online      bool
+	stashTabs   int
+	pageButtons StashPageButtons
+	currency    []string
+}
+
+func (f basicFlavor) Name() string                       { return f.name }
+func (f basicFlavor) Online() bool                       { return f.online }
+func (f basicFlavor) StashTabs() int                     { return f.stashTabs }
+func (f basicFlavor) StashPageButtons() StashPageButtons { return f.pageButtons }
+
+func (f basicFlavor) IsCurrency(itemName string) bool {
+	for _, name := range f.currency {
+		if strings.EqualFold(name, itemName) {
+			return true
+		}
+	}
+
+	return false
+}
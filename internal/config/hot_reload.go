@@ -0,0 +1,199 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Settings read once when the client starts, changing them needs a supervisor restart. Matched as path prefixes.
+var restartRequiredPaths = []string{
+	"username",
+	"password",
+	"authMethod",
+	"authToken",
+	"realm",
+	"characterName",
+	"commandLineArgs",
+	"classicMode",
+	"window",
+	"character.class",
+}
+
+// ConfigChange is a setting that differs between two versions of a character config.
+type ConfigChange struct {
+	Path string `json:"path"`
+	Old  string `json:"old"`
+	New  string `json:"new"`
+}
+
+func (c ConfigChange) String() string {
+	return fmt.Sprintf("%s: %s -> %s", c.Path, c.Old, c.New)
+}
+
+// ownSaves are the modification times of the config files written by the running supervisors themselves, the config
+// watchers don't reload them.
+var ownSaves = struct {
+	mu       sync.Mutex
+	modTimes map[string]time.Time
+}{modTimes: make(map[string]time.Time)}
+
+// IsOwnSave tells if the config files of the character were last written by its running supervisor.
+func IsOwnSave(name string, modTime time.Time) bool {
+	ownSaves.mu.Lock()
+	defer ownSaves.mu.Unlock()
+
+	return ownSaves.modTimes[name].Equal(modTime)
+}
+
+func recordOwnSave(name string) {
+	ownSaves.mu.Lock()
+	ownSaves.modTimes[name] = CharacterConfigModTime(name)
+	ownSaves.mu.Unlock()
+}
+
+// CharacterConfigModTime returns when the character config, or any profile it inherits from, was last written. Zero
+// if it can't be read.
+func CharacterConfigModTime(name string) time.Time {
+	return profilesModTime(name)
+}
+
+// ReloadCharacter reads the character config from disk along with its pickit rules and replaces the loaded one, the
+// config used by a running supervisor is left as it is.
+func ReloadCharacter(name string) (*CharacterCfg, error) {
+	cfgMux.Lock()
+	defer cfgMux.Unlock()
+
+	charCfg, err := readCharacterCfg(name)
+	if err != nil {
+		return nil, err
+	}
+	if err = charCfg.loadPickitRules(); err != nil {
+		return nil, err
+	}
+	charCfg.Validate()
+	Characters[name] = charCfg
+
+	return charCfg, nil
+}
+
+// ApplyHotReload copies into the config the settings of next that can change while the bot is running. The changes
+// needing a restart are left out and returned apart. The runtime state, which isn't in the file, is kept.
+func (c *CharacterCfg) ApplyHotReload(next *CharacterCfg) (applied, restartRequired []ConfigChange) {
+	// The auto difficulty plays another difficulty than the file one, its choice is kept unless the file one changed
+	keepAutoDifficulty := c.Runtime.ConfiguredDifficulty != "" && next.Game.Difficulty == c.Runtime.ConfiguredDifficulty
+	if keepAutoDifficulty {
+		nextCopy := *next
+		nextCopy.Game.Difficulty = c.Game.Difficulty
+		next = &nextCopy
+	}
+
+	for _, change := range DiffCharacter(c, next) {
+		if requiresRestart(change.Path) {
+			restartRequired = append(restartRequired, change)
+		} else {
+			applied = append(applied, change)
+		}
+	}
+	if len(applied) == 0 {
+		return applied, restartRequired
+	}
+
+	current := *c
+	*c = *next
+	c.Username = current.Username
+	c.Password = current.Password
+	c.AuthMethod = current.AuthMethod
+	c.AuthToken = current.AuthToken
+	c.Realm = current.Realm
+	c.CharacterName = current.CharacterName
+	c.CommandLineArgs = current.CommandLineArgs
+	c.ClassicMode = current.ClassicMode
+	c.Window = current.Window
+	c.Character.Class = current.Character.Class
+	c.ConfigFolderName = current.ConfigFolderName
+	c.Game.PublicGameCounter = current.Game.PublicGameCounter
+
+	// The rules come compiled from the new files, the rest of the runtime state belongs to the running bot
+	c.Runtime = current.Runtime
+	c.Runtime.Rules, c.Runtime.TierRules = next.Runtime.Rules, next.Runtime.TierRules
+	c.Runtime.BaseRules, c.Runtime.RunRules = next.Runtime.BaseRules, next.Runtime.RunRules
+	c.Runtime.UnidStashRules, c.Runtime.ImbueBaseRules = next.Runtime.UnidStashRules, next.Runtime.ImbueBaseRules
+	c.ApplyRunPickit(current.Runtime.PickitRun)
+	// New shopping settings start a new search for an item
+	if !reflect.DeepEqual(current.Shopping, next.Shopping) {
+		c.Runtime.ShoppingFound = false
+	}
+	if !keepAutoDifficulty {
+		c.Runtime.ConfiguredDifficulty = ""
+		c.Runtime.AutoDifficulty = ""
+	}
+
+	return applied, restartRequired
+}
+
+// DiffCharacter lists the settings changed between two character configs, named by their yaml path.
+func DiffCharacter(current, next *CharacterCfg) []ConfigChange {
+	var changes []ConfigChange
+	diffConfigValues("", reflect.ValueOf(current).Elem(), reflect.ValueOf(next).Elem(), &changes)
+
+	return changes
+}
+
+func diffConfigValues(path string, current, next reflect.Value, changes *[]ConfigChange) {
+	// Walk down our own structs only, anything else (time.Time, nip rules...) is compared as a whole
+	configPkg := reflect.TypeOf(CharacterCfg{}).PkgPath()
+	if t := current.Type(); current.Kind() == reflect.Struct && (t.Name() == "" || t.PkgPath() == configPkg) {
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+			if !field.IsExported() || name == "-" {
+				continue
+			}
+			if name == "" {
+				name = strings.ToLower(field.Name)
+			}
+			if path != "" {
+				name = path + "." + name
+			}
+			diffConfigValues(name, current.Field(i), next.Field(i), changes)
+		}
+		return
+	}
+
+	if reflect.DeepEqual(current.Interface(), next.Interface()) {
+		return
+	}
+
+	*changes = append(*changes, ConfigChange{
+		Path: path,
+		Old:  formatConfigValue(path, current),
+		New:  formatConfigValue(path, next),
+	})
+}
+
+func formatConfigValue(path string, v reflect.Value) string {
+	lowerPath := strings.ToLower(path)
+	if strings.Contains(lowerPath, "password") || strings.Contains(lowerPath, "token") {
+		return "***"
+	}
+
+	s := fmt.Sprintf("%v", v.Interface())
+	if len(s) > 80 {
+		s = s[:77] + "..."
+	}
+
+	return s
+}
+
+func requiresRestart(path string) bool {
+	for _, prefix := range restartRequiredPaths {
+		if path == prefix || strings.HasPrefix(path, prefix+".") {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,41 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/item"
+	"github.com/hectorgimenez/d2go/pkg/nip"
+)
+
+const (
+	IdentifyPolicyTown  = "town"
+	IdentifyPolicyStash = "stash"
+)
+
+// NeverIdentify returns true if the item is in the never identify list, those are sold unidentified.
+func (c *CharacterCfg) NeverIdentify(i data.Item) bool {
+	for _, name := range c.Game.Identify.NeverIdentify {
+		if strings.EqualFold(strings.TrimSpace(name), string(i.Name)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// KeepUnidentified returns true if the unidentified item has to be stashed as it is, either because it matches the
+// unidentified stash rules or because the policy keeps every unidentified item for manual review.
+func (c *CharacterCfg) KeepUnidentified(i data.Item) bool {
+	if i.Identified || i.Quality < item.QualityMagic || c.NeverIdentify(i) {
+		return false
+	}
+
+	if len(c.Runtime.UnidStashRules) > 0 {
+		if _, result := c.Runtime.UnidStashRules.EvaluateAll(i); result == nip.RuleResultFullMatch {
+			return true
+		}
+	}
+
+	return c.Game.Identify.Policy == IdentifyPolicyStash
+}
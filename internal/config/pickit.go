@@ -0,0 +1,164 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/hectorgimenez/d2go/pkg/nip"
+)
+
+// pickitDir returns the pickit directory of the character, the second value is true when the centralized pickit is
+// configured but missing, the local pickit is used instead.
+func pickitDir(c *CharacterCfg) (string, bool) {
+	localPath := getAbsPath(filepath.Join("config", c.ConfigFolderName, "pickit")) + "\\"
+	if Koolo.CentralizedPickitPath == "" || !c.UseCentralizedPickit {
+		return localPath, false
+	}
+	if _, err := os.Stat(Koolo.CentralizedPickitPath); os.IsNotExist(err) {
+		return localPath, true
+	}
+
+	return Koolo.CentralizedPickitPath + "\\", false
+}
+
+// PickitDir returns the directory the pickit rules of the character are read from.
+func (c *CharacterCfg) PickitDir() string {
+	dir, _ := pickitDir(c)
+	return dir
+}
+
+// characterNipPath resolves a NIP file path relative to the character config folder.
+func characterNipPath(c *CharacterCfg, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+
+	return getAbsPath(filepath.Join("config", c.ConfigFolderName, path))
+}
+
+func isLevelingCfg(c *CharacterCfg) bool {
+	return len(c.Game.Runs) > 0 && (c.Game.Runs[0] == "leveling" || c.Game.Runs[0] == "leveling_sequence")
+}
+
+// loadPickitRules compiles the pickit rules of the character, the current ones are kept if a file fails to compile.
+func (c *CharacterCfg) loadPickitRules() error {
+	dir, _ := pickitDir(c)
+	rules, err := getCachedRulesDir(dir)
+	if err != nil {
+		return fmt.Errorf("error reading pickit directory %s: %w", dir, err)
+	}
+
+	// Load the leveling pickit rules
+	if isLevelingCfg(c) {
+		for _, nipFile := range getLevelingNipFiles(c, c.ConfigFolderName) {
+			classRules, err := readSinglePickitFile(nipFile)
+			if err != nil {
+				return err
+			}
+			rules = append(rules, classRules...)
+		}
+	}
+
+	var unidRules nip.Rules
+	if c.Game.Identify.UnidStashRules != "" {
+		unidRulesPath := characterNipPath(c, c.Game.Identify.UnidStashRules)
+		unidRules, err = readSinglePickitFile(unidRulesPath)
+		if err != nil {
+			return fmt.Errorf("error reading unidentified stash rules %s: %w", unidRulesPath, err)
+		}
+	}
+
+	var imbueRules nip.Rules
+	if c.Game.Imbue.BaseRules != "" {
+		imbueRulesPath := characterNipPath(c, c.Game.Imbue.BaseRules)
+		imbueRules, err = readSinglePickitFile(imbueRulesPath)
+		if err != nil {
+			return fmt.Errorf("error reading imbue base rules %s: %w", imbueRulesPath, err)
+		}
+	}
+
+	runRules := make(map[string]nip.Rules, len(c.Game.PickitOverrides))
+	for run, path := range c.Game.PickitOverrides {
+		overridePath := characterNipPath(c, path)
+		overrideRules, err := readSinglePickitFile(overridePath)
+		if err != nil {
+			return fmt.Errorf("error reading %s pickit override %s: %w", run, overridePath, err)
+		}
+		runRules[run] = overrideRules
+	}
+
+	// Items picked up by the overrides are kept by the town routines no matter the run they are visited from
+	runs := make([]string, 0, len(runRules))
+	for run := range runRules {
+		runs = append(runs, run)
+	}
+	sort.Strings(runs)
+	allRules := append(nip.Rules{}, rules...)
+	for _, run := range runs {
+		allRules = append(allRules, runRules[run]...)
+	}
+
+	c.Runtime.Rules = allRules
+	c.Runtime.TierRules = tierRuleIndexes(allRules)
+	c.Runtime.BaseRules = rules
+	c.Runtime.UnidStashRules = unidRules
+	c.Runtime.ImbueBaseRules = imbueRules
+	c.Runtime.RunRules = runRules
+	c.ApplyRunPickit(c.Runtime.PickitRun)
+
+	return nil
+}
+
+// ReloadPickitRules recompiles the NIP files of the character, used to pick up edits without restarting.
+func (c *CharacterCfg) ReloadPickitRules() error {
+	ClearNIPCache()
+
+	return c.loadPickitRules()
+}
+
+// ApplyRunPickit activates the pickup rules for the given run, its override rules (if any) are merged over the base
+// rules so they are matched first. An empty run restores the base rules.
+func (c *CharacterCfg) ApplyRunPickit(run string) {
+	rules := c.Runtime.BaseRules
+	if override, found := c.Runtime.RunRules[run]; found && len(override) > 0 {
+		rules = append(append(nip.Rules{}, override...), c.Runtime.BaseRules...)
+	}
+
+	c.Runtime.PickitRun = run
+	c.Runtime.PickupRules = rules
+	c.Runtime.PickupTierRules = tierRuleIndexes(rules)
+}
+
+func tierRuleIndexes(rules nip.Rules) []int {
+	var tierRules []int
+	for ruleIndex, rule := range rules {
+		if rule.Tier() > 0 || rule.MercTier() > 0 {
+			tierRules = append(tierRules, ruleIndex)
+		}
+	}
+
+	return tierRules
+}
+
+// PickitFiles returns the NIP files the rules of the character are compiled from.
+func (c *CharacterCfg) PickitFiles() []string {
+	dir, _ := pickitDir(c)
+	files, _ := filepath.Glob(filepath.Join(dir, "*.nip"))
+
+	if isLevelingCfg(c) {
+		files = append(files, getLevelingNipFiles(c, c.ConfigFolderName)...)
+	}
+	if c.Game.Identify.UnidStashRules != "" {
+		files = append(files, characterNipPath(c, c.Game.Identify.UnidStashRules))
+	}
+	if c.Game.Imbue.BaseRules != "" {
+		files = append(files, characterNipPath(c, c.Game.Imbue.BaseRules))
+	}
+	for _, path := range c.Game.PickitOverrides {
+		files = append(files, characterNipPath(c, path))
+	}
+
+	return files
+}
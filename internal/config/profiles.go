@@ -0,0 +1,226 @@
+package config
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	defaultProfile = "base.yaml"
+	noProfile      = "none"
+	// Profiles can extend other profiles, deeper chains are most likely a loop
+	maxProfileDepth = 8
+)
+
+// profilePath resolves the profile a config extends, empty when it extends nothing. Character configs extend
+// base.yaml by default when it exists, profiles only extend what they name.
+func profilePath(extends string, isProfile bool) string {
+	extends = strings.TrimSpace(extends)
+	if strings.EqualFold(extends, noProfile) {
+		return ""
+	}
+
+	if extends == "" {
+		if isProfile {
+			return ""
+		}
+		path := getAbsPath(filepath.Join("config", defaultProfile))
+		if _, err := os.Stat(path); err != nil {
+			return ""
+		}
+		return path
+	}
+
+	if filepath.Ext(extends) == "" {
+		extends += ".yaml"
+	}
+
+	return getAbsPath(filepath.Join("config", extends))
+}
+
+// decodeProfile decodes the config file over cfg, after the profiles it extends so its own settings win. Nested
+// settings are merged, lists are replaced as a whole.
+func decodeProfile(cfg *CharacterCfg, path string, isProfile bool, depth int) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error loading %s: %w", path, err)
+	}
+
+	header := struct {
+		Extends string `yaml:"extends"`
+	}{}
+	if err = yaml.Unmarshal(content, &header); err != nil {
+		return fmt.Errorf("error reading %s: %w", path, err)
+	}
+	if strings.EqualFold(filepath.Base(header.Extends), "koolo.yaml") {
+		return fmt.Errorf("error reading %s: koolo.yaml can't be used as a profile", path)
+	}
+
+	if parent := profilePath(header.Extends, isProfile); parent != "" {
+		if depth >= maxProfileDepth {
+			return fmt.Errorf("error reading %s: too many profile levels, is a profile extending itself?", path)
+		}
+		if err = decodeProfile(cfg, parent, true, depth+1); err != nil {
+			return err
+		}
+	}
+
+	if err = yaml.Unmarshal(content, cfg); err != nil {
+		return fmt.Errorf("error reading %s: %w", path, err)
+	}
+	cfg.Extends = header.Extends
+
+	return nil
+}
+
+// profileFiles lists the config file of the character along with every profile it inherits from.
+func profileFiles(name string) []string {
+	files := []string{getAbsPath(filepath.Join("config", name, "config.yaml"))}
+	isProfile := false
+	for len(files) <= maxProfileDepth {
+		content, err := os.ReadFile(files[len(files)-1])
+		if err != nil {
+			break
+		}
+		header := struct {
+			Extends string `yaml:"extends"`
+		}{}
+		if yaml.Unmarshal(content, &header) != nil {
+			break
+		}
+		parent := profilePath(header.Extends, isProfile)
+		if parent == "" {
+			break
+		}
+		files = append(files, parent)
+		isProfile = true
+	}
+
+	return files
+}
+
+// profilesModTime returns the last time any of the files making the character config was written.
+func profilesModTime(name string) time.Time {
+	var latest time.Time
+	for _, f := range profileFiles(name) {
+		if info, err := os.Stat(f); err == nil && info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+
+	return latest
+}
+
+// marshalCharacterCfg returns the yaml saved for the character, only the settings differing from the inherited
+// profile when there is one.
+func marshalCharacterCfg(cfg *CharacterCfg) ([]byte, error) {
+	parent := profilePath(cfg.Extends, false)
+	if parent == "" {
+		return yaml.Marshal(cfg)
+	}
+
+	base := CharacterCfg{}
+	if err := decodeProfile(&base, parent, true, 1); err != nil {
+		return nil, err
+	}
+
+	// The struct values are compared rather than their yaml, omitempty would drop the false and 0 overrides
+	overrides := make(map[string]any)
+	diffStructs(reflect.ValueOf(cfg).Elem(), reflect.ValueOf(&base).Elem(), overrides)
+	if cfg.Extends != "" {
+		overrides["extends"] = cfg.Extends
+	}
+
+	return yaml.Marshal(overrides)
+}
+
+// redactedValue replaces the credentials in the resolved config.
+const redactedValue = "<redacted>"
+
+// ResolvedCharacterYAML returns the character config as used by the bot, with every inherited setting, along with
+// the files it's made of. The credentials are redacted.
+func ResolvedCharacterYAML(name string) ([]byte, []string, error) {
+	cfg, found := GetCharacter(name)
+	if !found {
+		return nil, nil, errors.New("character not found")
+	}
+
+	resolved := *cfg
+	for _, secret := range []*string{&resolved.Password, &resolved.AuthToken} {
+		if *secret != "" {
+			*secret = redactedValue
+		}
+	}
+
+	d, err := yaml.Marshal(&resolved)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return d, profileFiles(name), nil
+}
+
+var (
+	yamlMarshalerType = reflect.TypeOf((*yaml.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// diffStructs puts into diff, by yaml key, the fields of full that are different in base, walking down the nested
+// settings. The fields not saved in the yaml are skipped.
+func diffStructs(full, base reflect.Value, diff map[string]any) {
+	for i := 0; i < full.NumField(); i++ {
+		field := full.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+
+		value, baseValue := full.Field(i), base.Field(i)
+		if isNestedSettings(field.Type) {
+			if strings.Contains(opts, "inline") {
+				diffStructs(value, baseValue, diff)
+				continue
+			}
+			nested := make(map[string]any)
+			diffStructs(value, baseValue, nested)
+			if len(nested) > 0 {
+				diff[name] = nested
+			}
+			continue
+		}
+
+		if !reflect.DeepEqual(value.Interface(), baseValue.Interface()) {
+			diff[name] = value.Interface()
+		}
+	}
+}
+
+// isNestedSettings tells if the type is a settings group walked field by field, the types with their own yaml
+// encoding are compared as a whole.
+func isNestedSettings(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	for _, marshaler := range []reflect.Type{yamlMarshalerType, textMarshalerType} {
+		if t.Implements(marshaler) || reflect.PointerTo(t).Implements(marshaler) {
+			return false
+		}
+	}
+
+	return true
+}
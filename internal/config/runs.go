@@ -40,9 +40,11 @@ const (
 	SpiderCavernRun     Run = "spider_cavern"
 	EnduguRun           Run = "endugu"
 	UtilityRun          Run = "utility"
-	FireEyeRun          Run = "fire_eye"
-	RakanishuRun        Run = "rakanishu"
-	ShoppingRun         Run = "shopping"
+	// CompanionRun is built for followers with a follower mode, it's not selectable from the run list
+	CompanionRun Run = "companion"
+	FireEyeRun   Run = "fire_eye"
+	RakanishuRun Run = "rakanishu"
+	ShoppingRun  Run = "shopping"
 	//Leveling Sequence
 	DenRun                   Run = "den"
 	BloodravenRun            Run = "bloodraven"
@@ -66,11 +68,15 @@ const (
 	AncientsRun              Run = "ancients"
 	FrozenAuraMercRun        Run = "frozen_aura_merc"
 	TristramEarlyGoldfarmRun Run = "tristram_early_gold_farm"
+	UberKeysRun              Run = "uber_keys"
+	DiabloCloneRun           Run = "dclone"
+	CollectWaypointsRun      Run = "collect_waypoints"
 	OrgansRun                Run = "uber_organs"
 	PandemoniumRun           Run = "uber_torch"
 	UberIzualRun             Run = "uber_izual"
 	UberDurielRun            Run = "uber_duriel"
 	LilithRun                Run = "lilith"
+	RushRun                  Run = "rush"
 	// Development / Utility runs
 	DevelopmentRun Run = "development"
 )
@@ -121,6 +127,10 @@ var AvailableRuns = map[Run]interface{}{
 	UtilityRun:          nil,
 	FireEyeRun:          nil,
 	ShoppingRun:         nil,
+	UberKeysRun:         nil,
+	DiabloCloneRun:      nil,
+	RushRun:             nil,
+	CollectWaypointsRun: nil,
 	OrgansRun:           nil,
 	PandemoniumRun:      nil,
 	DevelopmentRun:      nil,
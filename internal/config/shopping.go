@@ -9,6 +9,8 @@ type ShoppingConfig struct {
 	RefreshesPerRun   int      `yaml:"refreshes_per_run"`
 	ShoppingRulesFile string   `yaml:"shopping_rules_file,omitempty"`
 	ItemTypes         []string `yaml:"item_types,omitempty"`
+	// ShopUntilFound keeps refreshing the vendors every game until a matching item is bought, then shopping stops.
+	ShopUntilFound bool `yaml:"shop_until_found"`
 
 	VendorAkara   bool `yaml:"vendor_akara"`
 	VendorCharsi  bool `yaml:"vendor_charsi"`
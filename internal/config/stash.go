@@ -0,0 +1,8 @@
+package config
+
+// Actions taken when an item can't be stashed because every stash tab is full.
+const (
+	StashFullActionSkip  = ""
+	StashFullActionEvict = "evict"
+	StashFullActionMule  = "mule"
+)
@@ -0,0 +1,247 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/hectorgimenez/d2go/pkg/data/area"
+	"github.com/hectorgimenez/koolo/internal/script"
+)
+
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Builds able to teleport without Enigma or charges
+var teleportClasses = []string{"sorceress", "sorceress_leveling", "fireballsorc", "nova", "hydraorb", "lightsorc"}
+
+// CharacterCheck is an extra validation for the packages config can't import, like the character builds.
+type CharacterCheck func(cfg *CharacterCfg) []ValidationIssue
+
+// ValidationIssue is a problem found in the configuration, Character is empty for koolo.yaml.
+type ValidationIssue struct {
+	Character string `json:"character"`
+	Severity  string `json:"severity"`
+	Field     string `json:"field"`
+	Message   string `json:"message"`
+}
+
+func (i ValidationIssue) String() string {
+	source := i.Character
+	if source == "" {
+		source = "koolo.yaml"
+	}
+
+	return fmt.Sprintf("[%s] %s: %s: %s", i.Severity, source, i.Field, i.Message)
+}
+
+// ValidateConfigs checks koolo.yaml and every character config, along with their NIP files, and returns all the
+// problems found instead of stopping at the first one. The loaded configuration is left untouched.
+func ValidateConfigs(checks ...CharacterCheck) []ValidationIssue {
+	cfgMux.RLock()
+	defer cfgMux.RUnlock()
+
+	var issues []ValidationIssue
+	if Koolo == nil {
+		return append(issues, ValidationIssue{Severity: SeverityError, Field: "koolo.yaml", Message: "configuration not loaded"})
+	}
+	issues = append(issues, validateKooloCfg(Koolo)...)
+
+	entries, err := os.ReadDir(getAbsPath("config"))
+	if err != nil {
+		return append(issues, ValidationIssue{Severity: SeverityError, Field: "config", Message: err.Error()})
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if _, err := os.Stat(getAbsPath(filepath.Join("config", entry.Name(), "config.yaml"))); os.IsNotExist(err) {
+			continue
+		}
+
+		charCfg, err := readCharacterCfg(entry.Name())
+		if err != nil {
+			issues = append(issues, ValidationIssue{Character: entry.Name(), Severity: SeverityError, Field: "config.yaml", Message: err.Error()})
+			continue
+		}
+		issues = append(issues, validateCharacterCfg(charCfg)...)
+		for _, check := range checks {
+			issues = append(issues, check(charCfg)...)
+		}
+	}
+
+	return issues
+}
+
+// HasValidationErrors tells if any of the issues prevents the bot from running.
+func HasValidationErrors(issues []ValidationIssue) bool {
+	for _, i := range issues {
+		if i.Severity == SeverityError {
+			return true
+		}
+	}
+
+	return false
+}
+
+func validateKooloCfg(cfg *KooloCfg) []ValidationIssue {
+	var issues []ValidationIssue
+	add := func(severity, field, message string) {
+		issues = append(issues, ValidationIssue{Severity: severity, Field: field, Message: message})
+	}
+
+	if _, err := os.Stat(filepath.Join(cfg.D2RPath, "d2r.exe")); err != nil {
+		add(SeverityError, "D2RPath", fmt.Sprintf("d2r.exe not found in %q", cfg.D2RPath))
+	}
+	if _, err := os.Stat(filepath.Join(cfg.D2LoDPath, "d2data.mpq")); err != nil {
+		add(SeverityError, "D2LoDPath", fmt.Sprintf("d2data.mpq not found in %q", cfg.D2LoDPath))
+	}
+	if cfg.CentralizedPickitPath != "" {
+		if _, err := os.Stat(cfg.CentralizedPickitPath); err != nil {
+			add(SeverityWarning, "centralizedPickitPath", fmt.Sprintf("%q doesn't exist, characters using it fall back to their local pickit", cfg.CentralizedPickitPath))
+		}
+	}
+
+	return issues
+}
+
+func validateCharacterCfg(cfg *CharacterCfg) []ValidationIssue {
+	var issues []ValidationIssue
+	add := func(severity, field, message string) {
+		issues = append(issues, ValidationIssue{Character: cfg.ConfigFolderName, Severity: severity, Field: field, Message: message})
+	}
+
+	if strings.TrimSpace(cfg.CharacterName) == "" {
+		add(SeverityError, "characterName", "character name is empty")
+	}
+	if cfg.Character.Class == "" {
+		add(SeverityError, "character.class", "class is empty")
+	}
+
+	if len(cfg.Game.Runs) == 0 && !(cfg.Companion.Enabled && !cfg.Companion.Leader) {
+		add(SeverityError, "game.runs", "no runs configured")
+	}
+	for _, r := range cfg.Game.Runs {
+		if _, found := AvailableRuns[r]; !found {
+			add(SeverityError, "game.runs", fmt.Sprintf("unknown run %q", r))
+		}
+	}
+	for r := range cfg.Game.PickitOverrides {
+		if _, found := AvailableRuns[Run(r)]; !found {
+			add(SeverityWarning, "game.pickitOverrides", fmt.Sprintf("override for unknown run %q is never used", r))
+		}
+	}
+	if name := cfg.Game.Flavor.Name; name != "" && !slices.Contains(GameFlavorNames(), strings.ToLower(name)) {
+		add(SeverityWarning, "game.flavor.name", fmt.Sprintf("unknown flavor %q, the standard one is used", name))
+	}
+	if flavor := cfg.Flavor(); flavor.StashTabs() > 4 && !FlavorStashPageButtons(flavor).Enabled() {
+		add(SeverityWarning, "game.flavor.stashPageButtons", "stash tabs past the 4th one need the page buttons, they are never used")
+	}
+	for i, hook := range cfg.Hooks {
+		field := fmt.Sprintf("hooks[%d]", i)
+		if !slices.Contains(script.Hooks, hook.On) {
+			add(SeverityWarning, field, fmt.Sprintf("unknown hook %q, it's never run", hook.On))
+		}
+		if hook.When != "" {
+			if _, err := script.CompileCondition(hook.When); err != nil {
+				add(SeverityError, field+".when", err.Error())
+			}
+		}
+		if _, err := script.CompileAction(hook.Do); err != nil {
+			add(SeverityError, field+".do", err.Error())
+		}
+	}
+	for r, count := range cfg.Game.Players.PerRun {
+		if _, found := AvailableRuns[Run(r)]; !found {
+			add(SeverityWarning, "game.players.perRun", fmt.Sprintf("players count for unknown run %q is never used", r))
+		}
+		if count < 0 || count > 8 {
+			add(SeverityWarning, "game.players.perRun", fmt.Sprintf("players count %d for %q is not between 0 and 8", count, r))
+		}
+	}
+
+	// The pickit is loaded on a copy, the rules of the loaded configuration stay as they are
+	pickitCfg := *cfg
+	if dir, centralizedMissing := pickitDir(&pickitCfg); centralizedMissing {
+		add(SeverityWarning, "useCentralizedPickit", "centralized pickit path doesn't exist, using "+dir)
+	}
+	if err := pickitCfg.loadPickitRules(); err != nil {
+		add(SeverityError, "pickit", err.Error())
+	}
+
+	for id, override := range cfg.AreaOverrides {
+		if _, found := area.Areas[id]; !found {
+			add(SeverityWarning, "areaOverrides", fmt.Sprintf("unknown area ID %d", id))
+		}
+		if override.ChickenAt >= 100 {
+			add(SeverityError, "areaOverrides", fmt.Sprintf("chicken at %d%% in %s leaves the game right away", override.ChickenAt, id.Area().Name))
+		}
+	}
+
+	if cfg.Character.UseTeleport && !isLevelingCfg(cfg) && !containsFold(teleportClasses, cfg.Character.Class) {
+		add(SeverityWarning, "character.useTeleport", fmt.Sprintf("teleport is enabled but %s can't teleport without Enigma or charges", cfg.Character.Class))
+	}
+	if bs := cfg.Character.BuildSwitch; bs.Enabled && !bs.Applied {
+		if bs.Level <= 0 {
+			add(SeverityError, "character.buildSwitch.level", "build switch level is not set")
+		}
+		if bs.Class != "" && len(bs.Runs) == 0 && isLevelingCfg(cfg) {
+			add(SeverityWarning, "character.buildSwitch.runs", fmt.Sprintf("switching to %s keeps the leveling runs, it only works with a leveling class", bs.Class))
+		}
+		for _, r := range bs.Runs {
+			if _, found := AvailableRuns[r]; !found {
+				add(SeverityError, "character.buildSwitch.runs", fmt.Sprintf("unknown run %q", r))
+			}
+		}
+	}
+	if cfg.Health.ChickenAt > 0 && cfg.Health.HealingPotionAt > 0 && cfg.Health.ChickenAt >= cfg.Health.HealingPotionAt {
+		add(SeverityWarning, "health.chickenAt", fmt.Sprintf("chicken at %d%% happens before drinking healing potions at %d%%", cfg.Health.ChickenAt, cfg.Health.HealingPotionAt))
+	}
+	if cfg.Health.TownChickenAt > 0 && cfg.Health.TownChickenAt <= cfg.Health.ChickenAt {
+		add(SeverityWarning, "health.townChickenAt", fmt.Sprintf("town chicken at %d%% never triggers before chicken at %d%%", cfg.Health.TownChickenAt, cfg.Health.ChickenAt))
+	}
+	if cfg.Companion.Enabled && cfg.Companion.Leader && cfg.Companion.FollowerMode != "" {
+		add(SeverityWarning, "companion.followerMode", "follower mode is ignored for the leader")
+	}
+	if cfg.Companion.Enabled && !cfg.Companion.Leader && cfg.Companion.LeaderName == "" {
+		add(SeverityError, "companion.leaderName", "followers need the leader name")
+	}
+	if cfg.Scheduler.Enabled && cfg.Scheduler.Mode != "duration" && !schedulerHasTimeRanges(cfg) {
+		add(SeverityWarning, "scheduler.days", "scheduler is enabled without any time range, the supervisor never starts")
+	}
+
+	if saveDir := resolveSaveDir(cfg.CommandLineArgs, Koolo.UseCustomSettings); saveDir != "" && cfg.CharacterName != "" {
+		if _, exists, err := resolveKeyBindingPath(saveDir, cfg.CharacterName, cfg.AuthMethod); err != nil {
+			add(SeverityWarning, "keyBindings", err.Error())
+		} else if !exists {
+			add(SeverityWarning, "keyBindings", fmt.Sprintf("no key bindings file for %s in %s, it's created the first time the character is played", cfg.CharacterName, saveDir))
+		}
+	}
+
+	return issues
+}
+
+func schedulerHasTimeRanges(cfg *CharacterCfg) bool {
+	for _, d := range cfg.Scheduler.Days {
+		if len(d.TimeRanges) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,39 @@
+package context
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/area"
+)
+
+// CompanionLeaderState is the last known location of a companion leader, published on every game data refresh and
+// read by its followers.
+type CompanionLeaderState struct {
+	Area      area.ID
+	Position  data.Position
+	UpdatedAt time.Time
+}
+
+var companionLeaders = struct {
+	mu     sync.RWMutex
+	states map[string]CompanionLeaderState
+}{states: make(map[string]CompanionLeaderState)}
+
+func publishCompanionLeaderState(name string, areaID area.ID, position data.Position) {
+	companionLeaders.mu.Lock()
+	defer companionLeaders.mu.Unlock()
+
+	companionLeaders.states[name] = CompanionLeaderState{Area: areaID, Position: position, UpdatedAt: time.Now()}
+}
+
+// GetCompanionLeaderState returns the last location published by the leader character.
+func GetCompanionLeaderState(name string) (CompanionLeaderState, bool) {
+	companionLeaders.mu.RLock()
+	defer companionLeaders.mu.RUnlock()
+
+	state, found := companionLeaders.states[name]
+
+	return state, found
+}
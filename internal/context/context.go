@@ -1,6 +1,7 @@
 package context
 
 import (
+	"errors"
 	"log/slog"
 	"runtime"
 	"strconv"
@@ -16,7 +17,9 @@ import (
 	"github.com/hectorgimenez/koolo/internal/event"
 	"github.com/hectorgimenez/koolo/internal/game"
 	"github.com/hectorgimenez/koolo/internal/health"
+	"github.com/hectorgimenez/koolo/internal/humanize"
 	"github.com/hectorgimenez/koolo/internal/pather"
+	"github.com/hectorgimenez/koolo/internal/replay"
 	"github.com/hectorgimenez/koolo/internal/utils"
 )
 
@@ -35,9 +38,64 @@ const (
 	PriorityStop       = 100
 )
 
+func (p Priority) String() string {
+	switch p {
+	case PriorityHigh:
+		return "high"
+	case PriorityNormal:
+		return "normal"
+	case PriorityBackground:
+		return "background"
+	case PriorityPause:
+		return "pause"
+	case PriorityStop:
+		return "stop"
+	}
+
+	return strconv.Itoa(int(p))
+}
+
 type Status struct {
 	*Context
 	Priority Priority
+	// Deadlines of the actions being run with a timeout, outermost first
+	actionDeadlines []time.Time
+}
+
+// ErrActionDeadline is returned by the steps once the deadline of an action being run has passed, the action
+// middleware that set the deadline turns it into its timeout error.
+var ErrActionDeadline = errors.New("action deadline passed")
+
+// PushActionDeadline sets the deadline of an action run in this routine, the returned depth is given back to
+// PopActionDeadline.
+func (s *Status) PushActionDeadline(deadline time.Time) int {
+	s.actionDeadlines = append(s.actionDeadlines, deadline)
+
+	return len(s.actionDeadlines) - 1
+}
+
+// ActionDeadlineExceeded returns ErrActionDeadline once the deadline of any action being run has passed. The steps
+// check it at their boundaries, along with the priority.
+func (s *Status) ActionDeadlineExceeded() error {
+	for _, deadline := range s.actionDeadlines {
+		if time.Now().After(deadline) {
+			return ErrActionDeadline
+		}
+	}
+
+	return nil
+}
+
+// ActionDeadlinePassed tells if the deadline at depth has passed.
+func (s *Status) ActionDeadlinePassed(depth int) bool {
+	return depth < len(s.actionDeadlines) && time.Now().After(s.actionDeadlines[depth])
+}
+
+// PopActionDeadline removes the deadline at depth and the ones of the actions nested in it.
+func (s *Status) PopActionDeadline(depth int) {
+	if depth < len(s.actionDeadlines) {
+		s.actionDeadlines = s.actionDeadlines[:depth]
+	}
 }
 
 type Context struct {
@@ -70,11 +128,45 @@ type Context struct {
 	IsBossEquipmentActive     bool          // flag for barb leveling
 	Drop                      *drop.Manager // Drop: Per-supervisor Drop manager
 	IsAllocatingStatsOrSkills atomic.Bool   // Prevents stuck detection during stat/skill allocation
+	ResumeSkipRuns            []string      // Runs already done in the game that crashed, skipped in the first game after restart
+	TownIdleOnly              bool          // The game version is not supported, the runs are replaced by an idle in town
+	ClientDegraded            atomic.Bool   // Set by the client watchdog, the client is restarted at the next town visit
+	PlannedRestart            bool          // The client was closed on purpose to restart it, not counted as a crash
+	SuspendRequested          atomic.Bool   // Safe suspend: the run parks the character at the next step and waits until cleared
+	SuspendHandler            func()        // Parks the character and waits, called from the normal priority routine
+	SuspendCheckpoint         *SuspendCheckpoint
+	Recorder                  atomic.Pointer[replay.Recorder] // Records the game when debug.recordReplays is set, nil otherwise
+	Humanizer                 *humanize.Profile               // Variance between games, nil leaves everything as configured
+	Profiler                  *Profiler                       // Time spent by phase (travel, combat, town...), flushed by every run
+	suspending                atomic.Bool
+	snapshot                  dataSnapshot
+	// SuspendedFor is the time spent suspended in the current game, in nanoseconds, left out of the game length
+	SuspendedFor atomic.Int64
+
+	// PendingConfig is the character config reloaded from disk, applied between two games
+	PendingConfig atomic.Pointer[config.CharacterCfg]
+	// PickitReloadPending is set when the NIP files changed, the rules are recompiled between two runs
+	PickitReloadPending atomic.Bool
+	// cfgMu is held while a reload changes the character config, the other routines read it through ConfigSnapshot
+	cfgMu sync.RWMutex
+}
+
+// SuspendCheckpoint is where the run was when it got suspended, used to go back there once resumed.
+type SuspendCheckpoint struct {
+	Run        string        `json:"run"`
+	Area       area.ID       `json:"area"`
+	Position   data.Position `json:"position"`
+	LastAction string        `json:"lastAction"`
+	Parked     bool          `json:"parked"`
+	At         time.Time     `json:"at"`
 }
 
 type Debug struct {
 	LastAction string `json:"lastAction"`
 	LastStep   string `json:"lastStep"`
+	// When the action/step started, repeated calls with the same name keep the time
+	ActionSince time.Time `json:"actionSince"`
+	StepSince   time.Time `json:"stepSince"`
 }
 
 type CurrentGameHelper struct {
@@ -88,6 +180,9 @@ type CurrentGameHelper struct {
 	IsPickingItems             bool
 	FailedToCreateGameAttempts int
 	FailedMenuAttempts         int
+	GameNameCollisions         int
+	CurrentRun                 string
+	RealmDownRetries           int
 	// When this is set, the supervisor will stop and the manager will start a new supervisor for the specified character.
 	SwitchToCharacter string
 	// Used to store the original character name when muling, so we can switch back.
@@ -95,7 +190,43 @@ type CurrentGameHelper struct {
 	CurrentMuleIndex  int
 	ShouldCheckStash  bool
 	StashFull         bool
-	mutex             sync.Mutex
+	// Set at run start, buffs are applied as soon as we leave town regardless of the last buff time.
+	PendingRunBuff bool
+	// Set while the player is dead, buffs are lost on death so the rebuff cooldown is skipped once alive again.
+	BuffsLostOnDeath bool
+	// Set the first time Diablo Clone is seen in the current game, with the area and position where it was. It's
+	// written from the data routine, see SetDiabloCloneSeen and DiabloCloneSighting.
+	diabloCloneSeen     bool
+	diabloCloneArea     area.ID
+	diabloClonePosition data.Position
+	// Valuable items left on the ground when chickening to town, recovered once back from town.
+	LostLoot     []data.Item
+	LostLootArea area.ID
+	// Players whose party button was already clicked, clicking it again would leave the party.
+	PartyInvited map[string]bool
+	StartedAt    time.Time
+	// Set while rushing, boss runs wait for the rushee before killing the boss.
+	Rushing bool
+	// Last /players count typed in this game, 0 until the first one.
+	PlayersCount int
+	// Stash page past the last tab button shown by the page buttons, 0 while on the tab pages.
+	StashPage int
+	// Last error returned by the actions run through the action middleware and how many times in a row it was returned.
+	LastActionError    string
+	ActionErrorRepeats int
+	// When the next anti-idle behavior is due, zero until the first wait.
+	NextIdleAt time.Time
+	// When the items were picked up, the drop reports tell the run they dropped in from it.
+	PickedUpAt map[int]time.Time
+	// Areas whose elite packs were already scored for the danger rules in this game.
+	DangerAssessed map[area.ID]bool
+	// Set once the stash organizer counted this game toward its games interval.
+	StashOrganizerCounted bool
+	// Monsters already hit by the charged skills in this game, each one is cast once per target.
+	ChargedSkillTargets map[data.UnitID]bool
+	// Equipped ethereal items already reported with a low durability in this game.
+	EtherealWarned map[data.UnitID]bool
+	mutex          sync.Mutex
 }
 
 func (ctx *Context) StopSupervisor() {
@@ -121,6 +252,7 @@ func NewContext(name string) *Status {
 			PriorityStop:       {},
 		},
 		CurrentGame:      NewGameHelper(),
+		Profiler:         NewProfiler(),
 		SkillPointIndex:  0,
 		ForceAttack:      false,
 		ManualModeActive: false, // Explicitly initialize to false
@@ -143,8 +275,13 @@ func NewGameHelper() *CurrentGameHelper {
 	return &CurrentGameHelper{
 		PickupItems:                true,
 		PickedUpItems:              make(map[int]int),
+		PickedUpAt:                 make(map[int]time.Time),
+		DangerAssessed:             make(map[area.ID]bool),
+		ChargedSkillTargets:        make(map[data.UnitID]bool),
+		EtherealWarned:             make(map[data.UnitID]bool),
 		BlacklistedItems:           []data.Item{},
 		FailedToCreateGameAttempts: 0,
+		StartedAt:                  time.Now(),
 	}
 }
 
@@ -155,11 +292,19 @@ func Get() *Status {
 }
 
 func (s *Status) SetLastAction(actionName string) {
-	s.Context.ContextDebug[s.Priority].LastAction = actionName
+	debug := s.Context.ContextDebug[s.Priority]
+	if debug.LastAction != actionName {
+		debug.ActionSince = time.Now()
+	}
+	debug.LastAction = actionName
 }
 
 func (s *Status) SetLastStep(stepName string) {
-	s.Context.ContextDebug[s.Priority].LastStep = stepName
+	debug := s.Context.ContextDebug[s.Priority]
+	if debug.LastStep != stepName {
+		debug.StepSince = time.Now()
+	}
+	debug.LastStep = stepName
 }
 
 func getGoroutineID() uint64 {
@@ -173,13 +318,30 @@ func getGoroutineID() uint64 {
 }
 
 func (ctx *Context) RefreshGameData() {
+	ctx.snapshot.mu.Lock()
+	defer ctx.snapshot.mu.Unlock()
+
+	// Reads right after another one return the same data, the last snapshot is kept unless an input was sent since
+	if coalesce := time.Duration(ctx.CharacterCfg.GameData.CoalesceMS) * time.Millisecond; coalesce > 0 &&
+		time.Since(ctx.snapshot.readAt) < coalesce && !ctx.inputSince(ctx.snapshot.readAt) {
+		return
+	}
+
 	*ctx.Data = ctx.GameReader.GetData()
+	ctx.snapshotTaken()
 	if ctx.IsLevelingCharacter == nil {
 		_, isLevelingCharacter := ctx.Char.(LevelingCharacter)
 		ctx.IsLevelingCharacter = &isLevelingCharacter
 	}
 	ctx.Data.IsLevelingCharacter = *ctx.IsLevelingCharacter
+	ctx.publish(*ctx.Data)
+	if recorder := ctx.Recorder.Load(); recorder != nil {
+		recorder.RecordData(*ctx.Data)
+	}
 
+	if ctx.CharacterCfg.Companion.Enabled && ctx.CharacterCfg.Companion.Leader {
+		publishCompanionLeaderState(ctx.CharacterCfg.CharacterName, ctx.Data.PlayerUnit.Area, ctx.Data.PlayerUnit.Position)
+	}
 }
 
 func (ctx *Context) RefreshInventory() {
@@ -202,6 +364,29 @@ func (ctx *Context) SwitchPriority(priority Priority) {
 	ctx.ExecutionPriority = priority
 }
 
+// SetDiabloCloneSeen records where Diablo Clone was first seen in the game, it returns false when it was already seen.
+func (ctx *Context) SetDiabloCloneSeen(areaID area.ID, position data.Position) bool {
+	ctx.CurrentGame.mutex.Lock()
+	defer ctx.CurrentGame.mutex.Unlock()
+
+	if ctx.CurrentGame.diabloCloneSeen {
+		return false
+	}
+	ctx.CurrentGame.diabloCloneSeen = true
+	ctx.CurrentGame.diabloCloneArea = areaID
+	ctx.CurrentGame.diabloClonePosition = position
+
+	return true
+}
+
+// DiabloCloneSighting returns where Diablo Clone was first seen in the game, seen is false until it is.
+func (ctx *Context) DiabloCloneSighting() (areaID area.ID, position data.Position, seen bool) {
+	ctx.CurrentGame.mutex.Lock()
+	defer ctx.CurrentGame.mutex.Unlock()
+
+	return ctx.CurrentGame.diabloCloneArea, ctx.CurrentGame.diabloClonePosition, ctx.CurrentGame.diabloCloneSeen
+}
+
 func (ctx *Context) DisableItemPickup() {
 	ctx.CurrentGame.PickupItems = false
 }
@@ -217,6 +402,12 @@ func (ctx *Context) SetPickingItems(value bool) {
 }
 
 func (s *Status) PauseIfNotPriority() {
+	// Steps are the atomic actions of a run, a requested suspend happens between two of them
+	if s.Priority == PriorityNormal && s.SuspendRequested.Load() && s.SuspendHandler != nil && s.suspending.CompareAndSwap(false, true) {
+		s.SuspendHandler()
+		s.suspending.Store(false)
+	}
+
 	// This prevents bot from trying to move when loading screen is shown.
 	if s.Data.OpenMenus.LoadingScreen {
 		time.Sleep(time.Millisecond * 5)
@@ -230,6 +421,64 @@ func (s *Status) PauseIfNotPriority() {
 		time.Sleep(time.Millisecond * 10)
 	}
 }
+
+// ApplyPendingConfig applies the character config reloaded from disk, if any. It's called by the bot between two games
+// so nothing reads the config while it changes.
+func (ctx *Context) ApplyPendingConfig() {
+	next := ctx.PendingConfig.Swap(nil)
+	if next == nil {
+		return
+	}
+
+	ctx.cfgMu.Lock()
+	applied, restartRequired := ctx.CharacterCfg.ApplyHotReload(next)
+	ctx.cfgMu.Unlock()
+	if len(applied) == 0 && len(restartRequired) == 0 {
+		return
+	}
+
+	changes := make([]string, 0, len(applied))
+	for _, c := range applied {
+		changes = append(changes, c.String())
+	}
+	pending := make([]string, 0, len(restartRequired))
+	for _, c := range restartRequired {
+		pending = append(pending, c.String())
+	}
+
+	ctx.Logger.Info("Character config reloaded", slog.Any("changes", changes))
+	if len(pending) > 0 {
+		ctx.Logger.Warn("Some config changes need a supervisor restart to be applied", slog.Any("changes", pending))
+	}
+	event.Send(event.ConfigChanged(event.Text(ctx.Name, "Config reloaded, "+strconv.Itoa(len(changes))+" changes applied"), changes, pending))
+}
+
+// ApplyPendingPickit recompiles the pickit rules when the NIP files changed. It's called by the bot between two runs, if
+// the edited files don't compile the current rules are kept.
+func (ctx *Context) ApplyPendingPickit() {
+	if !ctx.PickitReloadPending.Swap(false) {
+		return
+	}
+
+	ctx.cfgMu.Lock()
+	err := ctx.CharacterCfg.ReloadPickitRules()
+	ctx.cfgMu.Unlock()
+	if err != nil {
+		ctx.Logger.Error("Pickit files changed but could not be reloaded, keeping the current rules", slog.Any("error", err))
+		return
+	}
+	ctx.Logger.Info("Pickit rules reloaded", slog.Int("rules", len(ctx.CharacterCfg.Runtime.Rules)))
+}
+
+// ConfigSnapshot returns a copy of the character config for the routines other than the bot one, a reload replaces the
+// settings instead of changing them in place so the copy stays consistent.
+func (ctx *Context) ConfigSnapshot() config.CharacterCfg {
+	ctx.cfgMu.RLock()
+	defer ctx.cfgMu.RUnlock()
+
+	return *ctx.CharacterCfg
+}
+
 func (ctx *Context) WaitForGameToLoad() {
 	for ctx.Data.OpenMenus.LoadingScreen {
 		time.Sleep(100 * time.Millisecond)
@@ -256,4 +505,5 @@ func (ctx *Context) Cleanup() {
 	// Reset counters on cleanup for a new session
 	ctx.CurrentGame.FailedToCreateGameAttempts = 0
 	ctx.CurrentGame.FailedMenuAttempts = 0 // Also reset this on cleanup
+	ctx.CurrentGame.GameNameCollisions = 0
 }
@@ -0,0 +1,129 @@
+package context
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// Phase is what the bot is spending its time on, used to profile the runs.
+type Phase string
+
+const (
+	PhaseTravel     Phase = "travel"
+	PhaseCombat     Phase = "combat"
+	PhasePickup     Phase = "pickup"
+	PhaseTown       Phase = "town"
+	PhaseGameCreate Phase = "game_create"
+	PhaseOther      Phase = "other"
+)
+
+// Profiler attributes the wall time to phases. The phases are nested as the actions call each other, the innermost
+// one gets the time except in town where everything done counts as town. The time out of any phase goes to the base
+// phase, game creation while in the menus. Only the bot routine (normal priority) enters phases, the stack would mix
+// the phases of the other routines otherwise.
+type Profiler struct {
+	mu     sync.Mutex
+	base   Phase
+	stack  []Phase
+	since  time.Time
+	totals map[Phase]time.Duration
+}
+
+func NewProfiler() *Profiler {
+	return &Profiler{base: PhaseOther, since: time.Now(), totals: make(map[Phase]time.Duration)}
+}
+
+// Enter starts a phase, the returned function ends it and has to be called from the same routine, usually deferred.
+// It does nothing out of the bot routine.
+func (p *Profiler) Enter(phase Phase) func() {
+	if p == nil {
+		return func() {}
+	}
+	if s := Get(); s == nil || s.Priority != PriorityNormal {
+		return func() {}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.account()
+	p.stack = append(p.stack, phase)
+	depth := len(p.stack)
+
+	return func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
+		p.account()
+		// A panic (stop, timeout) may skip some leaves, the stack is cut at the phase being left
+		if depth <= len(p.stack) {
+			p.stack = p.stack[:depth-1]
+		}
+	}
+}
+
+// SetBase sets the phase of the time out of any other phase.
+func (p *Profiler) SetBase(phase Phase) {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.base == phase {
+		return
+	}
+	p.account()
+	p.base = phase
+}
+
+// Reset drops the phases left open, called at the start of every game since the routines entering them are gone.
+func (p *Profiler) Reset() {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.account()
+	p.stack = nil
+}
+
+// Flush returns the time spent by phase since the last flush, the runs flush it when they finish so the time between
+// two runs (town routines, next game creation) is counted in the next one.
+func (p *Profiler) Flush() map[Phase]time.Duration {
+	if p == nil {
+		return nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.account()
+	totals := p.totals
+	p.totals = make(map[Phase]time.Duration)
+
+	return totals
+}
+
+// current is the phase the time goes to, called with the lock held.
+func (p *Profiler) current() Phase {
+	if slices.Contains(p.stack, PhaseTown) {
+		return PhaseTown
+	}
+	if len(p.stack) > 0 {
+		return p.stack[len(p.stack)-1]
+	}
+
+	return p.base
+}
+
+// account adds the time since the last change to the current phase, called with the lock held.
+func (p *Profiler) account() {
+	now := time.Now()
+	p.totals[p.current()] += now.Sub(p.since)
+	p.since = now
+}
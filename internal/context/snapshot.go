@@ -0,0 +1,113 @@
+package context
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hectorgimenez/koolo/internal/game"
+)
+
+const defaultDataTick = 100 * time.Millisecond
+
+// dataSnapshot tracks the game data reads, every read replaces the whole data at once and wakes up the routines
+// waiting for a change.
+type dataSnapshot struct {
+	mu      sync.Mutex
+	readAt  time.Time
+	version uint64
+	// Closed and replaced on every read
+	changed chan struct{}
+
+	// Copy of the last read for the other goroutines (web UI), ctx.Data is only safe to read from the bot routines
+	publishedMu sync.RWMutex
+	published   *game.Data
+}
+
+// inputSince tells if a click, a key press or a packet was sent to the game after the time, the data read before it
+// doesn't show its result.
+func (ctx *Context) inputSince(t time.Time) bool {
+	if ctx.HID != nil && ctx.HID.LastInputAt().After(t) {
+		return true
+	}
+
+	return ctx.PacketSender != nil && ctx.PacketSender.LastSentAt().After(t)
+}
+
+// snapshotTaken records a read, called with the snapshot lock held.
+func (ctx *Context) snapshotTaken() {
+	ctx.snapshot.readAt = time.Now()
+	ctx.snapshot.version++
+	if ctx.snapshot.changed != nil {
+		close(ctx.snapshot.changed)
+	}
+	ctx.snapshot.changed = make(chan struct{})
+}
+
+// publish copies the data read for the other goroutines, called by the bot routine that read it.
+func (ctx *Context) publish(d game.Data) {
+	ctx.snapshot.publishedMu.Lock()
+	defer ctx.snapshot.publishedMu.Unlock()
+
+	ctx.snapshot.published = &d
+}
+
+// PublishedData returns the copy of the last game data read, it's the only game data the goroutines outside of the bot
+// (web UI) may read. False when nothing was read yet.
+func (ctx *Context) PublishedData() (game.Data, bool) {
+	ctx.snapshot.publishedMu.RLock()
+	defer ctx.snapshot.publishedMu.RUnlock()
+
+	if ctx.snapshot.published == nil {
+		return game.Data{}, false
+	}
+
+	return *ctx.snapshot.published, true
+}
+
+// DataTick is the rate the background routine reads the game data at.
+func (ctx *Context) DataTick() time.Duration {
+	if ctx.CharacterCfg != nil && ctx.CharacterCfg.GameData.TickMS > 0 {
+		return time.Duration(ctx.CharacterCfg.GameData.TickMS) * time.Millisecond
+	}
+
+	return defaultDataTick
+}
+
+// DataVersion returns the number of reads done so far and a channel closed on the next one, used to get notified
+// when the data changes.
+func (ctx *Context) DataVersion() (uint64, <-chan struct{}) {
+	ctx.snapshot.mu.Lock()
+	defer ctx.snapshot.mu.Unlock()
+
+	if ctx.snapshot.changed == nil {
+		ctx.snapshot.changed = make(chan struct{})
+	}
+
+	return ctx.snapshot.version, ctx.snapshot.changed
+}
+
+// AwaitCondition checks the condition on every new snapshot until it's true or the timeout expires, replacing the
+// fixed sleeps followed by a refresh ("wait until the item is in the stash, max 800ms"). The data is read at the data
+// tick rate when nothing else reads it, it returns the condition result of the last snapshot.
+func (ctx *Context) AwaitCondition(condition func(d *game.Data) bool, timeout time.Duration) bool {
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	tick := time.NewTicker(min(ctx.DataTick(), max(timeout, time.Millisecond)))
+	defer tick.Stop()
+
+	ctx.RefreshGameData()
+	for {
+		if condition(ctx.Data) {
+			return true
+		}
+
+		_, changed := ctx.DataVersion()
+		select {
+		case <-deadline.C:
+			return condition(ctx.Data)
+		case <-changed:
+		case <-tick.C:
+			ctx.RefreshGameData()
+		}
+	}
+}
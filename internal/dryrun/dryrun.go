@@ -0,0 +1,131 @@
+// Package dryrun runs the decision pipeline of a character (town routine, runs, actions, pickit) headlessly, against
+// synthetic or recorded game data instead of a game client. The inputs the bot would send are collected instead of
+// being sent, so a profile can be previewed or its run logic checked without the game.
+package dryrun
+
+import (
+	stdctx "context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"time"
+
+	"github.com/hectorgimenez/koolo/internal/action"
+	"github.com/hectorgimenez/koolo/internal/character"
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/context"
+	"github.com/hectorgimenez/koolo/internal/event"
+	"github.com/hectorgimenez/koolo/internal/game"
+	"github.com/hectorgimenez/koolo/internal/health"
+	"github.com/hectorgimenez/koolo/internal/pather"
+	"github.com/hectorgimenez/koolo/internal/run"
+)
+
+// Input is an input the bot would have sent to the game, with the action and the step sending it.
+type Input struct {
+	game.HIDEvent
+	Action string
+	Step   string
+}
+
+// RunReport is what a run did in the dry-run.
+type RunReport struct {
+	Run      string
+	Duration time.Duration
+	Inputs   []Input
+	Err      error
+}
+
+// Bot is a character context wired to the dry-run reader and HID.
+type Bot struct {
+	Ctx      *context.Status
+	listener *event.Listener
+	current  *RunReport
+}
+
+// NewBot builds the context of the character the same way the supervisor does, with the dry-run reader and HID. The
+// packet casting is disabled since there's no game to send the packets to. It must be used from the goroutine calling
+// it, the context is attached to it.
+func NewBot(name string, cfg *config.CharacterCfg, source game.DataSource, logger *slog.Logger) (*Bot, error) {
+	dryCfg := *cfg
+	dryCfg.PacketCasting = config.CharacterCfg{}.PacketCasting
+	// The run timeouts are set as action policies, the ones of the character are kept for its actions
+	dryCfg.ActionPolicies = maps.Clone(cfg.ActionPolicies)
+	if dryCfg.ActionPolicies == nil {
+		dryCfg.ActionPolicies = make(map[string]config.ActionPolicy)
+	}
+
+	ctx := context.NewContext(name)
+	gr := game.NewDryRunReader(&dryCfg, name, source, logger)
+	hid := game.NewDryRunHID(gr)
+	pf := pather.NewPathFinder(gr, ctx.Data, hid, &dryCfg)
+	bm := health.NewBeltManager(ctx.Data, hid, logger, name)
+
+	b := &Bot{Ctx: ctx, listener: event.NewListener(logger)}
+
+	ctx.CharacterCfg = &dryCfg
+	ctx.EventListener = b.listener
+	ctx.HID = hid
+	ctx.Logger = logger
+	ctx.Manager = game.NewGameManager(gr, hid, name)
+	ctx.GameReader = gr
+	ctx.PathFinder = pf
+	ctx.BeltManager = bm
+	ctx.HealthManager = health.NewHealthManager(bm, ctx.Data)
+	char, err := character.BuildCharacter(ctx.Context)
+	if err != nil {
+		return nil, fmt.Errorf("error creating character: %w", err)
+	}
+	ctx.Char = char
+
+	observe, _ := source.(interface{ Observe(game.HIDEvent) })
+	hid.SetObserver(func(e game.HIDEvent) {
+		if observe != nil {
+			observe.Observe(e)
+		}
+		if b.current != nil {
+			debug := ctx.ContextDebug[context.PriorityNormal]
+			b.current.Inputs = append(b.current.Inputs, Input{HIDEvent: e, Action: debug.LastAction, Step: debug.LastStep})
+		}
+	})
+
+	return b, nil
+}
+
+// Preview runs the town routine and the configured runs of the character, every one of them is stopped after the
+// timeout with an action timeout error. The movements are played on the data, runs waiting for the game to react
+// otherwise (a menu opening, a monster dying) only go as far as the data allows, the report shows the inputs sent until
+// then.
+func (b *Bot) Preview(timeout time.Duration) []RunReport {
+	listenCtx, cancel := stdctx.WithCancel(stdctx.Background())
+	defer cancel()
+	// Events are sent to a shared channel, they must be consumed
+	go b.listener.Listen(listenCtx)
+
+	b.Ctx.RefreshGameData()
+
+	reports := []RunReport{b.execute("PreRun", timeout, func() error { return action.PreRun(true) })}
+	runs := make([]string, 0, len(b.Ctx.CharacterCfg.Game.Runs))
+	for _, r := range b.Ctx.CharacterCfg.Game.Runs {
+		runs = append(runs, string(r))
+	}
+	for _, r := range run.BuildRuns(b.Ctx.CharacterCfg, runs) {
+		b.Ctx.CurrentGame.CurrentRun = r.Name()
+		reports = append(reports, b.execute(r.Name(), timeout, func() error { return r.Run(nil) }))
+	}
+
+	return reports
+}
+
+func (b *Bot) execute(name string, timeout time.Duration, fn func() error) RunReport {
+	report := RunReport{Run: name}
+	b.current = &report
+	startedAt := time.Now()
+
+	b.Ctx.CharacterCfg.ActionPolicies[name] = config.ActionPolicy{TimeoutSeconds: int(max(timeout, time.Second) / time.Second)}
+	report.Err = action.RunAction(name, fn)
+	report.Duration = time.Since(startedAt)
+	b.current = nil
+
+	return report
+}
@@ -0,0 +1,93 @@
+package dryrun
+
+import (
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/area"
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/game"
+)
+
+// townFixture is a static Rogue Encampment, walkable everywhere, with the player in the middle of it.
+func townFixture() game.Data {
+	raw := make([][]game.CollisionType, 100)
+	for y := range raw {
+		raw[y] = make([]game.CollisionType, 100)
+		for x := range raw[y] {
+			raw[y][x] = game.CollisionTypeWalkable
+		}
+	}
+	town := game.AreaData{Area: area.RogueEncampment, Grid: game.NewGrid(raw, 0, 0, false)}
+
+	return game.Data{
+		Areas:    map[area.ID]game.AreaData{area.RogueEncampment: town},
+		AreaData: town,
+		Data: data.Data{
+			PlayerUnit: data.PlayerUnit{
+				Name:     "dryrun",
+				Area:     area.RogueEncampment,
+				Position: data.Position{X: 50, Y: 50},
+				Stats: stat.Stats{
+					{ID: stat.Life, Value: 500},
+					{ID: stat.MaxLife, Value: 500},
+					{ID: stat.Mana, Value: 200},
+					{ID: stat.MaxMana, Value: 200},
+				},
+			},
+		},
+	}
+}
+
+func TestPreview(t *testing.T) {
+	cfg := &config.CharacterCfg{}
+	cfg.Character.Class = "sorceress"
+
+	type result struct {
+		reports []RunReport
+		err     error
+	}
+	done := make(chan result, 1)
+	// The bot context is attached to the goroutine building it
+	go func() {
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+		bot, err := NewBot("dryrun", cfg, NewScriptedSource(townFixture()), logger)
+		if err != nil {
+			done <- result{err: err}
+			return
+		}
+		done <- result{reports: bot.Preview(time.Second)}
+	}()
+
+	var res result
+	select {
+	case res = <-done:
+	case <-time.After(time.Minute):
+		t.Fatal("the preview didn't stop after the run timeouts")
+	}
+	if res.err != nil {
+		t.Fatalf("error creating the bot: %v", res.err)
+	}
+
+	if len(res.reports) != 1 || res.reports[0].Run != "PreRun" {
+		t.Fatalf("expected only the PreRun report without runs configured, got %+v", res.reports)
+	}
+}
+
+func TestScriptedSourceForceMove(t *testing.T) {
+	fixture := townFixture()
+	source := NewScriptedSource(fixture)
+
+	center := data.Position{X: game.DryRunGameAreaSizeX / 2, Y: game.DryRunGameAreaSizeY / 2}
+	source.Observe(game.HIDEvent{Kind: game.HIDMove, X: center.X + 100, Y: center.Y})
+	source.Observe(game.HIDEvent{Kind: game.HIDKey, Key: game.KeyBindingKey(fixture.KeyBindings.ForceMove)})
+
+	expected := game.ScreenCoordsToGameCoords(fixture.PlayerUnit.Position, center.X+100, center.Y, game.DryRunGameAreaSizeX, game.DryRunGameAreaSizeY)
+	if got := source.GetData().PlayerUnit.Position; got != expected {
+		t.Fatalf("expected the player moved to %v, got %v", expected, got)
+	}
+}
@@ -0,0 +1,108 @@
+package dryrun
+
+import (
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/skill"
+	"github.com/hectorgimenez/koolo/internal/game"
+	"github.com/hectorgimenez/koolo/internal/replay"
+)
+
+// ScriptedSource serves a list of game data snapshots, it moves to the next one every time the bot clicks or presses a
+// key and stays on the last one. The movements (forced moves and teleports) don't advance the snapshots, they move the
+// player to the walkable position clicked instead, until the area changes. A single snapshot is a static game the
+// character can move in.
+type ScriptedSource struct {
+	mu      sync.Mutex
+	steps   []game.Data
+	index   int
+	pointer data.Position
+	moved   *data.Position
+}
+
+func NewScriptedSource(steps ...game.Data) *ScriptedSource {
+	return &ScriptedSource{steps: steps}
+}
+
+// FromRecording serves the snapshots of a recorded game, see replay.Open.
+func FromRecording(rec *replay.Recording) (*ScriptedSource, error) {
+	var steps []game.Data
+	for {
+		step, err := rec.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, step.Data)
+	}
+
+	return NewScriptedSource(steps...), nil
+}
+
+func (s *ScriptedSource) GetData() game.Data {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.steps) == 0 {
+		return game.Data{}
+	}
+
+	d := s.steps[s.index]
+	if s.moved != nil {
+		d.PlayerUnit.Position = *s.moved
+	}
+
+	return d
+}
+
+// Observe advances the snapshots on the bot inputs and moves the player on the movements, it's set as the HID
+// observer.
+func (s *ScriptedSource) Observe(e game.HIDEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.steps) == 0 {
+		return
+	}
+
+	current := s.steps[s.index]
+	switch {
+	case e.Kind == game.HIDMove:
+		s.pointer = data.Position{X: e.X, Y: e.Y}
+		return
+	case e.Kind == game.HIDKeyUp:
+		return
+	case e.Kind == game.HIDKey && e.Key == game.KeyBindingKey(current.KeyBindings.ForceMove):
+		s.move(current, s.pointer)
+		return
+	case e.Kind == game.HIDClick && e.Button == game.RightButton && current.PlayerUnit.RightSkill == skill.Teleport:
+		s.move(current, data.Position{X: e.X, Y: e.Y})
+		return
+	}
+
+	if s.index < len(s.steps)-1 {
+		s.index++
+		if s.steps[s.index].PlayerUnit.Area != current.PlayerUnit.Area {
+			s.moved = nil
+		}
+	}
+}
+
+// move places the player on the position under the screen coords, when it's walkable.
+func (s *ScriptedSource) move(current game.Data, screen data.Position) {
+	from := current.PlayerUnit.Position
+	if s.moved != nil {
+		from = *s.moved
+	}
+
+	to := game.ScreenCoordsToGameCoords(from, screen.X, screen.Y, game.DryRunGameAreaSizeX, game.DryRunGameAreaSizeY)
+	if current.AreaData.Grid != nil && !current.AreaData.Grid.IsWalkable(to) {
+		return
+	}
+	s.moved = &to
+}
@@ -1,7 +1,10 @@
 package event
 
 import (
+	"time"
+
 	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/area"
 )
 
 const (
@@ -10,6 +13,7 @@ const (
 	FinishedChicken     FinishReason = "chicken"
 	FinishedMercChicken FinishReason = "merc chicken"
 	FinishedError       FinishReason = "error"
+	FinishedSkipped     FinishReason = "skipped"
 
 	InteractionTypeEntrance InteractionType = "entrance"
 	InteractionTypeNPC      InteractionType = "npc"
@@ -73,12 +77,15 @@ func RunFinished(be BaseEvent, runName string, reason FinishReason) RunFinishedE
 type ItemStashedEvent struct {
 	BaseEvent
 	Item data.Drop
+	// PickedUpAt is zero when the item wasn't picked up from the ground in this game
+	PickedUpAt time.Time
 }
 
-func ItemStashed(be BaseEvent, drop data.Drop) ItemStashedEvent {
+func ItemStashed(be BaseEvent, drop data.Drop, pickedUpAt time.Time) ItemStashedEvent {
 	return ItemStashedEvent{
-		BaseEvent: be,
-		Item:      drop,
+		BaseEvent:  be,
+		Item:       drop,
+		PickedUpAt: pickedUpAt,
 	}
 }
 
@@ -152,6 +159,37 @@ func GamePaused(be BaseEvent, paused bool) GamePausedEvent {
 	}
 }
 
+// ConfigChangedEvent is sent once a character config edited on disk is applied to the running bot, the changes are
+// "path: old -> new" lines.
+type ConfigChangedEvent struct {
+	BaseEvent
+	Changes         []string
+	RestartRequired []string
+}
+
+func ConfigChanged(be BaseEvent, changes, restartRequired []string) ConfigChangedEvent {
+	return ConfigChangedEvent{
+		BaseEvent:       be,
+		Changes:         changes,
+		RestartRequired: restartRequired,
+	}
+}
+
+// QueueUpdatedEvent is sent while waiting in the realm queue, and once more with InQueue false when we are through.
+type QueueUpdatedEvent struct {
+	BaseEvent
+	InQueue  bool
+	Position int
+}
+
+func QueueUpdated(be BaseEvent, inQueue bool, position int) QueueUpdatedEvent {
+	return QueueUpdatedEvent{
+		BaseEvent: be,
+		InQueue:   inQueue,
+		Position:  position,
+	}
+}
+
 type RunewordRerollEvent struct {
 	BaseEvent
 	Runeword      string
@@ -214,3 +252,198 @@ func ResetCompanionGameInfo(be BaseEvent, leader string) ResetCompanionGameInfoE
 		Leader:    leader,
 	}
 }
+
+type DiabloCloneSpawnedEvent struct {
+	BaseEvent
+	Area area.ID
+}
+
+func DiabloCloneSpawned(be BaseEvent, ar area.ID) DiabloCloneSpawnedEvent {
+	return DiabloCloneSpawnedEvent{
+		BaseEvent: be,
+		Area:      ar,
+	}
+}
+
+type GambleFinishedEvent struct {
+	BaseEvent
+	Bought    int
+	Kept      int
+	GoldSpent int
+}
+
+func GambleFinished(be BaseEvent, bought, kept, goldSpent int) GambleFinishedEvent {
+	return GambleFinishedEvent{
+		BaseEvent: be,
+		Bought:    bought,
+		Kept:      kept,
+		GoldSpent: goldSpent,
+	}
+}
+
+type GoldUpdatedEvent struct {
+	BaseEvent
+	InventoryGold int
+	StashedGold   int
+	MaxGold       int
+}
+
+func GoldUpdated(be BaseEvent, inventoryGold, stashedGold, maxGold int) GoldUpdatedEvent {
+	return GoldUpdatedEvent{
+		BaseEvent:     be,
+		InventoryGold: inventoryGold,
+		StashedGold:   stashedGold,
+		MaxGold:       maxGold,
+	}
+}
+
+type ExperienceGainedEvent struct {
+	BaseEvent
+	RunName  string
+	Gained   int64
+	Duration time.Duration
+	Level    int
+	// Nested is set for runs executed inside another run, their experience is already part of the outer run.
+	Nested bool
+}
+
+func ExperienceGained(be BaseEvent, runName string, gained int64, duration time.Duration, level int, nested bool) ExperienceGainedEvent {
+	return ExperienceGainedEvent{
+		BaseEvent: be,
+		RunName:   runName,
+		Gained:    gained,
+		Duration:  duration,
+		Level:     level,
+		Nested:    nested,
+	}
+}
+
+// ActionFinishedEvent is sent by the action middleware every time an action ends, Attempts includes the retries.
+type ActionFinishedEvent struct {
+	BaseEvent
+	Action   string
+	Duration time.Duration
+	Attempts int
+	Failed   bool
+	TimedOut bool
+}
+
+func ActionFinished(be BaseEvent, action string, duration time.Duration, attempts int, failed, timedOut bool) ActionFinishedEvent {
+	return ActionFinishedEvent{
+		BaseEvent: be,
+		Action:    action,
+		Duration:  duration,
+		Attempts:  attempts,
+		Failed:    failed,
+		TimedOut:  timedOut,
+	}
+}
+
+// SelfCheckRecoveryEvent is sent every time the self-check walks a step of its recovery ladder.
+type SelfCheckRecoveryEvent struct {
+	BaseEvent
+	Step   string
+	Reason string
+}
+
+func SelfCheckRecovery(be BaseEvent, step, reason string) SelfCheckRecoveryEvent {
+	return SelfCheckRecoveryEvent{
+		BaseEvent: be,
+		Step:      step,
+		Reason:    reason,
+	}
+}
+
+// RunProfiledEvent is sent when a run finishes with the time spent by phase (travel, combat, pickup, town, game
+// creation) since the previous run finished.
+type RunProfiledEvent struct {
+	BaseEvent
+	RunName string
+	Phases  map[string]time.Duration
+}
+
+func RunProfiled(be BaseEvent, runName string, phases map[string]time.Duration) RunProfiledEvent {
+	return RunProfiledEvent{
+		BaseEvent: be,
+		RunName:   runName,
+		Phases:    phases,
+	}
+}
+
+// TownVisitedEvent is sent at the end of every town routine.
+type TownVisitedEvent struct {
+	BaseEvent
+	Area area.ID
+}
+
+func TownVisited(be BaseEvent, a area.ID) TownVisitedEvent {
+	return TownVisitedEvent{
+		BaseEvent: be,
+		Area:      a,
+	}
+}
+
+// ScriptNotificationEvent is a message sent by a hook script.
+type ScriptNotificationEvent struct {
+	BaseEvent
+	Hook string
+}
+
+func ScriptNotification(be BaseEvent, hook string) ScriptNotificationEvent {
+	return ScriptNotificationEvent{
+		BaseEvent: be,
+		Hook:      hook,
+	}
+}
+
+type SessionGoalReachedEvent struct {
+	BaseEvent
+	Goal string
+}
+
+func SessionGoalReached(be BaseEvent, goal string) SessionGoalReachedEvent {
+	return SessionGoalReachedEvent{
+		BaseEvent: be,
+		Goal:      goal,
+	}
+}
+
+type LootLostEvent struct {
+	BaseEvent
+	Items  []data.Item
+	Area   area.ID
+	Reason string
+}
+
+func LootLost(be BaseEvent, items []data.Item, areaID area.ID, reason string) LootLostEvent {
+	return LootLostEvent{
+		BaseEvent: be,
+		Items:     items,
+		Area:      areaID,
+		Reason:    reason,
+	}
+}
+
+type WantedBasesUpdatedEvent struct {
+	BaseEvent
+	Missing []string
+}
+
+func WantedBasesUpdated(be BaseEvent, missing []string) WantedBasesUpdatedEvent {
+	return WantedBasesUpdatedEvent{
+		BaseEvent: be,
+		Missing:   missing,
+	}
+}
+
+type StashUpdatedEvent struct {
+	BaseEvent
+	Data data.Data
+}
+
+func StashUpdated(be BaseEvent, d data.Data) StashUpdatedEvent {
+	return StashUpdatedEvent{
+		BaseEvent: be,
+		Data:      d,
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"math"
 	"math/rand"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/hectorgimenez/koolo/internal/config"
@@ -16,7 +17,9 @@ import (
 var events = make(chan Event)
 
 type Listener struct {
+	mu           sync.Mutex
 	handlers     []Handler
+	owned        map[string][]Handler // Handlers registered by a supervisor, dropped when it stops
 	DropHandlers map[int]Handler
 	logger       *slog.Logger
 }
@@ -27,13 +30,41 @@ func NewListener(logger *slog.Logger) *Listener {
 	return &Listener{
 		logger:       logger,
 		DropHandlers: make(map[int]Handler),
+		owned:        make(map[string][]Handler),
 	}
 }
 
 func (l *Listener) Register(h Handler) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
 	l.handlers = append(l.handlers, h)
 }
 
+// RegisterFor adds a handler owned by the given supervisor, it's removed by Unregister when the supervisor stops.
+func (l *Listener) RegisterFor(owner string, h Handler) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.owned[owner] = append(l.owned[owner], h)
+}
+
+// Unregister removes every handler registered for the given supervisor.
+func (l *Listener) Unregister(owner string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.owned, owner)
+}
+
+func (l *Listener) currentHandlers() []Handler {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	handlers := append([]Handler(nil), l.handlers...)
+	for _, owned := range l.owned {
+		handlers = append(handlers, owned...)
+	}
+
+	return handlers
+}
+
 func (l *Listener) Listen(ctx context.Context) error {
 	for {
 		select {
@@ -53,7 +84,7 @@ func (l *Listener) Listen(ctx context.Context) error {
 				}
 			}
 
-			for _, h := range l.handlers {
+			for _, h := range l.currentHandlers() {
 				if err := h(ctx, e); err != nil && e.Message() != "" {
 					l.logger.Error("error running event handler", slog.Any("error", err))
 				}
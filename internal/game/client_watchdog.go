@@ -0,0 +1,175 @@
+package game
+
+import (
+	"fmt"
+	"log/slog"
+	"runtime"
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/utils/winproc"
+	"golang.org/x/sys/windows"
+)
+
+const clientWatchdogInterval = 10 * time.Second
+
+// ClientHealth is the last resources sample of a client.
+type ClientHealth struct {
+	CPUPercent    float64
+	MemoryMB      int
+	ReadLatencyMs int
+	Degraded      bool
+	Reason        string
+	SampledAt     time.Time
+}
+
+type processMemoryCounters struct {
+	Cb                         uint32
+	PageFaultCount             uint32
+	PeakWorkingSetSize         uintptr
+	WorkingSetSize             uintptr
+	QuotaPeakPagedPoolUsage    uintptr
+	QuotaPagedPoolUsage        uintptr
+	QuotaPeakNonPagedPoolUsage uintptr
+	QuotaNonPagedPoolUsage     uintptr
+	PagefileUsage              uintptr
+	PeakPagefileUsage          uintptr
+}
+
+// ClientWatchdog samples the CPU and memory used by the client process along with the memory read latency, and calls
+// onDegraded once the client stays over any of the configured limits.
+type ClientWatchdog struct {
+	pid        uint32
+	supervisor string
+	gr         *MemoryReader
+	logger     *slog.Logger
+	onDegraded func(reason string)
+	stopChan   chan struct{}
+
+	mu        sync.Mutex
+	health    ClientHealth
+	overLimit int
+	lastCPU   time.Duration
+	lastAt    time.Time
+}
+
+func NewClientWatchdog(sup string, pid uint32, gr *MemoryReader, logger *slog.Logger, onDegraded func(reason string)) *ClientWatchdog {
+	return &ClientWatchdog{
+		pid:        pid,
+		supervisor: sup,
+		gr:         gr,
+		logger:     logger,
+		onDegraded: onDegraded,
+		stopChan:   make(chan struct{}),
+	}
+}
+
+func (cw *ClientWatchdog) Start() {
+	ticker := time.NewTicker(clientWatchdogInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-cw.stopChan:
+			return
+		case <-ticker.C:
+			if !config.Koolo.ClientWatchdog.Enabled {
+				continue
+			}
+			cw.sample()
+		}
+	}
+}
+
+func (cw *ClientWatchdog) Stop() {
+	close(cw.stopChan)
+}
+
+// Health returns the last sample.
+func (cw *ClientWatchdog) Health() ClientHealth {
+	cw.mu.Lock()
+	defer cw.mu.Unlock()
+
+	return cw.health
+}
+
+func (cw *ClientWatchdog) sample() {
+	handle, err := windows.OpenProcess(windows.PROCESS_QUERY_LIMITED_INFORMATION|windows.PROCESS_VM_READ, false, cw.pid)
+	if err != nil {
+		return
+	}
+	defer windows.CloseHandle(handle)
+
+	cw.mu.Lock()
+	defer cw.mu.Unlock()
+
+	now := time.Now()
+	if cpu, err := processCPUTime(handle); err == nil {
+		if !cw.lastAt.IsZero() {
+			cw.health.CPUPercent = float64(cpu-cw.lastCPU) / float64(now.Sub(cw.lastAt)) / float64(runtime.NumCPU()) * 100
+		}
+		cw.lastCPU = cpu
+		cw.lastAt = now
+	}
+	cw.health.MemoryMB = processMemoryMB(handle)
+	cw.health.ReadLatencyMs = int(cw.gr.ReadLatency().Milliseconds())
+	cw.health.SampledAt = now
+
+	reason := cw.limitExceeded()
+	if reason == "" {
+		cw.overLimit = 0
+		return
+	}
+
+	cw.overLimit++
+	if cw.health.Degraded || cw.overLimit < max(config.Koolo.ClientWatchdog.Samples, 1) {
+		return
+	}
+
+	cw.health.Degraded = true
+	cw.health.Reason = reason
+	cw.logger.Warn("Client degraded, restarting it at the next town visit", slog.String("supervisor", cw.supervisor), slog.String("reason", reason))
+	if cw.onDegraded != nil {
+		cw.onDegraded(reason)
+	}
+}
+
+func (cw *ClientWatchdog) limitExceeded() string {
+	limits := config.Koolo.ClientWatchdog
+	switch {
+	case limits.MaxCPUPercent > 0 && cw.health.CPUPercent > float64(limits.MaxCPUPercent):
+		return fmt.Sprintf("CPU usage %.0f%%", cw.health.CPUPercent)
+	case limits.MaxMemoryMB > 0 && cw.health.MemoryMB > limits.MaxMemoryMB:
+		return fmt.Sprintf("memory usage %d MB", cw.health.MemoryMB)
+	case limits.MaxReadLatencyMs > 0 && cw.health.ReadLatencyMs > limits.MaxReadLatencyMs:
+		return fmt.Sprintf("memory read latency %d ms", cw.health.ReadLatencyMs)
+	}
+
+	return ""
+}
+
+func processCPUTime(handle windows.Handle) (time.Duration, error) {
+	var creation, exit, kernel, user windows.Filetime
+	if err := windows.GetProcessTimes(handle, &creation, &exit, &kernel, &user); err != nil {
+		return 0, err
+	}
+
+	// Filetime counts 100ns intervals
+	ticks := int64(kernel.HighDateTime)<<32 | int64(kernel.LowDateTime)
+	ticks += int64(user.HighDateTime)<<32 | int64(user.LowDateTime)
+
+	return time.Duration(ticks * 100), nil
+}
+
+func processMemoryMB(handle windows.Handle) int {
+	counters := processMemoryCounters{}
+	counters.Cb = uint32(unsafe.Sizeof(counters))
+	ret, _, _ := winproc.K32GetProcessMemoryInfo.Call(uintptr(handle), uintptr(unsafe.Pointer(&counters)), uintptr(counters.Cb))
+	if ret == 0 {
+		return 0
+	}
+
+	return int(counters.PagefileUsage / 1024 / 1024)
+}
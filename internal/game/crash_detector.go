@@ -4,6 +4,7 @@ import (
 	"log/slog"
 	"time"
 
+	"github.com/hectorgimenez/koolo/internal/utils/winproc"
 	"golang.org/x/sys/windows"
 )
 
@@ -29,7 +30,7 @@ func NewCrashDetector(sup string, pid int32, hwnd uintptr, logger *slog.Logger,
 
 func (cd *CrashDetector) Start() {
 	cd.logger.Info("Starting Crash Detector ...", slog.Int("PID", int(cd.pid)), slog.String("Supervisor", cd.supervisor))
-	ticker := time.NewTicker(5 * time.Second)
+	ticker := time.NewTicker(2 * time.Second)
 	defer ticker.Stop()
 
 	for {
@@ -38,7 +39,14 @@ func (cd *CrashDetector) Start() {
 			cd.logger.Info("Crash Detector stopped.", slog.Int("PID", int(cd.pid)), slog.String("Supervisor", cd.supervisor))
 			return
 		case <-ticker.C:
-			if !cd.isProcessRunning() {
+			running := cd.isProcessRunning()
+			if running && !cd.isWindowAlive() {
+				// The window is gone but the process hangs around, it would keep the handles of the new client
+				cd.logger.Error("Client window disappeared, terminating the process ...", slog.Int("PID", int(cd.pid)), slog.String("Supervisor", cd.supervisor))
+				cd.terminateProcess()
+				running = false
+			}
+			if !running {
 				cd.logger.Error("Client crash detected ...", slog.Int("PID", int(cd.pid)), slog.String("Supervisor", cd.supervisor))
 				if cd.restartFunc != nil {
 					cd.logger.Info("Attempting to restart client ...", slog.String("Supervisor", cd.supervisor))
@@ -74,3 +82,26 @@ func (cd *CrashDetector) isProcessRunning() bool {
 
 	return isRunning
 }
+
+func (cd *CrashDetector) isWindowAlive() bool {
+	if cd.hwnd == 0 {
+		return true
+	}
+
+	alive, _, _ := winproc.IsWindow.Call(cd.hwnd)
+
+	return alive != 0
+}
+
+func (cd *CrashDetector) terminateProcess() {
+	handle, err := windows.OpenProcess(windows.PROCESS_TERMINATE, false, uint32(cd.pid))
+	if err != nil {
+		cd.logger.Debug("Failed to open process", slog.Int("PID", int(cd.pid)), slog.String("err", err.Error()))
+		return
+	}
+	defer windows.CloseHandle(handle)
+
+	if err = windows.TerminateProcess(handle, 1); err != nil {
+		cd.logger.Debug("Failed to terminate process", slog.Int("PID", int(cd.pid)), slog.String("error", err.Error()))
+	}
+}
@@ -18,6 +18,12 @@ import (
 	"github.com/hectorgimenez/koolo/internal/config"
 )
 
+// Weapon sets as reported in ActiveWeaponSlot
+const (
+	MainWeaponSlot = 0
+	SwapWeaponSlot = 1
+)
+
 type Data struct {
 	Areas    map[area.ID]AreaData `json:"-"`
 	AreaData AreaData             `json:"-"`
@@ -111,6 +117,20 @@ func (d Data) CanTeleport() bool {
 	_, isTpBound := d.KeyBindings.KeyBindingForSkill(skill.Teleport)
 	canUsePacketSkillSelection := d.CharacterCfg.PacketCasting.UseForSkillSelection
 
+	// Teleport from item charges can't be cast once they are used up
+	for _, cs := range d.CharacterCfg.Character.ChargedSkills {
+		if strings.EqualFold(strings.TrimSpace(cs.Skill), "Teleport") {
+			if points, found := d.PlayerUnit.Skills[skill.Teleport]; !found || points.Charges == 0 {
+				return false
+			}
+		}
+	}
+
+	// The Teleport charges are on the second weapon set, they can only be used while it's the active one
+	if d.CharacterCfg.Character.TeleportWithSwap && d.ActiveWeaponSlot != SwapWeaponSlot {
+		return false
+	}
+
 	// Ensure Teleport is bound (or packet skill selection is enabled) and the current area is not a town
 	return (isTpBound || canUsePacketSkillSelection) && !d.PlayerUnit.Area.IsTown()
 }
@@ -0,0 +1,201 @@
+package game
+
+import (
+	"log/slog"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/memory"
+	"github.com/hectorgimenez/koolo/internal/config"
+)
+
+// Game area size used in dry-run mode, the screen coords are computed for a 1280x720 window.
+const (
+	DryRunGameAreaSizeX = 1280
+	DryRunGameAreaSizeY = 720
+)
+
+// DataSource gives the game data in dry-run mode instead of the game memory, the map data comes with it (Areas and
+// AreaData).
+type DataSource interface {
+	GetData() Data
+}
+
+// ScreenCoordsToGameCoords is the reverse of the pather game to screen conversion, it returns the game position under
+// the screen coords of the game area, relative to the player position.
+func ScreenCoordsToGameCoords(player data.Position, screenX, screenY, gameAreaSizeX, gameAreaSizeY int) data.Position {
+	isoX := float32(screenX-gameAreaSizeX/2) / 19.8
+	isoY := float32(screenY-gameAreaSizeY/2) / 9.9
+
+	return data.Position{
+		X: player.X + int((isoX+isoY)/2),
+		Y: player.Y + int((isoY-isoX)/2),
+	}
+}
+
+// NewDryRunReader returns a reader serving the data of the source, no game client is needed. Only the reads done while
+// in game are served, the lobby and the character selection screens are never reached in dry-run mode.
+func NewDryRunReader(cfg *config.CharacterCfg, supervisorName string, source DataSource, logger *slog.Logger) *MemoryReader {
+	return &MemoryReader{
+		// The memory reads have no process to read from, the ones the bot does are served below
+		GameReader:     &memory.GameReader{},
+		cfg:            cfg,
+		supervisorName: supervisorName,
+		source:         source,
+		logger:         logger,
+		GameAreaSizeX:  DryRunGameAreaSizeX,
+		GameAreaSizeY:  DryRunGameAreaSizeY,
+	}
+}
+
+// DryRun returns true when the data comes from a data source instead of the game memory.
+func (gd *MemoryReader) DryRun() bool {
+	return gd.source != nil
+}
+
+func (gd *MemoryReader) dryRunData() Data {
+	d := gd.source.GetData()
+	if gd.cfg != nil {
+		d.CharacterCfg = *gd.cfg
+	}
+
+	return d
+}
+
+// The reads below are the ones of the embedded memory reader used while in game, they are served from the data
+// source in dry-run mode.
+
+func (gd *MemoryReader) InGame() bool {
+	if gd.source != nil {
+		return true
+	}
+
+	return gd.GameReader.InGame()
+}
+
+func (gd *MemoryReader) GetInventory() data.Inventory {
+	if gd.source != nil {
+		return gd.source.GetData().Inventory
+	}
+
+	return gd.GameReader.GetInventory()
+}
+
+func (gd *MemoryReader) LegacyGraphics() bool {
+	if gd.source != nil {
+		return gd.source.GetData().LegacyGraphics
+	}
+
+	return gd.GameReader.LegacyGraphics()
+}
+
+func (gd *MemoryReader) GetPanel(panelPath ...string) data.Panel {
+	if gd.source != nil {
+		return data.Panel{}
+	}
+
+	return gd.GameReader.GetPanel(panelPath...)
+}
+
+// The reads below are the ones of the embedded memory reader used out of game or by the client checks, in dry-run
+// mode the game is always joined, online and without a modal.
+
+func (gd *MemoryReader) IsIngame() bool {
+	if gd.source != nil {
+		return true
+	}
+
+	return gd.GameReader.IsIngame()
+}
+
+func (gd *MemoryReader) IsOnline() bool {
+	if gd.source != nil {
+		return true
+	}
+
+	return gd.GameReader.IsOnline()
+}
+
+func (gd *MemoryReader) IsInLobby() bool {
+	if gd.source != nil {
+		return false
+	}
+
+	return gd.GameReader.IsInLobby()
+}
+
+func (gd *MemoryReader) IsInCharacterSelectionScreen() bool {
+	if gd.source != nil {
+		return false
+	}
+
+	return gd.GameReader.IsInCharacterSelectionScreen()
+}
+
+func (gd *MemoryReader) IsInCharacterCreationScreen() bool {
+	if gd.source != nil {
+		return false
+	}
+
+	return gd.GameReader.IsInCharacterCreationScreen()
+}
+
+func (gd *MemoryReader) IsDismissableModalPresent() (bool, string) {
+	if gd.source != nil {
+		return false, ""
+	}
+
+	return gd.GameReader.IsDismissableModalPresent()
+}
+
+func (gd *MemoryReader) GetSelectedCharacterName() string {
+	if gd.source != nil {
+		return gd.supervisorName
+	}
+
+	return gd.GameReader.GetSelectedCharacterName()
+}
+
+func (gd *MemoryReader) GetCharacterList() []string {
+	if gd.source != nil {
+		return []string{gd.supervisorName}
+	}
+
+	return gd.GameReader.GetCharacterList()
+}
+
+func (gd *MemoryReader) GetMercList() []memory.MercOption {
+	if gd.source != nil {
+		return nil
+	}
+
+	return gd.GameReader.GetMercList()
+}
+
+func (gd *MemoryReader) LastGameName() string {
+	if gd.source != nil {
+		return ""
+	}
+
+	return gd.GameReader.LastGameName()
+}
+
+func (gd *MemoryReader) LastGamePass() string {
+	if gd.source != nil {
+		return ""
+	}
+
+	return gd.GameReader.LastGamePass()
+}
+
+func (gd *MemoryReader) GetPID() uint32 {
+	if gd.source != nil {
+		return 0
+	}
+
+	return gd.GameReader.GetPID()
+}
+
+// NewDryRunHID returns a HID that sends nothing to the game, the inputs are only given to the observer.
+func NewDryRunHID(gr *MemoryReader) *HID {
+	return &HID{gr: gr, dryRun: true}
+}
@@ -0,0 +1,104 @@
+package game
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/hectorgimenez/koolo/internal/config"
+)
+
+const (
+	// Battle.net rejects game names and passwords longer than this
+	maxGameNameLength     = 15
+	defaultPasswordLength = 4
+	gameNameAlphabet      = "abcdefghijklmnopqrstuvwxyz0123456789"
+)
+
+// LobbyGame is the name and password used for a lobby game, they are generated once per game counter so the name
+// shown to followers and external tools is the one typed when the game is created.
+type LobbyGame struct {
+	Name     string `json:"name"`
+	Password string `json:"password"`
+	counter  int
+}
+
+// NextLobbyGame returns the name and password the next lobby game will be created with.
+func (gm *Manager) NextLobbyGame(gameCounter int) LobbyGame {
+	gm.nextGameMu.Lock()
+	defer gm.nextGameMu.Unlock()
+
+	if gm.nextGame == nil || gm.nextGame.counter != gameCounter {
+		cfg, _ := config.GetCharacter(gm.supervisorName)
+		lobbyGame := generateLobbyGame(cfg, gameCounter)
+		gm.nextGame = &lobbyGame
+	}
+
+	return *gm.nextGame
+}
+
+// discardNextLobbyGame forces a new name for the next game, used once the name has been taken, by us or anyone else.
+func (gm *Manager) discardNextLobbyGame() {
+	gm.nextGameMu.Lock()
+	gm.nextGame = nil
+	gm.nextGameMu.Unlock()
+}
+
+// generateLobbyGame builds the game name from the template, the zero padded counter and the random suffix, trimming
+// the template when needed so the counter and suffix always fit in the realm limit.
+func generateLobbyGame(cfg *config.CharacterCfg, gameCounter int) LobbyGame {
+	counter := fmt.Sprintf("%0*d", max(cfg.Companion.GameCounterDigits, 1), gameCounter)
+	suffix := randomGameString(min(max(cfg.Companion.GameNameSuffixLength, 0), maxGameNameLength-len(counter)))
+
+	prefix := sanitizeGameString(cfg.Companion.GameNameTemplate)
+	if room := maxGameNameLength - len(counter) - len(suffix); len(prefix) > room {
+		prefix = prefix[:max(room, 0)]
+	}
+	name := prefix + counter + suffix
+	if len(name) > maxGameNameLength {
+		name = name[len(name)-maxGameNameLength:]
+	}
+
+	return LobbyGame{
+		Name:     name,
+		Password: lobbyGamePassword(cfg),
+		counter:  gameCounter,
+	}
+}
+
+func lobbyGamePassword(cfg *config.CharacterCfg) string {
+	switch cfg.Companion.PasswordPolicy {
+	case config.PasswordPolicyNone:
+		return ""
+	case config.PasswordPolicyRandom:
+		length := cfg.Companion.PasswordLength
+		if length <= 0 {
+			length = defaultPasswordLength
+		}
+		return randomGameString(min(length, maxGameNameLength))
+	default:
+		// The followers join with the configured password as it is, it's typed unchanged
+		return cfg.Companion.GamePassword
+	}
+}
+
+// sanitizeGameString drops the characters the realm doesn't accept or we can't type from a generated game name.
+func sanitizeGameString(s string) string {
+	var sb strings.Builder
+	for _, ch := range s {
+		if ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || ch >= '0' && ch <= '9' || ch == '-' {
+			sb.WriteRune(ch)
+		}
+	}
+
+	return sb.String()
+}
+
+func randomGameString(length int) string {
+	b := make([]byte, length)
+	for i := range b {
+		b[i] = gameNameAlphabet[rand.Intn(len(gameNameAlphabet))]
+	}
+
+	return string(b)
+}
@@ -1,8 +1,40 @@
 package game
 
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// HID output kinds, see HIDEvent.
+const (
+	HIDMove    = "move"
+	HIDClick   = "click"
+	HIDKey     = "key"
+	HIDKeyDown = "keyDown"
+	HIDKeyUp   = "keyUp"
+)
+
 type HID struct {
 	gr *MemoryReader
 	gi *MemoryInjector
+
+	observerMu sync.RWMutex
+	observer   func(HIDEvent)
+	modifier   ModifierKey  // Modifier held by ClickWithModifier/PressKeyWithModifier, reported with the event
+	dryRun     bool         // Nothing is sent to the game, see NewDryRunHID
+	lastInput  atomic.Int64 // Unix nanoseconds of the last input, the game data read before it is stale
+}
+
+// HIDEvent is an input sent to the game window, X and Y are relative to the game area.
+type HIDEvent struct {
+	At       time.Time
+	Kind     string
+	X        int         `json:",omitempty"`
+	Y        int         `json:",omitempty"`
+	Button   MouseButton `json:",omitempty"`
+	Key      byte        `json:",omitempty"`
+	Modifier ModifierKey `json:",omitempty"`
 }
 
 func NewHID(gr *MemoryReader, gi *MemoryInjector) *HID {
@@ -11,3 +43,35 @@ func NewHID(gr *MemoryReader, gi *MemoryInjector) *HID {
 		gi: gi,
 	}
 }
+
+// LastInputAt returns when the last input was sent to the game, zero before the first one.
+func (hid *HID) LastInputAt() time.Time {
+	if at := hid.lastInput.Load(); at > 0 {
+		return time.Unix(0, at)
+	}
+
+	return time.Time{}
+}
+
+// SetObserver sets the function called with every input sent to the game, used to record them. Nil removes it.
+func (hid *HID) SetObserver(observer func(HIDEvent)) {
+	hid.observerMu.Lock()
+	defer hid.observerMu.Unlock()
+
+	hid.observer = observer
+}
+
+func (hid *HID) emit(e HIDEvent) {
+	hid.lastInput.Store(time.Now().UnixNano())
+
+	hid.observerMu.RLock()
+	observer := hid.observer
+	hid.observerMu.RUnlock()
+
+	if observer == nil {
+		return
+	}
+	e.At = time.Now()
+	e.Modifier = hid.modifier
+	observer(e)
+}
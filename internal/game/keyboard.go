@@ -17,6 +17,10 @@ const (
 
 // PressKey receives an ASCII code and sends a key press event to the game window
 func (hid *HID) PressKey(key byte) {
+	hid.emit(HIDEvent{Kind: HIDKey, Key: key})
+	if hid.dryRun {
+		return
+	}
 	win.PostMessage(hid.gr.HWND, win.WM_KEYDOWN, uintptr(key), hid.calculatelParam(key, true))
 	sleepTime := rand.Intn(keyPressMaxTime-keyPressMinTime) + keyPressMinTime
 	time.Sleep(time.Duration(sleepTime) * time.Millisecond)
@@ -32,9 +36,13 @@ func (hid *HID) KeySequence(keysToPress ...byte) {
 
 // PressKeyWithModifier works the same as PressKey but with a modifier key (shift, ctrl, alt)
 func (hid *HID) PressKeyWithModifier(key byte, modifier ModifierKey) {
-	hid.gi.OverrideGetKeyState(byte(modifier))
+	if !hid.dryRun {
+		hid.gi.OverrideGetKeyState(byte(modifier))
+		defer hid.gi.RestoreGetKeyState()
+	}
+	hid.modifier = modifier
 	hid.PressKey(key)
-	hid.gi.RestoreGetKeyState()
+	hid.modifier = 0
 }
 
 func (hid *HID) PressKeyBinding(kb data.KeyBinding) {
@@ -50,15 +58,28 @@ func (hid *HID) PressKeyBinding(kb data.KeyBinding) {
 // KeyDown sends a key down event to the game window
 func (hid *HID) KeyDown(kb data.KeyBinding) {
 	keys := getKeysForKB(kb)
+	hid.emit(HIDEvent{Kind: HIDKeyDown, Key: keys[0]})
+	if hid.dryRun {
+		return
+	}
 	win.PostMessage(hid.gr.HWND, win.WM_KEYDOWN, uintptr(keys[0]), hid.calculatelParam(keys[0], true))
 }
 
 // KeyUp sends a key up event to the game window
 func (hid *HID) KeyUp(kb data.KeyBinding) {
 	keys := getKeysForKB(kb)
+	hid.emit(HIDEvent{Kind: HIDKeyUp, Key: keys[0]})
+	if hid.dryRun {
+		return
+	}
 	win.PostMessage(hid.gr.HWND, win.WM_KEYUP, uintptr(keys[0]), hid.calculatelParam(keys[0], false))
 }
 
+// KeyBindingKey returns the key sent for the binding, as reported in the HID events.
+func KeyBindingKey(kb data.KeyBinding) byte {
+	return getKeysForKB(kb)[0]
+}
+
 func getKeysForKB(kb data.KeyBinding) [2]byte {
 	if kb.Key1[0] == 0 || kb.Key1[0] == 255 {
 		return [2]byte{kb.Key2[0], kb.Key2[1]}
@@ -108,6 +129,7 @@ var specialChars = map[string]byte{
 	"rwin":      win.VK_RWIN,
 	"end":       win.VK_END,
 	"-":         win.VK_OEM_MINUS,
+	"/":         win.VK_OEM_2,
 }
 
 func (hid *HID) calculatelParam(keyCode byte, down bool) uintptr {
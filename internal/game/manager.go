@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os/exec"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 	"unsafe"
@@ -23,8 +24,13 @@ type Manager struct {
 	gr             *MemoryReader
 	hid            *HID
 	supervisorName string
+	nextGameMu     sync.Mutex
+	nextGame       *LobbyGame
 }
 
+// ErrGameNameTaken is returned when the realm refuses the game name because another game already uses it.
+var ErrGameNameTaken = errors.New("error creating game! Game name already in use")
+
 func NewGameManager(gr *MemoryReader, hid *HID, sueprvisorName string) *Manager {
 	return &Manager{gr: gr, hid: hid, supervisorName: sueprvisorName}
 }
@@ -73,18 +79,70 @@ func (gm *Manager) ExitGame() error {
 }
 
 func (gm *Manager) NewGame() error {
+	return gm.startGame(600, 650)
+}
+
+// HostTCPGame hosts a TCP/IP game from the character selection screen, the difficulty is picked like for a new
+// offline game.
+func (gm *Manager) HostTCPGame() error {
+	return gm.startGame(460, 650)
+}
+
+// JoinTCPGame joins the TCP/IP game hosted at the address from the character selection screen.
+func (gm *Manager) JoinTCPGame(host string) error {
 	if gm.gr.InGame() {
 		return errors.New("character still in a game")
 	}
+	if !gm.waitCharacterSelection() {
+		return errors.New("character selection screen not found")
+	}
+
+	// Join Game button, it opens the host address prompt
+	gm.hid.Click(LeftButton, 760, 650)
+	utils.Sleep(500)
+	gm.clearGameNameOrPasswordField()
+	for _, ch := range host {
+		gm.hid.PressKey(gm.hid.GetASCIICode(fmt.Sprintf("%c", ch)))
+	}
+	gm.hid.PressKey(win.VK_RETURN)
 
+	for range 15 {
+		if gm.gr.InGame() {
+			return nil
+		}
+		utils.Sleep(1000)
+
+		// The host can't be reached or the game is full
+		panel := gm.gr.GetPanel("DismissableModal")
+		if panel.PanelName != "" && panel.PanelEnabled && panel.PanelVisible {
+			gm.hid.PressKey(win.VK_ESCAPE)
+			utils.Sleep(1000)
+			return fmt.Errorf("error joining the TCP/IP game at %s! Got error message", host)
+		}
+	}
+
+	return fmt.Errorf("error joining the TCP/IP game at %s! Timeout", host)
+}
+
+func (gm *Manager) waitCharacterSelection() bool {
 	for range 30 {
 		if gm.gr.IsInCharacterSelectionScreen() {
-			break
-		} else {
-			utils.Sleep(500)
+			return true
 		}
+		utils.Sleep(500)
 	}
 
+	return false
+}
+
+// startGame clicks the character selection button starting a game (Play or Host Game) and picks the difficulty.
+func (gm *Manager) startGame(buttonX, buttonY int) error {
+	if gm.gr.InGame() {
+		return errors.New("character still in a game")
+	}
+
+	gm.waitCharacterSelection()
+
 	difficultyPosition := map[difficulty.Difficulty]struct {
 		X, Y int
 	}{
@@ -96,7 +154,7 @@ func (gm *Manager) NewGame() error {
 	cfg, _ := config.GetCharacter(gm.supervisorName)
 	createX := difficultyPosition[cfg.Game.Difficulty].X
 	createY := difficultyPosition[cfg.Game.Difficulty].Y
-	gm.hid.Click(LeftButton, 600, 650)
+	gm.hid.Click(LeftButton, buttonX, buttonY)
 	utils.Sleep(250)
 	gm.hid.Click(LeftButton, createX, createY)
 
@@ -138,7 +196,9 @@ func (gm *Manager) CreateLobbyGame(gameCounter int) (string, error) {
 	// Click the game name textbox, delete text and type new game name
 	gm.hid.Click(LeftButton, 1000, 116)
 	gm.clearGameNameOrPasswordField()
-	gameName := cfg.Companion.GameNameTemplate + fmt.Sprintf("%d", gameCounter)
+	lobbyGame := gm.NextLobbyGame(gameCounter)
+	defer gm.discardNextLobbyGame()
+	gameName := lobbyGame.Name
 	for _, ch := range gameName {
 		gm.hid.PressKey(gm.hid.GetASCIICode(fmt.Sprintf("%c", ch)))
 	}
@@ -146,7 +206,7 @@ func (gm *Manager) CreateLobbyGame(gameCounter int) (string, error) {
 	// Same for password
 	gm.hid.Click(LeftButton, 1000, 161)
 	utils.Sleep(200)
-	gamePassword := cfg.Companion.GamePassword
+	gamePassword := lobbyGame.Password
 	if gamePassword != "" {
 		gm.clearGameNameOrPasswordField()
 		for _, ch := range gamePassword {
@@ -163,8 +223,12 @@ func (gm *Manager) CreateLobbyGame(gameCounter int) (string, error) {
 
 		panel := gm.gr.GetPanel("DismissableModal")
 		if panel.PanelName != "" && panel.PanelEnabled && panel.PanelVisible {
+			_, text := gm.gr.IsDismissableModalPresent()
 			gm.hid.PressKey(win.VK_ESCAPE)
 			utils.Sleep(1000)
+			if strings.Contains(strings.ToLower(text), "already exists") {
+				return gameName, ErrGameNameTaken
+			}
 			return gameName, errors.New("error creating game! Got error message")
 		}
 	}
@@ -0,0 +1,77 @@
+package map_client
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data/difficulty"
+)
+
+// maxStoredLayouts is how many seeds are kept in the layouts folder, the least recently used ones are removed first.
+// A character only plays a few seeds (one per difficulty, a new one when it's recreated).
+const maxStoredLayouts = 12
+
+// GetLayoutData returns the map data stored in the folder for the seed, a single-player character keeps the same seed
+// between games so the layout is generated once and reused. The data is fetched and stored when it's not there yet,
+// the bool is true when it came from the folder. Only the map data is reused, the offline lobby and game creation
+// screens are handled by the authMethod None menu flow.
+func GetLayoutData(dir, seed string, difficulty difficulty.Difficulty) (MapData, bool, error) {
+	path := filepath.Join(dir, fmt.Sprintf("%s_%s.json", seed, getDifficultyAsNum(difficulty)))
+	if content, err := os.ReadFile(path); err == nil {
+		var mapData MapData
+		if err = json.Unmarshal(content, &mapData); err == nil && len(mapData) > 0 {
+			// The modification time tells which layouts are still used when pruning
+			now := time.Now()
+			_ = os.Chtimes(path, now, now)
+			return mapData, true, nil
+		}
+	}
+
+	mapData, err := GetMapData(seed, difficulty)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// A failed write only means the layout is generated again next game
+	if err = os.MkdirAll(dir, 0o755); err == nil {
+		if content, err := json.Marshal(mapData); err == nil && os.WriteFile(path, content, 0o644) == nil {
+			pruneLayouts(dir, maxStoredLayouts)
+		}
+	}
+
+	return mapData, false, nil
+}
+
+// pruneLayouts removes the least recently used layouts of the folder past the first keep ones.
+func pruneLayouts(dir string, keep int) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+
+	type layoutFile struct {
+		path    string
+		modTime time.Time
+	}
+	var layouts []layoutFile
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+		if info, err := e.Info(); err == nil {
+			layouts = append(layouts, layoutFile{path: filepath.Join(dir, e.Name()), modTime: info.ModTime()})
+		}
+	}
+	if len(layouts) <= keep {
+		return
+	}
+
+	slices.SortFunc(layouts, func(a, b layoutFile) int { return b.modTime.Compare(a.modTime) })
+	for _, l := range layouts[keep:] {
+		_ = os.Remove(l.path)
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"path/filepath"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/hectorgimenez/d2go/pkg/data"
@@ -31,6 +33,10 @@ type MemoryReader struct {
 	cachedMapData  map[area.ID]AreaData
 	mapDataMu      sync.RWMutex // Protects cachedMapData from concurrent access
 	logger         *slog.Logger
+	readLatency    atomic.Int64 // Duration of the last GetData, watched by the client watchdog
+	source         DataSource   // Set in dry-run mode, the data comes from it instead of the game memory
+	coords         CoordinateMap
+	coordsMu       sync.RWMutex // Protects coords, read by the HID from any goroutine
 }
 
 func NewGameReader(cfg *config.CharacterCfg, supervisorName string, pid uint32, window win.HWND, logger *slog.Logger) (*MemoryReader, error) {
@@ -64,18 +70,37 @@ func (gd *MemoryReader) ClearMapData() {
 }
 
 func (gd *MemoryReader) FetchMapData() error {
+	// The data source gives the map data along the game data
+	if gd.source != nil {
+		return nil
+	}
+
 	// Clear old map data before fetching new data to allow GC to reclaim memory
 	gd.mapDataMu.Lock()
 	gd.cachedMapData = nil
 	gd.mapDataMu.Unlock()
 
 	d := gd.GameReader.GetData()
+	previousSeed := gd.mapSeed
 	gd.mapSeed, _ = gd.getMapSeed(d.PlayerUnit.Address)
 	t := time.Now()
 	cfg, _ := config.GetCharacter(gd.supervisorName)
 	gd.logger.Debug("Fetching map data...", slog.Uint64("seed", uint64(gd.mapSeed)), slog.String("difficulty", string(cfg.Game.Difficulty)))
 
-	mapData, err := map_client.GetMapData(strconv.Itoa(int(gd.mapSeed)), cfg.Game.Difficulty)
+	var mapData map_client.MapData
+	var err error
+	if cfg.AuthMethod == "None" && cfg.Game.SinglePlayer.ReuseLayouts {
+		if gd.mapSeed == previousSeed {
+			gd.logger.Debug("Static map seed, reusing the learned layout", slog.Uint64("seed", uint64(gd.mapSeed)))
+		}
+		var cached bool
+		mapData, cached, err = map_client.GetLayoutData(filepath.Join("config", gd.supervisorName, "layouts"), strconv.Itoa(int(gd.mapSeed)), cfg.Game.Difficulty)
+		if cached {
+			gd.logger.Debug("Map data loaded from the layouts folder", slog.Uint64("seed", uint64(gd.mapSeed)))
+		}
+	} else {
+		mapData, err = map_client.GetMapData(strconv.Itoa(int(gd.mapSeed)), cfg.Game.Difficulty)
+	}
 	if err != nil {
 		return fmt.Errorf("error fetching map data: %w", err)
 	}
@@ -243,10 +268,51 @@ func (gd *MemoryReader) updateWindowPositionData() {
 	gd.WindowTopY = int(point.Y)
 	gd.GameAreaSizeX = int(pos.RcNormalPosition.Right) - gd.WindowLeftX - 9
 	gd.GameAreaSizeY = int(pos.RcNormalPosition.Bottom) - gd.WindowTopY - 9
+
+	width, height := clientSize(uintptr(gd.HWND))
+	if width <= 0 || height <= 0 {
+		return
+	}
+	gd.coordsMu.Lock()
+	defer gd.coordsMu.Unlock()
+
+	if width != gd.coords.Width || height != gd.coords.Height {
+		coords, found := CoordinateMapFor(width, height)
+		if gd.logger != nil {
+			if found {
+				gd.logger.Debug("UI coordinate map selected", slog.Int("width", width), slog.Int("height", height), slog.Int("scale", coords.Scale))
+			} else {
+				gd.logger.Warn("No UI calibration for the window size, the UI coordinates are scaled to fit and may misclick",
+					slog.Int("width", width), slog.Int("height", height), slog.Int("scale", coords.Scale))
+			}
+		}
+		gd.coords = coords
+	}
+
+	// The bot works in the reference coordinates, the HID maps them to the window. The screenshots are taken at the
+	// window size, the regions read on them are mapped the same way.
+	if !gd.coords.Identity() {
+		gd.GameAreaSizeX = ReferenceWidth
+		gd.GameAreaSizeY = ReferenceHeight
+	}
+}
+
+// Coordinates returns the map from the reference UI coordinates to the game window.
+func (gd *MemoryReader) Coordinates() CoordinateMap {
+	gd.coordsMu.RLock()
+	defer gd.coordsMu.RUnlock()
+
+	return gd.coords
 }
 
 func (gd *MemoryReader) GetData() Data {
+	if gd.source != nil {
+		return gd.dryRunData()
+	}
+
+	readStartedAt := time.Now()
 	d := gd.GameReader.GetData()
+	gd.readLatency.Store(int64(time.Since(readStartedAt)))
 
 	// Take a snapshot of cachedMapData under lock to avoid race with ClearMapData
 	gd.mapDataMu.RLock()
@@ -291,6 +357,11 @@ func (gd *MemoryReader) GetData() Data {
 	}
 }
 
+// ReadLatency returns how long the last game data read took.
+func (gd *MemoryReader) ReadLatency() time.Duration {
+	return time.Duration(gd.readLatency.Load())
+}
+
 func (gd *MemoryReader) getMapSeed(playerUnit uintptr) (uint, error) {
 	actPtr := uintptr(gd.Process.ReadUInt(playerUnit+0x20, memory.Uint64))
 	//actMiscPtr := uintptr(gd.Process.ReadUInt(actPtr+0x78, memory.Uint64))
@@ -0,0 +1,127 @@
+package game
+
+import (
+	"image"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/hectorgimenez/koolo/internal/ocr"
+)
+
+// MenuScreen is the out of game screen the client is showing.
+type MenuScreen string
+
+const (
+	MenuScreenInGame             MenuScreen = "in game"
+	MenuScreenLoading            MenuScreen = "loading"
+	MenuScreenCharacterSelection MenuScreen = "character selection"
+	MenuScreenCharacterCreation  MenuScreen = "character creation"
+	MenuScreenLobby              MenuScreen = "lobby"
+	MenuScreenQueue              MenuScreen = "queue"
+	MenuScreenRealmDown          MenuScreen = "realm down"
+	MenuScreenModal              MenuScreen = "modal"
+	MenuScreenUnknown            MenuScreen = "unknown"
+)
+
+// Texts of the modals we know how to recover from, matched in lowercase
+var (
+	queueModalTexts     = []string{"queue", "position in line", "estimated wait"}
+	realmDownModalTexts = []string{"realm is down", "realm is currently unavailable", "unable to connect", "lost connection", "disconnected", "maintenance", "battle.net services"}
+)
+
+// ClassifyMenuScreen tells which screen the client is showing, along with the modal text if there is one. It relies on
+// the game memory first and falls back to the screenshot when none of the known screens is detected, the text of the
+// unknown screens is read with the OCR when it's enabled.
+func (gd *MemoryReader) ClassifyMenuScreen() (MenuScreen, string) {
+	if gd.InGame() {
+		return MenuScreenInGame, ""
+	}
+
+	if present, text := gd.IsDismissableModalPresent(); present {
+		return classifyModalText(text), text
+	}
+
+	switch {
+	case gd.IsInCharacterCreationScreen():
+		return MenuScreenCharacterCreation, ""
+	case gd.IsInCharacterSelectionScreen():
+		return MenuScreenCharacterSelection, ""
+	case gd.IsInLobby():
+		return MenuScreenLobby, ""
+	}
+
+	img := gd.Screenshot()
+	if isBlankScreen(img, gd.Coordinates().RectToWindow(image.Rect(0, 0, ReferenceWidth, ReferenceHeight))) {
+		return MenuScreenLoading, ""
+	}
+
+	// The queue and realm messages are sometimes drawn without the modal the memory tells about
+	if lines, err := ocr.ReadText(img); err == nil && len(lines) > 0 {
+		text := strings.Join(lines, " ")
+		if screen := classifyModalText(text); screen != MenuScreenModal {
+			return screen, text
+		}
+		return MenuScreenUnknown, text
+	}
+
+	return MenuScreenUnknown, ""
+}
+
+func classifyModalText(text string) MenuScreen {
+	text = strings.ToLower(text)
+	for _, t := range queueModalTexts {
+		if strings.Contains(text, t) {
+			return MenuScreenQueue
+		}
+	}
+	for _, t := range realmDownModalTexts {
+		if strings.Contains(text, t) {
+			return MenuScreenRealmDown
+		}
+	}
+
+	return MenuScreenModal
+}
+
+// QueuePosition reads our position from the queue message, 0 when the message doesn't show it.
+func QueuePosition(text string) int {
+	for _, field := range strings.FieldsFunc(text, func(r rune) bool { return !unicode.IsDigit(r) && r != ',' }) {
+		if position, err := strconv.Atoi(strings.ReplaceAll(field, ",", "")); err == nil {
+			return position
+		}
+	}
+
+	return 0
+}
+
+// isBlankScreen samples the game area of the screenshot (window pixels) looking for a black screen, shown while the
+// client switches between screens. The black bars around a game area fitted in the window aren't sampled.
+func isBlankScreen(img image.Image, gameArea image.Rectangle) bool {
+	if img == nil {
+		return false
+	}
+
+	const (
+		samples       = 40
+		darkThreshold = 24
+	)
+	bounds := gameArea.Intersect(img.Bounds())
+	if bounds.Empty() {
+		return false
+	}
+	dark, total := 0, 0
+	for y := 0; y < samples; y++ {
+		for x := 0; x < samples; x++ {
+			px := bounds.Min.X + (bounds.Dx()*x)/samples
+			py := bounds.Min.Y + (bounds.Dy()*y)/samples
+			r, g, b, _ := img.At(px, py).RGBA()
+			if r>>8 < darkThreshold && g>>8 < darkThreshold && b>>8 < darkThreshold {
+				dark++
+			}
+			total++
+		}
+	}
+
+	return dark*100/total >= 98
+}
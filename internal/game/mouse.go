@@ -23,7 +23,12 @@ const pointerReleaseDelay = 150 * time.Millisecond
 // MovePointer moves the mouse to the requested position, x and y should be the final position based on
 // pixels shown in the screen. Top-left corner is 0,0
 func (hid *HID) MovePointer(x, y int) {
+	hid.emit(HIDEvent{Kind: HIDMove, X: x, Y: y})
+	if hid.dryRun {
+		return
+	}
 	hid.gr.updateWindowPositionData()
+	x, y = hid.gr.Coordinates().ToWindow(x, y)
 	x = hid.gr.WindowLeftX + x
 	y = hid.gr.WindowTopY + y
 
@@ -37,6 +42,11 @@ func (hid *HID) MovePointer(x, y int) {
 // Click just does a single mouse click at current pointer position
 func (hid *HID) Click(btn MouseButton, x, y int) {
 	hid.MovePointer(x, y)
+	hid.emit(HIDEvent{Kind: HIDClick, X: x, Y: y, Button: btn})
+	if hid.dryRun {
+		return
+	}
+	x, y = hid.gr.Coordinates().ToWindow(x, y)
 	x = hid.gr.WindowLeftX + x
 	y = hid.gr.WindowTopY + y
 
@@ -55,9 +65,13 @@ func (hid *HID) Click(btn MouseButton, x, y int) {
 }
 
 func (hid *HID) ClickWithModifier(btn MouseButton, x, y int, modifier ModifierKey) {
-	hid.gi.OverrideGetKeyState(byte(modifier))
+	if !hid.dryRun {
+		hid.gi.OverrideGetKeyState(byte(modifier))
+		defer hid.gi.RestoreGetKeyState()
+	}
+	hid.modifier = modifier
 	hid.Click(btn, x, y)
-	hid.gi.RestoreGetKeyState()
+	hid.modifier = 0
 }
 
 func calculateLparam(x, y int) uintptr {
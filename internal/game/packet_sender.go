@@ -2,6 +2,8 @@ package game
 
 import (
 	"fmt"
+	"sync/atomic"
+	"time"
 
 	"github.com/hectorgimenez/d2go/pkg/data"
 	"github.com/hectorgimenez/d2go/pkg/data/skill"
@@ -15,6 +17,8 @@ type ProcessSender interface {
 
 type PacketSender struct {
 	process ProcessSender
+	// Unix nanoseconds of the last packet sent, the game data read before it is stale
+	lastSent atomic.Int64
 }
 
 func NewPacketSender(process ProcessSender) *PacketSender {
@@ -24,9 +28,19 @@ func NewPacketSender(process ProcessSender) *PacketSender {
 }
 
 func (ps *PacketSender) SendPacket(packet []byte) error {
+	ps.lastSent.Store(time.Now().UnixNano())
 	return ps.process.SendPacket(packet)
 }
 
+// LastSentAt returns when the last packet was sent, zero before the first one.
+func (ps *PacketSender) LastSentAt() time.Time {
+	if at := ps.lastSent.Load(); at > 0 {
+		return time.Unix(0, at)
+	}
+
+	return time.Time{}
+}
+
 func (ps *PacketSender) PickUpItem(item data.Item) error {
 	err := ps.SendPacket(packet.NewPickUpItem(item).GetPayload())
 	if err != nil {
@@ -0,0 +1,118 @@
+package game
+
+import (
+	"image"
+
+	"github.com/hectorgimenez/koolo/internal/config"
+)
+
+// ReferenceWidth and ReferenceHeight are the window size the UI coordinates of the bot are tuned for. The coordinates
+// given to the HID are always in this space, the CoordinateMap of the window moves them to the real window.
+const (
+	ReferenceWidth  = 1280
+	ReferenceHeight = 720
+
+	// Borders and rounding of the window sizes, windows this close to the reference size are taken as it
+	referenceSizeTolerance = 12
+)
+
+// CoordinateMap maps the reference coordinates to a window size and UI scale.
+type CoordinateMap struct {
+	Width   int
+	Height  int
+	Scale   int // UI scale in percent
+	ScaleX  float64
+	ScaleY  float64
+	OffsetX int
+	OffsetY int
+}
+
+// uiCalibrations are the maps of the common 16:9 window sizes at 100% UI scale, the whole UI is scaled with the
+// window. The other aspect ratios need a calibration in the config, they are fitted in the window until then.
+var uiCalibrations = []CoordinateMap{
+	{Width: 1280, Height: 720, Scale: 100, ScaleX: 1, ScaleY: 1},
+	{Width: 1366, Height: 768, Scale: 100, ScaleX: 1.0672, ScaleY: 1.0667},
+	{Width: 1600, Height: 900, Scale: 100, ScaleX: 1.25, ScaleY: 1.25},
+	{Width: 1920, Height: 1080, Scale: 100, ScaleX: 1.5, ScaleY: 1.5},
+	{Width: 2560, Height: 1440, Scale: 100, ScaleX: 2, ScaleY: 2},
+	{Width: 3840, Height: 2160, Scale: 100, ScaleX: 3, ScaleY: 3},
+}
+
+// CoordinateMapFor returns the map of the window size with the configured UI scale. The calibrations of the config
+// come first, then the built-in ones. Unknown sizes fit the reference area in the window keeping its aspect ratio;
+// found is false then, the UI may need a calibration.
+func CoordinateMapFor(width, height int) (CoordinateMap, bool) {
+	scale := config.Koolo.UI.Scale
+	if scale == 0 {
+		scale = 100
+	}
+
+	if abs(width-ReferenceWidth) <= referenceSizeTolerance && abs(height-ReferenceHeight) <= referenceSizeTolerance && scale == 100 {
+		return uiCalibrations[0], true
+	}
+
+	for _, c := range config.Koolo.UI.Calibrations {
+		cScale := c.Scale
+		if cScale == 0 {
+			cScale = 100
+		}
+		if c.Width == width && c.Height == height && cScale == scale {
+			return CoordinateMap{Width: c.Width, Height: c.Height, Scale: cScale, ScaleX: c.ScaleX, ScaleY: c.ScaleY, OffsetX: c.OffsetX, OffsetY: c.OffsetY}, true
+		}
+	}
+	for _, c := range uiCalibrations {
+		if c.Width == width && c.Height == height && c.Scale == scale {
+			return c, true
+		}
+	}
+
+	fit := min(float64(width)/ReferenceWidth, float64(height)/ReferenceHeight)
+	return CoordinateMap{
+		Width:   width,
+		Height:  height,
+		Scale:   scale,
+		ScaleX:  fit,
+		ScaleY:  fit,
+		OffsetX: (width - int(ReferenceWidth*fit)) / 2,
+		OffsetY: (height - int(ReferenceHeight*fit)) / 2,
+	}, false
+}
+
+// Identity returns true when the window is the reference one, nothing to map.
+func (m CoordinateMap) Identity() bool {
+	return m.ScaleX == 1 && m.ScaleY == 1 && m.OffsetX == 0 && m.OffsetY == 0
+}
+
+// ToWindow maps reference coordinates to the window.
+func (m CoordinateMap) ToWindow(x, y int) (int, int) {
+	if m.Identity() || m.ScaleX == 0 || m.ScaleY == 0 {
+		return x, y
+	}
+
+	return int(float64(x)*m.ScaleX+0.5) + m.OffsetX, int(float64(y)*m.ScaleY+0.5) + m.OffsetY
+}
+
+// RectToWindow maps a region in reference coordinates to the window, to read it on a screenshot.
+func (m CoordinateMap) RectToWindow(r image.Rectangle) image.Rectangle {
+	minX, minY := m.ToWindow(r.Min.X, r.Min.Y)
+	maxX, maxY := m.ToWindow(r.Max.X, r.Max.Y)
+
+	return image.Rect(minX, minY, maxX, maxY)
+}
+
+// FromWindow maps window coordinates back to the reference ones.
+func (m CoordinateMap) FromWindow(x, y int) (int, int) {
+	if m.Identity() || m.ScaleX == 0 || m.ScaleY == 0 {
+		return x, y
+	}
+
+	return int(float64(x-m.OffsetX)/m.ScaleX + 0.5), int(float64(y-m.OffsetY)/m.ScaleY + 0.5)
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+
+	return v
+}
@@ -32,7 +32,11 @@ func clientSize(hwnd uintptr) (width, height int) {
     return int(rc.Right - rc.Left), int(rc.Bottom - rc.Top)
 }
 
+// Screenshot captures the client area at the window size, the UI coordinates are mapped to it with Coordinates().
 func (gd *MemoryReader) Screenshot() image.Image {
+    if gd.DryRun() {
+        return nil
+    }
     gd.updateWindowPositionData()
 
     width, height := clientSize(uintptr(gd.HWND))
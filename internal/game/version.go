@@ -0,0 +1,92 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Version is the build of the D2R client, read from the file version of D2R.exe.
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+	Build int
+}
+
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d.%d", v.Major, v.Minor, v.Patch, v.Build)
+}
+
+func (v Version) IsZero() bool {
+	return v == Version{}
+}
+
+// Compare returns -1, 0 or 1 when v is older, the same or newer than other.
+func (v Version) Compare(other Version) int {
+	for _, d := range [][2]int{{v.Major, other.Major}, {v.Minor, other.Minor}, {v.Patch, other.Patch}, {v.Build, other.Build}} {
+		switch {
+		case d[0] < d[1]:
+			return -1
+		case d[0] > d[1]:
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// ParseVersion parses a version like 1.6.84219.0, the missing parts are 0.
+func ParseVersion(s string) (Version, error) {
+	parts := strings.Split(strings.TrimSpace(s), ".")
+	if len(parts) == 0 || len(parts) > 4 || parts[0] == "" {
+		return Version{}, fmt.Errorf("invalid version %q", s)
+	}
+
+	values := make([]int, 4)
+	for i, p := range parts {
+		v, err := strconv.Atoi(p)
+		if err != nil {
+			return Version{}, fmt.Errorf("invalid version %q: %w", s, err)
+		}
+		values[i] = v
+	}
+
+	return Version{Major: values[0], Minor: values[1], Patch: values[2], Build: values[3]}, nil
+}
+
+// DetectVersion reads the version of D2R.exe in the install folder.
+func DetectVersion(d2rPath string) (Version, error) {
+	exe := filepath.Join(d2rPath, "D2R.exe")
+
+	size, err := windows.GetFileVersionInfoSize(exe, nil)
+	if err != nil {
+		return Version{}, fmt.Errorf("reading the version of %s: %w", exe, err)
+	}
+
+	info := make([]byte, size)
+	if err = windows.GetFileVersionInfo(exe, 0, size, unsafe.Pointer(&info[0])); err != nil {
+		return Version{}, fmt.Errorf("reading the version of %s: %w", exe, err)
+	}
+
+	var fixed *windows.VS_FIXEDFILEINFO
+	var fixedLen uint32
+	if err = windows.VerQueryValue(unsafe.Pointer(&info[0]), `\`, unsafe.Pointer(&fixed), &fixedLen); err != nil {
+		return Version{}, fmt.Errorf("reading the version of %s: %w", exe, err)
+	}
+	if fixed == nil || fixedLen == 0 {
+		return Version{}, errors.New("no version info in " + exe)
+	}
+
+	return Version{
+		Major: int(fixed.FileVersionMS >> 16),
+		Minor: int(fixed.FileVersionMS & 0xffff),
+		Patch: int(fixed.FileVersionLS >> 16),
+		Build: int(fixed.FileVersionLS & 0xffff),
+	}, nil
+}
@@ -54,8 +54,12 @@ func (hm *Manager) HandleHealthAndMana() error {
 		return ErrDied
 	}
 
-	// Player chicken check
-	if hm.data.PlayerUnit.HPPercent() <= hpConfig.ChickenAt {
+	// Player chicken check, the area can raise or lower the threshold
+	chickenAt := hpConfig.ChickenAt
+	if override := hm.data.CharacterCfg.AreaOverride(hm.data.PlayerUnit.Area); override.ChickenAt > 0 {
+		chickenAt = override.ChickenAt
+	}
+	if hm.data.PlayerUnit.HPPercent() <= chickenAt {
 		return fmt.Errorf("%w: Current Health: %d percent", ErrChicken, hm.data.PlayerUnit.HPPercent())
 	}
 
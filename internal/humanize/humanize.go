@@ -0,0 +1,122 @@
+package humanize
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/hectorgimenez/koolo/internal/config"
+)
+
+// Profile is the variance of a supervisor between games: run order, pickup radius, town routes, delays between the
+// actions, game lengths and the scheduler session lengths. Everything is drawn from one RNG seeded from the config, so
+// the same seed and the same game events reproduce the same choices when debugging. A nil or disabled profile leaves
+// everything as configured.
+type Profile struct {
+	mu   sync.Mutex
+	cfg  config.Humanization
+	rng  *rand.Rand
+	seed int64
+}
+
+// New creates the profile, a zero seed picks a new one, see Seed.
+func New(cfg config.Humanization) *Profile {
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	return &Profile{
+		cfg:  cfg,
+		rng:  rand.New(rand.NewSource(seed)),
+		seed: seed,
+	}
+}
+
+// Seed returns the seed of the RNG, set it in the config to reproduce the session.
+func (p *Profile) Seed() int64 {
+	if p == nil {
+		return 0
+	}
+
+	return p.seed
+}
+
+func (p *Profile) enabled() bool {
+	return p != nil && p.cfg.Enabled
+}
+
+// Shuffle shuffles with the profile RNG like rand.Shuffle, it's used even when the profile is disabled so the
+// randomized run order is reproducible too.
+func (p *Profile) Shuffle(n int, swap func(i, j int)) {
+	if p == nil {
+		rand.Shuffle(n, swap)
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.rng.Shuffle(n, swap)
+}
+
+// ShuffleRuns returns true when the run order should be shuffled every game.
+func (p *Profile) ShuffleRuns() bool {
+	return p.enabled() && p.cfg.ShuffleRuns
+}
+
+// ShuffleTownRoute returns true when the town services should be visited in a random order instead of the shortest
+// route.
+func (p *Profile) ShuffleTownRoute() bool {
+	return p.enabled() && p.cfg.ShuffleTownRoute
+}
+
+// PickupRadius varies the pickup radius by up to PickupRadiusVariance, it never goes under the half of it.
+func (p *Profile) PickupRadius(radius int) int {
+	if !p.enabled() || p.cfg.PickupRadiusVariance <= 0 {
+		return radius
+	}
+
+	return max(radius+p.between(-p.cfg.PickupRadiusVariance, p.cfg.PickupRadiusVariance), radius/2)
+}
+
+// ActionDelay returns the pause to do before an action, between ActionDelayMinMS and ActionDelayMaxMS.
+func (p *Profile) ActionDelay() time.Duration {
+	if !p.enabled() || p.cfg.ActionDelayMaxMS <= 0 {
+		return 0
+	}
+
+	minMS := max(p.cfg.ActionDelayMinMS, 0)
+	maxMS := max(p.cfg.ActionDelayMaxMS, p.cfg.ActionDelayMinMS)
+
+	return time.Duration(p.between(minMS, maxMS)) * time.Millisecond
+}
+
+// GameLength shortens the max game length by up to GameLengthVariance percent, it's never longer than configured
+// since the supervisor cuts the game there anyway.
+func (p *Profile) GameLength(length time.Duration) time.Duration {
+	if !p.enabled() || p.cfg.GameLengthVariance <= 0 {
+		return length
+	}
+
+	percent := p.between(0, min(p.cfg.GameLengthVariance, 90))
+	return length - length*time.Duration(percent)/100
+}
+
+// SessionLength makes the scheduler play session shorter or longer by up to SessionLengthVariance percent, it's
+// never under a minute.
+func (p *Profile) SessionLength(minutes int) int {
+	if !p.enabled() || p.cfg.SessionLengthVariance <= 0 {
+		return minutes
+	}
+
+	variance := min(p.cfg.SessionLengthVariance, 90)
+	return max(minutes+minutes*p.between(-variance, variance)/100, 1)
+}
+
+func (p *Profile) between(from, to int) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return from + p.rng.Intn(to-from+1)
+}
@@ -0,0 +1,127 @@
+package mule
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+)
+
+var (
+	ledgerMu   sync.Mutex
+	ledgerPath = filepath.Join("config", "mule_ledger.json")
+)
+
+// Holding is an item stored in the personal stash of a mule.
+type Holding struct {
+	Name     string `json:"name"`
+	Quality  string `json:"quality"`
+	Ethereal bool   `json:"ethereal"`
+	Sockets  int    `json:"sockets"`
+}
+
+// Record is what a mule holds after its last muling session.
+type Record struct {
+	Mule      string    `json:"mule"`
+	ReturnTo  string    `json:"returnTo"`
+	Full      bool      `json:"full"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	Items     []Holding `json:"items"`
+}
+
+// RecordHoldings saves the personal stash content of the mule in the ledger, so we know which mule holds what.
+func RecordHoldings(muleName, returnTo string, full bool, stashed []data.Item) error {
+	ledgerMu.Lock()
+	defer ledgerMu.Unlock()
+
+	ledger, err := loadLedger()
+	if err != nil {
+		return err
+	}
+
+	record := Record{
+		Mule:      muleName,
+		ReturnTo:  returnTo,
+		Full:      full,
+		UpdatedAt: time.Now(),
+		Items:     make([]Holding, 0, len(stashed)),
+	}
+	for _, itm := range stashed {
+		name := string(itm.Name)
+		if itm.IdentifiedName != "" {
+			name = itm.IdentifiedName
+		}
+		sockets, _ := itm.FindStat(stat.NumSockets, 0)
+		record.Items = append(record.Items, Holding{
+			Name:     name,
+			Quality:  itm.Quality.ToString(),
+			Ethereal: itm.Ethereal,
+			Sockets:  sockets.Value,
+		})
+	}
+	ledger[muleName] = record
+
+	jsonData, err := json.MarshalIndent(ledger, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(ledgerPath, jsonData, 0644)
+}
+
+// Ledger returns the last known holdings of every mule.
+func Ledger() (map[string]Record, error) {
+	ledgerMu.Lock()
+	defer ledgerMu.Unlock()
+
+	return loadLedger()
+}
+
+// FindHolders returns the mules holding items whose name contains the query, with only the matching items.
+func FindHolders(query string) ([]Record, error) {
+	ledger, err := Ledger()
+	if err != nil {
+		return nil, err
+	}
+
+	query = strings.ToLower(query)
+	holders := make([]Record, 0)
+	for _, record := range ledger {
+		matching := make([]Holding, 0)
+		for _, h := range record.Items {
+			if strings.Contains(strings.ToLower(h.Name), query) {
+				matching = append(matching, h)
+			}
+		}
+		if len(matching) > 0 {
+			record.Items = matching
+			holders = append(holders, record)
+		}
+	}
+
+	return holders, nil
+}
+
+func loadLedger() (map[string]Record, error) {
+	ledger := make(map[string]Record)
+
+	jsonData, err := os.ReadFile(ledgerPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return ledger, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(jsonData, &ledger); err != nil {
+		return nil, err
+	}
+
+	return ledger, nil
+}
@@ -0,0 +1,92 @@
+package ocr
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/hectorgimenez/koolo/internal/config"
+)
+
+// ErrDisabled is returned when the OCR is not enabled in koolo.yaml.
+var ErrDisabled = errors.New("ocr is disabled")
+
+// Enabled returns true when the OCR is configured, it runs Tesseract so it has to be installed.
+func Enabled() bool {
+	return config.Koolo.OCR.Enabled
+}
+
+// ReadText returns the lines of text read in the image, the text is expected bright on a dark background like the game
+// tooltips.
+func ReadText(img image.Image) ([]string, error) {
+	if !Enabled() {
+		return nil, ErrDisabled
+	}
+
+	tesseract := config.Koolo.OCR.TesseractPath
+	if tesseract == "" {
+		tesseract = "tesseract"
+	}
+
+	f, err := os.CreateTemp("", "koolo-ocr-*.png")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(f.Name())
+
+	err = png.Encode(f, prepare(img))
+	f.Close()
+	if err != nil {
+		return nil, fmt.Errorf("encoding the ocr image: %w", err)
+	}
+
+	// psm 6 reads the image as one block of text, the tooltip lines
+	cmd := exec.Command(tesseract, filepath.Clean(f.Name()), "stdout", "--psm", "6")
+	cmd.SysProcAttr = &syscall.SysProcAttr{HideWindow: true}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("running tesseract: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	lines := make([]string, 0)
+	for _, line := range strings.Split(string(out), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	return lines, nil
+}
+
+// prepare turns the colored text on the dark background into black text on white, twice the size, which is what
+// Tesseract reads best.
+func prepare(img image.Image) image.Image {
+	b := img.Bounds()
+	out := image.NewGray(image.Rect(0, 0, b.Dx()*2, b.Dy()*2))
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			r, g, bl, _ := img.At(x, y).RGBA()
+			c := color.Gray{Y: 255}
+			if max(r, g, bl)>>8 > 90 {
+				c = color.Gray{Y: 0}
+			}
+			ox, oy := (x-b.Min.X)*2, (y-b.Min.Y)*2
+			out.SetGray(ox, oy, c)
+			out.SetGray(ox+1, oy, c)
+			out.SetGray(ox, oy+1, c)
+			out.SetGray(ox+1, oy+1, c)
+		}
+	}
+
+	return out
+}
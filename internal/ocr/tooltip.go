@@ -0,0 +1,129 @@
+package ocr
+
+import (
+	"image"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+)
+
+const (
+	tooltipWidth     = 460
+	tooltipMaxHeight = 420
+	// Distance between the hovered point and the bottom of the tooltip, it's shown above the item
+	tooltipMargin = 10
+)
+
+var (
+	socketsRegexp = regexp.MustCompile(`socketed\s*\((\d+)\)`)
+	defenseRegexp = regexp.MustCompile(`defense:\s*(\d+)`)
+	nonLetters    = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// Tooltip is the text of an item tooltip, the first line is the item name.
+type Tooltip struct {
+	Lines []string
+}
+
+// Mismatch is a value of the tooltip different from the one read in memory.
+type Mismatch struct {
+	Field   string
+	Memory  string
+	Tooltip string
+}
+
+// TooltipRegion returns the region of the tooltip of the item hovered at x, y, in the 1280x720 UI coordinates. The
+// tooltip is shown above the item, centered on it.
+func TooltipRegion(x, y int) image.Rectangle {
+	return image.Rect(x-tooltipWidth/2, y-tooltipMargin-tooltipMaxHeight, x+tooltipWidth/2, y-tooltipMargin)
+}
+
+// ReadTooltip reads the tooltip in the region of the screenshot (window pixels), clipped to the screenshot.
+func ReadTooltip(screenshot image.Image, region image.Rectangle) (Tooltip, error) {
+	region = region.Intersect(screenshot.Bounds())
+	if region.Empty() {
+		return Tooltip{}, nil
+	}
+
+	sub, ok := screenshot.(interface {
+		SubImage(r image.Rectangle) image.Image
+	})
+	if !ok {
+		return Tooltip{}, nil
+	}
+
+	lines, err := ReadText(sub.SubImage(region))
+	if err != nil {
+		return Tooltip{}, err
+	}
+
+	return Tooltip{Lines: lines}, nil
+}
+
+// Name returns the first line of the tooltip, the name of the item.
+func (t Tooltip) Name() string {
+	if len(t.Lines) == 0 {
+		return ""
+	}
+
+	return t.Lines[0]
+}
+
+// Verify cross-checks the values read in memory with the tooltip: name, ethereal, sockets and defense. A mismatch
+// usually means the memory offsets broke after a game patch, but OCR errors can cause some too.
+func (t Tooltip) Verify(itm data.Item) []Mismatch {
+	if len(t.Lines) == 0 {
+		return nil
+	}
+
+	text := strings.ToLower(strings.Join(t.Lines, "\n"))
+	mismatches := make([]Mismatch, 0)
+
+	names := []string{itm.Desc().Name}
+	if itm.Identified && itm.IdentifiedName != "" {
+		names = append(names, itm.IdentifiedName)
+	}
+	if itm.IsRuneword {
+		names = append(names, string(itm.RunewordName))
+	}
+	header := normalize(strings.Join(t.Lines[:min(3, len(t.Lines))], " "))
+	nameFound := false
+	for _, n := range names {
+		if n != "" && strings.Contains(header, normalize(n)) {
+			nameFound = true
+			break
+		}
+	}
+	if !nameFound {
+		mismatches = append(mismatches, Mismatch{Field: "name", Memory: strings.Join(names, " / "), Tooltip: t.Name()})
+	}
+
+	if ethereal := strings.Contains(text, "ethereal"); ethereal != itm.Ethereal {
+		mismatches = append(mismatches, Mismatch{Field: "ethereal", Memory: strconv.FormatBool(itm.Ethereal), Tooltip: strconv.FormatBool(ethereal)})
+	}
+
+	if m := socketsRegexp.FindStringSubmatch(text); m != nil {
+		sockets, _ := itm.FindStat(stat.NumSockets, 0)
+		if m[1] != strconv.Itoa(sockets.Value) {
+			mismatches = append(mismatches, Mismatch{Field: "sockets", Memory: strconv.Itoa(sockets.Value), Tooltip: m[1]})
+		}
+	}
+
+	// The tooltip shows the defense with the modifiers, only the plain one can be compared
+	_, enhanced := itm.FindStat(stat.EnhancedDefense, 0)
+	_, perLevel := itm.FindStat(stat.DefensePerLevel, 0)
+	if m := defenseRegexp.FindStringSubmatch(text); m != nil && !enhanced && !perLevel {
+		if defense, found := itm.FindStat(stat.Defense, 0); found && m[1] != strconv.Itoa(defense.Value) {
+			mismatches = append(mismatches, Mismatch{Field: "defense", Memory: strconv.Itoa(defense.Value), Tooltip: m[1]})
+		}
+	}
+
+	return mismatches
+}
+
+func normalize(s string) string {
+	return nonLetters.ReplaceAllString(strings.ToLower(s), "")
+}
@@ -0,0 +1,103 @@
+package pather
+
+import (
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/koolo/internal/game"
+)
+
+// HazardZone is an area the path finder routes around. A zone is a circle around Center, or a segment from Center to
+// End with the given Radius as half width (e.g. Diablo's lightning hose).
+type HazardZone struct {
+	Name   string
+	Center data.Position
+	End    data.Position
+	Radius int
+}
+
+func (h HazardZone) isSegment() bool {
+	return h.End != (data.Position{}) && h.End != h.Center
+}
+
+// Contains returns true if the position is inside the zone.
+func (h HazardZone) Contains(p data.Position) bool {
+	if !h.isSegment() {
+		dx := p.X - h.Center.X
+		dy := p.Y - h.Center.Y
+		return dx*dx+dy*dy <= h.Radius*h.Radius
+	}
+
+	// Distance from the point to the segment, projecting the point over it
+	sx := float64(h.End.X - h.Center.X)
+	sy := float64(h.End.Y - h.Center.Y)
+	px := float64(p.X - h.Center.X)
+	py := float64(p.Y - h.Center.Y)
+
+	t := (px*sx + py*sy) / (sx*sx + sy*sy)
+	t = max(0, min(1, t))
+
+	dx := px - t*sx
+	dy := py - t*sy
+
+	return dx*dx+dy*dy <= float64(h.Radius*h.Radius)
+}
+
+// hazardsTTL drops the hazard zones once the combat layer stops updating them, e.g. after the fight.
+const hazardsTTL = 3 * time.Second
+
+// SetHazards replaces the hazard zones used when calculating paths, it's called from the combat layer on every update.
+func (pf *PathFinder) SetHazards(zones []HazardZone) {
+	pf.hazards = zones
+	pf.hazardsAt = time.Now()
+}
+
+// Hazards returns the current hazard zones, none when they weren't updated in the last hazardsTTL.
+func (pf *PathFinder) Hazards() []HazardZone {
+	if time.Since(pf.hazardsAt) > hazardsTTL {
+		return nil
+	}
+
+	return pf.hazards
+}
+
+// InHazard returns true if the position is inside any of the current hazard zones.
+func (pf *PathFinder) InHazard(p data.Position) bool {
+	for _, h := range pf.Hazards() {
+		if h.Contains(p) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// markHazards sets the walkable tiles inside the hazard zones as low priority, so the path avoids them when possible
+// but we are still able to walk through if there is no other way.
+func (pf *PathFinder) markHazards(grid *game.Grid) {
+	for _, h := range pf.Hazards() {
+		end := h.Center
+		if h.isSegment() {
+			end = h.End
+		}
+		minX, maxX := min(h.Center.X, end.X)-h.Radius, max(h.Center.X, end.X)+h.Radius
+		minY, maxY := min(h.Center.Y, end.Y)-h.Radius, max(h.Center.Y, end.Y)+h.Radius
+
+		for y := minY; y <= maxY; y++ {
+			for x := minX; x <= maxX; x++ {
+				pos := data.Position{X: x, Y: y}
+				if !h.Contains(pos) {
+					continue
+				}
+
+				relativePos := grid.RelativePosition(pos)
+				if relativePos.X < 0 || relativePos.X >= grid.Width || relativePos.Y < 0 || relativePos.Y >= grid.Height {
+					continue
+				}
+				if grid.Get(relativePos.X, relativePos.Y) == game.CollisionTypeWalkable {
+					grid.Set(relativePos.X, relativePos.Y, game.CollisionTypeLowPriority)
+				}
+			}
+		}
+	}
+}
@@ -3,6 +3,8 @@ package pather
 import (
 	"fmt"
 	"math"
+	"sync"
+	"time"
 
 	"github.com/hectorgimenez/d2go/pkg/data"
 	"github.com/hectorgimenez/d2go/pkg/data/area"
@@ -22,6 +24,13 @@ type PathFinder struct {
 	// synchronization because pathfinding is only called from the PriorityNormal goroutine
 	// (main bot loop). Background goroutines (data refresh, health check) do not perform pathfinding.
 	astarBuffers *astar.AStarBuffers
+	// hazards are set by the combat layer from the same goroutine, see SetHazards.
+	hazards   []HazardZone
+	hazardsAt time.Time
+	// followed is the last path given to MoveThroughPath, read by the web UI from other goroutines.
+	followed   Path
+	followedAt time.Time
+	followedMu sync.Mutex
 }
 
 func NewPathFinder(gr *game.MemoryReader, data *game.Data, hid *game.HID, cfg *config.CharacterCfg) *PathFinder {
@@ -136,6 +145,9 @@ func (pf *PathFinder) GetPathFrom(from, to data.Position) (Path, int, bool) {
 		grid.Set(relativePos.X, relativePos.Y, game.CollisionTypeMonster)
 	}
 
+	// Route around ground effects and other hazards
+	pf.markHazards(grid)
+
 	// set barricade tower as non walkable in act 5
 	if a.Area == area.FrigidHighlands || a.Area == area.FrozenTundra || a.Area == area.ArreatPlateau {
 		towerCount := 0
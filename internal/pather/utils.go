@@ -76,6 +76,7 @@ func (pf *PathFinder) OptimizeRoomsTraverseOrder() []data.Room {
 }
 
 func (pf *PathFinder) MoveThroughPath(p Path, walkDuration time.Duration) {
+	pf.setFollowedPath(p)
 	if pf.data.CanTeleport() {
 		pf.moveThroughPathTeleport(p)
 	} else {
@@ -83,6 +84,32 @@ func (pf *PathFinder) MoveThroughPath(p Path, walkDuration time.Duration) {
 	}
 }
 
+// setFollowedPath keeps the path in world coordinates, the path is relative to the area origin.
+func (pf *PathFinder) setFollowedPath(p Path) {
+	followed := make(Path, len(p))
+	for i, pos := range p {
+		followed[i] = data.Position{X: pos.X + pf.data.AreaOrigin.X, Y: pos.Y + pf.data.AreaOrigin.Y}
+	}
+
+	pf.followedMu.Lock()
+	pf.followed = followed
+	pf.followedAt = time.Now()
+	pf.followedMu.Unlock()
+}
+
+// FollowedPath returns the path being followed in world coordinates, nothing once the last movement is older than
+// maxAge.
+func (pf *PathFinder) FollowedPath(maxAge time.Duration) Path {
+	pf.followedMu.Lock()
+	defer pf.followedMu.Unlock()
+
+	if time.Since(pf.followedAt) > maxAge {
+		return nil
+	}
+
+	return pf.followed
+}
+
 func (pf *PathFinder) moveThroughPathWalk(p Path, walkDuration time.Duration) {
 	// Calculate the max distance we can walk in the given duration
 	maxDistance := int(float64(25) * walkDuration.Seconds())
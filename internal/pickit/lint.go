@@ -0,0 +1,116 @@
+package pickit
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/item"
+	"github.com/hectorgimenez/d2go/pkg/nip"
+)
+
+// LintIssue is a NIP line that doesn't compile, or uses an unknown stat name
+type LintIssue struct {
+	File  string `json:"file"`
+	Line  int    `json:"line"`
+	Rule  string `json:"rule"`
+	Error string `json:"error"`
+}
+
+// RuleMatch is a rule matching the item given to DryEvaluate
+type RuleMatch struct {
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Rule   string `json:"rule"`
+	Result string `json:"result"`
+}
+
+// lintDummyItem is evaluated against every rule, same as the NIP loader does to detect format errors
+var lintDummyItem = data.Item{
+	ID:      516,
+	Name:    "healingpotion",
+	Quality: item.QualityNormal,
+}
+
+// LintFile checks every line of a NIP file, unlike the loader it doesn't stop at the first error
+func LintFile(path string) ([]LintIssue, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	_, issues := ParseNIP(f, filepath.Base(path))
+	return issues, nil
+}
+
+// ParseNIP compiles the valid rules read from r and returns the issues found in the invalid ones, filename is only
+// used to report them
+func ParseNIP(r io.Reader, filename string) (nip.Rules, []LintIssue) {
+	rules := make(nip.Rules, 0)
+	issues := make([]LintIssue, 0)
+
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := scanner.Text()
+
+		rule, err := nip.NewRule(line, filename, lineNumber)
+		if errors.Is(err, nip.ErrEmptyRule) {
+			continue
+		}
+		if err == nil {
+			err = rule.ValidateStats()
+		}
+		if err == nil {
+			_, err = rule.Evaluate(lintDummyItem)
+		}
+		if err != nil {
+			issues = append(issues, LintIssue{File: filename, Line: lineNumber, Rule: line, Error: err.Error()})
+			continue
+		}
+
+		rules = append(rules, rule)
+	}
+	if err := scanner.Err(); err != nil {
+		issues = append(issues, LintIssue{File: filename, Line: lineNumber, Error: err.Error()})
+	}
+
+	return rules, issues
+}
+
+// DryEvaluate returns every rule matching the item, in the order they are evaluated in game. Items given by name only
+// get their ID resolved, the rules match names by ID.
+func DryEvaluate(rules nip.Rules, itm data.Item) ([]RuleMatch, error) {
+	if itm.ID == 0 && itm.Name != "" {
+		itm.ID = item.GetIDByName(string(itm.Name))
+		if itm.ID < 0 {
+			return nil, errors.New("unknown item name " + string(itm.Name))
+		}
+	}
+
+	matches := make([]RuleMatch, 0)
+	for _, rule := range rules {
+		result, err := rule.Evaluate(itm)
+		if err != nil {
+			return nil, err
+		}
+
+		var resultName string
+		switch result {
+		case nip.RuleResultFullMatch:
+			resultName = "full"
+		case nip.RuleResultPartial:
+			resultName = "partial"
+		default:
+			continue
+		}
+		matches = append(matches, RuleMatch{File: rule.Filename, Line: rule.LineNumber, Rule: rule.RawLine, Result: resultName})
+	}
+
+	return matches, nil
+}
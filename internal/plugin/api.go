@@ -0,0 +1,156 @@
+package plugin
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/area"
+	"github.com/hectorgimenez/d2go/pkg/data/npc"
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+	"github.com/hectorgimenez/koolo/internal/action"
+	"github.com/hectorgimenez/koolo/internal/context"
+	"github.com/hectorgimenez/koolo/internal/game"
+	"github.com/hectorgimenez/koolo/internal/utils"
+)
+
+// API is what the plugins can use from the bot: a copy of the game state, the pathing, the inputs and the action
+// primitives. It only wraps the internals, a plugin using it keeps working when they change.
+type API struct {
+	ctx *context.Status
+}
+
+// NewAPI returns the API bound to the bot of the calling routine.
+func NewAPI() *API {
+	return &API{ctx: context.Get()}
+}
+
+// State returns a copy of the last game data read, RefreshData reads it again.
+func (a *API) State() State {
+	return newState(*a.ctx.Data)
+}
+
+func (a *API) RefreshData() {
+	a.ctx.RefreshGameData()
+}
+
+// Settings returns a copy of the character settings.
+func (a *API) Settings() Settings {
+	return newSettings(a.ctx.CharacterCfg)
+}
+
+func (a *API) Logger() *slog.Logger {
+	return a.ctx.Logger
+}
+
+// Checkpoint gives the priority back to the bot (health, chicken, pause) and stops the plugin when the bot is
+// stopped, long loops must call it.
+func (a *API) Checkpoint() {
+	a.ctx.PauseIfNotPriority()
+}
+
+func (a *API) Sleep(ms int) {
+	utils.Sleep(ms)
+}
+
+// Pathing
+
+// PathDistance returns the walking distance to the position, false when it can't be reached.
+func (a *API) PathDistance(to data.Position) (int, bool) {
+	_, distance, found := a.ctx.PathFinder.GetPath(to)
+	return distance, found
+}
+
+func (a *API) DistanceFromMe(p data.Position) int {
+	return a.ctx.PathFinder.DistanceFromMe(p)
+}
+
+func (a *API) MoveTo(to data.Position) error {
+	return action.MoveToCoords(to)
+}
+
+// MoveToArea walks to an adjacent area.
+func (a *API) MoveToArea(dst area.ID) error {
+	return action.MoveToArea(dst)
+}
+
+func (a *API) WayPoint(dst area.ID) error {
+	return action.WayPoint(dst)
+}
+
+func (a *API) ReturnTown() error {
+	return action.ReturnTown()
+}
+
+func (a *API) UsePortalInTown() error {
+	return action.UsePortalInTown()
+}
+
+// Combat and loot
+
+// ClearArea kills the monsters around the player.
+func (a *API) ClearArea(radius int) error {
+	return action.ClearAreaAroundPlayer(radius, data.MonsterAnyFilter())
+}
+
+// ClearLevel kills the monsters of the whole level, opening the chests when asked.
+func (a *API) ClearLevel(openChests bool) error {
+	return action.ClearCurrentLevel(openChests, data.MonsterAnyFilter())
+}
+
+// KillMonster kills the monster picked by the selector with the character skills, the monsters immune to one of the
+// resists are skipped.
+func (a *API) KillMonster(selector func(s State) (data.UnitID, bool), skipOnImmunities ...stat.Resist) error {
+	return a.ctx.Char.KillMonsterSequence(func(d game.Data) (data.UnitID, bool) {
+		return selector(newState(d))
+	}, skipOnImmunities)
+}
+
+func (a *API) Buff() {
+	action.Buff()
+}
+
+// PickupItems picks up the items matching the pickit rules within the distance.
+func (a *API) PickupItems(maxDistance int) error {
+	return action.ItemPickup(maxDistance)
+}
+
+// Interactions
+
+func (a *API) InteractNPC(id npc.ID) error {
+	return action.InteractNPC(id)
+}
+
+// InteractObject interacts with the object until isCompleted returns true, nil isCompleted interacts once.
+func (a *API) InteractObject(id data.UnitID, isCompleted func() bool) error {
+	o, found := a.ctx.Data.Objects.FindByID(id)
+	if !found {
+		return fmt.Errorf("object %d not found", id)
+	}
+
+	return action.InteractObject(o, isCompleted)
+}
+
+// RunAction runs the function through the action middleware (logging, metrics, action policies).
+func (a *API) RunAction(name string, fn func() error) error {
+	return action.RunAction(name, fn)
+}
+
+// Inputs, the coords are screen coords relative to the game window
+
+func (a *API) Click(x, y int) {
+	a.ctx.HID.Click(game.LeftButton, x, y)
+}
+
+func (a *API) RightClick(x, y int) {
+	a.ctx.HID.Click(game.RightButton, x, y)
+}
+
+func (a *API) PressKeyBinding(kb data.KeyBinding) {
+	a.ctx.HID.PressKeyBinding(kb)
+}
+
+// GameToScreen converts the game coords to screen coords.
+func (a *API) GameToScreen(p data.Position) (int, int) {
+	return a.ctx.PathFinder.GameCoordsToScreenCords(p.X, p.Y)
+}
@@ -0,0 +1,79 @@
+// Package plugin lets custom runs and town behaviors be added without forking the bot. Plugins are Go packages
+// compiled into koolo with the plugins build tag (see the plugins folder), they register themselves from init and
+// only use the API given to them, which is kept stable across the bot changes.
+package plugin
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/hectorgimenez/koolo/internal/action"
+	"github.com/hectorgimenez/koolo/internal/config"
+)
+
+// Run is a run added by a plugin, it's selected by adding its name to the runs of the character.
+type Run interface {
+	Name() string
+	Run(api *API) error
+}
+
+// ConditionalRun is implemented by the runs only worth doing in some cases, Skip returns true to skip them.
+type ConditionalRun interface {
+	Skip(api *API) bool
+}
+
+// TownHook is called at the end of every town routine, before leaving town.
+type TownHook func(api *API) error
+
+var registry = struct {
+	mu   sync.RWMutex
+	runs map[string]func() Run
+}{runs: make(map[string]func() Run)}
+
+// RegisterRun adds a run, the constructor is called every time the run is built for a game. It's meant to be called
+// from the init of the plugin, the run is listed with the other ones in the character settings.
+func RegisterRun(name string, constructor func() Run) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	if _, found := config.AvailableRuns[config.Run(name)]; found {
+		panic(fmt.Sprintf("plugin run %s is already registered", name))
+	}
+	registry.runs[name] = constructor
+	config.AvailableRuns[config.Run(name)] = nil
+}
+
+// RegisterTownHook adds a town behavior, it runs through the action middleware so the action policies apply to it.
+func RegisterTownHook(name string, hook TownHook) {
+	action.AddTownRoutineHook(name, func() error {
+		return hook(NewAPI())
+	})
+}
+
+// BuildRun returns the plugin run with this name.
+func BuildRun(name string) (Run, bool) {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	constructor, found := registry.runs[name]
+	if !found {
+		return nil, false
+	}
+
+	return constructor(), true
+}
+
+// RunNames returns the names of the plugin runs.
+func RunNames() []string {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	names := make([]string, 0, len(registry.runs))
+	for name := range registry.runs {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return names
+}
@@ -0,0 +1,110 @@
+package plugin
+
+import (
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/area"
+	"github.com/hectorgimenez/d2go/pkg/data/difficulty"
+	"github.com/hectorgimenez/d2go/pkg/data/item"
+	"github.com/hectorgimenez/d2go/pkg/data/npc"
+	"github.com/hectorgimenez/d2go/pkg/data/object"
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/game"
+)
+
+// State is what the plugins see of the game, copied from the game data when it's asked for. Changing it has no effect
+// on the bot.
+type State struct {
+	Area      area.ID
+	Position  data.Position
+	InTown    bool
+	HPPercent int
+	MPPercent int
+	Gold      int
+	Monsters  []Monster // Enemies around the player
+	Items     []GroundItem
+	Objects   []Object
+}
+
+type Monster struct {
+	UnitID     data.UnitID
+	Name       npc.ID
+	Type       data.MonsterType
+	Position   data.Position
+	Immunities []stat.Resist
+}
+
+type GroundItem struct {
+	UnitID   data.UnitID
+	Name     item.Name
+	Quality  item.Quality
+	Ethereal bool
+	Position data.Position
+}
+
+type Object struct {
+	UnitID     data.UnitID
+	Name       object.Name
+	Position   data.Position
+	Selectable bool
+}
+
+// Settings is what the plugins see of the character config.
+type Settings struct {
+	CharacterName string
+	Class         string
+	Difficulty    difficulty.Difficulty
+	Runs          []string
+}
+
+var resists = []stat.Resist{stat.ColdImmune, stat.FireImmune, stat.LightImmune, stat.PoisonImmune, stat.MagicImmune}
+
+func newState(d game.Data) State {
+	s := State{
+		Area:      d.PlayerUnit.Area,
+		Position:  d.PlayerUnit.Position,
+		InTown:    d.PlayerUnit.Area.IsTown(),
+		HPPercent: d.PlayerUnit.HPPercent(),
+		MPPercent: d.PlayerUnit.MPPercent(),
+		Gold:      d.PlayerUnit.TotalPlayerGold(),
+	}
+
+	for _, m := range d.Monsters.Enemies() {
+		monster := Monster{UnitID: m.UnitID, Name: m.Name, Type: m.Type, Position: m.Position}
+		for _, resist := range resists {
+			if m.IsImmune(resist) {
+				monster.Immunities = append(monster.Immunities, resist)
+			}
+		}
+		s.Monsters = append(s.Monsters, monster)
+	}
+
+	for _, it := range d.Inventory.ByLocation(item.LocationGround) {
+		s.Items = append(s.Items, GroundItem{
+			UnitID:   it.UnitID,
+			Name:     it.Name,
+			Quality:  it.Quality,
+			Ethereal: it.Ethereal,
+			Position: it.Position,
+		})
+	}
+
+	for _, o := range d.Objects {
+		s.Objects = append(s.Objects, Object{UnitID: o.ID, Name: o.Name, Position: o.Position, Selectable: o.Selectable})
+	}
+
+	return s
+}
+
+func newSettings(cfg *config.CharacterCfg) Settings {
+	s := Settings{
+		CharacterName: cfg.CharacterName,
+		Class:         cfg.Character.Class,
+		Difficulty:    cfg.Game.Difficulty,
+	}
+	for _, r := range cfg.Game.Runs {
+		s.Runs = append(s.Runs, string(r))
+	}
+
+	return s
+}
@@ -168,6 +168,11 @@ func (b *Bot) handleStatsRequest(s *discordgo.Session, m *discordgo.MessageCreat
 						Value:  fmt.Sprintf("%d", b.manager.GetSupervisorStats(supervisor).TotalErrors()),
 						Inline: true,
 					},
+					{
+						Name:   "Skipped",
+						Value:  fmt.Sprintf("%d", b.manager.GetSupervisorStats(supervisor).TotalSkipped()),
+						Inline: true,
+					},
 				},
 			}
 
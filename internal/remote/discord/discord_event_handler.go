@@ -63,6 +63,22 @@ func (b *Bot) Handle(ctx context.Context, e event.Event) error {
 		return b.sendEventMessage(ctx, message)
 	case event.NgrokTunnelEvent:
 		return b.sendEventMessage(ctx, evt.Message())
+	case event.DiabloCloneSpawnedEvent:
+		message := fmt.Sprintf("**[%s]** Diablo Clone spotted in **%s**", evt.Supervisor(), evt.Area.Area().Name)
+		if e.Image() == nil {
+			return b.sendEventMessage(ctx, message)
+		}
+		buf := new(bytes.Buffer)
+		if err := jpeg.Encode(buf, e.Image(), &jpeg.Options{Quality: 80}); err != nil {
+			return err
+		}
+		return b.sendScreenshot(ctx, message, buf.Bytes())
+	case event.GambleFinishedEvent:
+		return b.sendEventMessage(ctx, fmt.Sprintf("**[%s]** Gambled %d items for %d gold, kept %d", evt.Supervisor(), evt.Bought, evt.GoldSpent, evt.Kept))
+	case event.SessionGoalReachedEvent:
+		return b.sendEventMessage(ctx, fmt.Sprintf("**[%s]** Session goal reached: **%s**", evt.Supervisor(), evt.Goal))
+	case event.ScriptNotificationEvent:
+		return b.sendEventMessage(ctx, fmt.Sprintf("**[%s]** %s", evt.Supervisor(), evt.Message()))
 	case event.ItemStashedEvent:
 		if config.Koolo.Discord.DisableItemStashScreenshots {
 			if b.useWebhook {
@@ -476,6 +492,14 @@ func (b *Bot) shouldPublish(e event.Event) bool {
 		return config.Koolo.Discord.EnableRunFinishMessages
 	case event.NgrokTunnelEvent:
 		return true
+	case event.DiabloCloneSpawnedEvent:
+		return true
+	case event.GambleFinishedEvent:
+		return true
+	case event.SessionGoalReachedEvent:
+		return true
+	case event.ScriptNotificationEvent:
+		return true
 	default:
 		break
 	}
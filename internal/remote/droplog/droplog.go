@@ -24,19 +24,32 @@ type Record struct {
 	Character  string    `json:"character"` // in-game character name
 	Profile    string    `json:"profile"`   // config folder name
 	Drop       data.Drop `json:"drop"`
+	// PickedUpAt is zero for the items not picked up from the ground (cubed, bought, gambled)
+	PickedUpAt time.Time `json:"pickedUpAt"`
 }
 
 type Writer struct {
-	logDir string
-	logger *slog.Logger
+	logDir    string
+	logger    *slog.Logger
+	runStarts map[string]time.Time // Start of the current run by supervisor
 }
 
 func NewWriter(logDir string, logger *slog.Logger) *Writer {
-	return &Writer{logDir: logDir, logger: logger}
+	return &Writer{logDir: logDir, logger: logger, runStarts: make(map[string]time.Time)}
 }
 
-// Handle subscribes to the event bus and persists ItemStashedEvent to a daily JSONL file.
+// Handle subscribes to the event bus and persists ItemStashedEvent to a daily JSONL file, the finished runs are
+// persisted along them for the drop reports.
 func (w *Writer) Handle(_ context.Context, e event.Event) error {
+	switch evt := e.(type) {
+	case event.RunStartedEvent:
+		w.runStarts[e.Supervisor()] = e.OccurredAt()
+		return nil
+	case event.RunFinishedEvent:
+		w.writeRun(e.Supervisor(), evt)
+		return nil
+	}
+
 	ist, ok := e.(event.ItemStashedEvent)
 	if !ok {
 		return nil
@@ -57,6 +70,7 @@ func (w *Writer) Handle(_ context.Context, e event.Event) error {
 		Character:  charName,
 		Profile:    profile,
 		Drop:       ist.Item,
+		PickedUpAt: ist.PickedUpAt,
 	}
 
 	// Ensure directory exists
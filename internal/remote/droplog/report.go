@@ -0,0 +1,182 @@
+package droplog
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data/item"
+)
+
+// ReportFilter narrows the drop report, the empty fields match everything.
+type ReportFilter struct {
+	Supervisor string
+	Run        string
+	Since      time.Time
+}
+
+// Report is the drop quality by run type, to check the magic find changes against the drops.
+type Report struct {
+	Runs []RunReport `json:"runs"`
+	// Unattributed counts the drops without a pickup time or without a run before it (older logs, cubed items)
+	Unattributed int `json:"unattributed"`
+}
+
+// RunReport is the drop quality of a run type, the rates are per 100 runs.
+type RunReport struct {
+	Run              string         `json:"run"`
+	Runs             int            `json:"runs"`
+	Drops            int            `json:"drops"`
+	RunsWithoutDrops int            `json:"runsWithoutDrops"`
+	NoDropRate       float64        `json:"noDropRate"`
+	Rates            DropRates      `json:"rates"`
+	Runes            map[string]int `json:"runes"`
+	Trend            []TrendPoint   `json:"trend"`
+}
+
+// DropRates are the drops per 100 runs by quality, runes apart.
+type DropRates struct {
+	Uniques float64 `json:"uniques"`
+	Sets    float64 `json:"sets"`
+	Rares   float64 `json:"rares"`
+	Runes   float64 `json:"runes"`
+}
+
+// TrendPoint is the drop quality of a run type in a day.
+type TrendPoint struct {
+	Date       string    `json:"date"`
+	Runs       int       `json:"runs"`
+	Drops      int       `json:"drops"`
+	NoDropRate float64   `json:"noDropRate"`
+	Rates      DropRates `json:"rates"`
+}
+
+type dropCounts struct {
+	runs, drops, runsWithDrops  int
+	uniques, sets, rares, runes int
+}
+
+func (c dropCounts) rates() DropRates {
+	if c.runs == 0 {
+		return DropRates{}
+	}
+
+	per100 := func(n int) float64 { return float64(n) * 100 / float64(c.runs) }
+	return DropRates{Uniques: per100(c.uniques), Sets: per100(c.sets), Rares: per100(c.rares), Runes: per100(c.runes)}
+}
+
+func (c dropCounts) noDropRate() float64 {
+	if c.runs == 0 {
+		return 0
+	}
+
+	return float64(c.runs-c.runsWithDrops) * 100 / float64(c.runs)
+}
+
+func (c *dropCounts) add(rec Record) {
+	c.drops++
+	switch {
+	case rec.Drop.Item.Desc().Type == item.TypeRune:
+		c.runes++
+	case rec.Drop.Item.Quality == item.QualityUnique:
+		c.uniques++
+	case rec.Drop.Item.Quality == item.QualitySet:
+		c.sets++
+	case rec.Drop.Item.Quality == item.QualityRare:
+		c.rares++
+	}
+}
+
+// BuildReport attributes every drop to the last run of its supervisor started before it was picked up, the items
+// picked up after the run finished (post run pickup) still count for it.
+func BuildReport(drops []Record, runs []RunRecord, filter ReportFilter) Report {
+	matches := func(supervisor, run string, at time.Time) bool {
+		return (filter.Supervisor == "" || strings.EqualFold(filter.Supervisor, supervisor)) &&
+			(filter.Run == "" || strings.EqualFold(filter.Run, run)) &&
+			!at.Before(filter.Since)
+	}
+
+	runsBySupervisor := make(map[string][]int)
+	for i, r := range runs {
+		runsBySupervisor[r.Supervisor] = append(runsBySupervisor[r.Supervisor], i)
+	}
+	for _, idx := range runsBySupervisor {
+		sort.Slice(idx, func(i, j int) bool { return runs[idx[i]].StartedAt.Before(runs[idx[j]].StartedAt) })
+	}
+
+	report := Report{Runs: []RunReport{}}
+	dropsByRun := make(map[int][]Record)
+	for _, rec := range drops {
+		idx := runsBySupervisor[rec.Supervisor]
+		// First run started after the pickup, the one before is the run the item dropped in
+		n := sort.Search(len(idx), func(i int) bool { return runs[idx[i]].StartedAt.After(rec.PickedUpAt) })
+		if rec.PickedUpAt.IsZero() || n == 0 {
+			// Without a run the drop only counts when the report isn't narrowed to a run
+			if matches(rec.Supervisor, "", rec.Time) {
+				report.Unattributed++
+			}
+			continue
+		}
+		dropsByRun[idx[n-1]] = append(dropsByRun[idx[n-1]], rec)
+	}
+
+	total := make(map[string]*dropCounts)
+	byDay := make(map[string]map[string]*dropCounts)
+	runes := make(map[string]map[string]int)
+	for i, r := range runs {
+		if !matches(r.Supervisor, r.Run, r.StartedAt) {
+			continue
+		}
+
+		if total[r.Run] == nil {
+			total[r.Run] = &dropCounts{}
+			byDay[r.Run] = make(map[string]*dropCounts)
+			runes[r.Run] = make(map[string]int)
+		}
+		day := r.StartedAt.Format("2006-01-02")
+		if byDay[r.Run][day] == nil {
+			byDay[r.Run][day] = &dropCounts{}
+		}
+
+		for _, c := range []*dropCounts{total[r.Run], byDay[r.Run][day]} {
+			c.runs++
+			if len(dropsByRun[i]) > 0 {
+				c.runsWithDrops++
+			}
+			for _, rec := range dropsByRun[i] {
+				c.add(rec)
+			}
+		}
+		for _, rec := range dropsByRun[i] {
+			if rec.Drop.Item.Desc().Type == item.TypeRune {
+				runes[r.Run][string(rec.Drop.Item.Name)]++
+			}
+		}
+	}
+
+	for run, c := range total {
+		rr := RunReport{
+			Run:              run,
+			Runs:             c.runs,
+			Drops:            c.drops,
+			RunsWithoutDrops: c.runs - c.runsWithDrops,
+			NoDropRate:       c.noDropRate(),
+			Rates:            c.rates(),
+			Runes:            runes[run],
+		}
+		for day, dc := range byDay[run] {
+			rr.Trend = append(rr.Trend, TrendPoint{
+				Date:       day,
+				Runs:       dc.runs,
+				Drops:      dc.drops,
+				NoDropRate: dc.noDropRate(),
+				Rates:      dc.rates(),
+			})
+		}
+		sort.Slice(rr.Trend, func(i, j int) bool { return rr.Trend[i].Date < rr.Trend[j].Date })
+		report.Runs = append(report.Runs, rr)
+	}
+	sort.Slice(report.Runs, func(i, j int) bool { return report.Runs[i].Runs > report.Runs[j].Runs })
+
+	return report
+}
@@ -0,0 +1,89 @@
+package droplog
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hectorgimenez/koolo/internal/event"
+)
+
+// RunRecord is a finished run, the drop reports count the runs from them and tell the run a drop came from with the
+// time it was picked up.
+type RunRecord struct {
+	Supervisor string             `json:"supervisor"`
+	Run        string             `json:"run"`
+	StartedAt  time.Time          `json:"startedAt"`
+	FinishedAt time.Time          `json:"finishedAt"`
+	Reason     event.FinishReason `json:"reason"`
+}
+
+// writeRun persists the finished run to a daily JSONL file next to the drops.
+func (w *Writer) writeRun(supervisor string, evt event.RunFinishedEvent) {
+	startedAt, found := w.runStarts[supervisor]
+	if !found {
+		return
+	}
+	delete(w.runStarts, supervisor)
+
+	if err := os.MkdirAll(w.logDir, 0o755); err != nil {
+		w.logger.Error("Failed to create droplog directory", slog.Any("error", err), slog.String("dir", w.logDir))
+		return
+	}
+
+	file := filepath.Join(w.logDir, fmt.Sprintf("runlog-%s.jsonl", time.Now().Format("2006-01-02")))
+	f, err := os.OpenFile(file, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		w.logger.Error("Failed to open runlog file", slog.Any("error", err), slog.String("file", file))
+		return
+	}
+	defer f.Close()
+
+	enc, err := json.Marshal(RunRecord{
+		Supervisor: supervisor,
+		Run:        evt.RunName,
+		StartedAt:  startedAt,
+		FinishedAt: evt.OccurredAt(),
+		Reason:     evt.Reason,
+	})
+	if err != nil {
+		w.logger.Error("Failed to encode runlog record", slog.Any("error", err))
+		return
+	}
+	if _, err = f.Write(append(enc, '\n')); err != nil {
+		w.logger.Error("Failed to write runlog record", slog.Any("error", err))
+	}
+}
+
+// ReadRuns scans the log directory for runlog-*.jsonl files and returns all the runs, oldest first.
+func ReadRuns(logDir string) ([]RunRecord, error) {
+	files, err := filepath.Glob(filepath.Join(logDir, "runlog-*.jsonl"))
+	if err != nil {
+		return nil, err
+	}
+	sortStrings(files)
+
+	var out []RunRecord
+	for _, fpath := range files {
+		f, err := os.Open(fpath)
+		if err != nil {
+			continue
+		}
+
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			var rec RunRecord
+			if err := json.Unmarshal([]byte(strings.TrimSpace(scanner.Text())), &rec); err == nil {
+				out = append(out, rec)
+			}
+		}
+		f.Close()
+	}
+
+	return out, nil
+}
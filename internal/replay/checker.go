@@ -0,0 +1,158 @@
+package replay
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/area"
+	"github.com/hectorgimenez/d2go/pkg/data/skill"
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/game"
+	"github.com/hectorgimenez/koolo/internal/pather"
+)
+
+// Checker checks the pathing of a recorded game offline, step by step, without a game client. The bot decisions are
+// not re-run, only the pathing and the movement inputs are checked against what happened in the game. Data always
+// holds the game data of the step being checked and PathFinder works on it, the same way they are used in the game.
+type Checker struct {
+	Recording  *Recording
+	Data       *game.Data
+	PathFinder *pather.PathFinder
+	cfg        *config.CharacterCfg
+}
+
+// Check checks a step and returns an error when the pathing doesn't match the recording. Next is the step after it,
+// nil on the last one.
+type Check func(c *Checker, step Step, next *Step) error
+
+// Divergence is a step where a check failed.
+type Divergence struct {
+	Step     int
+	At       time.Time
+	Area     area.ID
+	Position data.Position
+	Err      error
+}
+
+type Report struct {
+	Steps       int
+	Divergences []Divergence
+}
+
+func (d Divergence) String() string {
+	return fmt.Sprintf("step %d (%s) %s %d,%d: %v", d.Step, d.At.Format(time.TimeOnly), d.Area.Area().Name, d.Position.X, d.Position.Y, d.Err)
+}
+
+// NewChecker prepares the recording to be checked with the character config, the config isn't part of the recording
+// so the current one of the character is usually given.
+func NewChecker(rec *Recording, cfg *config.CharacterCfg) *Checker {
+	d := &game.Data{}
+	// Only the game area size is used offline, to convert the game coords to screen coords
+	gr := &game.MemoryReader{GameAreaSizeX: rec.Header.GameAreaSizeX, GameAreaSizeY: rec.Header.GameAreaSizeY}
+
+	return &Checker{
+		Recording:  rec,
+		Data:       d,
+		PathFinder: pather.NewPathFinder(gr, d, nil, cfg),
+		cfg:        cfg,
+	}
+}
+
+// Run reads the recording and runs the checks on every step.
+func (c *Checker) Run(checks ...Check) (Report, error) {
+	report := Report{}
+	step, err := c.Recording.Next()
+	for err == nil {
+		next, nextErr := c.Recording.Next()
+		var nextStep *Step
+		if nextErr == nil {
+			nextStep = &next
+		}
+
+		*c.Data = step.Data
+		c.Data.CharacterCfg = *c.cfg
+		for _, check := range checks {
+			if checkErr := check(c, step, nextStep); checkErr != nil {
+				report.Divergences = append(report.Divergences, Divergence{
+					Step:     report.Steps,
+					At:       step.At,
+					Area:     step.Data.PlayerUnit.Area,
+					Position: step.Data.PlayerUnit.Position,
+					Err:      checkErr,
+				})
+			}
+		}
+		report.Steps++
+
+		step, err = next, nextErr
+	}
+	if !errors.Is(err, io.EOF) {
+		return report, err
+	}
+	if report.Steps == 0 {
+		return report, fmt.Errorf("recording %s has no game data", c.Recording.path)
+	}
+
+	return report, nil
+}
+
+// PathCheck checks the pathing between the recorded positions, every position the character reached in the game must
+// be reachable from the previous one. Area changes are skipped, they go through a portal, a waypoint or an exit.
+func PathCheck(c *Checker, step Step, next *Step) error {
+	if next == nil || step.Data.AreaData.Grid == nil {
+		return nil
+	}
+
+	from := step.Data.PlayerUnit.Position
+	to := next.Data.PlayerUnit
+	if to.Area != step.Data.PlayerUnit.Area || to.Position == from {
+		return nil
+	}
+
+	if _, _, found := c.PathFinder.GetPathFrom(from, to.Position); !found {
+		return fmt.Errorf("no path found to %d,%d", to.Position.X, to.Position.Y)
+	}
+
+	return nil
+}
+
+// MoveOutputCheck compares the movement inputs recorded on the step with the pathing: the pather only clicks positions
+// along a path, above the HUD, so every teleport click and forced move must target a position reachable from where
+// the character stood.
+func MoveOutputCheck(c *Checker, step Step, _ *Step) error {
+	if step.Data.AreaData.Grid == nil {
+		return nil
+	}
+
+	forceMove := game.KeyBindingKey(step.Data.KeyBindings.ForceMove)
+	teleport := step.Data.PlayerUnit.RightSkill == skill.Teleport
+	pointer := data.Position{}
+	for _, out := range step.Outputs {
+		var target data.Position
+		switch {
+		case out.Kind == game.HIDMove:
+			pointer = data.Position{X: out.X, Y: out.Y}
+			continue
+		case out.Kind == game.HIDKey && out.Key == forceMove:
+			target = pointer
+		case out.Kind == game.HIDClick && out.Button == game.RightButton && teleport:
+			target = data.Position{X: out.X, Y: out.Y}
+		default:
+			continue
+		}
+
+		if target.Y > int(float32(c.Recording.Header.GameAreaSizeY)/1.19) {
+			return fmt.Errorf("movement input at %d,%d over the HUD", target.X, target.Y)
+		}
+
+		dest := game.ScreenCoordsToGameCoords(step.Data.PlayerUnit.Position, target.X, target.Y, c.Recording.Header.GameAreaSizeX, c.Recording.Header.GameAreaSizeY)
+		if _, _, found := c.PathFinder.GetClosestWalkablePathFrom(step.Data.PlayerUnit.Position, dest); !found {
+			return fmt.Errorf("movement input at %d,%d targets %d,%d, no path found", target.X, target.Y, dest.X, dest.Y)
+		}
+	}
+
+	return nil
+}
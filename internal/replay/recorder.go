@@ -0,0 +1,196 @@
+package replay
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/area"
+	"github.com/hectorgimenez/koolo/internal/game"
+)
+
+const (
+	// dataInterval is the minimum time between two recorded snapshots, the game data is refreshed much more often
+	// than it changes. A snapshot is always recorded after an input or an area change.
+	dataInterval = 250 * time.Millisecond
+	// maxRecordingBytes caps the compressed size of a recording, the recording stops once it's reached.
+	maxRecordingBytes = 256 << 20
+	// maxRecordings is how many recordings are kept by supervisor, the oldest are removed when a new one starts.
+	maxRecordings = 20
+)
+
+// ErrRecordingFull is returned by Close when the recording stopped at maxRecordingBytes.
+var ErrRecordingFull = errors.New("recording size limit reached")
+
+// Frame is one line of a recording, only one of the pointers is set.
+type Frame struct {
+	At     time.Time
+	Header *Header        `json:",omitempty"`
+	Data   *data.Data     `json:",omitempty"`
+	Area   *game.AreaData `json:",omitempty"` // Map data of an area, written the first time the player enters it
+	HID    *game.HIDEvent `json:",omitempty"`
+}
+
+// Header is the first frame of a recording.
+type Header struct {
+	Supervisor    string
+	GameAreaSizeX int
+	GameAreaSizeY int
+}
+
+// Recorder writes the game data snapshots and the inputs sent to the game to a gzipped JSON lines file.
+type Recorder struct {
+	mu       sync.Mutex
+	file     *os.File
+	written  *countingWriter
+	buf      *bufio.Writer
+	gz       *gzip.Writer
+	enc      *json.Encoder
+	areas    map[area.ID]bool
+	lastData time.Time
+	lastArea area.ID
+	inputs   bool // Inputs were recorded since the last snapshot
+	err      error
+	closed   bool
+}
+
+// countingWriter counts the bytes written to the recording file.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+
+	return n, err
+}
+
+// RecordingPath returns the file of a new recording for the supervisor, replays/<supervisor>/<date>.jsonl.gz.
+func RecordingPath(supervisor string) string {
+	return filepath.Join("replays", supervisor, time.Now().Format("2006-01-02_15-04-05")+".jsonl.gz")
+}
+
+// NewRecorder creates the recording file and writes its header.
+func NewRecorder(path string, header Header) (*Recorder, error) {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return nil, fmt.Errorf("error creating replays directory: %w", err)
+	}
+	pruneRecordings(filepath.Dir(path))
+
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("error creating recording: %w", err)
+	}
+
+	written := &countingWriter{w: f}
+	buf := bufio.NewWriter(written)
+	gz, _ := gzip.NewWriterLevel(buf, gzip.BestSpeed)
+	r := &Recorder{
+		file:    f,
+		written: written,
+		buf:     buf,
+		gz:      gz,
+		enc:     json.NewEncoder(gz),
+		areas:   make(map[area.ID]bool),
+	}
+	r.write(Frame{At: time.Now(), Header: &header})
+
+	return r, r.err
+}
+
+// pruneRecordings removes the oldest recordings of the folder so the new one makes maxRecordings, the file names are
+// dates so they sort by age.
+func pruneRecordings(dir string) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+
+	var recordings []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".jsonl.gz") {
+			recordings = append(recordings, e.Name())
+		}
+	}
+	for len(recordings) >= maxRecordings {
+		os.Remove(filepath.Join(dir, recordings[0]))
+		recordings = recordings[1:]
+	}
+}
+
+// RecordData writes the snapshot, at most one every dataInterval unless an input was sent or the area changed since
+// the previous one. The map data of the current area is written along the first snapshot taken in it.
+func (r *Recorder) RecordData(d game.Data) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	if !r.inputs && d.PlayerUnit.Area == r.lastArea && now.Sub(r.lastData) < dataInterval {
+		return
+	}
+	r.lastData = now
+	r.lastArea = d.PlayerUnit.Area
+	r.inputs = false
+
+	if areaID := d.PlayerUnit.Area; !r.areas[areaID] && d.AreaData.Grid != nil {
+		areaData := d.AreaData
+		r.write(Frame{At: now, Area: &areaData})
+		r.areas[areaID] = true
+	}
+	r.write(Frame{At: now, Data: &d.Data})
+}
+
+// RecordHID writes an input sent to the game, it's meant to be set as the HID observer.
+func (r *Recorder) RecordHID(e game.HIDEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.inputs = true
+	r.write(Frame{At: e.At, HID: &e})
+}
+
+func (r *Recorder) write(f Frame) {
+	// The first error stops the recording, the game goes on
+	if r.err != nil || r.closed {
+		return
+	}
+	if r.written.n >= maxRecordingBytes {
+		r.err = ErrRecordingFull
+		return
+	}
+	r.err = r.enc.Encode(f)
+}
+
+// Close flushes and closes the recording, it returns the first error found while recording.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return r.err
+	}
+	r.closed = true
+
+	if err := r.gz.Close(); err != nil && r.err == nil {
+		r.err = err
+	}
+	if err := r.buf.Flush(); err != nil && r.err == nil {
+		r.err = err
+	}
+	if err := r.file.Close(); err != nil && r.err == nil {
+		r.err = err
+	}
+
+	return r.err
+}
@@ -0,0 +1,120 @@
+package replay
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data/area"
+	"github.com/hectorgimenez/koolo/internal/game"
+)
+
+// Recording is a recorded game opened with Open, its steps are read one at a time with Next so a long game isn't
+// loaded in memory at once.
+type Recording struct {
+	Header Header
+	path   string
+	file   *os.File
+	gz     *gzip.Reader
+	dec    *json.Decoder
+	areas  map[area.ID]game.AreaData
+	// The step being read, its inputs are the ones recorded until the next snapshot
+	pending *Step
+	done    bool
+}
+
+// Step is a game data snapshot and the inputs sent to the game until the next one.
+type Step struct {
+	At      time.Time
+	Data    game.Data
+	Outputs []game.HIDEvent
+}
+
+// Open opens a recording and reads its header.
+func Open(path string) (*Recording, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening recording: %w", err)
+	}
+
+	gz, err := gzip.NewReader(bufio.NewReader(f))
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("error reading recording %s: %w", path, err)
+	}
+
+	rec := &Recording{
+		path:  path,
+		file:  f,
+		gz:    gz,
+		dec:   json.NewDecoder(gz),
+		areas: make(map[area.ID]game.AreaData),
+	}
+
+	var frame Frame
+	if err = rec.dec.Decode(&frame); err != nil || frame.Header == nil {
+		rec.Close()
+		return nil, fmt.Errorf("recording %s has no header", path)
+	}
+	rec.Header = *frame.Header
+
+	return rec, nil
+}
+
+// Next returns the next step of the recording, io.EOF once they were all read. The game data of the step is rebuilt
+// with the recorded map data so the pathing works as it did in the game.
+func (r *Recording) Next() (Step, error) {
+	for !r.done {
+		var frame Frame
+		if err := r.dec.Decode(&frame); err != nil {
+			// Recordings of a crashed client end with a truncated frame, the steps read so far are kept
+			if !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+				return Step{}, fmt.Errorf("error decoding recording %s: %w", r.path, err)
+			}
+			r.done = true
+			break
+		}
+
+		switch {
+		case frame.Area != nil:
+			r.areas[frame.Area.Area] = *frame.Area
+		case frame.Data != nil:
+			// The steps share the areas map, the map data doesn't change during the game
+			step := &Step{At: frame.At, Data: game.Data{
+				Areas:    r.areas,
+				AreaData: r.areas[frame.Data.PlayerUnit.Area],
+				Data:     *frame.Data,
+			}}
+			previous := r.pending
+			r.pending = step
+			if previous != nil {
+				return *previous, nil
+			}
+		case frame.HID != nil:
+			// Inputs sent before the first snapshot can't be replayed
+			if r.pending != nil {
+				r.pending.Outputs = append(r.pending.Outputs, *frame.HID)
+			}
+		}
+	}
+
+	if r.pending == nil {
+		return Step{}, io.EOF
+	}
+	last := *r.pending
+	r.pending = nil
+
+	return last, nil
+}
+
+// Close closes the recording file.
+func (r *Recording) Close() error {
+	r.gz.Close()
+
+	return r.file.Close()
+}
@@ -0,0 +1,109 @@
+package rewards
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data/difficulty"
+)
+
+var (
+	ledgerMu   sync.Mutex
+	ledgerPath = filepath.Join("config", "quest_rewards.json")
+	// ledger is the file content, read once and written back only when a state changes
+	ledger map[string]Record
+)
+
+// State is what we know about the item rewards of a character in one difficulty.
+type State struct {
+	// ImbuePending is true while Charsi's imbue is unused, it's only known once the character was in that difficulty.
+	ImbuePending bool `json:"imbuePending"`
+	// ImbuedItem is the item Charsi imbued, empty until the reward is used.
+	ImbuedItem string `json:"imbuedItem,omitempty"`
+	// SocketPending is true while Larzuk's socket is unused.
+	SocketPending bool `json:"socketPending"`
+	// SocketedItem is the item Larzuk socketed, empty until the reward is used.
+	SocketedItem string    `json:"socketedItem,omitempty"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+// Record is the reward state of a character in every difficulty it was seen in.
+type Record map[difficulty.Difficulty]State
+
+// Update changes the reward state of the character in the difficulty, the ledger is saved when it actually changed.
+func Update(character string, diff difficulty.Difficulty, fn func(s *State)) error {
+	ledgerMu.Lock()
+	defer ledgerMu.Unlock()
+
+	if err := loadLedger(); err != nil {
+		return err
+	}
+
+	record, found := ledger[character]
+	if !found {
+		record = make(Record)
+	}
+	previous, known := record[diff]
+	state := previous
+	fn(&state)
+	if known && state == previous {
+		return nil
+	}
+	state.UpdatedAt = time.Now()
+	record[diff] = state
+	ledger[character] = record
+
+	return saveLedger()
+}
+
+// Get returns the reward state of the character, empty when it was never recorded.
+func Get(character string) (Record, error) {
+	ledgerMu.Lock()
+	defer ledgerMu.Unlock()
+
+	if err := loadLedger(); err != nil {
+		return nil, err
+	}
+
+	// A copy, the callers can't change the ledger without going through Update
+	record := make(Record)
+	for diff, state := range ledger[character] {
+		record[diff] = state
+	}
+
+	return record, nil
+}
+
+// loadLedger reads the ledger file the first time it's needed.
+func loadLedger() error {
+	if ledger != nil {
+		return nil
+	}
+
+	loaded := make(map[string]Record)
+	jsonData, err := os.ReadFile(ledgerPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err == nil {
+		if err = json.Unmarshal(jsonData, &loaded); err != nil {
+			return err
+		}
+	}
+	ledger = loaded
+
+	return nil
+}
+
+func saveLedger() error {
+	jsonData, err := json.MarshalIndent(ledger, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(ledgerPath, jsonData, 0644)
+}
@@ -0,0 +1,62 @@
+package rewards
+
+import (
+	"strings"
+
+	"github.com/hectorgimenez/d2go/pkg/data/difficulty"
+)
+
+// SocketJob is an item of the socket queue, Difficulty tells whose reward socketed it once it's done.
+type SocketJob struct {
+	Item       string                `json:"item"`
+	Priority   int                   `json:"priority"`
+	Done       bool                  `json:"done"`
+	Difficulty difficulty.Difficulty `json:"difficulty,omitempty"`
+}
+
+var difficulties = []difficulty.Difficulty{difficulty.Normal, difficulty.Nightmare, difficulty.Hell}
+
+// SocketQueue returns the queued items in priority order, matching them with the items socketed by the rewards of
+// the record. Each reward completes a single job, so an item queued twice needs two rewards.
+func SocketQueue(record Record, queue []string) []SocketJob {
+	used := make(map[difficulty.Difficulty]bool)
+	jobs := make([]SocketJob, 0, len(queue))
+	for priority, name := range queue {
+		job := SocketJob{Item: name, Priority: priority + 1}
+		for _, diff := range difficulties {
+			if !used[diff] && strings.EqualFold(record[diff].SocketedItem, name) {
+				used[diff] = true
+				job.Done = true
+				job.Difficulty = diff
+				break
+			}
+		}
+		jobs = append(jobs, job)
+	}
+
+	return jobs
+}
+
+// PendingSocketJobs returns the queued items still waiting for a socket.
+func PendingSocketJobs(record Record, queue []string) []SocketJob {
+	pending := make([]SocketJob, 0)
+	for _, job := range SocketQueue(record, queue) {
+		if !job.Done {
+			pending = append(pending, job)
+		}
+	}
+
+	return pending
+}
+
+// UnusedSocketRewards returns the difficulties whose socket reward is known to be unused.
+func UnusedSocketRewards(record Record) []difficulty.Difficulty {
+	unused := make([]difficulty.Difficulty, 0)
+	for _, diff := range difficulties {
+		if record[diff].SocketPending {
+			unused = append(unused, diff)
+		}
+	}
+
+	return unused
+}
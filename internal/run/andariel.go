@@ -133,13 +133,14 @@ func (a Andariel) CheckConditions(parameters *RunParameters) SequencerResult {
 		!action.HasAnyQuestStartedOrCompleted(quest.Act2RadamentsLair, quest.Act2TheSevenTombs) &&
 		a.ctx.Data.PlayerUnit.Area.Act() == 1)
 
+	keptOpen := action.QuestKeptOpen(quest.Act1SistersToTheSlaughter)
 	needToTalkToWarriv := a.ctx.Data.Quests[quest.Act1SistersToTheSlaughter].HasStatus(quest.StatusCompletedBefore) && !a.ctx.Data.Quests[quest.Act1SistersToTheSlaughter].HasStatus(quest.StatusRewardGranted)
-	if needToTalkToWarriv {
+	if needToTalkToWarriv && !keptOpen {
 		return SequencerOk
 	}
 
 	questCompleted := a.ctx.Data.Quests[quest.Act1SistersToTheSlaughter].Completed()
-	if (farmingRun && !questCompleted) || (!farmingRun && (questCompleted && !needLeaveTown)) {
+	if (farmingRun && !questCompleted && !keptOpen) || (!farmingRun && (questCompleted && !needLeaveTown)) {
 		return SequencerSkip
 	}
 	return SequencerOk
@@ -151,7 +152,7 @@ func (a Andariel) Run(parameters *RunParameters) error {
 
 	if IsQuestRun(parameters) {
 		needLeaveTown := a.ctx.Data.Quests[quest.Act1SistersToTheSlaughter].HasStatus(quest.StatusRewardGranted+quest.StatusLeaveTown+quest.StatusEnterArea) || a.ctx.Data.Quests[quest.Act1SistersToTheSlaughter].HasStatus(quest.StatusCompletedBefore)
-		if needLeaveTown {
+		if needLeaveTown && !action.QuestKeptOpen(quest.Act1SistersToTheSlaughter) {
 			a.goToAct2()
 			return nil
 		}
@@ -164,7 +165,11 @@ func (a Andariel) Run(parameters *RunParameters) error {
 	}
 
 	err = action.MoveToArea(area.CatacombsLevel3)
-	action.MoveToArea(area.CatacombsLevel4)
+	if err != nil {
+		return err
+	}
+
+	err = action.MoveToArea(area.CatacombsLevel4)
 	if err != nil {
 		return err
 	}
@@ -255,6 +260,7 @@ func (a Andariel) Run(parameters *RunParameters) error {
 		}
 	}
 
+	action.RushCheckpoint("andariel")
 	a.ctx.Logger.Info("Killing Andariel")
 	err = a.ctx.Char.KillAndariel()
 
@@ -0,0 +1,111 @@
+package run
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/npc"
+	"github.com/hectorgimenez/d2go/pkg/data/object"
+	"github.com/hectorgimenez/koolo/internal/action"
+	"github.com/hectorgimenez/koolo/internal/context"
+	"github.com/hectorgimenez/koolo/internal/pather"
+	"github.com/hectorgimenez/koolo/internal/utils"
+)
+
+const (
+	arcaneMaxPadJumps = 8
+	arcaneClearRadius = 20
+	arcanePadMinJump  = 10 // Distance we must have moved to consider the pad was taken
+)
+
+var (
+	arcanePads = []object.Name{
+		object.TeleportationPad1,
+		object.TeleportationPad2,
+		object.TeleportationPad3,
+		object.TeleportationPad4,
+	}
+
+	arcaneGhosts = map[npc.ID]bool{
+		npc.Ghost:      true,
+		npc.Wraith:     true,
+		npc.Specter:    true,
+		npc.Apparition: true,
+		npc.Ghost2:     true,
+		npc.Wraith2:    true,
+		npc.Specter2:   true,
+		npc.Specter3:   true,
+	}
+)
+
+// crossArcanePlatforms takes the teleportation pads until the destination can be reached walking. The pads are taken
+// closest to the destination first, every jump brings us to the next platform of the arm.
+func crossArcanePlatforms(ctx *context.Status, dest data.Position) error {
+	for jump := 0; jump < arcaneMaxPadJumps; jump++ {
+		if _, _, found := ctx.PathFinder.GetPath(dest); found {
+			return nil
+		}
+
+		pad, found := bestArcanePad(ctx, dest)
+		if !found {
+			return errors.New("no reachable teleportation pad found")
+		}
+
+		ctx.Logger.Debug("Taking teleportation pad", "position", pad.Position)
+		previousPos := ctx.Data.PlayerUnit.Position
+		err := action.InteractObject(pad, func() bool {
+			return pather.DistanceFromPoint(previousPos, ctx.Data.PlayerUnit.Position) > arcanePadMinJump
+		})
+		if err != nil {
+			return fmt.Errorf("failed taking teleportation pad: %w", err)
+		}
+		utils.Sleep(300)
+	}
+
+	return errors.New("destination not reachable after taking the teleportation pads")
+}
+
+// bestArcanePad returns the reachable pad closest to the destination.
+func bestArcanePad(ctx *context.Status, dest data.Position) (data.Object, bool) {
+	var best data.Object
+	bestDistance := 0
+	for _, o := range ctx.Data.Objects {
+		isPad := false
+		for _, name := range arcanePads {
+			if o.Name == name {
+				isPad = true
+				break
+			}
+		}
+		if !isPad {
+			continue
+		}
+		if _, _, found := ctx.PathFinder.GetPath(o.Position); !found {
+			continue
+		}
+
+		distance := pather.DistanceFromPoint(o.Position, dest)
+		if best.ID == 0 || distance < bestDistance {
+			best = o
+			bestDistance = distance
+		}
+	}
+
+	return best, best.ID != 0
+}
+
+// skipVoidGhostsFilter ignores the ghosts floating outside the platforms, their loot falls where nobody can pick it up.
+func skipVoidGhostsFilter(ctx *context.Status) data.MonsterFilter {
+	return func(m data.Monsters) []data.Monster {
+		var filteredMonsters []data.Monster
+		for _, mo := range m {
+			if arcaneGhosts[mo.Name] && !ctx.Data.AreaData.IsWalkable(mo.Position) {
+				continue
+			}
+			filteredMonsters = append(filteredMonsters, mo)
+		}
+
+		return filteredMonsters
+	}
+}
@@ -60,6 +60,20 @@ func (a Baal) CheckConditions(parameters *RunParameters) SequencerResult {
 	return SequencerOk
 }
 func (s *Baal) Run(parameters *RunParameters) error {
+	if err := s.run(); err != nil {
+		return err
+	}
+
+	if s.ctx.CharacterCfg.Game.Baal.Leader.Enabled {
+		s.waitForNextGame()
+	}
+
+	return nil
+}
+
+func (s *Baal) run() error {
+	leader := s.ctx.CharacterCfg.Game.Baal.Leader
+
 	// Set filter
 	filter := data.MonsterAnyFilter()
 	if s.ctx.CharacterCfg.Game.Baal.OnlyElites {
@@ -99,10 +113,13 @@ func (s *Baal) Run(parameters *RunParameters) error {
 		return errors.New("souls or dolls detected, skipping")
 	}
 
-	// Let's move to a safe area and open the portal in companion mode
-	if s.ctx.CharacterCfg.Companion.Leader {
+	// Let's move to a safe area and open the portal in companion or leader mode
+	if s.ctx.CharacterCfg.Companion.Leader || leader.Enabled {
 		action.MoveToCoords(data.Position{X: 15116, Y: 5071})
-		action.OpenTPIfLeader()
+		step.OpenPortal()
+	}
+	if leader.Enabled {
+		action.Say(leaderMessage(leader.TPMessage, baalLeaderTPMessage))
 	}
 
 	err = action.ClearAreaAroundPlayer(50, data.MonsterAnyFilter())
@@ -110,6 +127,10 @@ func (s *Baal) Run(parameters *RunParameters) error {
 		return err
 	}
 
+	if leader.Enabled && leader.WaitAtThrone {
+		s.waitForLeechers()
+	}
+
 	// Force rebuff before waves
 	action.Buff()
 
@@ -125,6 +146,7 @@ func (s *Baal) Run(parameters *RunParameters) error {
 
 	lastWaveDetected := false
 	isWaitingForPortal := false
+	currentWave := 0
 	_, isLevelingChar := s.ctx.Char.(context.LevelingCharacter)
 
 	for !s.hasBaalLeftThrone() && time.Now().Before(waveTimeout) {
@@ -138,7 +160,7 @@ func (s *Baal) Run(parameters *RunParameters) error {
 				lastWaveDetected = true
 			}
 		} else if lastWaveDetected {
-			if !s.ctx.CharacterCfg.Game.Baal.KillBaal && !isLevelingChar {
+			if !s.ctx.CharacterCfg.Game.Baal.KillBaal && !isLevelingChar && !s.ctx.CurrentGame.Rushing {
 				s.ctx.Logger.Info("Waves cleared, skipping Baal kill (Fast Exit).")
 				return nil
 			}
@@ -153,7 +175,15 @@ func (s *Baal) Run(parameters *RunParameters) error {
 			continue
 		}
 
+		if wave := s.currentWave(); wave > currentWave {
+			s.ctx.Logger.Info(fmt.Sprintf("Baal wave %d spawned", wave))
+			currentWave = wave
+		}
+
 		if !isWaitingForPortal {
+			if leader.Enabled {
+				s.waveTactics(currentWave)
+			}
 			action.ClearAreaAroundPosition(throneMainPos, 50, data.MonsterAnyFilter())
 			s.preAttackBaalWaves()
 		}
@@ -167,9 +197,15 @@ func (s *Baal) Run(parameters *RunParameters) error {
 
 	// Baal has entered the chamber
 	s.ctx.Logger.Info("Baal has entered the Worldstone Chamber")
+	if leader.Enabled {
+		action.Say(leaderMessage(leader.GoMessage, baalLeaderGoMessage))
+		if leader.WaitBeforeBaal {
+			s.waitForLeechers()
+		}
+	}
 
 	// Kill Baal Logic
-	if s.ctx.CharacterCfg.Game.Baal.KillBaal || isLevelingChar {
+	if s.ctx.CharacterCfg.Game.Baal.KillBaal || isLevelingChar || s.ctx.CurrentGame.Rushing {
 		action.Buff()
 
 		s.ctx.Logger.Info("Waiting for Baal portal...")
@@ -201,6 +237,7 @@ func (s *Baal) Run(parameters *RunParameters) error {
 		} else if err != nil {
 			return fmt.Errorf("failed to enter baal portal: %w", err)
 		}
+		action.RushCheckpoint("baal")
 
 		// Move to Baal (may fail due to tentacles)
 		s.ctx.Logger.Info("Moving to Baal...")
@@ -0,0 +1,127 @@
+package run
+
+import (
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/npc"
+	"github.com/hectorgimenez/koolo/internal/action"
+	"github.com/hectorgimenez/koolo/internal/utils"
+)
+
+const (
+	baalLeaderTPMessage   = "tp up"
+	baalLeaderGoMessage   = "go go"
+	baalLeaderWaitSeconds = 60
+)
+
+func leaderMessage(message, defaultMessage string) string {
+	if message == "" {
+		return defaultMessage
+	}
+
+	return message
+}
+
+// currentWave returns the throne wave by the monsters around, 0 when none of them is found.
+func (s *Baal) currentWave() int {
+	wave := 0
+	for _, m := range s.ctx.Data.Monsters.Enemies() {
+		switch m.Name {
+		case npc.WarpedFallen, npc.WarpedShaman:
+			wave = max(wave, 1)
+		case npc.BaalSubjectMummy, npc.BaalColdMage:
+			wave = max(wave, 2)
+		case npc.CouncilMemberBall:
+			wave = max(wave, 3)
+		case npc.VenomLord2:
+			wave = max(wave, 4)
+		case npc.BaalsMinion:
+			wave = max(wave, 5)
+		}
+	}
+
+	return wave
+}
+
+// waveTactics kills first the monsters making each wave dangerous, the rest is cleared as usual.
+func (s *Baal) waveTactics(wave int) {
+	switch wave {
+	case 2:
+		// Cold mages freeze the leechers from behind the mummies
+		action.ClearAreaAroundPosition(throneMainPos, 50, func(monsters data.Monsters) []data.Monster {
+			var mages []data.Monster
+			for _, m := range monsters {
+				if m.Name == npc.BaalColdMage {
+					mages = append(mages, m)
+				}
+			}
+			return mages
+		})
+	case 5:
+		// Lister and his pack
+		action.ClearAreaAroundPosition(throneMainPos, 50, data.MonsterEliteFilter())
+	}
+}
+
+// waitForLeechers waits until the configured amount of players is in our area, or until the wait time is over.
+func (s *Baal) waitForLeechers() {
+	leader := s.ctx.CharacterCfg.Game.Baal.Leader
+	if leader.Leechers <= 0 {
+		return
+	}
+
+	waitSeconds := leader.WaitSeconds
+	if waitSeconds <= 0 {
+		waitSeconds = baalLeaderWaitSeconds
+	}
+
+	s.ctx.Logger.Info("Waiting for leechers", "leechers", leader.Leechers, "seconds", waitSeconds)
+	deadline := time.Now().Add(time.Duration(waitSeconds) * time.Second)
+	for time.Now().Before(deadline) {
+		s.ctx.PauseIfNotPriority()
+		s.ctx.RefreshGameData()
+
+		if s.playersInArea() >= leader.Leechers {
+			return
+		}
+
+		action.ClearAreaAroundPlayer(15, data.MonsterAnyFilter())
+		action.AntiIdle()
+		utils.Sleep(1000)
+	}
+
+	s.ctx.Logger.Info("Leechers didn't show up in time, going on")
+}
+
+func (s *Baal) playersInArea() int {
+	players := 0
+	for _, member := range s.ctx.Data.Roster {
+		if member.Name != s.ctx.Data.PlayerUnit.Name && member.Area == s.ctx.Data.PlayerUnit.Area {
+			players++
+		}
+	}
+
+	return players
+}
+
+// waitForNextGame stays in town until the game lasted the configured time, the leechers get a fixed game length
+// and the next game is created right after.
+func (s *Baal) waitForNextGame() {
+	nextGame := time.Duration(s.ctx.CharacterCfg.Game.Baal.Leader.NextGameSeconds) * time.Second
+	remaining := nextGame - time.Since(s.ctx.CurrentGame.StartedAt)
+	if remaining <= 0 {
+		return
+	}
+
+	if err := action.ReturnTown(); err != nil {
+		s.ctx.Logger.Warn("Failed returning to town before the next game", "error", err)
+	}
+
+	s.ctx.Logger.Info("Waiting before moving on to the next game", "remaining", remaining.Round(time.Second))
+	deadline := time.Now().Add(remaining)
+	for time.Now().Before(deadline) {
+		s.ctx.PauseIfNotPriority()
+		utils.Sleep(1000)
+	}
+}
@@ -0,0 +1,93 @@
+package run
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/hectorgimenez/d2go/pkg/data/area"
+	"github.com/hectorgimenez/d2go/pkg/data/quest"
+	"github.com/hectorgimenez/koolo/internal/action"
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/context"
+)
+
+// CollectWaypoints visits every waypoint the character doesn't have yet in the acts it can access. The waypoint
+// action already walks from the closest known waypoint discovering the ones on the way.
+type CollectWaypoints struct {
+	ctx *context.Status
+}
+
+func NewCollectWaypoints() *CollectWaypoints {
+	return &CollectWaypoints{
+		ctx: context.Get(),
+	}
+}
+
+func (c CollectWaypoints) Name() string {
+	return string(config.CollectWaypointsRun)
+}
+
+func (c CollectWaypoints) CheckConditions(parameters *RunParameters) SequencerResult {
+	if len(c.missingWaypoints()) == 0 {
+		return SequencerSkip
+	}
+
+	return SequencerOk
+}
+
+func (c CollectWaypoints) Run(parameters *RunParameters) error {
+	for _, wp := range c.missingWaypoints() {
+		// Previous iterations may have discovered it while traversing
+		c.ctx.RefreshGameData()
+		if slices.Contains(c.ctx.Data.PlayerUnit.AvailableWaypoints, wp) {
+			continue
+		}
+
+		c.ctx.Logger.Info("Collecting waypoint", "area", wp.Area().Name)
+		if err := action.WayPoint(wp); err != nil {
+			c.ctx.Logger.Warn(fmt.Sprintf("Failed to collect waypoint %s: %v", wp.Area().Name, err))
+		}
+	}
+
+	return action.ReturnTown()
+}
+
+// actAccessible returns true if the character can travel to the act in the current difficulty.
+func (c CollectWaypoints) actAccessible(act int) bool {
+	switch act {
+	case 1:
+		return true
+	case 2:
+		return c.ctx.Data.Quests[quest.Act1SistersToTheSlaughter].Completed()
+	case 3:
+		return c.ctx.Data.Quests[quest.Act2TheSevenTombs].Completed()
+	case 4:
+		return c.ctx.Data.Quests[quest.Act3TheGuardian].Completed()
+	case 5:
+		return c.ctx.Data.Quests[quest.Act4TerrorsEnd].Completed()
+	}
+
+	return false
+}
+
+// missingWaypoints returns the waypoints not discovered yet, sorted by act and waypoint list order.
+func (c CollectWaypoints) missingWaypoints() []area.ID {
+	missing := make([]area.ID, 0)
+	for wp, address := range area.WPAddresses {
+		if !c.actAccessible(address.Tab) || slices.Contains(c.ctx.Data.PlayerUnit.AvailableWaypoints, wp) {
+			continue
+		}
+		missing = append(missing, wp)
+	}
+
+	sort.Slice(missing, func(i, j int) bool {
+		a, b := area.WPAddresses[missing[i]], area.WPAddresses[missing[j]]
+		if a.Tab != b.Tab {
+			return a.Tab < b.Tab
+		}
+		return a.Row < b.Row
+	})
+
+	return missing
+}
@@ -0,0 +1,162 @@
+package run
+
+import (
+	"errors"
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/koolo/internal/action"
+	"github.com/hectorgimenez/koolo/internal/action/step"
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/context"
+	"github.com/hectorgimenez/koolo/internal/utils"
+)
+
+const (
+	companionModeLeech = "leech"
+
+	companionAssistDistance = 5
+	companionLeechDistance  = 20
+	// Monsters closer than this make a leeching follower run back to the leader
+	companionLeechDangerRadius = 8
+	companionAssistRadius      = 15
+	// The leader is considered gone when it didn't publish its location for this long
+	companionLeaderTimeout = 2 * time.Minute
+	companionTickInterval  = 500
+)
+
+// Companion keeps a follower next to its leader for the whole game, assisting it in combat or leeching experience
+// from a safe distance. It ends when the leader finishes the game.
+type Companion struct {
+	ctx *context.Status
+}
+
+func NewCompanion() *Companion {
+	return &Companion{
+		ctx: context.Get(),
+	}
+}
+
+func (c *Companion) Name() string {
+	return string(config.CompanionRun)
+}
+
+func (c *Companion) CheckConditions(parameters *RunParameters) SequencerResult {
+	return SequencerOk
+}
+
+func (c *Companion) Run(parameters *RunParameters) error {
+	leader := c.ctx.CharacterCfg.Companion.LeaderName
+	if leader == "" {
+		return errors.New("companion follower mode needs the leader name")
+	}
+
+	c.ctx.Logger.Info("Following the companion leader", "leader", leader, "mode", c.ctx.CharacterCfg.Companion.FollowerMode)
+
+	for {
+		c.ctx.PauseIfNotPriority()
+		c.ctx.RefreshGameData()
+
+		// The leader resets the game info once its game is finished
+		if c.ctx.CharacterCfg.Companion.CompanionGameName == "" {
+			c.ctx.Logger.Info("Leader finished the game, leaving")
+			return nil
+		}
+
+		state, found := context.GetCompanionLeaderState(leader)
+		if !found || time.Since(state.UpdatedAt) > companionLeaderTimeout {
+			member, inGame := c.ctx.Data.Roster.FindByName(leader)
+			if !inGame {
+				if found && time.Since(state.UpdatedAt) > companionLeaderTimeout {
+					c.ctx.Logger.Info("Leader is gone, leaving")
+					return nil
+				}
+				action.AntiIdle()
+				utils.Sleep(companionTickInterval)
+				continue
+			}
+			// Leader is not handled by this koolo instance, follow it through the roster
+			state = context.CompanionLeaderState{Area: member.Area, Position: member.Position, UpdatedAt: time.Now()}
+		}
+
+		action.PartyWith(leader)
+
+		if err := c.follow(leader, state); err != nil {
+			c.ctx.Logger.Debug("Failed following the leader", "error", err)
+		}
+
+		utils.Sleep(companionTickInterval)
+	}
+}
+
+func (c *Companion) follow(leader string, state context.CompanionLeaderState) error {
+	myArea := c.ctx.Data.PlayerUnit.Area
+
+	if state.Area != myArea {
+		switch {
+		case state.Area.IsTown() && !myArea.IsTown():
+			return action.ReturnTown()
+		case state.Area.IsTown():
+			// Leader is in another town, wait for its portal
+			action.AntiIdle()
+			return nil
+		case myArea.IsTown():
+			return action.UsePortalFrom(leader)
+		default:
+			return action.MoveToArea(state.Area)
+		}
+	}
+
+	if myArea.IsTown() {
+		action.AntiIdle()
+		return nil
+	}
+
+	if c.ctx.CharacterCfg.Companion.FollowerMode == companionModeLeech {
+		return c.leech(state.Position)
+	}
+
+	return c.assist(state.Position)
+}
+
+// assist fights the monsters around the leader and stays close to it.
+func (c *Companion) assist(leaderPosition data.Position) error {
+	distance := c.followDistance(companionAssistDistance)
+
+	if err := action.ClearAreaAroundPosition(leaderPosition, companionAssistRadius, data.MonsterAnyFilter()); err != nil {
+		return err
+	}
+	action.ItemPickup(companionAssistRadius)
+
+	if c.ctx.PathFinder.DistanceFromMe(leaderPosition) > distance {
+		return action.MoveToCoords(leaderPosition, step.WithDistanceToFinish(distance))
+	}
+
+	return nil
+}
+
+// leech keeps a safe distance to the leader without fighting, close enough to share the experience. Monsters coming
+// close make us run back to the leader.
+func (c *Companion) leech(leaderPosition data.Position) error {
+	distance := c.followDistance(companionLeechDistance)
+
+	for _, m := range c.ctx.Data.Monsters.Enemies() {
+		if c.ctx.PathFinder.DistanceFromMe(m.Position) <= companionLeechDangerRadius {
+			return action.MoveToCoords(leaderPosition, step.WithDistanceToFinish(companionAssistDistance), step.WithIgnoreMonsters())
+		}
+	}
+
+	if c.ctx.PathFinder.DistanceFromMe(leaderPosition) > distance {
+		return action.MoveToCoords(leaderPosition, step.WithDistanceToFinish(distance), step.WithIgnoreMonsters())
+	}
+
+	return nil
+}
+
+func (c *Companion) followDistance(defaultDistance int) int {
+	if d := c.ctx.CharacterCfg.Companion.FollowDistance; d > 0 {
+		return d
+	}
+
+	return defaultDistance
+}
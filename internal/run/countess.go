@@ -96,6 +96,9 @@ func (c Countess) Run(parameters *RunParameters) error {
 			if err = action.ClearCurrentLevel(false, data.MonsterAnyFilter()); err != nil {
 				return err
 			}
+		} else if !c.ctx.Data.CanTeleport() && a != area.ForgottenTower {
+			// Walkers get body blocked on the narrow cellar stairs, clear what is waiting there before moving on
+			action.ClearAreaAroundPlayer(10, data.MonsterAnyFilter())
 		}
 	}
 
@@ -117,10 +120,15 @@ func (c Countess) Run(parameters *RunParameters) error {
 		return err
 	}
 
-	action.ItemPickup(30)
+	// Runes are the reason to run Countess, grab them before anything else
+	action.ItemPickupPrioritized(30, isRune)
 
 	if clearFloors {
 		return action.ClearCurrentLevel(false, data.MonsterAnyFilter())
 	}
 	return nil
 }
+
+func isRune(itm data.Item) bool {
+	return itm.Type().IsType(item.TypeRune)
+}
@@ -117,7 +117,27 @@ func (a Cows) Run(parameters *RunParameters) error {
 		return err
 	}
 
-	return action.ClearCurrentLevelCows(a.ctx.CharacterCfg.Game.Cows.OpenChests, data.MonsterAnyFilter())
+	filter := data.MonsterAnyFilter()
+	if a.ctx.CharacterCfg.Game.Cows.AvoidKing {
+		filter = a.cowKingFilter()
+	}
+
+	return action.ClearCurrentLevelCows(a.ctx.CharacterCfg.Game.Cows.OpenChests, filter)
+}
+
+// cowKingFilter excludes The Cow King, killing him locks the character out of the Cow Level for the difficulty.
+func (a Cows) cowKingFilter() data.MonsterFilter {
+	return func(m data.Monsters) []data.Monster {
+		var filteredMonsters []data.Monster
+		for _, mo := range m {
+			if mo.Name == npc.Cow && mo.Type == data.MonsterTypeSuperUnique {
+				continue
+			}
+			filteredMonsters = append(filteredMonsters, mo)
+		}
+
+		return filteredMonsters
+	}
 }
 
 func (a Cows) getWirtsLeg() error {
@@ -58,8 +58,7 @@ func (t *DevRun) captureCursor() (screenX, screenY, gameX, gameY int) {
 	win.GetCursorPos(&pt)
 	screenX = int(pt.X)
 	screenY = int(pt.Y)
-	gameX = screenX - t.ctx.GameReader.WindowLeftX
-	gameY = screenY - t.ctx.GameReader.WindowTopY
+	gameX, gameY = t.ctx.GameReader.Coordinates().FromWindow(screenX-t.ctx.GameReader.WindowLeftX, screenY-t.ctx.GameReader.WindowTopY)
 	return
 }
 
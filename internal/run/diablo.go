@@ -213,7 +213,7 @@ func (d *Diablo) Run(parameters *RunParameters) error {
 
 	}
 
-	if d.ctx.CharacterCfg.Game.Diablo.KillDiablo {
+	if d.ctx.CharacterCfg.Game.Diablo.KillDiablo || d.ctx.CurrentGame.Rushing {
 
 		// Buff BEFORE setting ClearPathDist to 0, so bot can defend itself during buff
 		action.Buff()
@@ -239,6 +239,7 @@ func (d *Diablo) Run(parameters *RunParameters) error {
 			d.ctx.DisableItemPickup()
 		}
 
+		action.RushCheckpoint("diablo")
 		if err := d.ctx.Char.KillDiablo(); err != nil {
 			return err
 		}
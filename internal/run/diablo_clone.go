@@ -0,0 +1,91 @@
+package run
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/difficulty"
+	"github.com/hectorgimenez/d2go/pkg/data/npc"
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+	"github.com/hectorgimenez/koolo/internal/action"
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/context"
+	"github.com/hectorgimenez/koolo/internal/game"
+)
+
+// DiabloClone hunts Diablo Clone once "Diablo walks the Earth" triggered. The clone replaces the next Super Unique
+// found in the game, so when it wasn't spotted yet we visit Pindleskin, the closest one to a waypoint.
+type DiabloClone struct {
+	ctx *context.Status
+}
+
+func NewDiabloClone() *DiabloClone {
+	return &DiabloClone{
+		ctx: context.Get(),
+	}
+}
+
+func (d DiabloClone) Name() string {
+	return string(config.DiabloCloneRun)
+}
+
+func (d DiabloClone) CheckConditions(parameters *RunParameters) SequencerResult {
+	if IsQuestRun(parameters) || d.ctx.CharacterCfg.Game.Difficulty != difficulty.Hell {
+		return SequencerSkip
+	}
+
+	cfg := d.ctx.CharacterCfg.Game.DiabloClone
+	if _, _, seen := d.ctx.DiabloCloneSighting(); cfg.OnlyWhenSeen && !seen {
+		return SequencerSkip
+	}
+	if err := checkGearRequirements(d.ctx, cfg.MinLife, cfg.CheckResists, cfg.MinResists, false); err != nil {
+		d.ctx.Logger.Warn(fmt.Sprintf("Skipping Diablo Clone, gear requirements not met: %v", err))
+		return SequencerSkip
+	}
+
+	return SequencerOk
+}
+
+func (d DiabloClone) Run(parameters *RunParameters) error {
+	if cloneArea, clonePosition, seen := d.ctx.DiabloCloneSighting(); seen {
+		if d.ctx.Data.PlayerUnit.Area != cloneArea {
+			if err := action.MoveToArea(cloneArea); err != nil {
+				return err
+			}
+		}
+		if err := action.MoveToCoords(clonePosition); err != nil {
+			return err
+		}
+	} else if err := goToPindleskin(d.ctx); err != nil {
+		return err
+	}
+
+	action.DetectDiabloClone()
+	if _, found := action.FindDiabloClone(); !found {
+		if _, _, seen := d.ctx.DiabloCloneSighting(); !seen {
+			d.ctx.Logger.Debug("Diablo Clone not found, Pindleskin wasn't replaced")
+			return nil
+		}
+		return errors.New("diablo clone not found where it was spotted")
+	}
+
+	d.ctx.Logger.Info("Killing Diablo Clone")
+	err := d.ctx.Char.KillMonsterSequence(func(gd game.Data) (data.UnitID, bool) {
+		for _, m := range gd.Monsters.Enemies() {
+			if m.Name == npc.DiabloClone && m.Stats[stat.Life] > 0 {
+				return m.UnitID, true
+			}
+		}
+
+		return 0, false
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	// Annihilus drops from the clone
+	action.ItemPickup(30)
+
+	return nil
+}
@@ -32,8 +32,6 @@ const (
 	thawingMinBuffSeconds = 100
 )
 
-var talTombs = []area.ID{area.TalRashasTomb1, area.TalRashasTomb2, area.TalRashasTomb3, area.TalRashasTomb4, area.TalRashasTomb5, area.TalRashasTomb6, area.TalRashasTomb7}
-
 type Duriel struct {
 	ctx *context.Status
 }
@@ -170,7 +168,7 @@ func (d Duriel) Run(parameters *RunParameters) error {
 	}
 
 	// Find and move to the real Tal Rasha tomb.
-	realTalRashaTomb, err := d.findRealTomb()
+	realTalRashaTomb, err := findTrueTalRashaTomb(d.ctx)
 	if err != nil {
 		return err
 	}
@@ -290,6 +288,7 @@ func (d Duriel) Run(parameters *RunParameters) error {
 	if err != nil {
 		return err
 	}
+	action.RushCheckpoint("duriel")
 
 	d.ctx.RefreshGameData()
 	utils.Sleep(200)
@@ -414,25 +413,6 @@ func (d Duriel) drinkThawingPotions(selfTarget, mercTarget int) (int, int) {
 	return selfCount, mercCount
 }
 
-func (d Duriel) findRealTomb() (area.ID, error) {
-	var realTomb area.ID
-
-	for _, tomb := range talTombs {
-		for _, obj := range d.ctx.Data.Areas[tomb].Objects {
-			if obj.Name == object.HoradricOrifice {
-				realTomb = tomb
-				break
-			}
-		}
-	}
-
-	if realTomb == 0 {
-		return 0, errors.New("failed to find the real Tal Rasha tomb")
-	}
-
-	return realTomb, nil
-}
-
 // Count only free, unlocked inventory cells.
 func (d Duriel) countFreeInventorySlots() int {
 	occupied := [4][10]bool{}
@@ -0,0 +1,37 @@
+package run
+
+import (
+	"fmt"
+
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+	"github.com/hectorgimenez/koolo/internal/context"
+)
+
+// Hell difficulty resistance penalty, the runs gated by gear requirements only exist in Hell
+const hellResistPenalty = 100
+
+// checkGearRequirements returns an error describing the first requirement the character doesn't meet. A zero min life
+// disables the life check, the resists are only checked when checkResists is set.
+func checkGearRequirements(ctx *context.Status, minLife int, checkResists bool, minResists int, requireLifeTap bool) error {
+	if minLife > 0 {
+		maxLife, _ := ctx.Data.PlayerUnit.FindStat(stat.MaxLife, 0)
+		if maxLife.Value < minLife {
+			return fmt.Errorf("max life %d is below the required %d", maxLife.Value, minLife)
+		}
+	}
+
+	if checkResists {
+		for _, id := range []stat.ID{stat.FireResist, stat.ColdResist, stat.LightningResist, stat.PoisonResist} {
+			raw, _ := ctx.Data.PlayerUnit.FindStat(id, 0)
+			if effective := raw.Value - hellResistPenalty; effective < minResists {
+				return fmt.Errorf("%s %d is below the required %d", id, effective, minResists)
+			}
+		}
+	}
+
+	if requireLifeTap && !hasLifeTap(ctx) {
+		return fmt.Errorf("life tap is required but no skill or charges are bound to a key")
+	}
+
+	return nil
+}
@@ -15,32 +15,14 @@ import (
 )
 
 const (
-	mainWeaponSlot    = 0
-	swapWeaponSlot    = 1
+	mainWeaponSlot    = step.MainWeaponSlot
+	swapWeaponSlot    = step.SwapWeaponSlot
 	weaponSlotUnknown = -1
 )
 
 // ensureActiveWeaponSlot swaps until the requested weapon set is active.
 func ensureActiveWeaponSlot(ctx *context.Status, slot int) error {
-	if slot != mainWeaponSlot && slot != swapWeaponSlot {
-		return fmt.Errorf("invalid weapon slot %d", slot)
-	}
-
-	ctx.RefreshGameData()
-	if ctx.Data.ActiveWeaponSlot == slot {
-		return nil
-	}
-
-	for attempt := 0; attempt < 3; attempt++ {
-		ctx.HID.PressKeyBinding(ctx.Data.KeyBindings.SwapWeapons)
-		utils.PingSleep(utils.Light, 150)
-		ctx.RefreshGameData()
-		if ctx.Data.ActiveWeaponSlot == slot {
-			return nil
-		}
-	}
-
-	return fmt.Errorf("failed to switch to weapon slot %d", slot)
+	return step.EnsureWeaponSlot(slot)
 }
 
 func weaponSlotForEquippedItem(itm data.Item) (int, bool) {
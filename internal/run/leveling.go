@@ -36,12 +36,20 @@ func (a Leveling) Run(parameters *RunParameters) error {
 	// Adjust settings based on difficulty
 	a.AdjustDifficultyConfig()
 
+	if err := action.ClaimQuestRewards(); err != nil {
+		a.ctx.Logger.Warn("Failed claiming quest rewards", "error", err)
+	}
+
 	a.GoToCurrentProgressionTown()
 
 	if err := a.AdjustGameDifficulty(); err != nil {
 		return err
 	}
 
+	if err := a.orchestrate(); err != nil {
+		return err
+	}
+
 	if err := a.act1(); err != nil {
 		return err
 	}
@@ -296,6 +304,80 @@ func (a Leveling) GetRunewords() []string {
 	return enabledRunewordRecipes
 }
 
+// requiredLevelForAct returns the character level needed to move on to the given act in the current difficulty,
+// 0 when there is no level requirement.
+func (a Leveling) requiredLevelForAct(act int) int {
+	cfg := a.ctx.CharacterCfg.Game.Leveling
+	orDefault := func(v, def int) int {
+		if v <= 0 {
+			return def
+		}
+		return v
+	}
+
+	switch act {
+	case 3:
+		return orDefault(cfg.Act3RequiredLevel, 24)
+	case 5:
+		switch a.ctx.CharacterCfg.Game.Difficulty {
+		case difficulty.Normal:
+			return orDefault(cfg.NormalAct5RequiredLevel, 30)
+		case difficulty.Nightmare:
+			return orDefault(cfg.NightmareAct5RequiredLevel, 60)
+		}
+	}
+
+	return 0
+}
+
+// minXPPerHour returns the XP/hour threshold configured for the character level, 0 when there is none.
+func (a Leveling) minXPPerHour() int {
+	lvl, _ := a.ctx.Data.PlayerUnit.FindStat(stat.Level, 0)
+
+	threshold, fromLevel := 0, 0
+	for level, xpPerHour := range a.ctx.CharacterCfg.Game.Leveling.MinXPPerHour {
+		if level <= lvl.Value && level >= fromLevel {
+			threshold, fromLevel = xpPerHour, level
+		}
+	}
+
+	return threshold
+}
+
+// farmingRuns leaves out the farming runs whose XP/hour dropped below the threshold for the character level, the
+// remaining ones get the time. When all of them are below it there is nothing better to switch to. Only the item and
+// experience farming of acts 4 and 5 goes through it, the low gold farms and the quest runs always run.
+func (a Leveling) farmingRuns(runs ...Run) []Run {
+	threshold := a.minXPPerHour()
+	if threshold <= 0 {
+		return runs
+	}
+
+	var selected []Run
+	for _, r := range runs {
+		xpPerHour, found := action.RunExperiencePerHour(r.Name())
+		if found && xpPerHour < float64(threshold) {
+			a.ctx.Logger.Info("Skipping farming run below the XP/hour threshold", "run", r.Name(), "xpPerHour", int(xpPerHour), "threshold", threshold)
+			continue
+		}
+		selected = append(selected, r)
+	}
+	if len(selected) == 0 {
+		return runs
+	}
+
+	return selected
+}
+
+// farm executes the farming runs tracking their experience, failed runs don't stop the others.
+func (a Leveling) farm(runs ...Run) {
+	for _, r := range a.farmingRuns(runs...) {
+		if err := action.TrackExperience(r.Name(), func() error { return r.Run(nil) }); err != nil {
+			a.ctx.Logger.Debug("Farming run failed", "run", r.Name(), "error", err)
+		}
+	}
+}
+
 func (a Leveling) ensureDifficultySwitchSettings() {
 	//Values have never been set (or user is dumb), reset to default
 	if a.ctx.CharacterCfg.Game.Leveling.NightmareRequiredLevel <= 1 &&
@@ -48,7 +48,7 @@ func (a Leveling) act2() error {
 	lvl, _ := a.ctx.Data.PlayerUnit.FindStat(stat.Level, 0)
 
 	// Priority 0: Check if Act 2 is fully completed (Seven Tombs quest completed)
-	if a.ctx.Data.Quests[quest.Act2TheSevenTombs].Completed() && lvl.Value >= 24 {
+	if a.ctx.Data.Quests[quest.Act2TheSevenTombs].Completed() && lvl.Value >= a.requiredLevelForAct(3) {
 		a.ctx.Logger.Info("Act 2, The Seven Tombs quest completed. Moving to Act 3.")
 		action.MoveToCoords(data.Position{
 			X: 5195,
@@ -57,7 +57,8 @@ func (a Leveling) act4() error {
 
 	lvl, _ := a.ctx.Data.PlayerUnit.FindStat(stat.Level, 0)
 	_, found := a.ctx.Data.Objects.FindOne(object.LastLastPortal)
-	if !found && a.ctx.Data.Quests[quest.Act4TerrorsEnd].Completed() && ((lvl.Value >= 60 && a.ctx.CharacterCfg.Game.Difficulty == difficulty.Nightmare && effectiveFireRes >= 75 && effectiveLightRes >= 50) || (lvl.Value >= 30 && a.ctx.CharacterCfg.Game.Difficulty == difficulty.Normal)) {
+	act5Level := a.requiredLevelForAct(5)
+	if !found && a.ctx.Data.Quests[quest.Act4TerrorsEnd].Completed() && ((lvl.Value >= act5Level && a.ctx.CharacterCfg.Game.Difficulty == difficulty.Nightmare && effectiveFireRes >= 75 && effectiveLightRes >= 50) || (lvl.Value >= act5Level && a.ctx.CharacterCfg.Game.Difficulty == difficulty.Normal)) {
 		err := action.InteractNPC(npc.Tyrael2)
 		if err != nil {
 			return err // It's good practice to handle errors
@@ -113,9 +114,7 @@ func (a Leveling) act4() error {
 
 		a.ctx.Logger.Info("Under level 90 we assume we must still farm items")
 
-		NewLowerKurastChest().Run(nil)
-		NewMephisto(nil).Run(nil)
-		NewMausoleum().Run(nil)
+		a.farm(NewLowerKurastChest(), NewMephisto(nil), NewMausoleum())
 		err := action.WayPoint(area.ThePandemoniumFortress)
 		if err != nil {
 			return err
@@ -136,7 +135,7 @@ func (a Leveling) act4() error {
 		}
 	}
 
-	if (a.ctx.Data.Quests[quest.Act4TheFallenAngel].Completed() && !a.ctx.Data.Quests[quest.Act4TerrorsEnd].Completed()) || (a.ctx.Data.Quests[quest.Act4TerrorsEnd].Completed() && a.ctx.CharacterCfg.Game.Difficulty == difficulty.Nightmare && (lvl.Value < 60 || effectiveFireRes < 75 || effectiveLightRes < 50)) || (a.ctx.Data.Quests[quest.Act4TerrorsEnd].Completed() && a.ctx.CharacterCfg.Game.Difficulty == difficulty.Normal && lvl.Value < 30) {
+	if (a.ctx.Data.Quests[quest.Act4TheFallenAngel].Completed() && !a.ctx.Data.Quests[quest.Act4TerrorsEnd].Completed()) || (a.ctx.Data.Quests[quest.Act4TerrorsEnd].Completed() && a.ctx.CharacterCfg.Game.Difficulty == difficulty.Nightmare && (lvl.Value < act5Level || effectiveFireRes < 75 || effectiveLightRes < 50)) || (a.ctx.Data.Quests[quest.Act4TerrorsEnd].Completed() && a.ctx.CharacterCfg.Game.Difficulty == difficulty.Normal && lvl.Value < act5Level) {
 		diabloRun := NewDiablo()
 		err := diabloRun.Run(nil)
 		if err != nil {
@@ -66,7 +66,7 @@ func (a Leveling) act5() error {
 	// If we reach this point, it means gold is sufficient, and we skip farming for this run.
 	lvl, _ := a.ctx.Data.PlayerUnit.FindStat(stat.Level, 0)
 
-	if a.ctx.CharacterCfg.Game.Difficulty == difficulty.Nightmare && lvl.Value < 60 || a.ctx.CharacterCfg.Game.Difficulty == difficulty.Normal && lvl.Value < 30 {
+	if a.ctx.CharacterCfg.Game.Difficulty != difficulty.Hell && lvl.Value < a.requiredLevelForAct(5) {
 
 		diabloRun := NewDiablo()
 		err := diabloRun.Run(nil)
@@ -239,9 +239,7 @@ func (a Leveling) act5() error {
 			}
 		}
 
-		NewLowerKurastChest().Run(nil)
-		NewMephisto(nil).Run(nil)
-		NewMausoleum().Run(nil)
+		a.farm(NewLowerKurastChest(), NewMephisto(nil), NewMausoleum())
 		diabloRun := NewDiablo()
 		err := diabloRun.Run(nil)
 		if err != nil {
@@ -0,0 +1,106 @@
+package run
+
+import (
+	"fmt"
+
+	"github.com/hectorgimenez/d2go/pkg/data/quest"
+	"github.com/hectorgimenez/koolo/internal/action"
+)
+
+// levelingQuest is a quest of the leveling progression. The act handlers do the quests needed to move forward, the
+// ones with a backfill run give rewards (skill points, resistances) and are done afterward when the act handler left
+// them behind.
+type levelingQuest struct {
+	act      int
+	quest    quest.Quest
+	name     string
+	backfill func() Run
+}
+
+// levelingQuests are the quests of every act in progression order, the last one of each act opens the next act.
+var levelingQuests = []levelingQuest{
+	{act: 1, quest: quest.Act1DenOfEvil, name: "Den of Evil", backfill: func() Run { return NewDen() }},
+	{act: 1, quest: quest.Act1TheSearchForCain, name: "Search for Cain"},
+	{act: 1, quest: quest.Act1SistersToTheSlaughter, name: "Andariel"},
+	{act: 2, quest: quest.Act2RadamentsLair, name: "Radament", backfill: func() Run { return NewRadament() }},
+	{act: 2, quest: quest.Act2TheHoradricStaff, name: "Horadric Staff (cube, staff and amulet)"},
+	{act: 2, quest: quest.Act2TheSummoner, name: "Summoner"},
+	{act: 2, quest: quest.Act2TheSevenTombs, name: "Duriel"},
+	{act: 3, quest: quest.Act3KhalimsWill, name: "Khalim's Flail"},
+	{act: 3, quest: quest.Act3TheGuardian, name: "Mephisto"},
+	{act: 4, quest: quest.Act4TheFallenAngel, name: "Izual", backfill: func() Run { return NewIzual() }},
+	{act: 4, quest: quest.Act4TerrorsEnd, name: "Diablo"},
+	{act: 5, quest: quest.Act5PrisonOfIce, name: "Anya", backfill: func() Run { return NewAnya() }},
+	{act: 5, quest: quest.Act5RiteOfPassage, name: "Ancients"},
+	{act: 5, quest: quest.Act5EveOfDestruction, name: "Baal"},
+}
+
+// actCompleted tells if the quest opening the next act is done, Baal for the last act.
+func (a Leveling) actCompleted(act int) bool {
+	last := levelingQuest{}
+	for _, q := range levelingQuests {
+		if q.act == act {
+			last = q
+		}
+	}
+
+	return last.name != "" && a.ctx.Data.Quests[last.quest].Completed()
+}
+
+// nextLevelingQuest returns the first quest of the progression not done yet.
+func (a Leveling) nextLevelingQuest() (levelingQuest, bool) {
+	for _, q := range levelingQuests {
+		if !a.ctx.Data.Quests[q.quest].Completed() {
+			return q, true
+		}
+	}
+
+	return levelingQuest{}, false
+}
+
+// orchestrate runs before the act handlers: it logs the progression, does the reward quests left behind in the
+// completed acts and collects the missing waypoints, then goes back to the progression town.
+func (a Leveling) orchestrate() error {
+	if next, found := a.nextLevelingQuest(); found {
+		a.ctx.Logger.Info("Leveling progression",
+			"difficulty", a.ctx.CharacterCfg.Game.Difficulty,
+			"act", next.act,
+			"nextQuest", next.name,
+		)
+	}
+
+	travelled := false
+	if a.ctx.CharacterCfg.Game.Leveling.BackfillQuests {
+		for _, q := range levelingQuests {
+			if q.backfill == nil || !a.actCompleted(q.act) || a.ctx.Data.Quests[q.quest].Completed() {
+				continue
+			}
+
+			r := q.backfill()
+			a.ctx.Logger.Info("Doing the quest left behind", "act", q.act, "quest", q.name)
+			if err := r.Run(nil); err != nil {
+				a.ctx.Logger.Warn(fmt.Sprintf("Failed to do the %s quest: %v", q.name, err))
+			}
+			travelled = true
+		}
+	}
+
+	if a.ctx.CharacterCfg.Game.Leveling.CollectWaypoints {
+		if wps := NewCollectWaypoints(); wps.CheckConditions(nil) == SequencerOk {
+			if err := wps.Run(nil); err != nil {
+				a.ctx.Logger.Warn(fmt.Sprintf("Failed to collect the waypoints: %v", err))
+			}
+			travelled = true
+		}
+	}
+
+	if !travelled {
+		return nil
+	}
+
+	if err := action.ReturnTown(); err != nil {
+		return err
+	}
+
+	return a.GoToCurrentProgressionTown()
+}
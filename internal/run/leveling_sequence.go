@@ -113,6 +113,10 @@ func (ls LevelingSequence) Run(parameters *RunParameters) error {
 		return loadErr
 	}
 
+	if err := action.ClaimQuestRewards(); err != nil {
+		ls.ctx.Logger.Warn("Failed claiming quest rewards", "error", err)
+	}
+
 	ls.GoToCurrentProgressionTown()
 
 	difficultyChanged, difErr := ls.AdjustDifficulty()
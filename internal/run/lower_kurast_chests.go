@@ -88,7 +88,7 @@ func (run LowerKurastChests) Run(parameters *RunParameters) error {
 		// Find the interactable objects
 		var objects []data.Object
 		for _, o := range run.ctx.Data.Objects {
-			if slices.Contains(interactableObjects, o.Name) && isChestWithinBonfireRange(o, bonfirePos) {
+			if slices.Contains(interactableObjects, o.Name) && isChestWithinBonfireRange(o, bonfirePos) && action.CanOpenChest(o) {
 				objects = append(objects, o)
 			}
 		}
@@ -49,7 +49,7 @@ func (m Mephisto) Name() string {
 
 func (m Mephisto) CheckConditions(parameters *RunParameters) SequencerResult {
 	if IsFarmingRun(parameters) {
-		if !m.ctx.Data.Quests[quest.Act3TheGuardian].Completed() {
+		if !m.ctx.Data.Quests[quest.Act3TheGuardian].Completed() && !action.QuestKeptOpen(quest.Act3TheGuardian) {
 			return SequencerSkip
 		}
 		return SequencerOk
@@ -121,6 +121,7 @@ func (m Mephisto) Run(parameters *RunParameters) error {
 	m.ctx.DisableItemPickup()
 
 	// Kill Mephisto
+	action.RushCheckpoint("mephisto")
 	err = m.ctx.Char.KillMephisto()
 
 	// Enable item pickup after the fight
@@ -138,7 +139,8 @@ func (m Mephisto) Run(parameters *RunParameters) error {
 		}
 	}
 
-	if IsQuestRun(parameters) || m.ctx.CharacterCfg.Game.Mephisto.ExitToA4 {
+	// Entering Act 4 completes the quest
+	if (IsQuestRun(parameters) || m.ctx.CharacterCfg.Game.Mephisto.ExitToA4) && !action.QuestKeptOpen(quest.Act3TheGuardian) {
 
 		_, isLevelingChar := m.ctx.Char.(context.LevelingCharacter)
 		if isLevelingChar {
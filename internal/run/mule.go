@@ -10,6 +10,7 @@ import (
 	"github.com/hectorgimenez/koolo/internal/config"
 	"github.com/hectorgimenez/koolo/internal/context"
 	"github.com/hectorgimenez/koolo/internal/game"
+	"github.com/hectorgimenez/koolo/internal/mule"
 	"github.com/hectorgimenez/koolo/internal/ui"
 	"github.com/hectorgimenez/koolo/internal/utils"
 )
@@ -78,6 +79,7 @@ func (m Mule) Run(parameters *RunParameters) error {
 		ctx.RestartWithCharacter = returnToChar
 		ctx.CleanStopRequested = true
 		ctx.StopSupervisor()
+		recordHoldings(ctx, returnToChar)
 		return err
 	}
 
@@ -170,6 +172,9 @@ func (m Mule) Run(parameters *RunParameters) error {
 		}
 	}
 
+	recordHoldings(ctx, returnToChar)
+	action.ReportStashUpdated()
+
 	ctx.Logger.Info("Preparing to switch character",
 		"from", ctx.Name,
 		"to", ctx.CurrentGame.SwitchToCharacter)
@@ -270,6 +275,14 @@ func findInventorySpace(ctx *context.Status, itm data.Item) (data.Position, bool
 	return data.Position{}, false
 }
 
+// recordHoldings saves what the mule holds, every way out of the run records it so the holdings don't go stale.
+func recordHoldings(ctx *context.Status, returnToChar string) {
+	ctx.RefreshGameData()
+	if err := mule.RecordHoldings(ctx.Name, returnToChar, isPrivateStashFull(ctx), ctx.Data.Inventory.ByLocation(item.LocationStash)); err != nil {
+		ctx.Logger.Warn("Failed to record the mule holdings", "error", err)
+	}
+}
+
 // isPrivateStashFull checks if the personal stash has any 2x2 free space.
 // This is a simple heuristic to determine if the stash is "full".
 func isPrivateStashFull(ctx *context.Status) bool {
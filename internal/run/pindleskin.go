@@ -49,6 +49,21 @@ func (p Pindleskin) CheckConditions(parameters *RunParameters) SequencerResult {
 }
 
 func (p Pindleskin) Run(parameters *RunParameters) error {
+	if err := goToPindleskin(p.ctx); err != nil {
+		return err
+	}
+
+	if err := p.ctx.Char.KillPindle(); err != nil {
+		return err
+	}
+
+	action.ItemPickup(30)
+
+	return nil
+}
+
+// goToPindleskin takes the red portal in Harrogath and walks to the safe spot in front of Pindleskin.
+func goToPindleskin(ctx *context.Status) error {
 	err := action.WayPoint(area.Harrogath)
 	if err != nil {
 		return err
@@ -56,21 +71,21 @@ func (p Pindleskin) Run(parameters *RunParameters) error {
 
 	_ = action.MoveToCoords(fixedPlaceNearRedPortal)
 
-	redPortal, found := p.ctx.Data.Objects.FindOne(object.PermanentTownPortal)
+	redPortal, found := ctx.Data.Objects.FindOne(object.PermanentTownPortal)
 	if !found {
 		if err := action.InteractNPC(npc.Drehya); err != nil {
 			return err
 		}
 		step.CloseAllMenus()
-		p.ctx.RefreshGameData()
-		redPortal, found = p.ctx.Data.Objects.FindOne(object.PermanentTownPortal)
+		ctx.RefreshGameData()
+		redPortal, found = ctx.Data.Objects.FindOne(object.PermanentTownPortal)
 		if !found {
 			return errors.New("red portal not found after talking to anya")
 		}
 	}
 
 	err = action.InteractObject(redPortal, func() bool {
-		return p.ctx.Data.AreaData.Area == area.NihlathaksTemple && p.ctx.Data.AreaData.IsInside(p.ctx.Data.PlayerUnit.Position)
+		return ctx.Data.AreaData.Area == area.NihlathaksTemple && ctx.Data.AreaData.IsInside(ctx.Data.PlayerUnit.Position)
 	})
 	if err != nil {
 		return err
@@ -78,11 +93,5 @@ func (p Pindleskin) Run(parameters *RunParameters) error {
 
 	_ = action.MoveToCoords(pindleSafePosition)
 
-	if err := p.ctx.Char.KillPindle(); err != nil {
-		return err
-	}
-
-	action.ItemPickup(30)
-
 	return nil
 }
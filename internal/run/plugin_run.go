@@ -0,0 +1,30 @@
+package run
+
+import (
+	"github.com/hectorgimenez/koolo/internal/plugin"
+)
+
+// PluginRun runs a run added by a plugin.
+type PluginRun struct {
+	run plugin.Run
+}
+
+func NewPluginRun(r plugin.Run) *PluginRun {
+	return &PluginRun{run: r}
+}
+
+func (p PluginRun) Name() string {
+	return p.run.Name()
+}
+
+func (p PluginRun) CheckConditions(parameters *RunParameters) SequencerResult {
+	if c, ok := p.run.(plugin.ConditionalRun); ok && c.Skip(plugin.NewAPI()) {
+		return SequencerSkip
+	}
+
+	return SequencerOk
+}
+
+func (p PluginRun) Run(parameters *RunParameters) error {
+	return p.run.Run(plugin.NewAPI())
+}
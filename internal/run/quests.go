@@ -82,6 +82,11 @@ func (a Quests) Run(parameters *RunParameters) error {
 		a.killAncientsQuest()
 	}
 
+	// Rewards of the quests done above or in earlier games, like Anya's resistance scroll and personalization
+	if err := action.ClaimQuestRewards(); err != nil {
+		a.ctx.Logger.Warn("Failed claiming quest rewards", "error", err)
+	}
+
 	return nil
 }
 
@@ -729,6 +734,10 @@ func (a Quests) killShenkQuest() error {
 		return err
 	}
 
+	if action.QuestKeptOpen(quest.Act5SiegeOnHarrogath) {
+		return nil
+	}
+
 	err = action.InteractNPC(npc.Larzuk)
 	if err != nil {
 		return err
@@ -2,6 +2,8 @@ package run
 
 import (
 	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/context"
+	"github.com/hectorgimenez/koolo/internal/plugin"
 )
 
 type SequencerResult int8
@@ -30,9 +32,9 @@ type TownRoutineSkipper interface {
 }
 
 func BuildRuns(cfg *config.CharacterCfg, runs []string) (builtRuns []Run) {
-	//if cfg.Companion.Enabled && !cfg.Companion.Leader {
-	//	return []Run{Companion{baseRun: baseRun}}
-	//}
+	if cfg.Companion.Enabled && !cfg.Companion.Leader && cfg.Companion.FollowerMode != "" {
+		return []Run{NewCompanion()}
+	}
 
 	for _, run := range cfg.Game.Runs {
 		// Prepend terror zone runs, we want to run it always first
@@ -49,7 +51,15 @@ func BuildRuns(cfg *config.CharacterCfg, runs []string) (builtRuns []Run) {
 		}
 	}
 
+	if cfg.Game.TerrorZone.PrioritizeMatchingRuns {
+		runs = prioritizeTerrorizedRuns(runs, context.Get().Data.TerrorZones)
+	}
+
 	for _, run := range runs {
+		if met, reason := runConditionsMet(context.Get(), cfg, run); !met {
+			context.Get().Logger.Info("Run conditions not met, skipping run", "run", run, "reason", reason)
+			continue
+		}
 		if runInterface := BuildRun(run); runInterface != nil {
 			builtRuns = append(builtRuns, runInterface)
 		}
@@ -183,6 +193,12 @@ func BuildRun(run string) Run {
 		return NewFrozenAuraMerc()
 	case string(config.TristramEarlyGoldfarmRun):
 		return NewTristramEarlyGoldfarm()
+	case string(config.UberKeysRun):
+		return NewUberKeys()
+	case string(config.DiabloCloneRun):
+		return NewDiabloClone()
+	case string(config.CollectWaypointsRun):
+		return NewCollectWaypoints()
 	case string(config.OrgansRun):
 		return NewOrgans()
 	case string(config.PandemoniumRun):
@@ -193,11 +209,17 @@ func BuildRun(run string) Run {
 		return NewUberDuriel()
 	case string(config.LilithRun):
 		return NewLilith()
+	case string(config.RushRun):
+		return NewRush()
 	// Development / Utility runs
 	case string(config.DevelopmentRun):
 		return NewDevRun()
 	}
 
+	if p, found := plugin.BuildRun(run); found {
+		return NewPluginRun(p)
+	}
+
 	return nil
 }
 
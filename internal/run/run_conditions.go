@@ -0,0 +1,73 @@
+package run
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hectorgimenez/d2go/pkg/data/item"
+	"github.com/hectorgimenez/d2go/pkg/data/skill"
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/context"
+)
+
+// runConditionsMet checks the configured conditions of the run against the current character, returning the reason
+// when they are not met. Runs without conditions are always allowed.
+func runConditionsMet(ctx *context.Status, cfg *config.CharacterCfg, run string) (bool, string) {
+	cond, found := cfg.Game.RunConditions[config.Run(run)]
+	if !found {
+		return true, ""
+	}
+
+	lvl, _ := ctx.Data.PlayerUnit.FindStat(stat.Level, 0)
+	if cond.MinLevel > 0 && lvl.Value < cond.MinLevel {
+		return false, fmt.Sprintf("level %d below %d", lvl.Value, cond.MinLevel)
+	}
+	if cond.MaxLevel > 0 && lvl.Value > cond.MaxLevel {
+		return false, fmt.Sprintf("level %d above %d", lvl.Value, cond.MaxLevel)
+	}
+
+	if len(cond.Difficulties) > 0 && !slices.Contains(cond.Difficulties, cfg.Game.Difficulty) {
+		return false, fmt.Sprintf("difficulty %s not allowed", cfg.Game.Difficulty)
+	}
+
+	if cond.RequiredSkill != "" && !hasSkillNamed(ctx, cond.RequiredSkill) {
+		return false, fmt.Sprintf("skill %s not learned", cond.RequiredSkill)
+	}
+
+	for _, name := range cond.RequiredEquipped {
+		if !hasEquippedNamed(ctx, name) {
+			return false, fmt.Sprintf("%s not equipped", name)
+		}
+	}
+
+	return true, ""
+}
+
+// hasSkillNamed accepts both the skill key (e.g. "blizzard") and the display name (e.g. "Blizzard").
+func hasSkillNamed(ctx *context.Status, name string) bool {
+	for id, points := range ctx.Data.PlayerUnit.Skills {
+		if points.Level == 0 {
+			continue
+		}
+		if strings.EqualFold(skill.SkillNames[id], name) || strings.EqualFold(skill.Skills[id].Name, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasEquippedNamed matches the base name, the unique/set name or the runeword name of the equipped items.
+func hasEquippedNamed(ctx *context.Status, name string) bool {
+	for _, itm := range ctx.Data.Inventory.ByLocation(item.LocationEquipped) {
+		if strings.EqualFold(string(itm.Name), name) ||
+			strings.EqualFold(itm.IdentifiedName, name) ||
+			(itm.IsRuneword && strings.EqualFold(string(itm.RunewordName), name)) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,144 @@
+package run
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/area"
+	"github.com/hectorgimenez/d2go/pkg/data/npc"
+	"github.com/hectorgimenez/d2go/pkg/data/object"
+	"github.com/hectorgimenez/koolo/internal/action"
+	"github.com/hectorgimenez/koolo/internal/action/step"
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/context"
+	"github.com/hectorgimenez/koolo/internal/game"
+	"github.com/hectorgimenez/koolo/internal/utils"
+)
+
+// rushQuests are the quests the rush can go through, in act order.
+var rushQuests = []string{"andariel", "summoner", "duriel", "travincal", "mephisto", "hellforge", "diablo", "baal"}
+
+// Rush takes a second character through the quests: for each boss it opens a portal, waits for the rushee and kills
+// the boss while the rushee is around.
+type Rush struct {
+	ctx *context.Status
+}
+
+func NewRush() *Rush {
+	return &Rush{
+		ctx: context.Get(),
+	}
+}
+
+func (r *Rush) Name() string {
+	return string(config.RushRun)
+}
+
+func (r *Rush) CheckConditions(parameters *RunParameters) SequencerResult {
+	return SequencerOk
+}
+
+func (r *Rush) Run(parameters *RunParameters) error {
+	rush := r.ctx.CharacterCfg.Game.Rush
+	if rush.Rushee == "" {
+		return errors.New("rush needs the rushee character name")
+	}
+
+	quests := rush.Quests
+	if len(quests) == 0 {
+		quests = rushQuests
+	}
+
+	action.PartyWith(rush.Rushee)
+	r.ctx.CurrentGame.Rushing = true
+	defer func() {
+		r.ctx.CurrentGame.Rushing = false
+	}()
+
+	for _, quest := range rushQuests {
+		if !slices.Contains(quests, quest) {
+			continue
+		}
+
+		r.ctx.Logger.Info("Rushing quest", "quest", quest, "rushee", rush.Rushee)
+		if err := r.rushQuest(quest); err != nil {
+			return fmt.Errorf("rushing %s: %w", quest, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *Rush) rushQuest(quest string) error {
+	switch quest {
+	case "andariel":
+		return NewAndariel().Run(nil)
+	case "summoner":
+		return NewSummoner().Run(nil)
+	case "duriel":
+		return NewDuriel().Run(nil)
+	case "travincal":
+		return NewTravincal().Run(nil)
+	case "mephisto":
+		return NewMephisto(nil).Run(nil)
+	case "hellforge":
+		return r.hellforge()
+	case "diablo":
+		return NewDiablo().Run(nil)
+	case "baal":
+		return NewBaal(nil).Run(nil)
+	}
+
+	return fmt.Errorf("unknown rush quest %s", quest)
+}
+
+// hellforge kills Hephasto and keeps the forge safe while the rushee smashes the soulstone with the hammer.
+func (r *Rush) hellforge() error {
+	if err := action.WayPoint(area.RiverOfFlame); err != nil {
+		return err
+	}
+
+	hellforge, found := r.ctx.Data.Objects.FindOne(object.HellForge)
+	if !found {
+		return errors.New("couldn't find hellforge")
+	}
+
+	if err := action.MoveToCoords(hellforge.Position, step.WithDistanceToFinish(20)); err != nil {
+		return err
+	}
+
+	action.RushCheckpoint("hellforge")
+
+	if hephasto, found := r.ctx.Data.Monsters.FindOne(npc.Hephasto, data.MonsterTypeNone); found {
+		r.ctx.Char.KillMonsterSequence(func(d game.Data) (data.UnitID, bool) {
+			return hephasto.UnitID, true
+		}, nil)
+	}
+	action.ClearAreaAroundPlayer(40, data.MonsterAnyFilter())
+
+	// The rushee picks up the hammer and smashes the soulstone, we stay around until it leaves the area
+	waitSeconds := max(r.ctx.CharacterCfg.Game.Rush.WaitSeconds, 60)
+	deadline := time.Now().Add(time.Duration(waitSeconds) * time.Second)
+	arrived := false
+	for time.Now().Before(deadline) {
+		r.ctx.PauseIfNotPriority()
+		r.ctx.RefreshGameData()
+		if action.RusheeInArea() {
+			arrived = true
+		} else if arrived {
+			return nil
+		}
+
+		action.ClearAreaAroundPlayer(30, data.MonsterAnyFilter())
+		utils.Sleep(1000)
+	}
+
+	if !arrived {
+		return errors.New("the rushee never came to the hellforge")
+	}
+
+	return nil
+}
@@ -82,6 +82,10 @@ func (s Shenk) Run(parameters *RunParameters) error {
 		return err
 	}
 
+	if action.QuestKeptOpen(quest.Act5SiegeOnHarrogath) {
+		return nil
+	}
+
 	err = action.InteractNPC(npc.Larzuk)
 	if err != nil {
 		return err
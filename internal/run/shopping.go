@@ -20,6 +20,9 @@ func (r Shopping) CheckConditions(parameters *RunParameters) SequencerResult {
 	if !shop.Enabled || len(shop.SelectedVendors()) == 0 {
 		return SequencerSkip
 	}
+	if shop.ShopUntilFound && ctx.CharacterCfg.Runtime.ShoppingFound {
+		return SequencerSkip
+	}
 	return SequencerOk
 }
 
@@ -35,5 +38,16 @@ func (r Shopping) Run(parameters *RunParameters) error {
 	)
 
 	// Delegate to action layer; it adapts the config internally
-	return action.RunShoppingFromConfig(&shop)
+	purchased, err := action.RunShoppingFromConfig(&shop)
+	if err != nil {
+		return err
+	}
+
+	// Without a match the next games refresh the vendors again, the run stops once something was bought
+	if shop.ShopUntilFound && purchased > 0 {
+		ctx.Logger.Info("Shopping item found, the shopping run is done", slog.Int("items", purchased))
+		ctx.CharacterCfg.Runtime.ShoppingFound = true
+	}
+
+	return nil
 }
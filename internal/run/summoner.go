@@ -154,12 +154,28 @@ func (s Summoner) runStandard(parameters *RunParameters) error {
 		return errors.New("failed to find the Summoner")
 	}
 
+	summonerPos := summonerNPC.Positions[0]
+
+	// Walkers take the teleportation pads to reach the Summoner platform
+	if !s.ctx.Data.CanTeleport() {
+		if err := crossArcanePlatforms(s.ctx, summonerPos); err != nil {
+			return err
+		}
+	}
+
+	if s.ctx.CharacterCfg.Game.Summoner.ClearPath {
+		if err := action.ClearThroughPath(summonerPos, arcaneClearRadius, skipVoidGhostsFilter(s.ctx)); err != nil {
+			s.ctx.Logger.Debug("Failed clearing the path to the Summoner", "error", err)
+		}
+	}
+
 	// Move to the Summoner's position using the static coordinates from map data
-	if err := action.MoveToCoords(summonerNPC.Positions[0]); err != nil {
+	if err := action.MoveToCoords(summonerPos); err != nil {
 		return err
 	}
 
 	// Kill Summoner
+	action.RushCheckpoint("summoner")
 	if err := s.ctx.Char.KillSummoner(); err != nil {
 		return err
 	}
@@ -1,6 +1,8 @@
 package run
 
 import (
+	"errors"
+
 	"github.com/hectorgimenez/d2go/pkg/data"
 	"github.com/hectorgimenez/d2go/pkg/data/area"
 	"github.com/hectorgimenez/d2go/pkg/data/object"
@@ -45,11 +47,39 @@ var talRashaTombs = []area.ID{
 	area.TalRashasTomb7,
 }
 
+// findTrueTalRashaTomb returns the tomb holding the Horadric Orifice (Duriel's Lair entrance) from the map data.
+func findTrueTalRashaTomb(ctx *context.Status) (area.ID, error) {
+	for _, tomb := range talRashaTombs {
+		for _, obj := range ctx.Data.Areas[tomb].Objects {
+			if obj.Name == object.HoradricOrifice {
+				return tomb, nil
+			}
+		}
+	}
+
+	return 0, errors.New("failed to find the real Tal Rasha tomb")
+}
+
 func (a TalRashaTombs) Run(parameters *RunParameters) error {
+	filter := data.MonsterAnyFilter()
+	if a.ctx.CharacterCfg.Game.TalRashaTombs.OnlyElites {
+		filter = data.MonsterEliteFilter()
+	}
+
+	trueTomb, err := findTrueTalRashaTomb(a.ctx)
+	if err != nil {
+		a.ctx.Logger.Warn("Tal Rasha Tombs run: true tomb not found, checking every tomb", "error", err)
+	}
+
 	// Iterate over all Tal Rasha Tombs.
 	for _, tomb := range talRashaTombs {
+		if tomb == trueTomb && a.ctx.CharacterCfg.Game.TalRashaTombs.SkipTrueTomb {
+			a.ctx.Logger.Debug("Tal Rasha Tombs run: skipping the true tomb", "area", tomb.Area().Name)
+			continue
+		}
+
 		// Travel to the Canyon of the Magi waypoint.
-		err := action.WayPoint(area.CanyonOfTheMagi)
+		err = action.WayPoint(area.CanyonOfTheMagi)
 		if err != nil {
 			return err
 		}
@@ -65,10 +95,14 @@ func (a TalRashaTombs) Run(parameters *RunParameters) error {
 		// Buff before we start
 		action.Buff()
 
-		// Find the Duriel tomb (orifice) or regular tomb (sparkly chest) special room.
+		// The true tomb special room holds the orifice, the wrong tombs one holds the sparkly chest.
+		specialObject := object.SparklyChest
+		if tomb == trueTomb || trueTomb == 0 {
+			specialObject = object.HoradricOrifice
+		}
 		findSpecialRoom := func() data.Object {
 			for _, obj := range a.ctx.Data.Objects {
-				if obj.Name == object.HoradricOrifice || obj.Name == object.SparklyChest {
+				if obj.Name == specialObject || (trueTomb == 0 && obj.Name == object.SparklyChest) {
 					return obj
 				}
 			}
@@ -78,14 +112,14 @@ func (a TalRashaTombs) Run(parameters *RunParameters) error {
 
 		// If we can teleport, clear the full level first to maximize coverage.
 		if a.ctx.Data.CanTeleport() {
-			if err = action.ClearCurrentLevel(true, data.MonsterAnyFilter()); err != nil {
+			if err = action.ClearCurrentLevel(true, filter); err != nil {
 				return err
 			}
 		} else {
 			if targetObject.Name == 0 {
 				// Clear the tomb until finding the special room.
 				a.ctx.Logger.Warn("Tal Rasha Tombs run: special room not found, exploring tomb")
-				if err = action.ClearCurrentLevelEx(true, data.MonsterAnyFilter(), func() bool {
+				if err = action.ClearCurrentLevelEx(true, filter, func() bool {
 					targetObject = findSpecialRoom()
 					if targetObject.Name != 0 {
 						a.ctx.Logger.Warn("Tal Rasha Tombs run: special room found during exploration")
@@ -105,7 +139,7 @@ func (a TalRashaTombs) Run(parameters *RunParameters) error {
 				if err := action.MoveToCoords(targetObject.Position); err != nil {
 					return err
 				}
-				if err := action.ClearAreaAroundPosition(targetObject.Position, 20, data.MonsterAnyFilter()); err != nil {
+				if err := action.ClearAreaAroundPosition(targetObject.Position, 20, filter); err != nil {
 					return err
 				}
 				if targetObject.Name == object.SparklyChest && targetObject.Selectable {
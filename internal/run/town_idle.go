@@ -0,0 +1,42 @@
+package run
+
+import (
+	"github.com/hectorgimenez/koolo/internal/action"
+	"github.com/hectorgimenez/koolo/internal/context"
+	"github.com/hectorgimenez/koolo/internal/utils"
+)
+
+// TownIdle replaces the runs when the game version is not supported: the character stays in town without fighting
+// or trading until the game ends, the memory offsets may be broken.
+type TownIdle struct {
+	ctx *context.Status
+}
+
+func NewTownIdle() *TownIdle {
+	return &TownIdle{
+		ctx: context.Get(),
+	}
+}
+
+func (t TownIdle) Name() string {
+	return "town_idle"
+}
+
+func (t TownIdle) SkipTownRoutines() bool {
+	return true
+}
+
+func (t TownIdle) CheckConditions(parameters *RunParameters) SequencerResult {
+	return SequencerOk
+}
+
+func (t TownIdle) Run(parameters *RunParameters) error {
+	t.ctx.Logger.Warn("Game version not supported, idling in town until the game ends")
+
+	for {
+		t.ctx.PauseIfNotPriority()
+		t.ctx.RefreshGameData()
+		action.AntiIdle()
+		utils.Sleep(1000)
+	}
+}
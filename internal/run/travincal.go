@@ -31,7 +31,7 @@ func (t *Travincal) Name() string {
 func (t *Travincal) CheckConditions(parameters *RunParameters) SequencerResult {
 	farmingRun := IsFarmingRun(parameters)
 	questCompleted := t.ctx.Data.Quests[quest.Act3TheBlackenedTemple].Completed() && t.ctx.Data.Quests[quest.Act3KhalimsWill].Completed()
-	if (farmingRun && !questCompleted) || (!farmingRun && questCompleted) {
+	if (farmingRun && !questCompleted && !action.QuestKeptOpen(quest.Act3TheBlackenedTemple)) || (!farmingRun && questCompleted) {
 		return SequencerSkip
 	}
 	return SequencerOk
@@ -69,6 +69,7 @@ func (t *Travincal) Run(parameters *RunParameters) error {
 		return err
 	}
 
+	action.RushCheckpoint("travincal")
 	if err := t.ctx.Char.KillCouncil(); err != nil {
 		return err
 	}
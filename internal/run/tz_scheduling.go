@@ -0,0 +1,66 @@
+package run
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/hectorgimenez/d2go/pkg/data/area"
+	"github.com/hectorgimenez/koolo/internal/config"
+)
+
+// runAreas maps the farming runs to the areas they clear, used to know which runs benefit from the active terror zone.
+var runAreas = map[config.Run][]area.ID{
+	config.CountessRun:       {area.ForgottenTower, area.TowerCellarLevel1, area.TowerCellarLevel2, area.TowerCellarLevel3, area.TowerCellarLevel4, area.TowerCellarLevel5},
+	config.AndarielRun:       {area.CatacombsLevel1, area.CatacombsLevel2, area.CatacombsLevel3, area.CatacombsLevel4},
+	config.TristramRun:       {area.Tristram},
+	config.CowsRun:           {area.MooMooFarm},
+	config.PitRun:            {area.PitLevel1, area.PitLevel2},
+	config.MausoleumRun:      {area.BurialGrounds, area.Crypt, area.Mausoleum},
+	config.JailRun:           {area.JailLevel1, area.JailLevel2, area.JailLevel3},
+	config.CaveRun:           {area.CaveLevel1, area.CaveLevel2},
+	config.StonyTombRun:      {area.StonyTombLevel1, area.StonyTombLevel2},
+	config.AncientTunnelsRun: {area.AncientTunnels},
+	config.SummonerRun:       {area.ArcaneSanctuary},
+	config.TalRashaTombsRun:  {area.TalRashasTomb1, area.TalRashasTomb2, area.TalRashasTomb3, area.TalRashasTomb4, area.TalRashasTomb5, area.TalRashasTomb6, area.TalRashasTomb7},
+	config.DurielRun:         {area.DurielsLair},
+	config.ArachnidLairRun:   {area.SpiderForest, area.SpiderCavern},
+	config.SpiderCavernRun:   {area.SpiderForest, area.SpiderCavern},
+	config.FlayerJungleRun:   {area.FlayerJungle, area.FlayerDungeonLevel1, area.FlayerDungeonLevel2, area.FlayerDungeonLevel3},
+	config.LowerKurastRun:    {area.LowerKurast},
+	config.KurastTemplesRun:  {area.KurastBazaar, area.UpperKurast, area.RuinedTemple, area.DisusedFane, area.ForgottenTemple, area.ForgottenReliquary},
+	config.TravincalRun:      {area.Travincal},
+	config.MephistoRun:       {area.DuranceOfHateLevel1, area.DuranceOfHateLevel2, area.DuranceOfHateLevel3},
+	config.RiverOfFlameRun:   {area.RiverOfFlame, area.CityOfTheDamned},
+	config.DiabloRun:         {area.ChaosSanctuary},
+	config.PindleskinRun:     {area.NihlathaksTemple},
+	config.NihlathakRun:      {area.HallsOfAnguish, area.HallsOfPain, area.HallsOfVaught},
+	config.EldritchRun:       {area.FrigidHighlands},
+	config.DrifterCavernRun:  {area.GlacialTrail, area.DrifterCavern},
+	config.BaalRun:           {area.TheWorldStoneKeepLevel1, area.TheWorldStoneKeepLevel2, area.TheWorldStoneKeepLevel3, area.ThroneOfDestruction, area.TheWorldstoneChamber},
+}
+
+// IsTerrorizedRun returns true if the run clears any of the given terror zones.
+func IsTerrorizedRun(run string, terrorZones []area.ID) bool {
+	for _, a := range runAreas[config.Run(run)] {
+		if slices.Contains(terrorZones, a) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// prioritizeTerrorizedRuns moves the runs matching the active terror zones to the front, keeping the configured order
+// otherwise. Without active terror zones the rotation is left untouched.
+func prioritizeTerrorizedRuns(runs []string, terrorZones []area.ID) []string {
+	if len(terrorZones) == 0 {
+		return runs
+	}
+
+	sorted := slices.Clone(runs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return IsTerrorizedRun(sorted[i], terrorZones) && !IsTerrorizedRun(sorted[j], terrorZones)
+	})
+
+	return sorted
+}
@@ -0,0 +1,40 @@
+package run
+
+import (
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/skill"
+	"github.com/hectorgimenez/d2go/pkg/data/state"
+	"github.com/hectorgimenez/koolo/internal/action/step"
+	"github.com/hectorgimenez/koolo/internal/context"
+	"github.com/hectorgimenez/koolo/internal/utils"
+)
+
+func hasLifeTap(ctx *context.Status) bool {
+	sk, found := ctx.Data.PlayerUnit.Skills[skill.LifeTap]
+	if !found || (sk.Level == 0 && sk.Charges == 0) {
+		return false
+	}
+
+	_, bound := ctx.Data.KeyBindings.KeyBindingForSkill(skill.LifeTap)
+	return bound
+}
+
+// checkUberGear returns an error describing the first gear requirement the character doesn't meet, ubers kill
+// undergeared characters in seconds so we'd rather skip the run.
+func checkUberGear(ctx *context.Status) error {
+	cfg := ctx.CharacterCfg.Game.Ubers
+	return checkGearRequirements(ctx, cfg.MinLife, cfg.CheckResists, cfg.MinResists, cfg.RequireLifeTap)
+}
+
+// castLifeTap curses the uber boss with Life Tap when available, it's what keeps most builds alive against Uber
+// Mephisto and Uber Baal.
+func castLifeTap(ctx *context.Status, boss data.Monster) {
+	if !hasLifeTap(ctx) || boss.States.HasState(state.Lifetap) {
+		return
+	}
+
+	if step.CastAtPosition(skill.LifeTap, false, boss.Position) {
+		ctx.Logger.Debug("Life Tap casted on uber boss", "boss", boss.Name)
+		utils.Sleep(200)
+	}
+}
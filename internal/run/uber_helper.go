@@ -353,23 +353,23 @@ func checkForRejuv(ctx *context.Status) error {
 	return nil
 }
 
-func hasKeys(ctx *context.Status) bool {
-	terrorCount := 0
-	destructionCount := 0
-	hateCount := 0
-
+// countUberKeys returns the stashed keys of every type, by item name.
+func countUberKeys(ctx *context.Status) map[string]int {
+	counts := make(map[string]int)
 	for _, itm := range ctx.Data.Inventory.ByLocation(item.LocationStash, item.LocationSharedStash) {
 		switch string(itm.Name) {
-		case "KeyOfTerror":
-			terrorCount++
-		case "KeyOfDestruction":
-			destructionCount++
-		case "KeyOfHate":
-			hateCount++
+		case "KeyOfTerror", "KeyOfDestruction", "KeyOfHate":
+			counts[string(itm.Name)]++
 		}
 	}
 
-	return terrorCount >= 3 && destructionCount >= 3 && hateCount >= 3
+	return counts
+}
+
+func hasKeys(ctx *context.Status) bool {
+	counts := countUberKeys(ctx)
+
+	return counts["KeyOfTerror"] >= uberKeysPerType && counts["KeyOfDestruction"] >= uberKeysPerType && counts["KeyOfHate"] >= uberKeysPerType
 }
 
 func getKeySet(ctx *context.Status) ([]data.Item, error) {
@@ -556,7 +556,7 @@ func findTorchInInventory(ctx *context.Status, excludeUnitID data.UnitID) (data.
 }
 
 func stashToSharedStash(ctx *context.Status, itm data.Item) (int, error) {
-	for tab := 2; tab <= 4; tab++ {
+	for tab := 2; tab <= ctx.CharacterCfg.Flavor().StashTabs(); tab++ {
 		action.SwitchStashTab(tab)
 		utils.Sleep(300)
 		ctx.RefreshGameData()
@@ -580,7 +580,26 @@ func stashToSharedStash(ctx *context.Status, itm data.Item) (int, error) {
 	return 0, fmt.Errorf("failed to stash item - all shared stash tabs may be full")
 }
 
+// restoreFromSharedStash takes the item back from the tab it was stashed to. The other shared tabs and stash pages are
+// searched when it's not there anymore, the pages of an infinite stash can shift.
 func restoreFromSharedStash(ctx *context.Status, unitID data.UnitID, stashTab int) (data.Item, bool) {
+	if itm, found := restoreFromStashTab(ctx, unitID, stashTab); found {
+		return itm, true
+	}
+
+	for tab := 2; tab <= ctx.CharacterCfg.Flavor().StashTabs(); tab++ {
+		if tab == stashTab {
+			continue
+		}
+		if itm, found := restoreFromStashTab(ctx, unitID, tab); found {
+			return itm, true
+		}
+	}
+
+	return data.Item{}, false
+}
+
+func restoreFromStashTab(ctx *context.Status, unitID data.UnitID, stashTab int) (data.Item, bool) {
 	action.SwitchStashTab(stashTab)
 	utils.Sleep(300)
 	ctx.RefreshGameData()
@@ -0,0 +1,73 @@
+package run
+
+import (
+	"fmt"
+
+	"github.com/hectorgimenez/d2go/pkg/data/difficulty"
+	"github.com/hectorgimenez/koolo/internal/action"
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/context"
+)
+
+// Keys needed of each type to open the three Pandemonium portals
+const uberKeysPerType = 3
+
+// UberKeys farms the three key bosses: Countess (Terror), Summoner (Hate) and Nihlathak (Destruction). Bosses are
+// skipped once the stash holds enough keys of their type.
+type UberKeys struct {
+	ctx *context.Status
+}
+
+func NewUberKeys() *UberKeys {
+	return &UberKeys{
+		ctx: context.Get(),
+	}
+}
+
+func (u UberKeys) Name() string {
+	return string(config.UberKeysRun)
+}
+
+func (u UberKeys) CheckConditions(parameters *RunParameters) SequencerResult {
+	if !u.ctx.CharacterCfg.Game.Ubers.FarmKeys || u.ctx.CharacterCfg.Game.Difficulty != difficulty.Hell {
+		return SequencerSkip
+	}
+	if len(u.missingKeyRuns()) == 0 {
+		return SequencerSkip
+	}
+
+	return SequencerOk
+}
+
+func (u UberKeys) Run(parameters *RunParameters) error {
+	for _, r := range u.missingKeyRuns() {
+		u.ctx.Logger.Info("Farming uber key", "run", r.Name())
+		// A failed run may have left us dead or out of the game, the next ones can't go on from there
+		if err := r.Run(parameters); err != nil {
+			return fmt.Errorf("failed to farm key from %s: %w", r.Name(), err)
+		}
+
+		if err := action.ReturnTown(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// missingKeyRuns returns the key boss runs for the keys we don't have enough of yet.
+func (u UberKeys) missingKeyRuns() []Run {
+	counts := countUberKeys(u.ctx)
+	runs := make([]Run, 0, 3)
+	if counts["KeyOfTerror"] < uberKeysPerType {
+		runs = append(runs, NewCountess())
+	}
+	if counts["KeyOfHate"] < uberKeysPerType {
+		runs = append(runs, NewSummoner())
+	}
+	if counts["KeyOfDestruction"] < uberKeysPerType {
+		runs = append(runs, NewNihlathak())
+	}
+
+	return runs
+}
@@ -34,6 +34,10 @@ func (o Organs) CheckConditions(parameters *RunParameters) SequencerResult {
 		o.ctx.Logger.Warn("Not enough keys in stash. Need 3x Key of Terror, 3x Key of Destruction, 3x Key of Hate")
 		return SequencerSkip
 	}
+	if err := checkUberGear(o.ctx); err != nil {
+		o.ctx.Logger.Warn(fmt.Sprintf("Skipping organs run, gear requirements not met: %v", err))
+		return SequencerSkip
+	}
 	return SequencerOk
 }
 
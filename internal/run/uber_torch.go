@@ -39,6 +39,10 @@ func (t Torch) CheckConditions(parameters *RunParameters) SequencerResult {
 		t.ctx.Logger.Warn("Not enough organs in stash. Need 1x Mephisto's Brain, 1x Diablo's Horn, 1x Baal's Eye")
 		return SequencerSkip
 	}
+	if err := checkUberGear(t.ctx); err != nil {
+		t.ctx.Logger.Warn(fmt.Sprintf("Skipping Uber Tristram, gear requirements not met: %v", err))
+		return SequencerSkip
+	}
 	return SequencerOk
 }
 
@@ -180,9 +184,10 @@ func (t Torch) mephisto() error {
 	retryCount := 0
 
 	for retryCount < maxRetries {
-		found, _, _ := isUberMephistoNearby(t.ctx, maxDistance)
+		found, boss, _ := isUberMephistoNearby(t.ctx, maxDistance)
 		if found {
 			t.ctx.Logger.Info("Found Uber Mephisto, starting fight")
+			castLifeTap(t.ctx, boss)
 			if err := t.ctx.Char.KillUberMephisto(); err != nil {
 				return fmt.Errorf("failed to kill Uber Mephisto: %w", err)
 			}
@@ -278,7 +283,7 @@ func (t Torch) baal() error {
 
 	for retryCount < maxRetries {
 		diabloFound, _, diabloDistanceUnits := isUberDiabloNearby(t.ctx, maxDistance)
-		baalFound, _, baalDistanceUnits := isUberBaalNearby(t.ctx, maxDistance)
+		baalFound, baal, baalDistanceUnits := isUberBaalNearby(t.ctx, maxDistance)
 
 		if diabloFound || baalFound {
 			if diabloFound && baalFound {
@@ -290,6 +295,7 @@ func (t Torch) baal() error {
 					t.ctx.Logger.Info("Successfully killed Uber Diablo")
 				} else {
 					t.ctx.Logger.Info("Found both bosses, killing Uber Baal first (closer)")
+					castLifeTap(t.ctx, baal)
 					if err := t.ctx.Char.KillUberBaal(); err != nil {
 						return fmt.Errorf("failed to kill Uber Baal: %w", err)
 					}
@@ -303,6 +309,7 @@ func (t Torch) baal() error {
 				t.ctx.Logger.Info("Successfully killed Uber Diablo")
 			} else if baalFound {
 				t.ctx.Logger.Info("Found Uber Baal, starting fight")
+				castLifeTap(t.ctx, baal)
 				if err := t.ctx.Char.KillUberBaal(); err != nil {
 					return fmt.Errorf("failed to kill Uber Baal: %w", err)
 				}
@@ -0,0 +1,57 @@
+// Package script evaluates the small scripts attached to the lifecycle hooks of a character. The scripts are expr
+// expressions (https://expr-lang.org), they can only read the hook data and call the functions of Env, nothing else
+// of the bot or of the system is reachable from them.
+package script
+
+import (
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
+)
+
+// Hooks the scripts can be attached to.
+const (
+	OnRunStart    = "on_run_start"
+	OnItemStashed = "on_item_stashed"
+	OnDeath       = "on_death"
+	OnTownVisit   = "on_town_visit"
+)
+
+var Hooks = []string{OnRunStart, OnItemStashed, OnDeath, OnTownVisit}
+
+// Env is what a script sees, the fields not related to the hook are empty.
+type Env struct {
+	Hook      string `expr:"hook"`
+	Character string `expr:"character"`
+	Run       string `expr:"run"`    // on_run_start, on_item_stashed and on_death
+	Area      string `expr:"area"`   // Area name, on_town_visit
+	Reason    string `expr:"reason"` // on_death
+	Item      Item   `expr:"item"`   // on_item_stashed
+
+	// Functions return true so they can be chained: notify("Jah!") && stopSession()
+	Log         func(msg string) bool `expr:"log"`
+	Notify      func(msg string) bool `expr:"notify"`      // Sends the message to Discord/Telegram
+	StopSession func() bool           `expr:"stopSession"` // Stops the supervisor once the game is finished
+}
+
+// Item is the stashed item of on_item_stashed.
+type Item struct {
+	Name           string `expr:"name"`
+	IdentifiedName string `expr:"identifiedName"`
+	Quality        string `expr:"quality"`
+	Ethereal       bool   `expr:"ethereal"`
+	Rule           string `expr:"rule"` // Pickit rule that matched the item
+}
+
+// CompileCondition compiles the when expression of a hook, it must return a bool.
+func CompileCondition(src string) (*vm.Program, error) {
+	return expr.Compile(src, expr.Env(Env{}), expr.AsBool())
+}
+
+// CompileAction compiles the do expression of a hook.
+func CompileAction(src string) (*vm.Program, error) {
+	return expr.Compile(src, expr.Env(Env{}))
+}
+
+func Run(program *vm.Program, env Env) (any, error) {
+	return expr.Run(program, env)
+}
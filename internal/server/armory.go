@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/hectorgimenez/koolo/internal/bot"
 )
@@ -97,3 +98,15 @@ func (s *HttpServer) armoryAllAPI(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(allArmories)
 }
+
+// itemSearchAPI searches the item index of every character, including mules, for items matching the q parameter
+func (s *HttpServer) itemSearchAPI(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("q")
+	if len(strings.TrimSpace(query)) < 2 {
+		http.Error(w, "q parameter must have at least 2 characters", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(bot.SearchItemIndex(s.manager.AvailableSupervisors(), query))
+}
@@ -385,12 +385,14 @@ document.addEventListener('DOMContentLoaded', function () {
             { value: 'assassin', label: 'Assassin (Leveling)' },
             { value: 'trapsin', label: 'Lightning Trapsin' },
             { value: 'mosaic', label: 'Mosaic Assassin' },
+            { value: 'hybrid_trapsin', label: 'Kicksin/Trapsin Hybrid' },
         ],
         barbarian: [
             { value: 'barb_leveling', label: 'Barbarian (Leveling)' },
             { value: 'berserker', label: 'Berserk Barbarian' },
             { value: 'warcry_barb', label: 'Warcry Barbarian' },
             { value: 'whirlwind_barb', label: 'Whirlwind Barbarian' },
+            { value: 'frenzy_barb', label: 'Frenzy/Whirlwind Barbarian' },
         ],
         druid: [
             { value: 'druid_leveling', label: 'Druid (Leveling)' },
@@ -398,6 +400,7 @@ document.addEventListener('DOMContentLoaded', function () {
         ],
         necromancer: [
             { value: 'necromancer', label: 'Necromancer (Leveling)' },
+            { value: 'poison_bone_necro', label: 'Poison/Bone Necromancer' },
         ],
         paladin: [
             { value: 'paladin', label: 'Paladin (Leveling)' },
@@ -416,6 +419,7 @@ document.addEventListener('DOMContentLoaded', function () {
         ],
         other: [
             { value: 'mule', label: 'Mule' },
+            { value: 'custom_rotation', label: 'Custom Rotation (Profile)' },
             { value: 'development', label: 'Development' },
         ],
     };
@@ -616,6 +620,9 @@ document.addEventListener('DOMContentLoaded', function () {
         const amazonLevelingOptions = document.querySelector('.amazon_leveling-options');
         const druidLevelingOptions = document.querySelector('.druid_leveling-options');
         const necromancerLevelingOptions = document.querySelector('.necromancer-options');
+        const poisonBoneNecroOptions = document.querySelector('.poison-bone-necro-options');
+        const hybridTrapsinOptions = document.querySelector('.hybrid-trapsin-options');
+        const frenzyBarbOptions = document.querySelector('.frenzy-barb-options');
         const paladinLevelingOptions = document.querySelector('.paladin-options');
         const smiterOptions = document.querySelector('.smiter-options');
         const javazonOptions = document.querySelector('.javazon-options');
@@ -640,6 +647,9 @@ document.addEventListener('DOMContentLoaded', function () {
         if (amazonLevelingOptions) amazonLevelingOptions.style.display = 'none';
         if (druidLevelingOptions) druidLevelingOptions.style.display = 'none';
         if (necromancerLevelingOptions) necromancerLevelingOptions.style.display = 'none';
+        if (poisonBoneNecroOptions) poisonBoneNecroOptions.style.display = 'none';
+        if (hybridTrapsinOptions) hybridTrapsinOptions.style.display = 'none';
+        if (frenzyBarbOptions) frenzyBarbOptions.style.display = 'none';
         if (paladinLevelingOptions) paladinLevelingOptions.style.display = 'none';
         if (smiterOptions) smiterOptions.style.display = 'none';
         if (javazonOptions) javazonOptions.style.display = 'none';
@@ -680,6 +690,12 @@ document.addEventListener('DOMContentLoaded', function () {
             if (druidLevelingOptions) druidLevelingOptions.style.display = 'block';
         } else if (selectedClass === 'necromancer') {
             if (necromancerLevelingOptions) necromancerLevelingOptions.style.display = 'block';
+        } else if (selectedClass === 'poison_bone_necro') {
+            if (poisonBoneNecroOptions) poisonBoneNecroOptions.style.display = 'block';
+        } else if (selectedClass === 'hybrid_trapsin') {
+            if (hybridTrapsinOptions) hybridTrapsinOptions.style.display = 'block';
+        } else if (selectedClass === 'frenzy_barb') {
+            if (frenzyBarbOptions) frenzyBarbOptions.style.display = 'block';
         } else if (selectedClass === 'paladin') {
             if (paladinLevelingOptions) paladinLevelingOptions.style.display = 'block';
         } else if (selectedClass === 'smiter') {
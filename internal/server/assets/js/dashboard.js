@@ -14,6 +14,16 @@ function connectWebSocket() {
 
   socket.onmessage = function (event) {
     const data = JSON.parse(event.data);
+    if (data.type === "supervisor_state") {
+      updateSupervisorStates(data.states);
+      return;
+    }
+    if (data.type === "minimap") {
+      updateMinimaps(data.minimaps);
+      return;
+    }
+    // Other typed messages (updater, rollback...) are handled by their own pages
+    if (data.type) return;
     updateDashboard(data);
   };
 
@@ -52,6 +62,8 @@ function updateDashboard(data) {
     }
   }
 
+  updateGameVersionWarning(data.GameVersion);
+
   const container = document.getElementById("characters-container");
   if (!container) return;
 
@@ -85,6 +97,127 @@ function updateDashboard(data) {
   });
 }
 
+function updateGameVersionWarning(gameVersion) {
+  const warning = document.getElementById("game-version-warning");
+  if (!warning) return;
+
+  if (!gameVersion || !gameVersion.Unsupported) {
+    warning.style.display = "none";
+    return;
+  }
+
+  const modes = {
+    stop: "the supervisors won't start",
+    town_idle: "the characters stay idle in town",
+    ignore: "the bot keeps running, it may misbehave",
+  };
+  warning.querySelector(".game-version-text").textContent =
+    `D2R ${gameVersion.Version} is newer than the supported ${gameVersion.Supported}, ${modes[gameVersion.Mode] || modes.stop}. Accept it once the bot is updated.`;
+  warning.style.display = "flex";
+}
+
+function acceptGameVersion() {
+  fetch("/api/game-version/accept", { method: "POST" })
+    .then((response) => {
+      if (!response.ok) {
+        return response.text().then((text) => {
+          throw new Error(text);
+        });
+      }
+      document.getElementById("game-version-warning").style.display = "none";
+    })
+    .catch((error) => alert("Failed accepting the game version: " + error.message));
+}
+
+function updateSupervisorStates(states) {
+  for (const state of states || []) {
+    const card = document.getElementById(`card-${state.supervisor}`);
+    if (!card) continue;
+
+    const stateElement = card.querySelector(".supervisor-state");
+    if (!stateElement) continue;
+
+    const parts = [state.run, state.action, state.step].filter((p) => p);
+    if (parts.length === 0) {
+      stateElement.textContent = "";
+      continue;
+    }
+    stateElement.textContent = `${parts.join(" › ")} (${Math.floor(state.inStateSeconds)}s)`;
+  }
+}
+
+const minimapRadius = 60;
+const minimapLootColors = {
+  Magic: "#6969ff",
+  Rare: "#ffff64",
+  Set: "#00fc00",
+  Unique: "#c7b377",
+  Crafted: "#ffa800",
+};
+
+function updateMinimaps(minimaps) {
+  const inGame = new Set();
+  for (const minimap of minimaps || []) {
+    const card = document.getElementById(`card-${minimap.supervisor}`);
+    if (!card) continue;
+
+    const canvas = card.querySelector(".minimap");
+    if (!canvas) continue;
+
+    inGame.add(minimap.supervisor);
+    canvas.style.display = "block";
+    canvas.title = `${minimap.area} (${minimap.x}, ${minimap.y})`;
+    drawMinimap(canvas, minimap);
+  }
+
+  // Hide the minimap of the supervisors out of game
+  document.querySelectorAll(".character-card .minimap").forEach((canvas) => {
+    const key = canvas.closest(".character-card").id.replace("card-", "");
+    if (!inGame.has(key)) {
+      canvas.style.display = "none";
+    }
+  });
+}
+
+function drawMinimap(canvas, minimap) {
+  const g = canvas.getContext("2d");
+  const scale = canvas.width / (minimapRadius * 2);
+  const toCanvas = (p) => [canvas.width / 2 + p.x * scale, canvas.height / 2 + p.y * scale];
+
+  g.fillStyle = "#111418";
+  g.fillRect(0, 0, canvas.width, canvas.height);
+
+  if (minimap.path.length > 0) {
+    g.strokeStyle = "#3a7bff";
+    g.lineWidth = 1;
+    g.beginPath();
+    g.moveTo(canvas.width / 2, canvas.height / 2);
+    for (const p of minimap.path) {
+      g.lineTo(...toCanvas(p));
+    }
+    g.stroke();
+  }
+
+  for (const l of minimap.loot) {
+    const [x, y] = toCanvas(l);
+    g.fillStyle = minimapLootColors[l.q] || "#dddddd";
+    g.fillRect(x - 2, y - 2, 4, 4);
+  }
+
+  for (const m of minimap.monsters) {
+    const [x, y] = toCanvas(m);
+    g.fillStyle = m.e ? "#ffa800" : "#d9534f";
+    g.beginPath();
+    g.arc(x, y, m.e ? 3 : 2, 0, Math.PI * 2);
+    g.fill();
+  }
+
+  g.fillStyle = "#ffffff";
+  g.beginPath();
+  g.arc(canvas.width / 2, canvas.height / 2, 3, 0, Math.PI * 2);
+  g.fill();
+}
+
 function createCharacterCard(key) {
   const card = document.createElement("div");
   card.className = "character-card";
@@ -116,6 +249,8 @@ function createCharacterCard(key) {
                           <span class="co-difficulty">Difficulty</span>
                       </div>
                     </div>
+                    <div class="supervisor-state" title="Run › Action › Step"></div>
+                    <canvas class="minimap" width="160" height="160" style="display:none;"></canvas>
                   </div>
                 </div>
                 <div class="character-controls">
@@ -192,6 +327,10 @@ function createCharacterCard(key) {
                         <div class="stat-label">Errors</div>
                         <div class="stat-value errors">0</div>
                     </div>
+                    <div class="stat-item">
+                        <div class="stat-label">Crashes</div>
+                        <div class="stat-value crashes">0</div>
+                    </div>
                 </div>
                 <div class="scheduler-status" style="display:none;">
                     <div class="scheduler-phase"></div>
@@ -202,6 +341,12 @@ function createCharacterCard(key) {
                     <button class="btn btn-outline" onclick="location.href='/debug?characterName=${key}'" title="Open Debug Page">
                         <i class="bi bi-bug"></i>
                     </button>
+                    <button class="btn btn-outline save-window-btn" title="Save Window Position">
+                        <i class="bi bi-window"></i>
+                    </button>
+                    <button class="btn btn-outline suspend-btn" title="Suspend (park in town and resume the run later)">
+                        <i class="bi bi-pause-circle"></i>
+                    </button>
                 </div>
                 <div class="run-stats"></div>
             </div>
@@ -240,6 +385,34 @@ function setupEventListeners(card, key) {
     });
   }
 
+  const saveWindowBtn = card.querySelector(".save-window-btn");
+  if (saveWindowBtn) {
+    saveWindowBtn.addEventListener("click", (e) => {
+      e.stopPropagation();
+      fetch("/api/supervisors/save-window?characterName=" + key, {
+        method: "POST",
+      }).then((response) => {
+        if (response.ok) {
+          alert("Window position for " + key + " saved, it will be used every time the game starts.");
+        } else {
+          response.text().then((text) => alert("Failed to save window position: " + text));
+        }
+      });
+    });
+  }
+
+  const suspendBtn = card.querySelector(".suspend-btn");
+  if (suspendBtn) {
+    suspendBtn.addEventListener("click", function (e) {
+      e.stopPropagation();
+      const action = this.dataset.suspended === "true" ? "resume" : "suspend";
+      fetch(`/${action}?characterName=${key}`)
+        .then((response) => response.json())
+        .then((data) => updateDashboard(data))
+        .catch((error) => console.error("Error:", error));
+    });
+  }
+
   if (toggleDetailsBtn) {
     toggleDetailsBtn.addEventListener("click", function () {
       card.classList.toggle("expanded");
@@ -347,7 +520,7 @@ function updateCharacterCard(card, key, value, dropCount, schedulerInfo) {
   const schedulerStatusDiv = card.querySelector(".scheduler-status");
 
   if (statusBadge && statusDetails) {
-    updateStatus(statusBadge, statusDetails, value.SupervisorStatus);
+    updateStatus(statusBadge, statusDetails, value.SupervisorStatus, value);
   }
 
   if (statusIndicator) {
@@ -371,8 +544,24 @@ function updateCharacterCard(card, key, value, dropCount, schedulerInfo) {
   }
 
   updateStats(card, key, value.Games, dropCount);
+  const crashesEl = card.querySelector(".crashes");
+  if (crashesEl) {
+    crashesEl.textContent = value.Crashes || 0;
+    crashesEl.title = value.RecentCrashes > 0 ? `${value.RecentCrashes} in the last 15 minutes` : "";
+  }
   updateRunStats(card, value.Games);
 
+  const suspendBtn = card.querySelector(".suspend-btn");
+  if (suspendBtn) {
+    suspendBtn.dataset.suspended = value.Suspended ? "true" : "false";
+    suspendBtn.querySelector("i").className = value.Suspended ? "bi bi-play-circle" : "bi bi-pause-circle";
+    suspendBtn.title = value.Suspended
+      ? value.SuspendCheckpoint
+        ? `Resume ${value.SuspendCheckpoint.run || "run"} (suspended at ${value.SuspendCheckpoint.lastAction || "unknown action"})`
+        : "Resume (waiting for a safe point to suspend)"
+      : "Suspend (park in town and resume the run later)";
+  }
+
   // Enrich with live character overview (support both UI and ui keys)
   const uiPayload = value.UI || value.ui || null;
   updateCharacterOverview(card, uiPayload, value.SupervisorStatus);
@@ -463,11 +652,18 @@ function updateStatusIndicator(statusIndicator, status) {
   }
 }
 
-function updateStatus(statusBadge, statusDetails, status) {
+function updateStatus(statusBadge, statusDetails, status, value) {
   if (!statusBadge || !statusDetails) return;
 
   const statusText = status || "Not started";
-  statusBadge.innerHTML = `<span class="status-label">Status:</span> <span class="status-value">${statusText}</span>`;
+  let queueText = "";
+  if (value && value.InQueue) {
+    queueText = value.QueuePosition > 0 ? ` (queue #${value.QueuePosition})` : " (in queue)";
+  }
+  if (value && value.ClientHealth && value.ClientHealth.Degraded) {
+    queueText += ` (degraded: ${value.ClientHealth.Reason}, restarting in town)`;
+  }
+  statusBadge.innerHTML = `<span class="status-label">Status:</span> <span class="status-value">${statusText}${queueText}</span>`;
   statusBadge.className = `status-badge status-${statusText
     .toLowerCase()
     .replace(" ", "")}`;
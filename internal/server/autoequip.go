@@ -0,0 +1,46 @@
+package server
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// formatStatWeights renders the auto equip stat weights as "stat=weight, stat=weight", sorted by stat name.
+func formatStatWeights(weights map[string]float64) string {
+	names := make([]string, 0, len(weights))
+	for name := range weights {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	entries := make([]string, 0, len(names))
+	for _, name := range names {
+		entries = append(entries, fmt.Sprintf("%s=%s", name, strconv.FormatFloat(weights[name], 'f', -1, 64)))
+	}
+
+	return strings.Join(entries, ", ")
+}
+
+// parseStatWeights parses the "stat=weight, stat=weight" format, stat names are lowercased and invalid entries ignored.
+func parseStatWeights(value string) map[string]float64 {
+	weights := make(map[string]float64)
+	for _, entry := range strings.Split(value, ",") {
+		name, weightStr, found := strings.Cut(entry, "=")
+		if !found {
+			continue
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		weight, err := strconv.ParseFloat(strings.TrimSpace(weightStr), 64)
+		if err != nil {
+			continue
+		}
+		weights[name] = weight
+	}
+
+	return weights
+}
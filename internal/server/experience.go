@@ -0,0 +1,46 @@
+package server
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// formatXPThresholds renders the XP/hour thresholds by level as "level=xp, level=xp", sorted by level.
+func formatXPThresholds(thresholds map[int]int) string {
+	levels := make([]int, 0, len(thresholds))
+	for level := range thresholds {
+		levels = append(levels, level)
+	}
+	sort.Ints(levels)
+
+	entries := make([]string, 0, len(levels))
+	for _, level := range levels {
+		entries = append(entries, fmt.Sprintf("%d=%d", level, thresholds[level]))
+	}
+
+	return strings.Join(entries, ", ")
+}
+
+// parseXPThresholds parses the "level=xp, level=xp" format, invalid entries are ignored.
+func parseXPThresholds(value string) map[int]int {
+	thresholds := make(map[int]int)
+	for _, entry := range strings.Split(value, ",") {
+		levelStr, xpStr, found := strings.Cut(entry, "=")
+		if !found {
+			continue
+		}
+		level, err := strconv.Atoi(strings.TrimSpace(levelStr))
+		if err != nil || level < 1 || level > 99 {
+			continue
+		}
+		xp, err := strconv.Atoi(strings.TrimSpace(xpStr))
+		if err != nil || xp <= 0 {
+			continue
+		}
+		thresholds[level] = xp
+	}
+
+	return thresholds
+}
@@ -208,6 +208,84 @@ func (s *HttpServer) BroadcastStatus() {
 	}
 }
 
+// BroadcastSupervisorStates pushes the run/action/step of the supervisors as soon as they change, the status broadcast
+// is too slow to follow the state machine.
+func (s *HttpServer) BroadcastSupervisorStates() {
+	last := make(map[string]bot.SupervisorState)
+	for {
+		time.Sleep(250 * time.Millisecond)
+
+		states := s.manager.States()
+		changed := len(states) != len(last)
+		for _, state := range states {
+			if prev, found := last[state.Supervisor]; !found || prev.Changed(state) {
+				changed = true
+			}
+		}
+		if !changed {
+			continue
+		}
+
+		last = make(map[string]bot.SupervisorState, len(states))
+		for _, state := range states {
+			last[state.Supervisor] = state
+		}
+
+		jsonData, err := json.Marshal(struct {
+			Type   string                `json:"type"`
+			States []bot.SupervisorState `json:"states"`
+		}{Type: "supervisor_state", States: states})
+		if err != nil {
+			slog.Error("Failed to marshal supervisor states", "error", err)
+			continue
+		}
+
+		s.wsServer.broadcast <- jsonData
+	}
+}
+
+// BroadcastMinimaps pushes the minimap of the supervisors in game every tick, the dashboard draws the tactical view
+// from it.
+func (s *HttpServer) BroadcastMinimaps() {
+	sentEmpty := false
+	for {
+		time.Sleep(250 * time.Millisecond)
+		s.broadcastMinimaps(&sentEmpty)
+	}
+}
+
+// broadcastMinimaps sends the minimaps of one tick, a panic while building them skips the tick instead of stopping the
+// broadcast.
+func (s *HttpServer) broadcastMinimaps(sentEmpty *bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			slog.Error("Failed to build the minimaps", "error", r)
+		}
+	}()
+
+	minimaps := s.manager.Minimaps()
+	// A single empty message is enough to clear the minimaps once the supervisors leave the game
+	if len(minimaps) == 0 {
+		if *sentEmpty {
+			return
+		}
+		*sentEmpty = true
+	} else {
+		*sentEmpty = false
+	}
+
+	jsonData, err := json.Marshal(struct {
+		Type     string        `json:"type"`
+		Minimaps []bot.Minimap `json:"minimaps"`
+	}{Type: "minimap", Minimaps: minimaps})
+	if err != nil {
+		slog.Error("Failed to marshal minimaps", "error", err)
+		return
+	}
+
+	s.wsServer.broadcast <- jsonData
+}
+
 func New(logger *slog.Logger, manager *bot.SupervisorManager, scheduler *bot.Scheduler) (*HttpServer, error) {
 	var templates *template.Template
 	helperFuncs := template.FuncMap{
@@ -229,6 +307,10 @@ func New(logger *slog.Logger, manager *bot.SupervisorManager, scheduler *bot.Sch
 		},
 		"runDisplayName": func(run string) string {
 			switch run {
+			case string(config.UberKeysRun):
+				return "Uber (Keys)"
+			case string(config.DiabloCloneRun):
+				return "Diablo Clone"
 			case string(config.OrgansRun):
 				return "Uber (Organs)"
 			case string(config.PandemoniumRun):
@@ -237,9 +319,13 @@ func New(logger *slog.Logger, manager *bot.SupervisorManager, scheduler *bot.Sch
 				return run
 			}
 		},
-		"qualityClass": qualityClass,
-		"statIDToText": statIDToText,
-		"contains":     containss,
+		"qualityClass":          qualityClass,
+		"statIDToText":          statIDToText,
+		"contains":              containss,
+		"formatPickitOverrides": formatPickitOverrides,
+		"formatPlayersCounts":   formatPlayersCounts,
+		"formatXPThresholds":    formatXPThresholds,
+		"formatStatWeights":     formatStatWeights,
 		"seq": func(start, end int) []int {
 			var result []int
 			for i := start; i <= end; i++ {
@@ -494,15 +580,15 @@ func resolveSkillClassFromBuild(build string) string {
 		return "ama"
 	case "sorceress", "nova", "hydraorb", "lightsorc", "fireballsorc", "sorceress_leveling":
 		return "sor"
-	case "necromancer":
+	case "necromancer", "poison_bone_necro":
 		return "nec"
 	case "paladin", "hammerdin", "foh", "dragondin", "smiter":
 		return "pal"
-	case "barb_leveling", "berserker", "warcry_barb":
+	case "barb_leveling", "berserker", "warcry_barb", "frenzy_barb":
 		return "bar"
 	case "druid_leveling", "winddruid":
 		return "dru"
-	case "assassin", "trapsin", "mosaic":
+	case "assassin", "trapsin", "mosaic", "hybrid_trapsin":
 		return "ass"
 	default:
 		return ""
@@ -603,6 +689,7 @@ func (s *HttpServer) updateAutoStatSkillFromForm(values url.Values, cfg *config.
 
 	skillKeys := values["autoStatSkillSkill[]"]
 	skillTargets := values["autoStatSkillSkillTarget[]"]
+	skillMinLevels := values["autoStatSkillSkillMinLevel[]"]
 	skills := make([]config.AutoStatSkillSkill, 0, len(skillKeys))
 	for i, skillKey := range skillKeys {
 		if i >= len(skillTargets) {
@@ -616,7 +703,13 @@ func (s *HttpServer) updateAutoStatSkillFromForm(values url.Values, cfg *config.
 		if err != nil || target <= 0 {
 			continue
 		}
-		skills = append(skills, config.AutoStatSkillSkill{Skill: skillKey, Target: target})
+		minLevel := 0
+		if i < len(skillMinLevels) {
+			if lvl, err := strconv.Atoi(strings.TrimSpace(skillMinLevels[i])); err == nil && lvl > 0 {
+				minLevel = min(lvl, 99)
+			}
+		}
+		skills = append(skills, config.AutoStatSkillSkill{Skill: skillKey, Target: target, MinLevel: minLevel})
 	}
 	cfg.Character.AutoStatSkill.Skills = skills
 
@@ -782,6 +875,11 @@ func (s *HttpServer) getStatusData() IndexData {
 				pr = pr - penalty
 			}
 
+			terrorZones := make([]string, 0, len(data.TerrorZones))
+			for _, tz := range data.TerrorZones {
+				terrorZones = append(terrorZones, tz.Area().Name)
+			}
+
 			// Resolve difficulty and area names
 			diffStr := fmt.Sprint(data.CharacterCfg.Game.Difficulty)
 			areaStr := ""
@@ -812,6 +910,7 @@ func (s *HttpServer) getStatusData() IndexData {
 				ColdResist:      cr,
 				LightningResist: lr,
 				PoisonResist:    pr,
+				TerrorZones:     terrorZones,
 			}
 		}
 
@@ -895,6 +994,7 @@ func (s *HttpServer) getStatusData() IndexData {
 		SchedulerStatus:             schedulerStatus,
 		GlobalAutoStartEnabled:      config.Koolo.AutoStart.Enabled,
 		GlobalAutoStartDelaySeconds: config.Koolo.AutoStart.DelaySeconds,
+		GameVersion:                 bot.GameVersion(),
 	}
 }
 
@@ -902,6 +1002,8 @@ func (s *HttpServer) Listen(port int) error {
 	s.wsServer = NewWebSocketServer()
 	go s.wsServer.Run()
 	go s.BroadcastStatus()
+	go s.BroadcastSupervisorStates()
+	go s.BroadcastMinimaps()
 
 	http.HandleFunc("/", s.getRoot)
 	http.HandleFunc("/config", s.config)
@@ -914,12 +1016,18 @@ func (s *HttpServer) Listen(port int) error {
 	http.HandleFunc("/start", s.startSupervisor)
 	http.HandleFunc("/stop", s.stopSupervisor)
 	http.HandleFunc("/togglePause", s.togglePause)
+	http.HandleFunc("/suspend", s.suspendSupervisor)
+	http.HandleFunc("/resume", s.resumeSupervisor)
 	http.HandleFunc("/autostart/toggle", s.toggleAutoStart)
 	http.HandleFunc("/autostart/run-once", s.runAutoStartOnce)
 	http.HandleFunc("/debug", s.debugHandler)
 	http.HandleFunc("/debug-data", s.debugData)
+	http.HandleFunc("/api/supervisor-state", s.supervisorState)
+	http.HandleFunc("/api/game-version/accept", s.acceptGameVersion)
+	http.HandleFunc("/api/run-profile", s.runProfile)
 	http.HandleFunc("/drops", s.drops)
 	http.HandleFunc("/all-drops", s.allDrops)
+	http.HandleFunc("/api/drop-report", s.dropReport)
 	http.HandleFunc("/export-drops", s.exportDrops)
 	http.HandleFunc("/open-droplogs", s.openDroplogs)
 	http.HandleFunc("/reset-droplogs", s.resetDroplogs)
@@ -929,8 +1037,13 @@ func (s *HttpServer) Listen(port int) error {
 	http.HandleFunc("/initial-data", s.initialData)                            // Web socket data
 	http.HandleFunc("/api/reload-config", s.reloadConfig)                      // New handler
 	http.HandleFunc("/api/companion-join", s.companionJoin)                    // Companion join handler
+	http.HandleFunc("/api/game-name", s.gameName)                              // Current and next lobby game name
+	http.HandleFunc("/api/config/validate", s.validateConfig)                  // Every problem found in the configuration
+	http.HandleFunc("/api/config/resolved", s.resolvedConfig)                  // Character config merged with its profiles
 	http.HandleFunc("/api/generate-battlenet-token", s.generateBattleNetToken) // Battle.net token generation
 	http.HandleFunc("/reset-muling", s.resetMuling)
+	http.HandleFunc("/api/supervisors/save-window", s.saveWindowPosition)
+	http.HandleFunc("/api/supervisors/run-blacklist", s.runBlacklist)
 
 	// Updater routes
 	http.HandleFunc("/api/updater/version", s.getVersion)
@@ -967,6 +1080,8 @@ func (s *HttpServer) Listen(port int) error {
 	http.HandleFunc("/api/pickit/files/rules/append", s.pickitAPI.handleAppendNIPLine)
 	http.HandleFunc("/api/pickit/browse-folder", s.pickitAPI.handleBrowseFolder)
 	http.HandleFunc("/api/pickit/simulate", s.pickitAPI.handleSimulate)
+	http.HandleFunc("/api/pickit/lint", s.pickitAPI.handleLintNIP)
+	http.HandleFunc("/api/pickit/evaluate", s.pickitAPI.handleEvaluateNIP)
 	http.HandleFunc("/api/sequence-editor/runs", s.sequenceAPI.handleListRuns)
 	http.HandleFunc("/api/sequence-editor/file", s.sequenceAPI.handleGetSequence)
 	http.HandleFunc("/api/sequence-editor/open", s.sequenceAPI.handleBrowseSequence)
@@ -984,6 +1099,10 @@ func (s *HttpServer) Listen(port int) error {
 	http.HandleFunc("/api/armory", s.armoryAPI)
 	http.HandleFunc("/api/armory/characters", s.armoryCharactersAPI)
 	http.HandleFunc("/api/armory/all", s.armoryAllAPI)
+	http.HandleFunc("/api/items/search", s.itemSearchAPI)
+	http.HandleFunc("/api/stash/manifest", s.stashManifestAPI)
+	http.HandleFunc("/api/mules", s.mulesAPI)
+	http.HandleFunc("/api/quest-rewards", s.questRewardsAPI)
 
 	s.registerDropRoutes()
 
@@ -1114,6 +1233,85 @@ func (s *HttpServer) debugData(w http.ResponseWriter, r *http.Request) {
 	w.Write(jsonData)
 }
 
+// runProfile returns the share of the time spent by phase (travel, combat, pickup, town, game creation) in every run
+// type of the session, or in a single one with the run parameter.
+func (s *HttpServer) runProfile(w http.ResponseWriter, r *http.Request) {
+	characterName := r.URL.Query().Get("characterName")
+	if characterName == "" {
+		http.Error(w, "characterName parameter required", http.StatusBadRequest)
+		return
+	}
+	runName := r.URL.Query().Get("run")
+
+	type phaseBreakdown struct {
+		Phase   string  `json:"phase"`
+		Seconds float64 `json:"seconds"`
+		Percent float64 `json:"percent"`
+	}
+	type runBreakdown struct {
+		Run            string           `json:"run"`
+		Runs           int              `json:"runs"`
+		AverageSeconds float64          `json:"averageSeconds"`
+		Phases         []phaseBreakdown `json:"phases"`
+	}
+
+	breakdowns := make([]runBreakdown, 0)
+	for name, profile := range s.manager.Status(characterName).Profile {
+		if (runName != "" && name != runName) || profile.Runs == 0 || profile.Total <= 0 {
+			continue
+		}
+
+		rb := runBreakdown{Run: name, Runs: profile.Runs, AverageSeconds: profile.Total.Seconds() / float64(profile.Runs)}
+		for phase, d := range profile.Phases {
+			rb.Phases = append(rb.Phases, phaseBreakdown{
+				Phase:   phase,
+				Seconds: d.Seconds(),
+				Percent: d.Seconds() * 100 / profile.Total.Seconds(),
+			})
+		}
+		sort.Slice(rb.Phases, func(i, j int) bool { return rb.Phases[i].Seconds > rb.Phases[j].Seconds })
+		breakdowns = append(breakdowns, rb)
+	}
+	sort.Slice(breakdowns, func(i, j int) bool { return breakdowns[i].Run < breakdowns[j].Run })
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(breakdowns)
+}
+
+// acceptGameVersion makes the installed game version the supported one.
+func (s *HttpServer) acceptGameVersion(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := bot.AcceptGameVersion(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.logger.Info("Game version accepted as supported", "version", bot.GameVersion().Version)
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// supervisorState returns the state of the supervisor, or of all the running ones when no character is given.
+func (s *HttpServer) supervisorState(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	characterName := r.URL.Query().Get("characterName")
+	if characterName == "" {
+		json.NewEncoder(w).Encode(s.manager.States())
+		return
+	}
+
+	state, found := s.manager.State(characterName)
+	if !found {
+		http.Error(w, "Supervisor is not running", http.StatusNotFound)
+		return
+	}
+	json.NewEncoder(w).Encode(state)
+}
+
 func (s *HttpServer) debugHandler(w http.ResponseWriter, r *http.Request) {
 	s.templates.ExecuteTemplate(w, "debug.gohtml", nil)
 }
@@ -1345,6 +1543,17 @@ func (s *HttpServer) togglePause(w http.ResponseWriter, r *http.Request) {
 	s.initialData(w, r)
 }
 
+// suspendSupervisor parks the character at the next safe point, unlike togglePause the run can be left mid-fight.
+func (s *HttpServer) suspendSupervisor(w http.ResponseWriter, r *http.Request) {
+	s.manager.Suspend(r.URL.Query().Get("characterName"))
+	s.initialData(w, r)
+}
+
+func (s *HttpServer) resumeSupervisor(w http.ResponseWriter, r *http.Request) {
+	s.manager.Resume(r.URL.Query().Get("characterName"))
+	s.initialData(w, r)
+}
+
 func (s *HttpServer) index(w http.ResponseWriter) {
 	status := make(map[string]bot.Stats)
 	drops := make(map[string]int)
@@ -1451,6 +1660,43 @@ func (s *HttpServer) allDrops(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// dropReport returns the drop quality by run type from the droplogs: no-drop rate, uniques/sets/rares/runes per 100
+// runs and the daily trend. It can be narrowed with the supervisor, run and days parameters.
+func (s *HttpServer) dropReport(w http.ResponseWriter, r *http.Request) {
+	base := config.Koolo.LogSaveDirectory
+	if base == "" {
+		base = "logs"
+	}
+	dir := filepath.Join(base, "droplogs")
+
+	filter := droplog.ReportFilter{
+		Supervisor: strings.TrimSpace(r.URL.Query().Get("supervisor")),
+		Run:        strings.TrimSpace(r.URL.Query().Get("run")),
+	}
+	if days := r.URL.Query().Get("days"); days != "" {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			http.Error(w, "Invalid days", http.StatusBadRequest)
+			return
+		}
+		filter.Since = time.Now().AddDate(0, 0, -n)
+	}
+
+	drops, err := droplog.ReadAll(dir)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	runs, err := droplog.ReadRuns(dir)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(droplog.BuildReport(drops, runs, filter))
+}
+
 // exportDrops renders a static HTML of the centralized drops and returns it as a file download.
 func (s *HttpServer) exportDrops(w http.ResponseWriter, r *http.Request) {
 	// Reuse allDrops data generation
@@ -1591,6 +1837,7 @@ func (s *HttpServer) config(w http.ResponseWriter, r *http.Request) {
 		newConfig.Debug.Log = r.Form.Get("debug_log") == "true"
 		newConfig.Debug.Screenshots = r.Form.Get("debug_screenshots") == "true"
 		newConfig.Debug.OpenOverlayMapOnGameStart = r.Form.Get("debug_open_overlay_map") == "true"
+		newConfig.Debug.RecordReplays = r.Form.Get("debug_record_replays") == "true"
 		// Discord
 		newConfig.Discord.Enabled = r.Form.Get("discord_enabled") == "true"
 		newConfig.Discord.EnableGameCreatedMessages = r.Form.Has("enable_game_created_messages")
@@ -1683,6 +1930,25 @@ func (s *HttpServer) config(w http.ResponseWriter, r *http.Request) {
 		}
 		newConfig.AutoStart.DelaySeconds = autoStartDelay
 
+		// Game Creation
+		for field, value := range map[string]*int{
+			"game_creation_max_concurrent": &newConfig.GameCreation.MaxConcurrent,
+			"game_creation_stagger":        &newConfig.GameCreation.StaggerSeconds,
+			"game_creation_jitter":         &newConfig.GameCreation.JitterSeconds,
+			// Client Watchdog
+			"client_watchdog_max_cpu":          &newConfig.ClientWatchdog.MaxCPUPercent,
+			"client_watchdog_max_memory":       &newConfig.ClientWatchdog.MaxMemoryMB,
+			"client_watchdog_max_read_latency": &newConfig.ClientWatchdog.MaxReadLatencyMs,
+			"client_watchdog_samples":          &newConfig.ClientWatchdog.Samples,
+		} {
+			v, err := strconv.Atoi(r.Form.Get(field))
+			if err != nil || v < 0 {
+				v = 0
+			}
+			*value = v
+		}
+		newConfig.ClientWatchdog.Enabled = r.Form.Get("client_watchdog_enabled") == "true"
+
 		err = config.ValidateAndSaveConfig(newConfig)
 		if err != nil {
 			s.templates.ExecuteTemplate(w, "config.gohtml", ConfigData{
@@ -1775,12 +2041,25 @@ func (s *HttpServer) updateConfigFromForm(values url.Values, cfg *config.Charact
 		if cfg.Scheduler.Mode == "" {
 			cfg.Scheduler.Mode = "timeSlots"
 		}
+		cfg.Scheduler.SuspendOnBreaks = values.Has("schedulerSuspendOnBreaks")
 
 		// Global variance for time slots mode
 		if v := values.Get("globalVarianceMin"); v != "" {
 			cfg.Scheduler.GlobalVarianceMin, _ = strconv.Atoi(v)
 		}
 
+		// Play sessions and breaks inside the time slots
+		for field, target := range map[string]*int{
+			"slotBreaksSessionMinutes":  &cfg.Scheduler.Breaks.SessionMinutes,
+			"slotBreaksSessionVariance": &cfg.Scheduler.Breaks.SessionVariance,
+			"slotBreaksBreakMinutes":    &cfg.Scheduler.Breaks.BreakMinutes,
+			"slotBreaksBreakVariance":   &cfg.Scheduler.Breaks.BreakVariance,
+		} {
+			if v, err := strconv.Atoi(values.Get(field)); err == nil {
+				*target = min(max(v, 0), 1440)
+			}
+		}
+
 		// Reset scheduler days if we are updating them
 		if len(cfg.Scheduler.Days) != 7 {
 			cfg.Scheduler.Days = make([]config.Day, 7)
@@ -1921,7 +2200,15 @@ func (s *HttpServer) updateConfigFromForm(values url.Values, cfg *config.Charact
 	// General (Character & Game)
 	if sections.General {
 		cfg.Character.StashToShared = values.Has("characterStashToShared")
+		switch stashFullAction := values.Get("characterStashFullAction"); stashFullAction {
+		case config.StashFullActionEvict, config.StashFullActionMule:
+			cfg.Character.StashFullAction = stashFullAction
+		default:
+			cfg.Character.StashFullAction = config.StashFullActionSkip
+		}
 		cfg.Character.UseTeleport = values.Has("characterUseTeleport")
+		cfg.Character.AvoidDeathEffects = values.Has("characterAvoidDeathEffects")
+		cfg.Character.DodgeHazards = values.Has("characterDodgeHazards")
 		cfg.Character.UseExtraBuffs = values.Has("characterUseExtraBuffs")
 		s.updateAutoStatSkillFromForm(values, cfg)
 
@@ -1932,6 +2219,36 @@ func (s *HttpServer) updateConfigFromForm(values url.Values, cfg *config.Charact
 		cfg.UseCentralizedPickit = values.Has("useCentralizedPickit")
 		cfg.Game.UseCainIdentify = values.Has("useCainIdentify")
 		cfg.Game.DisableIdentifyTome = values.Get("game.disableIdentifyTome") == "on"
+		cfg.Game.Identify.Policy = config.IdentifyPolicyTown
+		if values.Get("gameIdentifyPolicy") == config.IdentifyPolicyStash {
+			cfg.Game.Identify.Policy = config.IdentifyPolicyStash
+		}
+		cfg.Game.Identify.InField = values.Has("gameIdentifyInField")
+		cfg.Game.Identify.NeverIdentify = []string{}
+		for _, name := range strings.Split(values.Get("gameIdentifyNeverIdentify"), ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				cfg.Game.Identify.NeverIdentify = append(cfg.Game.Identify.NeverIdentify, name)
+			}
+		}
+		cfg.Game.Identify.UnidStashRules = strings.TrimSpace(values.Get("gameIdentifyUnidStashRules"))
+		cfg.Game.PickitOverrides = parsePickitOverrides(values.Get("gamePickitOverrides"))
+		cfg.Game.Players.Enabled = values.Has("gamePlayersEnabled")
+		cfg.Game.Players.Default, _ = strconv.Atoi(values.Get("gamePlayersDefault"))
+		cfg.Game.Players.PerRun = parsePlayersCounts(values.Get("gamePlayersPerRun"))
+		cfg.Game.SinglePlayer.ReuseLayouts = values.Has("gameSinglePlayerReuseLayouts")
+		cfg.Game.Flavor.Name = values.Get("gameFlavorName")
+		cfg.Game.Flavor.TCPHost = strings.TrimSpace(values.Get("gameFlavorTCPHost"))
+		cfg.Game.Flavor.StashTabs, _ = strconv.Atoi(values.Get("gameFlavorStashTabs"))
+		cfg.Game.Flavor.StashPageButtons.PrevX, _ = strconv.Atoi(values.Get("gameFlavorStashPagePrevX"))
+		cfg.Game.Flavor.StashPageButtons.PrevY, _ = strconv.Atoi(values.Get("gameFlavorStashPagePrevY"))
+		cfg.Game.Flavor.StashPageButtons.NextX, _ = strconv.Atoi(values.Get("gameFlavorStashPageNextX"))
+		cfg.Game.Flavor.StashPageButtons.NextY, _ = strconv.Atoi(values.Get("gameFlavorStashPageNextY"))
+		cfg.Game.Flavor.CurrencyItems = []string{}
+		for _, name := range strings.Split(values.Get("gameFlavorCurrencyItems"), ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				cfg.Game.Flavor.CurrencyItems = append(cfg.Game.Flavor.CurrencyItems, name)
+			}
+		}
 		cfg.Game.InteractWithShrines = values.Has("interactWithShrines")
 		cfg.Game.InteractWithChests = values.Has("interactWithChests")
 		cfg.Game.InteractWithSuperChests = values.Has("interactWithSuperChests")
@@ -1947,8 +2264,13 @@ func (s *HttpServer) updateConfigFromForm(values url.Values, cfg *config.Charact
 
 		if sections.GeneralExtras {
 			cfg.Character.UseSwapForBuffs = values.Has("useSwapForBuffs")
+			cfg.Character.TeleportWithSwap = values.Has("characterTeleportWithSwap")
 			cfg.Character.BuffOnNewArea = values.Has("characterBuffOnNewArea")
 			cfg.Character.BuffAfterWP = values.Has("characterBuffAfterWP")
+			cfg.Character.BuffOnRunStart = values.Has("characterBuffOnRunStart")
+			if v := values.Get("characterRebuffIntervalSeconds"); v != "" {
+				cfg.Character.RebuffIntervalSeconds, _ = strconv.Atoi(v)
+			}
 
 			// Process ClearPathDist - only relevant when teleport is disabled
 			if !cfg.Character.UseTeleport {
@@ -1988,18 +2310,39 @@ func (s *HttpServer) updateConfigFromForm(values url.Values, cfg *config.Charact
 			if v := values.Get("rejuvPotionCount"); v != "" {
 				cfg.Inventory.RejuvPotionCount, _ = strconv.Atoi(v)
 			}
+			if v, err := strconv.Atoi(values.Get("tpScrollTarget")); err == nil {
+				cfg.Inventory.TPScrollTarget = min(max(v, 0), 20)
+			}
+			if v, err := strconv.Atoi(values.Get("idScrollTarget")); err == nil {
+				cfg.Inventory.IDScrollTarget = min(max(v, 0), 20)
+			}
+			cfg.Inventory.BackupTPTome = values.Has("backupTPTome")
+			cfg.Inventory.ChickenOnNoTPs = values.Has("chickenOnNoTPs")
 
 			cfg.Game.CreateLobbyGames = values.Has("createLobbyGames")
 			cfg.Game.IsNonLadderChar = values.Has("isNonLadderChar")
 			cfg.Game.IsHardCoreChar = values.Has("isHardCoreChar")
 			cfg.Game.Difficulty = difficulty.Difficulty(values.Get("gameDifficulty"))
 			cfg.Game.RandomizeRuns = values.Has("gameRandomizeRuns")
+			cfg.Game.RunTimeBudget = values.Has("gameRunTimeBudget")
+			if v, err := strconv.Atoi(values.Get("gameRunFailuresMax")); err == nil {
+				cfg.Game.RunFailures.MaxFailures = min(max(v, 0), 20)
+			}
+			if v, err := strconv.Atoi(values.Get("gameRunFailuresBlacklistGames")); err == nil {
+				cfg.Game.RunFailures.BlacklistGames = min(max(v, 0), 1000)
+			}
 
 			// Back To Town Settings
 			cfg.BackToTown.NoHpPotions = values.Has("noHpPotions")
 			cfg.BackToTown.NoMpPotions = values.Has("noMpPotions")
 			cfg.BackToTown.MercDied = values.Has("mercDied")
 			cfg.BackToTown.EquipmentBroken = values.Has("equipmentBroken")
+			if v, err := strconv.Atoi(values.Get("repairPercent")); err == nil {
+				cfg.BackToTown.RepairPercent = min(max(v, 1), 100)
+			}
+			if v, err := strconv.Atoi(values.Get("etherealWarnPercent")); err == nil {
+				cfg.BackToTown.EtherealWarnPercent = min(max(v, 0), 100)
+			}
 
 			// Companion
 			cfg.Companion.Enabled = values.Has("companionEnabled")
@@ -2007,6 +2350,23 @@ func (s *HttpServer) updateConfigFromForm(values url.Values, cfg *config.Charact
 			cfg.Companion.LeaderName = values.Get("companionLeaderName")
 			cfg.Companion.GameNameTemplate = values.Get("companionGameNameTemplate")
 			cfg.Companion.GamePassword = values.Get("companionGamePassword")
+			if v, err := strconv.Atoi(values.Get("companionGameCounterDigits")); err == nil {
+				cfg.Companion.GameCounterDigits = min(max(v, 0), 5)
+			}
+			if v, err := strconv.Atoi(values.Get("companionGameNameSuffixLength")); err == nil {
+				cfg.Companion.GameNameSuffixLength = min(max(v, 0), 10)
+			}
+			cfg.Companion.PasswordPolicy = values.Get("companionPasswordPolicy")
+			if v, err := strconv.Atoi(values.Get("companionPasswordLength")); err == nil {
+				cfg.Companion.PasswordLength = min(max(v, 1), 15)
+			}
+			if v, err := strconv.Atoi(values.Get("companionNameCollisionRetries")); err == nil {
+				cfg.Companion.NameCollisionRetries = min(max(v, 0), 10)
+			}
+			cfg.Companion.FollowerMode = values.Get("companionFollowerMode")
+			if v, err := strconv.Atoi(values.Get("companionFollowDistance")); err == nil {
+				cfg.Companion.FollowDistance = min(max(v, 0), 40)
+			}
 
 			// Gambling
 			cfg.Gambling.Enabled = values.Has("gamblingEnabled")
@@ -2022,6 +2382,45 @@ func (s *HttpServer) updateConfigFromForm(values url.Values, cfg *config.Charact
 			} else {
 				cfg.Gambling.Items = []string{}
 			}
+			if v, err := strconv.Atoi(values.Get("gamblingStartGold")); err == nil {
+				cfg.Gambling.StartGold = min(max(v, 0), 5000000)
+			}
+			if v, err := strconv.Atoi(values.Get("gamblingGoldFloor")); err == nil {
+				cfg.Gambling.GoldFloor = min(max(v, 0), 5000000)
+			}
+
+			// Gold
+			if v, err := strconv.Atoi(values.Get("goldStashAbove")); err == nil {
+				cfg.Gold.StashAbove = min(max(v, 0), 5000000)
+			}
+			if v, err := strconv.Atoi(values.Get("goldTownVisitPercent")); err == nil {
+				cfg.Gold.TownVisitPercent = min(max(v, 0), 100)
+			}
+
+			// Stash organizer
+			cfg.StashOrganizer.Enabled = values.Has("stashOrganizerEnabled")
+			if v, err := strconv.Atoi(values.Get("stashOrganizerEveryGames")); err == nil {
+				cfg.StashOrganizer.EveryGames = min(max(v, 1), 1000)
+			}
+
+			// Session goals
+			cfg.SessionGoals.Enabled = values.Has("sessionGoalsEnabled")
+			if v, err := strconv.Atoi(values.Get("sessionGoalsMaxRuns")); err == nil {
+				cfg.SessionGoals.MaxRuns = min(max(v, 0), 100000)
+			}
+			if v, err := strconv.Atoi(values.Get("sessionGoalsMaxMinutes")); err == nil {
+				cfg.SessionGoals.MaxMinutes = min(max(v, 0), 100000)
+			}
+			cfg.SessionGoals.Items = []string{}
+			for _, name := range strings.Split(values.Get("sessionGoalsItems"), ",") {
+				if name = strings.TrimSpace(name); name != "" {
+					cfg.SessionGoals.Items = append(cfg.SessionGoals.Items, name)
+				}
+			}
+			if v, err := strconv.Atoi(values.Get("sessionGoalsLevel")); err == nil {
+				cfg.SessionGoals.Level = min(max(v, 0), 99)
+			}
+			cfg.SessionGoals.SwitchTo = strings.TrimSpace(values.Get("sessionGoalsSwitchTo"))
 		}
 
 		// Class-specific options are only updated when identity is explicitly updated.
@@ -2309,6 +2708,36 @@ func (s *HttpServer) updateClassSpecificConfig(values url.Values, cfg *config.Ch
 		cfg.Character.NovaSorceress.AggressiveNovaPositioning = values.Has("aggressiveNovaPositioning")
 	}
 
+	// Frenzy/Whirlwind Barbarian specific options
+	if cfg.Character.Class == "frenzy_barb" {
+		cfg.Character.FrenzyBarb.BuffParty = values.Has("frenzyBarbBuffParty")
+		cfg.Character.FrenzyBarb.HorkNormalMonsters = values.Has("frenzyBarbHorkNormalMonsters")
+		if v, err := strconv.Atoi(values.Get("frenzyBarbWhirlwindMinMonsters")); err == nil && v > 0 {
+			cfg.Character.FrenzyBarb.WhirlwindMinMonsters = v
+		}
+		if v, err := strconv.Atoi(values.Get("frenzyBarbHorkMonsterCheckRange")); err == nil && v > 0 {
+			cfg.Character.FrenzyBarb.HorkMonsterCheckRange = v
+		}
+	}
+
+	// Kicksin/Trapsin hybrid specific options
+	if cfg.Character.Class == "hybrid_trapsin" {
+		cfg.Character.HybridTrapsin.UseDragonFlight = values.Has("hybridTrapsinUseDragonFlight")
+		cfg.Character.HybridTrapsin.UseBurstOfSpeed = values.Has("hybridTrapsinUseBurstOfSpeed")
+		if v, err := strconv.Atoi(values.Get("hybridTrapsinDeathSentryMinCorpses")); err == nil && v > 0 {
+			cfg.Character.HybridTrapsin.DeathSentryMinCorpses = v
+		}
+	}
+
+	// Poison/Bone Necromancer specific options
+	if cfg.Character.Class == "poison_bone_necro" {
+		cfg.Character.PoisonBoneNecro.Mode = values.Get("poisonBoneNecroMode")
+		cfg.Character.PoisonBoneNecro.UseCorpseExplosion = values.Has("poisonBoneNecroUseCorpseExplosion")
+		if v, err := strconv.Atoi(values.Get("poisonBoneNecroCorpseExplosionMinMonsters")); err == nil && v > 0 {
+			cfg.Character.PoisonBoneNecro.CorpseExplosionMinMonsters = v
+		}
+	}
+
 	// Javazon specific options
 	if cfg.Character.Class == "javazon" {
 		cfg.Character.Javazon.DensityKillerEnabled = values.Has("javazonDensityKillerEnabled")
@@ -2336,6 +2765,11 @@ func (s *HttpServer) updateClassSpecificConfig(values url.Values, cfg *config.Ch
 		} else if cfg.Character.Javazon.DensityKillerForceRefillBelowPercent == 0 {
 			cfg.Character.Javazon.DensityKillerForceRefillBelowPercent = 50
 		}
+		cfg.Character.Javazon.UseDecoy = values.Has("javazonUseDecoy")
+		cfg.Character.Javazon.ReplenishFromStash = values.Has("javazonReplenishFromStash")
+		if v, err := strconv.Atoi(values.Get("javazonMinFuryJavelins")); err == nil && v >= 0 {
+			cfg.Character.Javazon.MinFuryJavelins = v
+		}
 	}
 
 	// Lightning Sorceress specific options
@@ -2360,10 +2794,22 @@ func getAllRunIDs() []string {
 		"spider_cavern", "arachnid_lair", "mephisto", "tristram",
 		"nihlathak", "summoner", "baal", "eldritch", "lower_kurast_chest",
 		"diablo", "leveling", "leveling_sequence", "quests", "terror_zone",
-		"utility", "shopping",
+		"utility", "shopping", "rush",
 	}
 }
 
+// parseRushQuests parses the comma separated rush quests, lowercased and without empty entries.
+func parseRushQuests(value string) []string {
+	quests := []string{}
+	for _, quest := range strings.Split(value, ",") {
+		if quest = strings.ToLower(strings.TrimSpace(quest)); quest != "" {
+			quests = append(quests, quest)
+		}
+	}
+
+	return quests
+}
+
 func sanitizeFavoriteRunSelection(selected []string) []string {
 	seen := make(map[string]struct{}, len(selected))
 	result := make([]string, 0, len(selected))
@@ -2516,11 +2962,24 @@ func (s *HttpServer) characterSettings(w http.ResponseWriter, r *http.Request) {
 			cfg.Game.RunewordRerollRules = nil
 		}
 		cfg.Character.StashToShared = r.Form.Has("characterStashToShared")
+		switch stashFullAction := r.Form.Get("characterStashFullAction"); stashFullAction {
+		case config.StashFullActionEvict, config.StashFullActionMule:
+			cfg.Character.StashFullAction = stashFullAction
+		default:
+			cfg.Character.StashFullAction = config.StashFullActionSkip
+		}
 		cfg.Character.UseTeleport = r.Form.Has("characterUseTeleport")
+		cfg.Character.AvoidDeathEffects = r.Form.Has("characterAvoidDeathEffects")
+		cfg.Character.DodgeHazards = r.Form.Has("characterDodgeHazards")
 		cfg.Character.UseExtraBuffs = r.Form.Has("characterUseExtraBuffs")
 		cfg.Character.UseSwapForBuffs = r.Form.Has("useSwapForBuffs")
+		cfg.Character.TeleportWithSwap = r.Form.Has("characterTeleportWithSwap")
 		cfg.Character.BuffOnNewArea = r.Form.Has("characterBuffOnNewArea")
 		cfg.Character.BuffAfterWP = r.Form.Has("characterBuffAfterWP")
+		cfg.Character.BuffOnRunStart = r.Form.Has("characterBuffOnRunStart")
+		if v := r.Form.Get("characterRebuffIntervalSeconds"); v != "" {
+			cfg.Character.RebuffIntervalSeconds, _ = strconv.Atoi(v)
+		}
 		s.updateAutoStatSkillFromForm(r.Form, cfg)
 
 		// Process ClearPathDist - only relevant when teleport is disabled
@@ -2750,6 +3209,36 @@ func (s *HttpServer) characterSettings(w http.ResponseWriter, r *http.Request) {
 			cfg.Character.NovaSorceress.AggressiveNovaPositioning = r.Form.Has("aggressiveNovaPositioning")
 		}
 
+		// Frenzy/Whirlwind Barbarian specific options
+		if cfg.Character.Class == "frenzy_barb" {
+			cfg.Character.FrenzyBarb.BuffParty = r.Form.Has("frenzyBarbBuffParty")
+			cfg.Character.FrenzyBarb.HorkNormalMonsters = r.Form.Has("frenzyBarbHorkNormalMonsters")
+			if v, err := strconv.Atoi(r.Form.Get("frenzyBarbWhirlwindMinMonsters")); err == nil && v > 0 {
+				cfg.Character.FrenzyBarb.WhirlwindMinMonsters = v
+			}
+			if v, err := strconv.Atoi(r.Form.Get("frenzyBarbHorkMonsterCheckRange")); err == nil && v > 0 {
+				cfg.Character.FrenzyBarb.HorkMonsterCheckRange = v
+			}
+		}
+
+		// Kicksin/Trapsin hybrid specific options
+		if cfg.Character.Class == "hybrid_trapsin" {
+			cfg.Character.HybridTrapsin.UseDragonFlight = r.Form.Has("hybridTrapsinUseDragonFlight")
+			cfg.Character.HybridTrapsin.UseBurstOfSpeed = r.Form.Has("hybridTrapsinUseBurstOfSpeed")
+			if v, err := strconv.Atoi(r.Form.Get("hybridTrapsinDeathSentryMinCorpses")); err == nil && v > 0 {
+				cfg.Character.HybridTrapsin.DeathSentryMinCorpses = v
+			}
+		}
+
+		// Poison/Bone Necromancer specific options
+		if cfg.Character.Class == "poison_bone_necro" {
+			cfg.Character.PoisonBoneNecro.Mode = r.Form.Get("poisonBoneNecroMode")
+			cfg.Character.PoisonBoneNecro.UseCorpseExplosion = r.Form.Has("poisonBoneNecroUseCorpseExplosion")
+			if v, err := strconv.Atoi(r.Form.Get("poisonBoneNecroCorpseExplosionMinMonsters")); err == nil && v > 0 {
+				cfg.Character.PoisonBoneNecro.CorpseExplosionMinMonsters = v
+			}
+		}
+
 		// Javazon specific options
 		if cfg.Character.Class == "javazon" {
 			cfg.Character.Javazon.DensityKillerEnabled = r.Form.Has("javazonDensityKillerEnabled")
@@ -2777,6 +3266,11 @@ func (s *HttpServer) characterSettings(w http.ResponseWriter, r *http.Request) {
 			} else if cfg.Character.Javazon.DensityKillerForceRefillBelowPercent == 0 {
 				cfg.Character.Javazon.DensityKillerForceRefillBelowPercent = 50
 			}
+			cfg.Character.Javazon.UseDecoy = r.Form.Has("javazonUseDecoy")
+			cfg.Character.Javazon.ReplenishFromStash = r.Form.Has("javazonReplenishFromStash")
+			if v, err := strconv.Atoi(r.Form.Get("javazonMinFuryJavelins")); err == nil && v >= 0 {
+				cfg.Character.Javazon.MinFuryJavelins = v
+			}
 		}
 
 		for y, row := range cfg.Inventory.InventoryLock {
@@ -2794,6 +3288,10 @@ func (s *HttpServer) characterSettings(w http.ResponseWriter, r *http.Request) {
 		cfg.Inventory.HealingPotionCount, _ = strconv.Atoi(r.Form.Get("healingPotionCount"))
 		cfg.Inventory.ManaPotionCount, _ = strconv.Atoi(r.Form.Get("manaPotionCount"))
 		cfg.Inventory.RejuvPotionCount, _ = strconv.Atoi(r.Form.Get("rejuvPotionCount"))
+		cfg.Inventory.TPScrollTarget = s.getIntFromForm(r, "tpScrollTarget", 0, 20, 0)
+		cfg.Inventory.IDScrollTarget = s.getIntFromForm(r, "idScrollTarget", 0, 20, 0)
+		cfg.Inventory.BackupTPTome = r.Form.Has("backupTPTome")
+		cfg.Inventory.ChickenOnNoTPs = r.Form.Has("chickenOnNoTPs")
 
 		// Game
 		cfg.Game.CreateLobbyGames = r.Form.Has("createLobbyGames")
@@ -2801,6 +3299,36 @@ func (s *HttpServer) characterSettings(w http.ResponseWriter, r *http.Request) {
 		cfg.UseCentralizedPickit = r.Form.Has("useCentralizedPickit")
 		cfg.Game.UseCainIdentify = r.Form.Has("useCainIdentify")
 		cfg.Game.DisableIdentifyTome = r.PostFormValue("game.disableIdentifyTome") == "on"
+		cfg.Game.Identify.Policy = config.IdentifyPolicyTown
+		if r.Form.Get("gameIdentifyPolicy") == config.IdentifyPolicyStash {
+			cfg.Game.Identify.Policy = config.IdentifyPolicyStash
+		}
+		cfg.Game.Identify.InField = r.Form.Has("gameIdentifyInField")
+		cfg.Game.Identify.NeverIdentify = []string{}
+		for _, name := range strings.Split(r.Form.Get("gameIdentifyNeverIdentify"), ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				cfg.Game.Identify.NeverIdentify = append(cfg.Game.Identify.NeverIdentify, name)
+			}
+		}
+		cfg.Game.Identify.UnidStashRules = strings.TrimSpace(r.Form.Get("gameIdentifyUnidStashRules"))
+		cfg.Game.PickitOverrides = parsePickitOverrides(r.Form.Get("gamePickitOverrides"))
+		cfg.Game.Players.Enabled = r.Form.Has("gamePlayersEnabled")
+		cfg.Game.Players.Default, _ = strconv.Atoi(r.Form.Get("gamePlayersDefault"))
+		cfg.Game.Players.PerRun = parsePlayersCounts(r.Form.Get("gamePlayersPerRun"))
+		cfg.Game.SinglePlayer.ReuseLayouts = r.Form.Has("gameSinglePlayerReuseLayouts")
+		cfg.Game.Flavor.Name = r.Form.Get("gameFlavorName")
+		cfg.Game.Flavor.TCPHost = strings.TrimSpace(r.Form.Get("gameFlavorTCPHost"))
+		cfg.Game.Flavor.StashTabs, _ = strconv.Atoi(r.Form.Get("gameFlavorStashTabs"))
+		cfg.Game.Flavor.StashPageButtons.PrevX, _ = strconv.Atoi(r.Form.Get("gameFlavorStashPagePrevX"))
+		cfg.Game.Flavor.StashPageButtons.PrevY, _ = strconv.Atoi(r.Form.Get("gameFlavorStashPagePrevY"))
+		cfg.Game.Flavor.StashPageButtons.NextX, _ = strconv.Atoi(r.Form.Get("gameFlavorStashPageNextX"))
+		cfg.Game.Flavor.StashPageButtons.NextY, _ = strconv.Atoi(r.Form.Get("gameFlavorStashPageNextY"))
+		cfg.Game.Flavor.CurrencyItems = []string{}
+		for _, name := range strings.Split(r.Form.Get("gameFlavorCurrencyItems"), ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				cfg.Game.Flavor.CurrencyItems = append(cfg.Game.Flavor.CurrencyItems, name)
+			}
+		}
 		cfg.Game.InteractWithShrines = r.Form.Has("interactWithShrines")
 		cfg.Game.InteractWithChests = r.Form.Has("interactWithChests")
 		cfg.Game.InteractWithSuperChests = r.Form.Has("interactWithSuperChests")
@@ -2821,6 +3349,9 @@ func (s *HttpServer) characterSettings(w http.ResponseWriter, r *http.Request) {
 		cfg.PacketCasting.UseForSkillSelection = r.Form.Has("packetCastingUseForSkillSelection")
 		cfg.Game.Difficulty = difficulty.Difficulty(r.Form.Get("gameDifficulty"))
 		cfg.Game.RandomizeRuns = r.Form.Has("gameRandomizeRuns")
+		cfg.Game.RunTimeBudget = r.Form.Has("gameRunTimeBudget")
+		cfg.Game.RunFailures.MaxFailures = s.getIntFromForm(r, "gameRunFailuresMax", 0, 20, cfg.Game.RunFailures.MaxFailures)
+		cfg.Game.RunFailures.BlacklistGames = s.getIntFromForm(r, "gameRunFailuresBlacklistGames", 0, 1000, cfg.Game.RunFailures.BlacklistGames)
 
 		// Runs specific config
 		enabledRuns := make([]config.Run, 0)
@@ -2832,6 +3363,7 @@ func (s *HttpServer) characterSettings(w http.ResponseWriter, r *http.Request) {
 		s.applyShoppingFromForm(r.Form, cfg)
 
 		cfg.Game.Cows.OpenChests = r.Form.Has("gameCowsOpenChests")
+		cfg.Game.Cows.AvoidKing = r.Form.Has("gameCowsAvoidKing")
 
 		cfg.Game.Pit.MoveThroughBlackMarsh = r.Form.Has("gamePitMoveThroughBlackMarsh")
 		cfg.Game.Pit.OpenChests = r.Form.Has("gamePitOpenChests")
@@ -2878,12 +3410,34 @@ func (s *HttpServer) characterSettings(w http.ResponseWriter, r *http.Request) {
 
 		cfg.Game.Nihlathak.ClearArea = r.Form.Has("gameNihlathakClearArea")
 		cfg.Game.Summoner.KillFireEye = r.Form.Has("gameSummonerKillFireEye")
+		cfg.Game.Summoner.ClearPath = r.Form.Has("gameSummonerClearPath")
 
 		cfg.Game.Baal.KillBaal = r.Form.Has("gameBaalKillBaal")
 		cfg.Game.Baal.DollQuit = r.Form.Has("gameBaalDollQuit")
 		cfg.Game.Baal.SoulQuit = r.Form.Has("gameBaalSoulQuit")
 		cfg.Game.Baal.ClearFloors = r.Form.Has("gameBaalClearFloors")
 		cfg.Game.Baal.OnlyElites = r.Form.Has("gameBaalOnlyElites")
+		cfg.Game.Baal.Leader.Enabled = r.Form.Has("gameBaalLeaderEnabled")
+		cfg.Game.Baal.Leader.TPMessage = r.Form.Get("gameBaalLeaderTPMessage")
+		cfg.Game.Baal.Leader.GoMessage = r.Form.Get("gameBaalLeaderGoMessage")
+		cfg.Game.Baal.Leader.Leechers = s.getIntFromForm(r, "gameBaalLeaderLeechers", 0, 7, 0)
+		cfg.Game.Baal.Leader.WaitAtThrone = r.Form.Has("gameBaalLeaderWaitAtThrone")
+		cfg.Game.Baal.Leader.WaitBeforeBaal = r.Form.Has("gameBaalLeaderWaitBeforeBaal")
+		cfg.Game.Baal.Leader.WaitSeconds = s.getIntFromForm(r, "gameBaalLeaderWaitSeconds", 0, 600, 0)
+		cfg.Game.Baal.Leader.NextGameSeconds = s.getIntFromForm(r, "gameBaalLeaderNextGameSeconds", 0, 3600, 0)
+
+		cfg.Game.DiabloClone.OnlyWhenSeen = r.Form.Has("gameDiabloCloneOnlyWhenSeen")
+		cfg.Game.DiabloClone.MinLife = s.getIntFromForm(r, "gameDiabloCloneMinLife", 0, 10000, 0)
+		cfg.Game.DiabloClone.CheckResists = r.Form.Has("gameDiabloCloneCheckResists")
+		cfg.Game.DiabloClone.MinResists = s.getIntFromForm(r, "gameDiabloCloneMinResists", -100, 75, 0)
+		cfg.Game.TalRashaTombs.OnlyElites = r.Form.Has("gameTalRashaTombsOnlyElites")
+		cfg.Game.TalRashaTombs.SkipTrueTomb = r.Form.Has("gameTalRashaTombsSkipTrueTomb")
+
+		cfg.Game.Ubers.FarmKeys = r.Form.Has("gameUbersFarmKeys")
+		cfg.Game.Ubers.MinLife = s.getIntFromForm(r, "gameUbersMinLife", 0, 10000, 0)
+		cfg.Game.Ubers.CheckResists = r.Form.Has("gameUbersCheckResists")
+		cfg.Game.Ubers.MinResists = s.getIntFromForm(r, "gameUbersMinResists", -100, 75, 0)
+		cfg.Game.Ubers.RequireLifeTap = r.Form.Has("gameUbersRequireLifeTap")
 
 		cfg.Game.Eldritch.KillShenk = r.Form.Has("gameEldritchKillShenk")
 
@@ -2898,10 +3452,19 @@ func (s *HttpServer) characterSettings(w http.ResponseWriter, r *http.Request) {
 		cfg.Game.Leveling.EnsureKeyBinding = r.Form.Has("gameLevelingEnsureKeyBinding")
 		cfg.Game.Leveling.AutoEquip = r.Form.Has("gameLevelingAutoEquip")
 		cfg.Game.Leveling.AutoEquipFromSharedStash = r.Form.Has("gameLevelingAutoEquipFromSharedStash")
+		cfg.Game.Leveling.AutoEquipNonLeveling = r.Form.Has("gameLevelingAutoEquipNonLeveling")
+		cfg.Game.Leveling.AutoEquipMinUpgrade = s.getIntFromForm(r, "gameLevelingAutoEquipMinUpgrade", 0, 1000, 0)
+		cfg.Game.Leveling.AutoEquipStatWeights = parseStatWeights(r.Form.Get("gameLevelingAutoEquipStatWeights"))
 		cfg.Game.Leveling.NightmareRequiredLevel = s.getIntFromForm(r, "gameLevelingNightmareRequiredLevel", 1, 99, 41)
 		cfg.Game.Leveling.HellRequiredLevel = s.getIntFromForm(r, "gameLevelingHellRequiredLevel", 1, 99, 70)
 		cfg.Game.Leveling.HellRequiredFireRes = s.getIntFromForm(r, "gameLevelingHellRequiredFireRes", -100, 75, 15)
 		cfg.Game.Leveling.HellRequiredLightRes = s.getIntFromForm(r, "gameLevelingHellRequiredLightRes", -100, 75, -10)
+		cfg.Game.Leveling.Act3RequiredLevel = s.getIntFromForm(r, "gameLevelingAct3RequiredLevel", 0, 99, 0)
+		cfg.Game.Leveling.NormalAct5RequiredLevel = s.getIntFromForm(r, "gameLevelingNormalAct5RequiredLevel", 0, 99, 0)
+		cfg.Game.Leveling.NightmareAct5RequiredLevel = s.getIntFromForm(r, "gameLevelingNightmareAct5RequiredLevel", 0, 99, 0)
+		cfg.Game.Leveling.BackfillQuests = r.Form.Has("gameLevelingBackfillQuests")
+		cfg.Game.Leveling.CollectWaypoints = r.Form.Has("gameLevelingCollectWaypoints")
+		cfg.Game.Leveling.MinXPPerHour = parseXPThresholds(r.Form.Get("gameLevelingMinXPPerHour"))
 
 		cfg.Game.LevelingSequence.SequenceFile = r.Form.Get("gameLevelingSequenceFile")
 
@@ -2920,10 +3483,25 @@ func (s *HttpServer) characterSettings(w http.ResponseWriter, r *http.Request) {
 		cfg.Game.Quests.KillShenk = r.Form.Has("gameQuestsKillShenk")
 		cfg.Game.Quests.RescueAnya = r.Form.Has("gameQuestsRescueAnya")
 		cfg.Game.Quests.KillAncients = r.Form.Has("gameQuestsKillAncients")
+		cfg.Game.Quests.PersonalizeItem = strings.TrimSpace(r.Form.Get("gameQuestsPersonalizeItem"))
+		cfg.Game.Imbue.Enabled = r.Form.Has("gameImbueEnabled")
+		cfg.Game.Imbue.BaseRules = strings.TrimSpace(r.Form.Get("gameImbueBaseRules"))
+		cfg.Game.Larzuk.Enabled = r.Form.Has("gameLarzukEnabled")
+		cfg.Game.Larzuk.Queue = []string{}
+		for _, name := range strings.Split(r.Form.Get("gameLarzukQueue"), ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				cfg.Game.Larzuk.Queue = append(cfg.Game.Larzuk.Queue, name)
+			}
+		}
+		cfg.Game.QuestBugs.Andariel = r.Form.Has("gameQuestBugsAndariel")
+		cfg.Game.QuestBugs.Council = r.Form.Has("gameQuestBugsCouncil")
+		cfg.Game.QuestBugs.Mephisto = r.Form.Has("gameQuestBugsMephisto")
+		cfg.Game.QuestBugs.Shenk = r.Form.Has("gameQuestBugsShenk")
 
 		cfg.Game.TerrorZone.FocusOnElitePacks = r.Form.Has("gameTerrorZoneFocusOnElitePacks")
 		cfg.Game.TerrorZone.SkipOtherRuns = r.Form.Has("gameTerrorZoneSkipOtherRuns")
 		cfg.Game.TerrorZone.OpenChests = r.Form.Has("gameTerrorZoneOpenChests")
+		cfg.Game.TerrorZone.PrioritizeMatchingRuns = r.Form.Has("gameTerrorZonePrioritizeMatchingRuns")
 
 		cfg.Game.TerrorZone.SkipOnImmunities = []stat.Resist{}
 		for _, i := range r.Form["gameTerrorZoneSkipOnImmunities[]"] {
@@ -2937,6 +3515,11 @@ func (s *HttpServer) characterSettings(w http.ResponseWriter, r *http.Request) {
 		}
 		cfg.Game.TerrorZone.Areas = tzAreas
 
+		// Rush
+		cfg.Game.Rush.Rushee = strings.TrimSpace(r.Form.Get("gameRushRushee"))
+		cfg.Game.Rush.Quests = parseRushQuests(r.Form.Get("gameRushQuests"))
+		cfg.Game.Rush.WaitSeconds = s.getIntFromForm(r, "gameRushWaitSeconds", 0, 1800, 0)
+
 		// Utility
 		if parkingActStr := r.Form.Get("gameUtilityParkingAct"); parkingActStr != "" {
 			if parkingAct, err := strconv.Atoi(parkingActStr); err == nil {
@@ -2958,6 +3541,29 @@ func (s *HttpServer) characterSettings(w http.ResponseWriter, r *http.Request) {
 		} else {
 			cfg.Gambling.Items = []string{}
 		}
+		cfg.Gambling.StartGold = s.getIntFromForm(r, "gamblingStartGold", 0, 5000000, 2480000)
+		cfg.Gambling.GoldFloor = s.getIntFromForm(r, "gamblingGoldFloor", 0, 5000000, 500000)
+
+		// Gold
+		cfg.Gold.StashAbove = s.getIntFromForm(r, "goldStashAbove", 0, 5000000, 0)
+		cfg.Gold.TownVisitPercent = s.getIntFromForm(r, "goldTownVisitPercent", 0, 100, 0)
+
+		// Stash organizer
+		cfg.StashOrganizer.Enabled = r.Form.Has("stashOrganizerEnabled")
+		cfg.StashOrganizer.EveryGames = s.getIntFromForm(r, "stashOrganizerEveryGames", 1, 1000, 10)
+
+		// Session goals
+		cfg.SessionGoals.Enabled = r.Form.Has("sessionGoalsEnabled")
+		cfg.SessionGoals.MaxRuns = s.getIntFromForm(r, "sessionGoalsMaxRuns", 0, 100000, 0)
+		cfg.SessionGoals.MaxMinutes = s.getIntFromForm(r, "sessionGoalsMaxMinutes", 0, 100000, 0)
+		cfg.SessionGoals.Items = []string{}
+		for _, name := range strings.Split(r.Form.Get("sessionGoalsItems"), ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				cfg.SessionGoals.Items = append(cfg.SessionGoals.Items, name)
+			}
+		}
+		cfg.SessionGoals.Level = s.getIntFromForm(r, "sessionGoalsLevel", 0, 99, 0)
+		cfg.SessionGoals.SwitchTo = strings.TrimSpace(r.Form.Get("sessionGoalsSwitchTo"))
 
 		// Cube Recipes
 		cfg.CubeRecipes.Enabled = r.Form.Has("enableCubeRecipes")
@@ -2979,12 +3585,27 @@ func (s *HttpServer) characterSettings(w http.ResponseWriter, r *http.Request) {
 		cfg.Companion.LeaderName = r.Form.Get("companionLeaderName")
 		cfg.Companion.GameNameTemplate = r.Form.Get("companionGameNameTemplate")
 		cfg.Companion.GamePassword = r.Form.Get("companionGamePassword")
+		cfg.Companion.GameCounterDigits = s.getIntFromForm(r, "companionGameCounterDigits", 0, 5, 0)
+		cfg.Companion.GameNameSuffixLength = s.getIntFromForm(r, "companionGameNameSuffixLength", 0, 10, 0)
+		cfg.Companion.PasswordPolicy = r.Form.Get("companionPasswordPolicy")
+		cfg.Companion.PasswordLength = s.getIntFromForm(r, "companionPasswordLength", 1, 15, 4)
+		cfg.Companion.NameCollisionRetries = s.getIntFromForm(r, "companionNameCollisionRetries", 0, 10, 0)
+		cfg.Companion.FollowerMode = r.Form.Get("companionFollowerMode")
+		if v, err := strconv.Atoi(r.Form.Get("companionFollowDistance")); err == nil {
+			cfg.Companion.FollowDistance = min(max(v, 0), 40)
+		}
 
 		// Back to town config
 		cfg.BackToTown.NoHpPotions = r.Form.Has("noHpPotions")
 		cfg.BackToTown.NoMpPotions = r.Form.Has("noMpPotions")
 		cfg.BackToTown.MercDied = r.Form.Has("mercDied")
 		cfg.BackToTown.EquipmentBroken = r.Form.Has("equipmentBroken")
+		if v, err := strconv.Atoi(r.Form.Get("repairPercent")); err == nil {
+			cfg.BackToTown.RepairPercent = min(max(v, 1), 100)
+		}
+		if v, err := strconv.Atoi(r.Form.Get("etherealWarnPercent")); err == nil {
+			cfg.BackToTown.EtherealWarnPercent = min(max(v, 0), 100)
+		}
 
 		// Scheduler
 		cfg.Scheduler.Enabled = r.Form.Has("schedulerEnabled")
@@ -2992,12 +3613,25 @@ func (s *HttpServer) characterSettings(w http.ResponseWriter, r *http.Request) {
 		if cfg.Scheduler.Mode == "" {
 			cfg.Scheduler.Mode = "timeSlots"
 		}
+		cfg.Scheduler.SuspendOnBreaks = r.Form.Has("schedulerSuspendOnBreaks")
 
 		// Global variance for time slots mode
 		if v := r.Form.Get("globalVarianceMin"); v != "" {
 			cfg.Scheduler.GlobalVarianceMin, _ = strconv.Atoi(v)
 		}
 
+		// Play sessions and breaks inside the time slots
+		for field, target := range map[string]*int{
+			"slotBreaksSessionMinutes":  &cfg.Scheduler.Breaks.SessionMinutes,
+			"slotBreaksSessionVariance": &cfg.Scheduler.Breaks.SessionVariance,
+			"slotBreaksBreakMinutes":    &cfg.Scheduler.Breaks.BreakMinutes,
+			"slotBreaksBreakVariance":   &cfg.Scheduler.Breaks.BreakVariance,
+		} {
+			if v, err := strconv.Atoi(r.Form.Get(field)); err == nil {
+				*target = min(max(v, 0), 1440)
+			}
+		}
+
 		// Reset scheduler days if we are updating them
 		if len(cfg.Scheduler.Days) != 7 {
 			cfg.Scheduler.Days = make([]config.Day, 7)
@@ -3256,6 +3890,74 @@ func (s *HttpServer) companionJoin(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(map[string]bool{"success": true})
 }
 
+// gameName returns the game the supervisor is in and the one it will create next, so followers and external tools
+// know where to join.
+func (s *HttpServer) gameName(w http.ResponseWriter, r *http.Request) {
+	supervisor := r.URL.Query().Get("supervisor")
+	if supervisor == "" {
+		http.Error(w, "supervisor parameter required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := s.manager.GetContext(supervisor)
+	if ctx == nil || ctx.Manager == nil {
+		http.Error(w, "Supervisor not running", http.StatusNotFound)
+		return
+	}
+
+	response := struct {
+		InGame  bool            `json:"inGame"`
+		Current game.LobbyGame  `json:"current"`
+		Next    *game.LobbyGame `json:"next,omitempty"`
+	}{InGame: ctx.Manager.InGame()}
+
+	if response.InGame {
+		response.Current = game.LobbyGame{Name: ctx.GameReader.LastGameName(), Password: ctx.GameReader.LastGamePass()}
+	}
+	// Names are only known in advance for lobby games, the other ones are picked by the game
+	if ctx.CharacterCfg.Game.CreateLobbyGames {
+		next := ctx.Manager.NextLobbyGame(ctx.CharacterCfg.Game.PublicGameCounter)
+		response.Next = &next
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func (s *HttpServer) validateConfig(w http.ResponseWriter, r *http.Request) {
+	issues := bot.ValidateConfigs()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(struct {
+		Valid  bool                     `json:"valid"`
+		Issues []config.ValidationIssue `json:"issues"`
+	}{
+		Valid:  !config.HasValidationErrors(issues),
+		Issues: issues,
+	})
+}
+
+// resolvedConfig shows the character config the bot runs with, the inherited profile settings included.
+func (s *HttpServer) resolvedConfig(w http.ResponseWriter, r *http.Request) {
+	supervisor := r.URL.Query().Get("supervisor")
+	if supervisor == "" {
+		http.Error(w, "supervisor parameter required", http.StatusBadRequest)
+		return
+	}
+
+	resolved, files, err := config.ResolvedCharacterYAML(supervisor)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/yaml; charset=utf-8")
+	for _, f := range files {
+		fmt.Fprintf(w, "# %s\n", f)
+	}
+	w.Write(resolved)
+}
+
 // applyShoppingFromForm parses shopping-specific fields (used in updateConfigFromForm)
 func (s *HttpServer) applyShoppingFromForm(values url.Values, cfg *config.CharacterCfg) {
 	cfg.Shopping.Enabled = values.Has("shoppingEnabled")
@@ -3271,6 +3973,7 @@ func (s *HttpServer) applyShoppingFromForm(values url.Values, cfg *config.Charac
 	}
 
 	cfg.Shopping.ShoppingRulesFile = values.Get("shoppingRulesFile")
+	cfg.Shopping.ShopUntilFound = values.Has("shoppingShopUntilFound")
 
 	if raw := strings.TrimSpace(values.Get("shoppingItemTypes")); raw != "" {
 		parts := strings.Split(raw, ",")
@@ -3313,6 +4016,7 @@ func (s *HttpServer) applyRunDetails(values url.Values, cfg *config.CharacterCfg
 			cfg.Game.Pit.OnlyClearLevel2 = values.Has("gamePitOnlyClearLevel2")
 		case "cows":
 			cfg.Game.Cows.OpenChests = values.Has("gameCowsOpenChests")
+			cfg.Game.Cows.AvoidKing = values.Has("gameCowsAvoidKing")
 		case "pindleskin":
 			if raw, ok := values["gamePindleskinSkipOnImmunities[]"]; ok {
 				skips := make([]stat.Resist, 0, len(raw))
@@ -3356,12 +4060,50 @@ func (s *HttpServer) applyRunDetails(values url.Values, cfg *config.CharacterCfg
 			cfg.Game.Nihlathak.ClearArea = values.Has("gameNihlathakClearArea")
 		case "summoner":
 			cfg.Game.Summoner.KillFireEye = values.Has("gameSummonerKillFireEye")
+			cfg.Game.Summoner.ClearPath = values.Has("gameSummonerClearPath")
 		case "baal":
 			cfg.Game.Baal.KillBaal = values.Has("gameBaalKillBaal")
 			cfg.Game.Baal.DollQuit = values.Has("gameBaalDollQuit")
 			cfg.Game.Baal.SoulQuit = values.Has("gameBaalSoulQuit")
 			cfg.Game.Baal.ClearFloors = values.Has("gameBaalClearFloors")
 			cfg.Game.Baal.OnlyElites = values.Has("gameBaalOnlyElites")
+			cfg.Game.Baal.Leader.Enabled = values.Has("gameBaalLeaderEnabled")
+			cfg.Game.Baal.Leader.TPMessage = values.Get("gameBaalLeaderTPMessage")
+			cfg.Game.Baal.Leader.GoMessage = values.Get("gameBaalLeaderGoMessage")
+			for field, value := range map[string]*int{
+				"gameBaalLeaderLeechers":        &cfg.Game.Baal.Leader.Leechers,
+				"gameBaalLeaderWaitSeconds":     &cfg.Game.Baal.Leader.WaitSeconds,
+				"gameBaalLeaderNextGameSeconds": &cfg.Game.Baal.Leader.NextGameSeconds,
+			} {
+				if v, err := strconv.Atoi(values.Get(field)); err == nil {
+					*value = max(v, 0)
+				}
+			}
+			cfg.Game.Baal.Leader.WaitAtThrone = values.Has("gameBaalLeaderWaitAtThrone")
+			cfg.Game.Baal.Leader.WaitBeforeBaal = values.Has("gameBaalLeaderWaitBeforeBaal")
+		case "dclone":
+			cfg.Game.DiabloClone.OnlyWhenSeen = values.Has("gameDiabloCloneOnlyWhenSeen")
+			if v, err := strconv.Atoi(values.Get("gameDiabloCloneMinLife")); err == nil {
+				cfg.Game.DiabloClone.MinLife = max(0, v)
+			}
+			cfg.Game.DiabloClone.CheckResists = values.Has("gameDiabloCloneCheckResists")
+			if v, err := strconv.Atoi(values.Get("gameDiabloCloneMinResists")); err == nil {
+				cfg.Game.DiabloClone.MinResists = min(max(v, -100), 75)
+			}
+		case "tal_rasha_tombs":
+			cfg.Game.TalRashaTombs.OnlyElites = values.Has("gameTalRashaTombsOnlyElites")
+			cfg.Game.TalRashaTombs.SkipTrueTomb = values.Has("gameTalRashaTombsSkipTrueTomb")
+		case "uber_keys":
+			cfg.Game.Ubers.FarmKeys = values.Has("gameUbersFarmKeys")
+		case "uber_torch":
+			if v, err := strconv.Atoi(values.Get("gameUbersMinLife")); err == nil {
+				cfg.Game.Ubers.MinLife = max(0, v)
+			}
+			cfg.Game.Ubers.CheckResists = values.Has("gameUbersCheckResists")
+			if v, err := strconv.Atoi(values.Get("gameUbersMinResists")); err == nil {
+				cfg.Game.Ubers.MinResists = min(max(v, -100), 75)
+			}
+			cfg.Game.Ubers.RequireLifeTap = values.Has("gameUbersRequireLifeTap")
 		case "eldritch":
 			cfg.Game.Eldritch.KillShenk = values.Has("gameEldritchKillShenk")
 		case "lower_kurast_chest":
@@ -3386,6 +4128,18 @@ func (s *HttpServer) applyRunDetails(values url.Values, cfg *config.CharacterCfg
 			cfg.Game.Leveling.EnsureKeyBinding = values.Has("gameLevelingEnsureKeyBinding")
 			cfg.Game.Leveling.AutoEquip = values.Has("gameLevelingAutoEquip")
 			cfg.Game.Leveling.AutoEquipFromSharedStash = values.Has("gameLevelingAutoEquipFromSharedStash")
+			cfg.Game.Leveling.AutoEquipNonLeveling = values.Has("gameLevelingAutoEquipNonLeveling")
+			if v := values.Get("gameLevelingAutoEquipMinUpgrade"); v != "" {
+				if n, err := strconv.Atoi(v); err == nil {
+					if n < 0 {
+						n = 0
+					} else if n > 1000 {
+						n = 1000
+					}
+					cfg.Game.Leveling.AutoEquipMinUpgrade = n
+				}
+			}
+			cfg.Game.Leveling.AutoEquipStatWeights = parseStatWeights(values.Get("gameLevelingAutoEquipStatWeights"))
 			if v := values.Get("gameLevelingNightmareRequiredLevel"); v != "" {
 				if n, err := strconv.Atoi(v); err == nil {
 					if n < 0 {
@@ -3426,6 +4180,39 @@ func (s *HttpServer) applyRunDetails(values url.Values, cfg *config.CharacterCfg
 					cfg.Game.Leveling.HellRequiredLightRes = n
 				}
 			}
+			if v := values.Get("gameLevelingAct3RequiredLevel"); v != "" {
+				if n, err := strconv.Atoi(v); err == nil {
+					if n < 0 {
+						n = 0
+					} else if n > 99 {
+						n = 99
+					}
+					cfg.Game.Leveling.Act3RequiredLevel = n
+				}
+			}
+			if v := values.Get("gameLevelingNormalAct5RequiredLevel"); v != "" {
+				if n, err := strconv.Atoi(v); err == nil {
+					if n < 0 {
+						n = 0
+					} else if n > 99 {
+						n = 99
+					}
+					cfg.Game.Leveling.NormalAct5RequiredLevel = n
+				}
+			}
+			if v := values.Get("gameLevelingNightmareAct5RequiredLevel"); v != "" {
+				if n, err := strconv.Atoi(v); err == nil {
+					if n < 0 {
+						n = 0
+					} else if n > 99 {
+						n = 99
+					}
+					cfg.Game.Leveling.NightmareAct5RequiredLevel = n
+				}
+			}
+			cfg.Game.Leveling.BackfillQuests = values.Has("gameLevelingBackfillQuests")
+			cfg.Game.Leveling.CollectWaypoints = values.Has("gameLevelingCollectWaypoints")
+			cfg.Game.Leveling.MinXPPerHour = parseXPThresholds(values.Get("gameLevelingMinXPPerHour"))
 		case "leveling_sequence":
 			cfg.Game.LevelingSequence.SequenceFile = values.Get("gameLevelingSequenceFile")
 		case "quests":
@@ -3439,10 +4226,25 @@ func (s *HttpServer) applyRunDetails(values url.Values, cfg *config.CharacterCfg
 			cfg.Game.Quests.KillShenk = values.Has("gameQuestsKillShenk")
 			cfg.Game.Quests.RescueAnya = values.Has("gameQuestsRescueAnya")
 			cfg.Game.Quests.KillAncients = values.Has("gameQuestsKillAncients")
+			cfg.Game.Quests.PersonalizeItem = strings.TrimSpace(values.Get("gameQuestsPersonalizeItem"))
+			cfg.Game.Imbue.Enabled = values.Has("gameImbueEnabled")
+			cfg.Game.Imbue.BaseRules = strings.TrimSpace(values.Get("gameImbueBaseRules"))
+			cfg.Game.Larzuk.Enabled = values.Has("gameLarzukEnabled")
+			cfg.Game.Larzuk.Queue = []string{}
+			for _, name := range strings.Split(values.Get("gameLarzukQueue"), ",") {
+				if name = strings.TrimSpace(name); name != "" {
+					cfg.Game.Larzuk.Queue = append(cfg.Game.Larzuk.Queue, name)
+				}
+			}
+			cfg.Game.QuestBugs.Andariel = values.Has("gameQuestBugsAndariel")
+			cfg.Game.QuestBugs.Council = values.Has("gameQuestBugsCouncil")
+			cfg.Game.QuestBugs.Mephisto = values.Has("gameQuestBugsMephisto")
+			cfg.Game.QuestBugs.Shenk = values.Has("gameQuestBugsShenk")
 		case "terror_zone":
 			cfg.Game.TerrorZone.FocusOnElitePacks = values.Has("gameTerrorZoneFocusOnElitePacks")
 			cfg.Game.TerrorZone.SkipOtherRuns = values.Has("gameTerrorZoneSkipOtherRuns")
 			cfg.Game.TerrorZone.OpenChests = values.Has("gameTerrorZoneOpenChests")
+			cfg.Game.TerrorZone.PrioritizeMatchingRuns = values.Has("gameTerrorZonePrioritizeMatchingRuns")
 
 			if raw, ok := values["gameTerrorZoneSkipOnImmunities[]"]; ok {
 				skips := make([]stat.Resist, 0, len(raw))
@@ -3471,6 +4273,12 @@ func (s *HttpServer) applyRunDetails(values url.Values, cfg *config.CharacterCfg
 			} else {
 				cfg.Game.TerrorZone.Areas = nil
 			}
+		case "rush":
+			cfg.Game.Rush.Rushee = strings.TrimSpace(values.Get("gameRushRushee"))
+			cfg.Game.Rush.Quests = parseRushQuests(values.Get("gameRushQuests"))
+			if v, err := strconv.Atoi(values.Get("gameRushWaitSeconds")); err == nil {
+				cfg.Game.Rush.WaitSeconds = min(max(v, 0), 1800)
+			}
 		case "utility":
 			if v := values.Get("gameUtilityParkingAct"); v != "" {
 				if n, err := strconv.Atoi(v); err == nil {
@@ -3605,6 +4413,68 @@ func (s *HttpServer) resetMuling(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 }
 
+// saveWindowPosition stores the current game window position and size of a running supervisor, it's applied again every
+// time the client starts. With clear=true the saved window is forgotten.
+func (s *HttpServer) saveWindowPosition(w http.ResponseWriter, r *http.Request) {
+	characterName := r.URL.Query().Get("characterName")
+	if characterName == "" {
+		http.Error(w, "Character name is required", http.StatusBadRequest)
+		return
+	}
+
+	cfg, found := config.GetCharacter(characterName)
+	if !found {
+		http.Error(w, "Character config not found", http.StatusNotFound)
+		return
+	}
+
+	if r.URL.Query().Get("clear") == "true" {
+		cfg.Window.Enabled = false
+	} else {
+		supervisor := s.manager.GetSupervisor(characterName)
+		if supervisor == nil {
+			http.Error(w, "Supervisor not running", http.StatusConflict)
+			return
+		}
+		cfg.Window.X, cfg.Window.Y, cfg.Window.Width, cfg.Window.Height = supervisor.WindowBounds()
+		cfg.Window.Enabled = true
+	}
+
+	if err := config.SaveSupervisorConfig(characterName, cfg); err != nil {
+		http.Error(w, "Failed to save updated config", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(cfg.Window)
+}
+
+// runBlacklist returns the runs skipped after failing too many times in a row, clear=true puts them back in the rotation.
+func (s *HttpServer) runBlacklist(w http.ResponseWriter, r *http.Request) {
+	characterName := r.URL.Query().Get("characterName")
+	if characterName == "" {
+		http.Error(w, "Character name is required", http.StatusBadRequest)
+		return
+	}
+
+	supervisor := s.manager.GetSupervisor(characterName)
+	if supervisor == nil {
+		http.Error(w, "Supervisor not running", http.StatusConflict)
+		return
+	}
+
+	if r.URL.Query().Get("clear") == "true" {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.manager.ClearRunBlacklist(characterName)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(supervisor.Stats().BlacklistedRuns)
+}
+
 func (s *HttpServer) skillOptionsAPI(w http.ResponseWriter, r *http.Request) {
 	build := r.URL.Query().Get("build")
 	payload := struct {
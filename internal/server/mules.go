@@ -0,0 +1,30 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/hectorgimenez/koolo/internal/mule"
+)
+
+// mulesAPI returns what every mule holds, or only the mules holding items matching the q parameter.
+func (s *HttpServer) mulesAPI(w http.ResponseWriter, r *http.Request) {
+	var (
+		result any
+		err    error
+	)
+
+	if query := strings.TrimSpace(r.URL.Query().Get("q")); query != "" {
+		result, err = mule.FindHolders(query)
+	} else {
+		result, err = mule.Ledger()
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
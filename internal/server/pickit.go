@@ -0,0 +1,32 @@
+package server
+
+import (
+	"sort"
+	"strings"
+)
+
+// formatPickitOverrides renders the per-run pickit overrides as "run=file.nip, run=file.nip".
+func formatPickitOverrides(overrides map[string]string) string {
+	entries := make([]string, 0, len(overrides))
+	for run, file := range overrides {
+		entries = append(entries, run+"="+file)
+	}
+	sort.Strings(entries)
+
+	return strings.Join(entries, ", ")
+}
+
+// parsePickitOverrides parses the "run=file.nip, run=file.nip" format, invalid entries are ignored.
+func parsePickitOverrides(value string) map[string]string {
+	overrides := make(map[string]string)
+	for _, entry := range strings.Split(value, ",") {
+		run, file, found := strings.Cut(entry, "=")
+		run, file = strings.TrimSpace(run), strings.TrimSpace(file)
+		if !found || run == "" || file == "" {
+			continue
+		}
+		overrides[run] = file
+	}
+
+	return overrides
+}
@@ -57,6 +57,8 @@ func (api *PickitAPI) RegisterRoutes(mux *http.ServeMux) {
 	// Utility endpoints
 	mux.HandleFunc("/api/pickit/stats", api.handleGetStats)
 	mux.HandleFunc("/api/pickit/simulate", api.handleSimulate)
+	mux.HandleFunc("/api/pickit/lint", api.handleLintNIP)
+	mux.HandleFunc("/api/pickit/evaluate", api.handleEvaluateNIP)
 	mux.HandleFunc("/api/pickit/suggestions", api.handleGetSuggestions)
 	mux.HandleFunc("/api/pickit/conflicts", api.handleDetectConflicts)
 }
@@ -0,0 +1,165 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/nip"
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/pickit"
+)
+
+// pickitLintRequest selects the rules to lint or evaluate: the given content, a file from the pickit directory of the
+// character (or a path inside it), every file of that directory, or the loaded rules of the character when nothing
+// else is set.
+type pickitLintRequest struct {
+	Path      string     `json:"path"`
+	Character string     `json:"character"`
+	File      string     `json:"file"`
+	Content   string     `json:"content"`
+	Item      *data.Item `json:"item,omitempty"`
+}
+
+// handleLintNIP reports the syntax errors and unknown stat names of a pickit file, with their line numbers
+func (api *PickitAPI) handleLintNIP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		api.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req pickitLintRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.sendError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	rules, issues, err := api.lintRequestRules(req)
+	if err != nil {
+		api.sendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	api.sendJSON(w, map[string]interface{}{
+		"valid":  len(issues) == 0,
+		"rules":  len(rules),
+		"issues": issues,
+	})
+}
+
+// handleEvaluateNIP dry-evaluates an item against the rules, showing which rules match it
+func (api *PickitAPI) handleEvaluateNIP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		api.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req pickitLintRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.sendError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.Item == nil {
+		api.sendError(w, "item is required", http.StatusBadRequest)
+		return
+	}
+
+	rules, issues, err := api.lintRequestRules(req)
+	if err != nil {
+		api.sendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	matches, err := pickit.DryEvaluate(rules, *req.Item)
+	if err != nil {
+		api.sendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	api.sendJSON(w, map[string]interface{}{
+		"matched": len(matches) > 0 && matches[0].Result == "full",
+		"matches": matches,
+		"issues":  issues,
+	})
+}
+
+func (api *PickitAPI) lintRequestRules(req pickitLintRequest) (nip.Rules, []pickit.LintIssue, error) {
+	if req.Content != "" {
+		rules, issues := pickit.ParseNIP(strings.NewReader(req.Content), req.File)
+		return rules, issues, nil
+	}
+
+	// Evaluating for a character without naming a file uses the rules loaded by the bot, overrides included
+	if req.Item != nil && req.Path == "" && req.File == "" {
+		if cfg, found := config.GetCharacter(req.Character); found {
+			return cfg.Runtime.Rules, []pickit.LintIssue{}, nil
+		}
+	}
+
+	directory := req.Path
+	if directory != "" {
+		var err error
+		if directory, err = characterPickitPath(req.Character, directory); err != nil {
+			return nil, nil, err
+		}
+	}
+	if directory == "" && req.Character != "" {
+		directory = filepath.Join("config", req.Character, "pickit")
+	}
+	if directory == "" {
+		return nil, nil, errors.New("either content, path or character is required")
+	}
+
+	files := []string{filepath.Join(directory, filepath.Base(req.File))}
+	if req.File == "" {
+		var err error
+		if files, err = filepath.Glob(filepath.Join(directory, "*.nip")); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	rules := make(nip.Rules, 0)
+	issues := make([]pickit.LintIssue, 0)
+	for _, file := range files {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error opening %s: %w", filepath.Base(file), err)
+		}
+		fileRules, fileIssues := pickit.ParseNIP(f, filepath.Base(file))
+		f.Close()
+
+		rules = append(rules, fileRules...)
+		issues = append(issues, fileIssues...)
+	}
+
+	return rules, issues, nil
+}
+
+// characterPickitPath checks that the path is the pickit directory of the character or one of its folders, so the
+// API can't read files from anywhere else on the disk.
+func characterPickitPath(character, path string) (string, error) {
+	cfg, found := config.GetCharacter(character)
+	if !found {
+		return "", errors.New("a path requires the character whose pickit directory it is in")
+	}
+
+	base, err := filepath.Abs(cfg.PickitDir())
+	if err != nil {
+		return "", err
+	}
+	target, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(base, target)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("path %s is outside of the pickit directory of %s", path, character)
+	}
+
+	return target, nil
+}
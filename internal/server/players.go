@@ -0,0 +1,38 @@
+package server
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// formatPlayersCounts renders the per-run /players counts as "run=count, run=count".
+func formatPlayersCounts(counts map[string]int) string {
+	entries := make([]string, 0, len(counts))
+	for run, count := range counts {
+		entries = append(entries, fmt.Sprintf("%s=%d", run, count))
+	}
+	sort.Strings(entries)
+
+	return strings.Join(entries, ", ")
+}
+
+// parsePlayersCounts parses the "run=count, run=count" format, invalid entries and counts out of 0-8 are ignored.
+func parsePlayersCounts(value string) map[string]int {
+	counts := make(map[string]int)
+	for _, entry := range strings.Split(value, ",") {
+		run, countStr, found := strings.Cut(entry, "=")
+		run = strings.TrimSpace(run)
+		if !found || run == "" {
+			continue
+		}
+		count, err := strconv.Atoi(strings.TrimSpace(countStr))
+		if err != nil || count < 0 || count > 8 {
+			continue
+		}
+		counts[run] = count
+	}
+
+	return counts
+}
@@ -0,0 +1,46 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/hectorgimenez/d2go/pkg/data/difficulty"
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/rewards"
+)
+
+// questRewardsAPI returns the quest item rewards known for the character and the socket jobs still waiting for one.
+func (s *HttpServer) questRewardsAPI(w http.ResponseWriter, r *http.Request) {
+	characterName := r.URL.Query().Get("characterName")
+	if characterName == "" {
+		http.Error(w, "Character name is required", http.StatusBadRequest)
+		return
+	}
+
+	cfg, found := config.GetCharacter(characterName)
+	if !found {
+		http.Error(w, "Character not found", http.StatusNotFound)
+		return
+	}
+
+	record, err := rewards.Get(characterName)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	payload := struct {
+		Rewards             rewards.Record          `json:"rewards"`
+		SocketQueue         []rewards.SocketJob     `json:"socketQueue"`
+		PendingSocketJobs   []rewards.SocketJob     `json:"pendingSocketJobs"`
+		UnusedSocketRewards []difficulty.Difficulty `json:"unusedSocketRewards"`
+	}{
+		Rewards:             record,
+		SocketQueue:         rewards.SocketQueue(record, cfg.Game.Larzuk.Queue),
+		PendingSocketJobs:   rewards.PendingSocketJobs(record, cfg.Game.Larzuk.Queue),
+		UnusedSocketRewards: rewards.UnusedSocketRewards(record),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(payload)
+}
@@ -376,6 +376,8 @@ func (s *HttpServer) runewordSettings(w http.ResponseWriter, r *http.Request) {
 		cfg.Game.RunewordMaker.AutoUpgrade = r.Form.Has("rwAutoUpgrade")
 		cfg.Game.RunewordMaker.OnlyIfWearable = r.Form.Has("rwOnlyIfWearable")
 		cfg.Game.RunewordMaker.AutoTierByDifficulty = r.Form.Has("rwAutoTierByDifficulty")
+		cfg.Game.RunewordMaker.CollectBases = r.Form.Has("rwCollectBases")
+		cfg.Game.RunewordMaker.CollectSocketableBases = r.Form.Has("rwCollectSocketableBases")
 
 		if _, ok := r.Form["runewordMakerEnabled"]; ok {
 			cfg.Game.RunewordMaker.Enabled = r.Form.Has("runewordMakerEnabled")
@@ -0,0 +1,43 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/hectorgimenez/koolo/internal/action"
+)
+
+// stashManifestAPI returns the stash manifest of a character, optionally filtered by name (q) and category.
+func (s *HttpServer) stashManifestAPI(w http.ResponseWriter, r *http.Request) {
+	characterName := r.URL.Query().Get("character")
+	if characterName == "" {
+		http.Error(w, "character parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	manifest, err := action.LoadStashManifest(characterName)
+	if err != nil {
+		http.Error(w, "no stash manifest found, enable the stash organizer first", http.StatusNotFound)
+		return
+	}
+
+	query := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("q")))
+	category := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("category")))
+	if query != "" || category != "" {
+		filtered := manifest.Items[:0]
+		for _, itm := range manifest.Items {
+			if query != "" && !strings.Contains(strings.ToLower(itm.Name), query) {
+				continue
+			}
+			if category != "" && itm.Category != category {
+				continue
+			}
+			filtered = append(filtered, itm)
+		}
+		manifest.Items = filtered
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(manifest)
+}
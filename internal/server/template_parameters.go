@@ -45,6 +45,7 @@ type IndexData struct {
 	GlobalAutoStartEnabled      bool
 	GlobalAutoStartDelaySeconds int
 	ShowAutoStartPrompt         bool
+	GameVersion                 bot.GameVersionStatus
 }
 
 type DropData struct {
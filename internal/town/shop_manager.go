@@ -15,6 +15,12 @@ import (
 	"github.com/hectorgimenez/koolo/internal/utils"
 )
 
+const (
+	maxTomeScrolls = 20
+	// Missing scrolls from which the tome is filled with a shift-click instead of buying them one by one
+	bulkScrolls = 5
+)
+
 var questItems = []item.Name{
 	"StaffOfKings",
 	"HoradricStaff",
@@ -86,14 +92,17 @@ func BuyConsumables(forceRefill bool) {
 	if shouldBuyTPs || forceRefill {
 		ctx.Logger.Debug("Filling TP Tome...")
 		if itm, found := ctx.Data.Inventory.Find(item.ScrollOfTownPortal, item.LocationVendor); found {
-			if ctx.Data.PlayerUnit.TotalPlayerGold() > 6000 {
-				if !buyFullStack(itm, -1) { // -1 for irrelevant currentKeysInInventory
-					return
-				}
-			} else {
-				if !buyItemOrAbortOnNoGold(itm, 1) {
-					return
-				}
+			if !buyScrolls(itm, scrollsMissing(item.TomeOfTownPortal, ctx.CharacterCfg.Inventory.TPScrollTarget, forceRefill), 6000) {
+				return
+			}
+		}
+	}
+
+	if NeedsBackupTPTome() && ctx.Data.PlayerUnit.TotalPlayerGold() > 6000 {
+		if itm, found := ctx.Data.Inventory.Find(item.TomeOfTownPortal, item.LocationVendor); found {
+			ctx.Logger.Info("Buying a backup TP Tome for the stash...")
+			if !buyItemOrAbortOnNoGold(itm, 1) {
+				return
 			}
 		}
 	}
@@ -128,14 +137,8 @@ func BuyConsumables(forceRefill bool) {
 		}
 		ctx.Logger.Debug("Filling IDs Tome...")
 		if itm, found := ctx.Data.Inventory.Find(item.ScrollOfIdentify, item.LocationVendor); found {
-			if ctx.Data.PlayerUnit.TotalPlayerGold() > 16000 {
-				if !buyFullStack(itm, -1) { // -1 for irrelevant currentKeysInInventory
-					return
-				}
-			} else {
-				if !buyItemOrAbortOnNoGold(itm, 1) {
-					return
-				}
+			if !buyScrolls(itm, scrollsMissing(item.TomeOfIdentify, ctx.CharacterCfg.Inventory.IDScrollTarget, forceRefill), 16000) {
+				return
 			}
 		}
 	}
@@ -171,14 +174,18 @@ func findFirstMatch(itemNames ...string) (data.Item, bool) {
 }
 
 func ShouldBuyTPs() bool {
-	portalTome, found := context.Get().Data.Inventory.Find(item.TomeOfTownPortal, item.LocationInventory)
+	ctx := context.Get()
+
+	qty, found := tomeQuantity(item.TomeOfTownPortal)
 	if !found {
 		return true
 	}
 
-	qty, found := portalTome.FindStat(stat.Quantity, 0)
+	if target := ctx.CharacterCfg.Inventory.TPScrollTarget; target > 0 {
+		return qty < min(target, maxTomeScrolls)
+	}
 
-	return qty.Value < 5 || !found
+	return qty < 5
 }
 
 func ShouldBuyIDs() bool {
@@ -193,14 +200,76 @@ func ShouldBuyIDs() bool {
 		return false
 	}
 
+	qty, found := tomeQuantity(item.TomeOfIdentify)
+	if !found {
+		return true
+	}
+
+	if target := ctx.CharacterCfg.Inventory.IDScrollTarget; target > 0 {
+		return qty < min(target, maxTomeScrolls)
+	}
+
 	// Original behaviour: keep at least 10 IDs in the tome
-	idTome, found := ctx.Data.Inventory.Find(item.TomeOfIdentify, item.LocationInventory)
+	return qty < 10
+}
+
+// NeedsBackupTPTome tells if a spare TP tome has to be bought for the stash, when enabled and there isn't one yet.
+func NeedsBackupTPTome() bool {
+	ctx := context.Get()
+	if !ctx.CharacterCfg.Inventory.BackupTPTome {
+		return false
+	}
+
+	tomes := 0
+	for _, itm := range ctx.Data.Inventory.ByLocation(item.LocationInventory, item.LocationStash, item.LocationSharedStash) {
+		if itm.Name == item.TomeOfTownPortal {
+			tomes++
+		}
+	}
+
+	// Without any tome the regular one is bought first
+	return tomes == 1
+}
+
+// tomeQuantity returns the scrolls in the inventory tome, false when there's no tome.
+func tomeQuantity(tome item.Name) (int, bool) {
+	itm, found := context.Get().Data.Inventory.Find(tome, item.LocationInventory)
 	if !found {
+		return 0, false
+	}
+
+	qty, _ := itm.FindStat(stat.Quantity, 0)
+
+	return qty.Value, true
+}
+
+// scrollsMissing returns the scrolls to buy to bring the tome to its target, a full tome without target or on forced
+// refills.
+func scrollsMissing(tome item.Name, target int, forceRefill bool) int {
+	if target <= 0 || target > maxTomeScrolls || forceRefill {
+		target = maxTomeScrolls
+	}
+	qty, _ := tomeQuantity(tome)
+
+	return max(target-qty, 0)
+}
+
+// buyScrolls buys scrolls for a tome, filling it with a single shift-click when many are missing and there's gold to
+// spare. Low on gold only one is bought per visit, as before.
+func buyScrolls(i data.Item, count, bulkGold int) bool {
+	ctx := context.Get()
+	if count <= 0 {
 		return true
 	}
 
-	qty, found := idTome.FindStat(stat.Quantity, 0)
-	return !found || qty.Value < 10
+	if ctx.Data.PlayerUnit.TotalPlayerGold() <= bulkGold {
+		return buyItemOrAbortOnNoGold(i, 1)
+	}
+	if count >= bulkScrolls {
+		return buyFullStack(i, -1) // -1 for irrelevant currentKeysInInventory
+	}
+
+	return buyItemOrAbortOnNoGold(i, count)
 }
 
 func ShouldBuyKeys() (int, bool) {
@@ -535,6 +604,10 @@ func ItemsToBeSold(lockConfig ...[][]int) (items []data.Item) {
 			continue
 		}
 
+		if ctx.CharacterCfg.KeepUnidentified(itm) {
+			continue
+		}
+
 		if _, result := ctx.CharacterCfg.Runtime.Rules.EvaluateAllIgnoreTiers(itm); result == nip.RuleResultFullMatch && !itm.IsPotion() {
 			continue
 		}
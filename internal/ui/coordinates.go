@@ -270,6 +270,14 @@ const (
 	CharOfflineHardcoreBtnX  = 605
 	CharOfflineExpansionBtnX = 675
 
+	// Invite/accept button of the first player listed in the party screen, the next players are one row below
+	PartyInviteBtnX        = 563
+	PartyInviteBtnXClassic = 440
+	PartyInviteBtnY        = 166
+	PartyInviteBtnYClassic = 170
+	PartyRowHeight         = 40
+	PartyRowHeightClassic  = 38
+
 	CharCreateNewBtnX = 1125
 	CharCreateNewBtnY = 640
 
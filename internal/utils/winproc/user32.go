@@ -21,4 +21,5 @@ var (
     RedrawWindow            = USER32.NewProc("RedrawWindow")
     UpdateWindow            = USER32.NewProc("UpdateWindow")
     EnumChildWindows        = USER32.NewProc("EnumChildWindows")
+    IsWindow                = USER32.NewProc("IsWindow")
 )
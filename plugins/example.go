@@ -0,0 +1,28 @@
+//go:build plugins_example
+
+package plugins
+
+import (
+	"github.com/hectorgimenez/d2go/pkg/data/area"
+	"github.com/hectorgimenez/koolo/internal/plugin"
+)
+
+func init() {
+	plugin.RegisterRun("example_cold_plains", func() plugin.Run { return coldPlains{} })
+}
+
+// coldPlains clears the Cold Plains, an example of a run made with the plugin API.
+type coldPlains struct{}
+
+func (coldPlains) Name() string {
+	return "example_cold_plains"
+}
+
+func (coldPlains) Run(api *plugin.API) error {
+	if err := api.WayPoint(area.ColdPlains); err != nil {
+		return err
+	}
+	api.Buff()
+
+	return api.ClearLevel(false)
+}
@@ -0,0 +1,14 @@
+// Package plugins holds the plugins compiled into koolo, they are only included when building with the plugins tag:
+//
+//	go build -tags static,plugins ./cmd/koolo
+//
+// A plugin is a Go file of this package registering its runs and town behaviors from init, using the plugin API only:
+//
+//	func init() {
+//		plugin.RegisterRun("my_run", func() plugin.Run { return myRun{} })
+//		plugin.RegisterTownHook("my_town_step", func(api *plugin.API) error { ... })
+//	}
+//
+// The registered runs are listed with the other runs in the character settings. See example.go, built with the
+// plugins_example tag.
+package plugins